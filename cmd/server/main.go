@@ -6,6 +6,10 @@ import (
 	"os"
 	"smart_school_be/internal/config"
 	"smart_school_be/internal/database"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/service"
+	"smart_school_be/internal/utils"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -16,8 +20,28 @@ func main() {
 	seed := flag.Bool("seed", false, "Run database seeding only")
 	devMigrate := flag.Bool("dev-migrate", false, "Run DEV database migrations (GORM AutoMigrate)")
 	migrateSql := flag.Bool("migrate-sql", false, "Run SQL migrations from /migrations folder")
+	purgeTrash := flag.Bool("purge-trash", false, "Permanently delete soft-deleted records older than the retention window")
+	retentionDays := flag.Int("retention-days", -1, "Retention window in days for -purge-trash (default: TRASH_RETENTION_DAYS)")
+	seedDemo := flag.Bool("seed-demo", false, "Seed a realistic demo school (requires an empty students table)")
+	demoSize := flag.String("demo-size", "medium", "Demo school size for -seed-demo: small, medium or large")
+	demoSeed := flag.Int64("demo-seed", 1, "Random seed for -seed-demo (same seed gives the same data)")
+	backfillHouseholds := flag.Bool("backfill-households", false, "Group existing students into households by No KK")
+	checkGuardianRefs := flag.Bool("check-guardian-refs", false, "Report students whose guardian points to a missing or deleted parent/guardian")
+	fixGuardianRefs := flag.Bool("fix-guardian-refs", false, "Like -check-guardian-refs, then repair the broken references")
 	flag.Parse()
 
+	// Subcommand: server migrate <status|up|down|goto|force|create> ...
+	if flag.NArg() > 0 && flag.Arg(0) == "migrate" {
+		runMigrateCommand(flag.Args()[1:])
+		return
+	}
+
+	// Subcommand: server backup <export|import> ...
+	if flag.NArg() > 0 && flag.Arg(0) == "backup" {
+		runBackupCommand(flag.Args()[1:])
+		return
+	}
+
 	if *migrateSql {
 		runSqlMigrationsOnly()
 		return
@@ -33,11 +57,31 @@ func main() {
 		return
 	}
 
+	if *seedDemo {
+		runDemoSeedingOnly(*demoSize, *demoSeed)
+		return
+	}
+
 	if *devMigrate {
 		runDevMigrationsOnly()
 		return
 	}
 
+	if *purgeTrash {
+		runPurgeTrashOnly(*retentionDays)
+		return
+	}
+
+	if *backfillHouseholds {
+		runBackfillHouseholdsOnly()
+		return
+	}
+
+	if *checkGuardianRefs || *fixGuardianRefs {
+		runGuardianRefsCheckOnly(*fixGuardianRefs)
+		return
+	}
+
 	// Create and start a server
 	server := NewServer()
 
@@ -124,3 +168,131 @@ func runSeedingOnly() {
 	log.Println("Seeding completed successfully")
 	os.Exit(0)
 }
+
+// runDemoSeedingOnly mengisi database kosong dengan sekolah demo yang datanya saling konsisten
+func runDemoSeedingOnly(size string, seed int64) {
+	log.Println("Running demo data seeding...")
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	demoSize, ok := database.DemoSizes[size]
+	if !ok {
+		log.Fatalf("Unknown demo size %q (use small, medium or large)", size)
+	}
+
+	cfg := config.LoadConfig()
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	encryptionUtil, err := utils.NewEncryptionUtil(cfg.EncryptionKey)
+	if err != nil {
+		log.Fatal("Failed to initialize encryption util:", err)
+	}
+
+	if err := database.SeedDemoData(db, encryptionUtil, database.DemoOptions{Size: demoSize, Seed: seed}); err != nil {
+		log.Fatal("Failed to seed demo data:", err)
+	}
+
+	log.Println("Demo seeding completed successfully")
+	os.Exit(0)
+}
+
+// runPurgeTrashOnly menghapus permanen data soft delete yang melewati masa retensi
+func runPurgeTrashOnly(retentionDays int) {
+	log.Println("Purging soft-deleted records...")
+
+	cfg := config.LoadConfig()
+	if retentionDays < 0 {
+		retentionDays = cfg.TrashRetentionDays
+	}
+
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	trashService := service.NewTrashService(repository.NewTrashRepository(db))
+	result, err := trashService.Purge(time.Duration(retentionDays) * 24 * time.Hour)
+	if result != nil {
+		for entityType, count := range result.Purged {
+			log.Printf("Purged %d %s deleted before %s", count, entityType, result.Cutoff.Format(time.RFC3339))
+		}
+		for entityType, count := range result.Kept {
+			log.Printf("Kept %d %s in trash because they still have teaching assignments or class history", count, entityType)
+		}
+	}
+	if err != nil {
+		log.Fatal("Failed to purge trash:", err)
+	}
+
+	log.Println("Trash purge completed successfully")
+	os.Exit(0)
+}
+
+// runBackfillHouseholdsOnly mengelompokkan siswa lama ke household berdasarkan No KK
+func runBackfillHouseholdsOnly() {
+	log.Println("Backfilling households...")
+
+	cfg := config.LoadConfig()
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	encryptionUtil, err := utils.NewEncryptionUtil(cfg.EncryptionKey)
+	if err != nil {
+		log.Fatal("Failed to initialize encryption util:", err)
+	}
+
+	householdService := service.NewHouseholdService(repository.NewHouseholdRepository(db), repository.NewStudentRepository(db), encryptionUtil)
+	assigned, err := householdService.Backfill()
+	log.Printf("Assigned %d students to households", assigned)
+	if err != nil {
+		log.Fatal("Failed to backfill households:", err)
+	}
+
+	log.Println("Household backfill completed successfully")
+	os.Exit(0)
+}
+
+// runGuardianRefsCheckOnly melaporkan (dan jika fix, memperbaiki) pointer wali siswa yang rusak.
+// Pointer dipindah ke orang tua aktif dari student_parent bila ada, selain itu dikosongkan.
+func runGuardianRefsCheckOnly(fix bool) {
+	log.Println("Checking student guardian references...")
+
+	cfg := config.LoadConfig()
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	integrityService := service.NewGuardianIntegrityService(repository.NewStudentRepository(db))
+	report, err := integrityService.CheckGuardianRefs(fix)
+	if report != nil {
+		for _, issue := range report.Issues {
+			target := "-"
+			if issue.ReassignedToID != nil {
+				target = *issue.ReassignedToID
+			}
+			log.Printf("%s (%s) guardian=%s/%s reason=%s action=%s target=%s",
+				issue.FullName, issue.StudentID,
+				utils.SafeString(issue.GuardianType), utils.SafeString(issue.GuardianID),
+				issue.Reason, issue.Action, target)
+		}
+		log.Printf("Found %d broken guardian references", len(report.Issues))
+	}
+	if err != nil {
+		log.Fatal("Failed to check guardian references:", err)
+	}
+
+	if fix {
+		log.Println("Guardian references repaired successfully")
+	} else if len(report.Issues) > 0 {
+		log.Println("Run with -fix-guardian-refs to repair them")
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"smart_school_be/internal/config"
+	"smart_school_be/internal/database"
+)
+
+const migrateUsage = `Usage: server migrate <command> [args] [-dry-run]
+
+Commands:
+  status             Show current version and applied/pending migrations
+  up [N]             Apply the next N pending migrations (all if N is omitted)
+  down N             Roll back the last N applied migrations
+  goto VERSION       Migrate up or down to VERSION
+  force VERSION      Set VERSION and clear the dirty flag without running SQL
+  create NAME        Scaffold <timestamp>_NAME.up.sql and .down.sql in migrations/
+
+Flags:
+  -dry-run           List the migrations that would run without executing them`
+
+// runMigrateCommand menangani subcommand `server migrate ...`
+func runMigrateCommand(args []string) {
+	dryRun := false
+	var positional []string
+	for _, arg := range args {
+		if arg == "-dry-run" || arg == "--dry-run" {
+			dryRun = true
+			continue
+		}
+		positional = append(positional, arg)
+	}
+
+	if len(positional) == 0 {
+		fmt.Println(migrateUsage)
+		os.Exit(1)
+	}
+
+	command := positional[0]
+	if command == "create" {
+		if len(positional) < 2 {
+			log.Fatal("create requires a migration NAME")
+		}
+		upPath, downPath, err := database.CreateMigrationFiles(database.MigrationDir, positional[1], time.Now())
+		if err != nil {
+			log.Fatal("Failed to create migration:", err)
+		}
+		log.Printf("Created %s", upPath)
+		log.Printf("Created %s", downPath)
+		os.Exit(0)
+	}
+
+	cfg := config.LoadConfig()
+
+	switch command {
+	case "status":
+		printMigrationStatus(cfg)
+	case "up", "down", "goto", "force":
+		var n uint
+		if len(positional) > 1 {
+			parsed, err := strconv.ParseUint(positional[1], 10, 64)
+			if err != nil {
+				log.Fatalf("Invalid number %q: %v", positional[1], err)
+			}
+			n = uint(parsed)
+		} else if command != "up" {
+			log.Fatalf("%s requires an argument\n\n%s", command, migrateUsage)
+		}
+
+		steps, err := database.RunMigrationCommand(cfg, command, n, dryRun)
+		printMigrationSteps(command, n, steps, dryRun)
+		if err != nil {
+			log.Fatal("Migration failed:", err)
+		}
+	default:
+		fmt.Println(migrateUsage)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func printMigrationStatus(cfg *config.Config) {
+	status, err := database.GetMigrationStatus(cfg)
+	if err != nil {
+		log.Fatal("Failed to read migration status:", err)
+	}
+
+	if status.HasVersion {
+		fmt.Printf("Current version: %d (dirty: %t)\n\n", status.Version, status.Dirty)
+	} else {
+		fmt.Print("Current version: none\n\n")
+	}
+
+	pending := 0
+	for _, file := range status.Files {
+		state := "pending"
+		if status.Applied(file.Version) {
+			state = "applied"
+		} else {
+			pending++
+		}
+		if status.Dirty && file.Version == status.Version {
+			state = "DIRTY"
+		}
+		missing := ""
+		if !file.HasUp || !file.HasDown {
+			missing = " (missing up or down file)"
+		}
+		fmt.Printf("  %-8s %d  %s%s\n", state, file.Version, file.Name, missing)
+	}
+	fmt.Printf("\n%d migrations, %d pending\n", len(status.Files), pending)
+
+	// Cek model GORM yang tabelnya belum dibuat oleh SQL migration
+	db, err := database.NewDB(cfg)
+	if err != nil {
+		log.Println("Skipping model sync check:", err)
+		return
+	}
+	missing, err := database.MissingModelTables(db)
+	if err != nil {
+		log.Println("Skipping model sync check:", err)
+		return
+	}
+	if len(missing) > 0 {
+		fmt.Printf("\nModels without a table (add a SQL migration): %v\n", missing)
+	}
+}
+
+func printMigrationSteps(command string, n uint, steps []database.MigrationStep, dryRun bool) {
+	prefix := "Ran"
+	if dryRun {
+		prefix = "Would run"
+	}
+
+	if command == "force" {
+		if dryRun {
+			log.Printf("Would force version to %d and clear the dirty flag", n)
+		} else {
+			log.Printf("Forced version to %d", n)
+		}
+		return
+	}
+
+	if len(steps) == 0 {
+		log.Println("No migrations to run")
+		return
+	}
+	for _, step := range steps {
+		log.Printf("%s %-4s %d_%s", prefix, step.Direction, step.Version, step.Name)
+	}
+}
@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAdmissionRoutes(router *gin.RouterGroup, admissionHandler *handler.AdmissionHandler, authService service.AuthService) {
+	// Endpoint publik untuk calon siswa (tanpa login)
+	public := router.Group("/admissions")
+	{
+		public.GET("/waves/open", admissionHandler.GetOpenWaves)
+		public.POST("/apply", admissionHandler.Apply)
+		public.GET("/status", admissionHandler.CheckStatus)
+	}
+
+	// Gelombang pendaftaran
+	waves := router.Group("/admission-waves")
+	waves.Use(middleware.AuthMiddleware(authService))
+	{
+		waves.GET("", middleware.PermissionMiddleware("admissions.read", authService), admissionHandler.GetWaves)
+		waves.POST("", middleware.PermissionMiddleware("admissions.manage", authService), admissionHandler.CreateWave)
+		waves.PUT("/:id", middleware.PermissionMiddleware("admissions.manage", authService), admissionHandler.UpdateWave)
+		waves.DELETE("/:id", middleware.PermissionMiddleware("admissions.manage", authService), admissionHandler.DeleteWave)
+	}
+
+	// Pendaftar
+	applicants := router.Group("/applicants")
+	applicants.Use(middleware.AuthMiddleware(authService))
+	{
+		applicants.GET("", middleware.PermissionMiddleware("admissions.read", authService), admissionHandler.GetApplicants)
+		applicants.GET("/:id", middleware.PermissionMiddleware("admissions.read", authService), admissionHandler.GetApplicantByID)
+		applicants.PATCH("/:id/status", middleware.PermissionMiddleware("admissions.review", authService), admissionHandler.UpdateStatus)
+		applicants.POST("/:id/convert", middleware.PermissionMiddleware("admissions.convert", authService), admissionHandler.ConvertToStudent)
+	}
+}
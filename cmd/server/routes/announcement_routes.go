@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterAnnouncementRoutes(router *gin.RouterGroup, announcementHandler *handler.AnnouncementHandler, authService service.AuthService) {
+	announcements := router.Group("/announcements")
+	announcements.Use(middleware.AuthMiddleware(authService))
+	{
+		announcements.GET("", middleware.PermissionMiddleware("announcements.read", authService), announcementHandler.GetAnnouncements)
+		announcements.GET("/:id", middleware.PermissionMiddleware("announcements.read", authService), announcementHandler.GetAnnouncementByID)
+		announcements.POST("", middleware.PermissionMiddleware("announcements.manage", authService), announcementHandler.CreateAnnouncement)
+		announcements.PUT("/:id", middleware.PermissionMiddleware("announcements.manage", authService), announcementHandler.UpdateAnnouncement)
+		announcements.DELETE("/:id", middleware.PermissionMiddleware("announcements.manage", authService), announcementHandler.DeleteAnnouncement)
+		announcements.GET("/:id/recipients", middleware.PermissionMiddleware("announcements.manage", authService), announcementHandler.GetRecipients)
+	}
+}
@@ -37,6 +37,12 @@ func RegisterAttendanceRoutes(router *gin.RouterGroup, h *handler.AttendanceHand
 			h.GetHistoryByAssignment,
 		)
 
+		// Siswa alpa pada tanggal tsb beserta kontak keluarga yang dihubungi
+		group.GET("/absence-alerts",
+			middleware.PermissionMiddleware("attendance.read", authService),
+			h.GetAbsenceAlerts,
+		)
+
 		group.GET("/check", h.CheckSession) // GET /api/v1/attendances/check?schedule_id=...&date=...
 
 		// Delete Session
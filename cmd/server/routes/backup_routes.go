@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterBackupRoutes(router *gin.RouterGroup, h *handler.BackupHandler, authService service.AuthService) {
+	group := router.Group("/backup")
+	group.Use(middleware.AuthMiddleware(authService))
+	{
+		group.GET("/export", middleware.PermissionMiddleware("backup.manage", authService), h.Export)
+		group.POST("/import", middleware.PermissionMiddleware("backup.manage", authService), h.Import)
+	}
+}
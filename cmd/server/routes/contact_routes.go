@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterContactRoutes mendaftarkan preferensi komunikasi keluarga dan routing kontak siswa
+func RegisterContactRoutes(router *gin.RouterGroup, contactHandler *handler.ContactHandler, authService service.AuthService) {
+	contacts := router.Group("/contacts")
+	contacts.Use(middleware.AuthMiddleware(authService))
+	{
+		contacts.GET("/:type/:id/preference", middleware.PermissionMiddleware("contacts.read", authService), contactHandler.GetPreference)
+		contacts.PUT("/:type/:id/preference", middleware.PermissionMiddleware("contacts.manage", authService), contactHandler.UpdatePreference)
+
+		contacts.GET("/students/:id/route", middleware.PermissionMiddleware("contacts.read", authService), contactHandler.ResolveStudent)
+		contacts.PUT("/students/:id/primary", middleware.PermissionMiddleware("contacts.manage", authService), contactHandler.SetPrimaryContact)
+		contacts.DELETE("/students/:id/primary", middleware.PermissionMiddleware("contacts.manage", authService), contactHandler.ClearPrimaryContact)
+	}
+
+	// Orang tua/wali mengatur preferensinya sendiri (akun harus tertaut ke data orang tua/wali)
+	me := router.Group("/me")
+	me.Use(middleware.AuthMiddleware(authService))
+	{
+		me.GET("/contact-preference", contactHandler.GetMyPreference)
+		me.PUT("/contact-preference", contactHandler.UpdateMyPreference)
+	}
+}
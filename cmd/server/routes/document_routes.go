@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDocumentRoutes(router *gin.RouterGroup, documentHandler *handler.DocumentHandler, authService service.AuthService) {
+	// Jenis dokumen (didefinisikan admin)
+	types := router.Group("/document-types")
+	types.Use(middleware.AuthMiddleware(authService))
+	{
+		types.GET("", middleware.PermissionMiddleware("documents.read", authService), documentHandler.GetTypes)
+		types.POST("", middleware.PermissionMiddleware("document_types.manage", authService), documentHandler.CreateType)
+		types.PUT("/:id", middleware.PermissionMiddleware("document_types.manage", authService), documentHandler.UpdateType)
+		types.DELETE("/:id", middleware.PermissionMiddleware("document_types.manage", authService), documentHandler.DeleteType)
+	}
+
+	documents := router.Group("/documents")
+	documents.Use(middleware.AuthMiddleware(authService))
+	{
+		documents.GET("/completeness", middleware.PermissionMiddleware("documents.read", authService), documentHandler.GetClassroomCompleteness)
+		documents.PATCH("/:id/verify", middleware.PermissionMiddleware("documents.verify", authService), documentHandler.VerifyDocument)
+		documents.DELETE("/:id", middleware.PermissionMiddleware("documents.delete", authService), documentHandler.DeleteDocument)
+	}
+
+	// Dokumen milik siswa
+	students := router.Group("/students/:id/documents")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("", middleware.PermissionMiddleware("documents.read", authService), documentHandler.GetStudentDocuments)
+		students.GET("/history", middleware.PermissionMiddleware("documents.read", authService), documentHandler.GetStudentDocumentHistory)
+		students.POST("", middleware.PermissionMiddleware("documents.upload", authService), documentHandler.UploadStudentDocument)
+	}
+
+	// Dokumen milik pegawai
+	employees := router.Group("/employees/:id/documents")
+	employees.Use(middleware.AuthMiddleware(authService))
+	{
+		employees.GET("", middleware.PermissionMiddleware("documents.read", authService), documentHandler.GetEmployeeDocuments)
+		employees.GET("/history", middleware.PermissionMiddleware("documents.read", authService), documentHandler.GetEmployeeDocumentHistory)
+		employees.POST("", middleware.PermissionMiddleware("documents.upload", authService), documentHandler.UploadEmployeeDocument)
+	}
+}
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDormitoryRoutes(router *gin.RouterGroup, dormitoryHandler *handler.DormitoryHandler, authService service.AuthService) {
+	// Asrama
+	dormitories := router.Group("/dormitories")
+	dormitories.Use(middleware.AuthMiddleware(authService))
+	{
+		dormitories.GET("", middleware.PermissionMiddleware("dormitories.read", authService), dormitoryHandler.GetDormitories)
+		dormitories.GET("/occupancy", middleware.PermissionMiddleware("dormitories.read", authService), dormitoryHandler.GetOccupancyReport)
+		dormitories.GET("/:id", middleware.PermissionMiddleware("dormitories.read", authService), dormitoryHandler.GetDormitoryByID)
+		dormitories.POST("", middleware.PermissionMiddleware("dormitories.manage", authService), dormitoryHandler.CreateDormitory)
+		dormitories.PUT("/:id", middleware.PermissionMiddleware("dormitories.manage", authService), dormitoryHandler.UpdateDormitory)
+		dormitories.DELETE("/:id", middleware.PermissionMiddleware("dormitories.manage", authService), dormitoryHandler.DeleteDormitory)
+		dormitories.POST("/:id/rooms", middleware.PermissionMiddleware("dormitories.manage", authService), dormitoryHandler.CreateRoom)
+	}
+
+	// Kamar. Daftar kamar dan penghuni juga bisa diakses musyrif (dormitories.read_own) untuk kamar binaannya, dicek di service.
+	rooms := router.Group("/dorm-rooms")
+	rooms.Use(middleware.AuthMiddleware(authService))
+	{
+		rooms.GET("", dormitoryHandler.GetRooms)
+		rooms.GET("/:id/occupants", dormitoryHandler.GetRoomOccupants)
+		rooms.PUT("/:id", middleware.PermissionMiddleware("dormitories.manage", authService), dormitoryHandler.UpdateRoom)
+		rooms.DELETE("/:id", middleware.PermissionMiddleware("dormitories.manage", authService), dormitoryHandler.DeleteRoom)
+		rooms.POST("/:id/assignments", middleware.PermissionMiddleware("dormitories.assign", authService), dormitoryHandler.AssignStudents)
+	}
+
+	// Penempatan santri
+	assignments := router.Group("/dorm-assignments")
+	assignments.Use(middleware.AuthMiddleware(authService))
+	{
+		assignments.POST("/move", middleware.PermissionMiddleware("dormitories.assign", authService), dormitoryHandler.MoveStudent)
+		assignments.POST("/:id/checkout", middleware.PermissionMiddleware("dormitories.assign", authService), dormitoryHandler.Checkout)
+	}
+
+	students := router.Group("/students/:id")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("/dorm-history", middleware.PermissionMiddleware("dormitories.read", authService), dormitoryHandler.GetStudentHistory)
+	}
+}
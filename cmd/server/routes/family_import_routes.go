@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterFamilyImportRoutes mendaftarkan import massal orang tua & wali dari Excel
+func RegisterFamilyImportRoutes(
+	router *gin.RouterGroup,
+	familyImportHandler *handler.FamilyImportHandler,
+	authService service.AuthService,
+) {
+	parents := router.Group("/parents/import")
+	parents.Use(middleware.AuthMiddleware(authService))
+	{
+		parents.GET("/template",
+			middleware.PermissionMiddleware("parents.import", authService),
+			familyImportHandler.DownloadImportTemplate)
+		parents.POST("",
+			middleware.PermissionMiddleware("parents.import", authService),
+			familyImportHandler.ImportFamilies)
+	}
+}
@@ -33,6 +33,15 @@ func RegisterGradeRoutes(router *gin.RouterGroup, gradeHandler *handler.GradeHan
 		middleware.PermissionMiddleware("assignments.manage", authService),
 		gradeHandler.DeleteAssessment)
 
+	// Publikasi nilai ke portal orang tua
+	gradeGroup.POST("/assessments/:id/publish",
+		middleware.PermissionMiddleware("assignments.manage", authService),
+		gradeHandler.PublishAssessment)
+
+	gradeGroup.DELETE("/assessments/:id/publish",
+		middleware.PermissionMiddleware("assignments.manage", authService),
+		gradeHandler.UnpublishAssessment)
+
 	// Scores
 	gradeGroup.POST("/scores/bulk",
 		middleware.PermissionMiddleware("assignments.manage", authService),
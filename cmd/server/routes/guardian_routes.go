@@ -37,6 +37,11 @@ func RegisterGuardianRoutes(
 			middleware.PermissionMiddleware("guardians.delete", authService),
 			guardianHandler.DeleteGuardian)
 
+		// Siswa yang walinya (students.guardian_type + guardian_id) menunjuk ke wali ini
+		guardians.GET("/:id/wards",
+			middleware.PermissionMiddleware("guardians.read", authService),
+			guardianHandler.GetWards)
+
 		guardians.POST("/:id/link-user",
 			middleware.PermissionMiddleware("guardians.manage_account", authService),
 			guardianHandler.LinkUser)
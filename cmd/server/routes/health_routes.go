@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHealthRoutes(router *gin.RouterGroup, healthHandler *handler.HealthHandler, authService service.AuthService) {
+	// Akses baca dicek di service: health.read (petugas UKS) atau health.read_homeroom (wali kelas siswa)
+	students := router.Group("/students/:id")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("/health", healthHandler.GetProfile)
+		students.PUT("/health", middleware.PermissionMiddleware("health.manage", authService), healthHandler.UpdateProfile)
+		students.GET("/clinic-visits", healthHandler.GetStudentVisits)
+	}
+
+	// Log kunjungan UKS
+	visits := router.Group("/clinic-visits")
+	visits.Use(middleware.AuthMiddleware(authService))
+	{
+		visits.GET("", middleware.PermissionMiddleware("health.read", authService), healthHandler.GetVisits)
+		visits.GET("/:id", healthHandler.GetVisitByID)
+		visits.POST("", middleware.PermissionMiddleware("health.manage", authService), healthHandler.CreateVisit)
+	}
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterHouseholdRoutes(router *gin.RouterGroup, householdHandler *handler.HouseholdHandler, authService service.AuthService) {
+	students := router.Group("/students/:id")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("/siblings", middleware.PermissionMiddleware("students.read", authService), householdHandler.GetStudentSiblings)
+	}
+
+	// Keluarga (satu No KK) beserta anak-anak yang terdaftar
+	households := router.Group("/households")
+	households.Use(middleware.AuthMiddleware(authService))
+	{
+		households.GET("", middleware.PermissionMiddleware("students.read", authService), householdHandler.GetHouseholds)
+		households.GET("/:id", middleware.PermissionMiddleware("students.read", authService), householdHandler.GetHouseholdByID)
+	}
+}
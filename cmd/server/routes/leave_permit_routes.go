@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterLeavePermitRoutes(router *gin.RouterGroup, leavePermitHandler *handler.LeavePermitHandler, authService service.AuthService) {
+	permits := router.Group("/leave-permits")
+	permits.Use(middleware.AuthMiddleware(authService))
+	{
+		// Pengajuan, daftar, konfirmasi wali & pembatalan terbuka untuk petugas dan akun orang tua/wali;
+		// cakupan datanya dicek di service
+		permits.GET("", leavePermitHandler.GetPermits)
+		permits.POST("", leavePermitHandler.CreatePermit)
+		permits.GET("/overdue", middleware.PermissionMiddleware("leave_permits.read", authService), leavePermitHandler.GetOverduePermits)
+		permits.GET("/:id", leavePermitHandler.GetPermitByID)
+		permits.POST("/:id/confirm-guardian", leavePermitHandler.ConfirmGuardian)
+		permits.POST("/:id/cancel", leavePermitHandler.CancelPermit)
+
+		// Persetujuan musyrif (leave_permits.approve / leave_permits.approve_own, dicek di service)
+		permits.POST("/:id/approve", leavePermitHandler.ApprovePermit)
+		permits.POST("/:id/reject", leavePermitHandler.RejectPermit)
+
+		// Pos gerbang
+		permits.POST("/:id/check-out", middleware.PermissionMiddleware("leave_permits.gate", authService), leavePermitHandler.CheckOut)
+		permits.POST("/:id/check-in", middleware.PermissionMiddleware("leave_permits.gate", authService), leavePermitHandler.CheckIn)
+	}
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterParentPortalRoutes mendaftarkan API portal orang tua/wali. Tidak memakai PermissionMiddleware:
+// akses dibatasi di service ke anak yang tertaut dengan akun login.
+func RegisterParentPortalRoutes(router *gin.RouterGroup, portalHandler *handler.ParentPortalHandler, authService service.AuthService) {
+	children := router.Group("/me/children")
+	children.Use(middleware.AuthMiddleware(authService))
+	{
+		children.GET("", portalHandler.GetChildren)
+		children.GET("/:id/schedule", portalHandler.GetSchedule)
+		children.GET("/:id/attendance", portalHandler.GetAttendance)
+		children.GET("/:id/scores", portalHandler.GetScores)
+		children.GET("/:id/violations", portalHandler.GetViolations)
+		children.GET("/:id/announcements", portalHandler.GetAnnouncements)
+	}
+}
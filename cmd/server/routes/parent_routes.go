@@ -37,6 +37,11 @@ func RegisterParentRoutes(
 			middleware.PermissionMiddleware("parents.delete", authService),
 			parentHandler.DeleteParent)
 
+		// Siswa yang walinya (students.guardian_type + guardian_id) menunjuk ke orang tua ini
+		parents.GET("/:id/wards",
+			middleware.PermissionMiddleware("parents.read", authService),
+			parentHandler.GetWards)
+
 		parents.POST("/:id/link-user",
 			middleware.PermissionMiddleware("parents.manage_account", authService),
 			parentHandler.LinkUser)
@@ -31,6 +31,24 @@ func SetupRoutes(
 	gradeHandler *handler.GradeHandler,
 	violationHandler handler.ViolationHandler,
 	financeHandler *handler.FinanceHandler,
+	trashHandler *handler.TrashHandler,
+	backupHandler *handler.BackupHandler,
+	documentHandler *handler.DocumentHandler,
+	studentMutationHandler *handler.StudentMutationHandler,
+	studentCardHandler *handler.StudentCardHandler,
+	studentTimelineHandler *handler.StudentTimelineHandler,
+	admissionHandler *handler.AdmissionHandler,
+	householdHandler *handler.HouseholdHandler,
+	healthHandler *handler.HealthHandler,
+	dormitoryHandler *handler.DormitoryHandler,
+	tahfidzHandler *handler.TahfidzHandler,
+	leavePermitHandler *handler.LeavePermitHandler,
+	announcementHandler *handler.AnnouncementHandler,
+	parentPortalHandler *handler.ParentPortalHandler,
+	familyImportHandler *handler.FamilyImportHandler,
+	contactHandler *handler.ContactHandler,
+	staffAttendanceHandler *handler.StaffAttendanceHandler,
+	teacherWorkloadHandler *handler.TeacherWorkloadHandler,
 ) {
 	// API v1 group
 	apiV1 := router.Group("/api/v1")
@@ -54,6 +72,24 @@ func SetupRoutes(
 	RegisterGradeRoutes(apiV1, gradeHandler, authService)
 	RegisterViolationRoutes(apiV1, violationHandler, authService)
 	RegisterFinanceRoutes(apiV1, financeHandler, authService)
+	RegisterTrashRoutes(apiV1, trashHandler, authService)
+	RegisterBackupRoutes(apiV1, backupHandler, authService)
+	RegisterDocumentRoutes(apiV1, documentHandler, authService)
+	RegisterStudentMutationRoutes(apiV1, studentMutationHandler, authService)
+	RegisterStudentCardRoutes(apiV1, studentCardHandler, authService)
+	RegisterAdmissionRoutes(apiV1, admissionHandler, authService)
+	RegisterStudentTimelineRoutes(apiV1, studentTimelineHandler, authService)
+	RegisterHealthRoutes(apiV1, healthHandler, authService)
+	RegisterHouseholdRoutes(apiV1, householdHandler, authService)
+	RegisterTahfidzRoutes(apiV1, tahfidzHandler, authService)
+	RegisterDormitoryRoutes(apiV1, dormitoryHandler, authService)
+	RegisterAnnouncementRoutes(apiV1, announcementHandler, authService)
+	RegisterLeavePermitRoutes(apiV1, leavePermitHandler, authService)
+	RegisterFamilyImportRoutes(apiV1, familyImportHandler, authService)
+	RegisterParentPortalRoutes(apiV1, parentPortalHandler, authService)
+	RegisterContactRoutes(apiV1, contactHandler, authService)
+	RegisterStaffAttendanceRoutes(apiV1, staffAttendanceHandler, authService)
+	RegisterTeacherWorkloadRoutes(apiV1, teacherWorkloadHandler, authService)
 
 	protected := apiV1.Group("/")
 	protected.Use(middleware.AuthMiddleware(authService))
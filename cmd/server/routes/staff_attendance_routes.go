@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterStaffAttendanceRoutes mendaftarkan kehadiran pegawai, shift kerja, cuti dan import log mesin fingerprint
+func RegisterStaffAttendanceRoutes(router *gin.RouterGroup, staffAttendanceHandler *handler.StaffAttendanceHandler, authService service.AuthService) {
+	shifts := router.Group("/work-shifts")
+	shifts.Use(middleware.AuthMiddleware(authService))
+	{
+		shifts.GET("", middleware.PermissionMiddleware("staff_attendance.read", authService), staffAttendanceHandler.GetShifts)
+		shifts.POST("", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.CreateShift)
+		shifts.PUT("/:id", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.UpdateShift)
+		shifts.DELETE("/:id", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.DeleteShift)
+	}
+
+	attendance := router.Group("/staff-attendance")
+	attendance.Use(middleware.AuthMiddleware(authService))
+	{
+		// Absen mandiri pegawai (akun harus tertaut ke data pegawai)
+		attendance.POST("/clock-in", middleware.PermissionMiddleware("staff_attendance.clock", authService), staffAttendanceHandler.ClockIn)
+		attendance.POST("/clock-out", middleware.PermissionMiddleware("staff_attendance.clock", authService), staffAttendanceHandler.ClockOut)
+
+		attendance.GET("/daily", middleware.PermissionMiddleware("staff_attendance.read", authService), staffAttendanceHandler.GetDaily)
+		attendance.PUT("", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.RecordManual)
+		attendance.POST("/import", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.ImportDeviceLog)
+		attendance.PUT("/employees/:id/settings", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.UpdateEmployeeSettings)
+
+		attendance.GET("/leaves", middleware.PermissionMiddleware("staff_attendance.read", authService), staffAttendanceHandler.GetLeaves)
+		attendance.POST("/leaves", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.CreateLeave)
+		attendance.DELETE("/leaves/:id", middleware.PermissionMiddleware("staff_attendance.manage", authService), staffAttendanceHandler.DeleteLeave)
+
+		attendance.GET("/recap", middleware.PermissionMiddleware("staff_attendance.read", authService), staffAttendanceHandler.GetMonthlyRecap)
+		attendance.GET("/recap/export", middleware.PermissionMiddleware("staff_attendance.read", authService), staffAttendanceHandler.ExportRecap)
+	}
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStudentCardRoutes(router *gin.RouterGroup, cardHandler *handler.StudentCardHandler, authService service.AuthService) {
+	students := router.Group("/students/:id")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.PUT("/photo", middleware.PermissionMiddleware("students.update", authService), cardHandler.UploadPhoto)
+		students.DELETE("/photo", middleware.PermissionMiddleware("students.update", authService), cardHandler.DeletePhoto)
+		students.GET("/id-card", middleware.PermissionMiddleware("students.print_card", authService), cardHandler.GetStudentCard)
+	}
+
+	classrooms := router.Group("/classrooms/:id")
+	classrooms.Use(middleware.AuthMiddleware(authService))
+	{
+		classrooms.GET("/id-cards", middleware.PermissionMiddleware("students.print_card", authService), cardHandler.GetClassroomCards)
+	}
+
+	// Verifikasi QR kartu pelajar, bisa dipakai tanpa login (mis. petugas gerbang atau pihak luar)
+	router.GET("/student-cards/verify", cardHandler.VerifyCard)
+}
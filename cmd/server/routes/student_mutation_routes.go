@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStudentMutationRoutes(router *gin.RouterGroup, mutationHandler *handler.StudentMutationHandler, authService service.AuthService) {
+	students := router.Group("/students/:id/mutations")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("", middleware.PermissionMiddleware("students.read", authService), mutationHandler.GetStudentMutations)
+		students.POST("", middleware.PermissionMiddleware("students.mutate", authService), mutationHandler.CreateMutation)
+	}
+
+	// Laporan mutasi bulanan
+	mutations := router.Group("/student-mutations")
+	mutations.Use(middleware.AuthMiddleware(authService))
+	{
+		mutations.GET("/report", middleware.PermissionMiddleware("students.read", authService), mutationHandler.GetMonthlyReport)
+	}
+}
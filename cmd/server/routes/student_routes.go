@@ -68,5 +68,21 @@ func RegisterStudentRoutes(
 		students.GET("/:id/export/pdf",
 			middleware.PermissionMiddleware("students.read", authService),
 			studentHandler.ExportStudentBiodata)
+
+		// Import massal dari Excel
+		students.GET("/import/template",
+			middleware.PermissionMiddleware("students.create", authService),
+			studentHandler.DownloadImportTemplate)
+		students.POST("/import",
+			middleware.PermissionMiddleware("students.create", authService),
+			studentHandler.ImportStudents)
+
+		// Deteksi & penggabungan data ganda
+		students.GET("/duplicates",
+			middleware.PermissionMiddleware("students.read", authService),
+			studentHandler.FindDuplicates)
+		students.POST("/merge",
+			middleware.PermissionMiddleware("students.merge", authService),
+			studentHandler.MergeStudents)
 	}
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterStudentTimelineRoutes(router *gin.RouterGroup, timelineHandler *handler.StudentTimelineHandler, authService service.AuthService) {
+	students := router.Group("/students/:id")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("/timeline", middleware.PermissionMiddleware("students.read", authService), timelineHandler.GetTimeline)
+	}
+}
@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTahfidzRoutes(router *gin.RouterGroup, tahfidzHandler *handler.TahfidzHandler, authService service.AuthService) {
+	tahfidz := router.Group("/tahfidz")
+	tahfidz.Use(middleware.AuthMiddleware(authService))
+	{
+		tahfidz.GET("/surahs", middleware.PermissionMiddleware("tahfidz.read", authService), tahfidzHandler.GetSurahs)
+		tahfidz.GET("/sessions", middleware.PermissionMiddleware("tahfidz.read", authService), tahfidzHandler.GetSessions)
+		tahfidz.POST("/sessions", middleware.PermissionMiddleware("tahfidz.record", authService), tahfidzHandler.CreateSession)
+		tahfidz.DELETE("/sessions/:id", middleware.PermissionMiddleware("tahfidz.record", authService), tahfidzHandler.DeleteSession)
+		tahfidz.PUT("/targets", middleware.PermissionMiddleware("tahfidz.manage", authService), tahfidzHandler.SetTargets)
+		tahfidz.GET("/export", middleware.PermissionMiddleware("tahfidz.read", authService), tahfidzHandler.ExportExcel)
+	}
+
+	students := router.Group("/students/:id/tahfidz")
+	students.Use(middleware.AuthMiddleware(authService))
+	{
+		students.GET("/progress", middleware.PermissionMiddleware("tahfidz.read", authService), tahfidzHandler.GetProgress)
+		students.GET("/report-card", middleware.PermissionMiddleware("tahfidz.read", authService), tahfidzHandler.GetReportCardSection)
+	}
+}
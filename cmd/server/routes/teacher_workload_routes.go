@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterTeacherWorkloadRoutes mendaftarkan laporan beban mengajar guru (JP) untuk bagian kurikulum
+func RegisterTeacherWorkloadRoutes(router *gin.RouterGroup, teacherWorkloadHandler *handler.TeacherWorkloadHandler, authService service.AuthService) {
+	workload := router.Group("/teacher-workload")
+	workload.Use(middleware.AuthMiddleware(authService))
+	workload.Use(middleware.PermissionMiddleware("teacher_workload.read", authService))
+	{
+		workload.GET("", teacherWorkloadHandler.GetReport)
+		workload.GET("/export/excel", teacherWorkloadHandler.ExportExcel)
+		workload.GET("/export/pdf", teacherWorkloadHandler.ExportPDF)
+	}
+}
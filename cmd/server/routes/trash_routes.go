@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"smart_school_be/internal/handler"
+	"smart_school_be/internal/middleware"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTrashRoutes(router *gin.RouterGroup, h *handler.TrashHandler, authService service.AuthService) {
+	group := router.Group("/trash")
+	group.Use(middleware.AuthMiddleware(authService))
+	{
+		// :type = students | parents | guardians | employees | classrooms | subjects | violations
+		group.GET("/:type", middleware.PermissionMiddleware("trash.manage", authService), h.GetTrash)
+		group.POST("/:type/:id/restore", middleware.PermissionMiddleware("trash.manage", authService), h.Restore)
+	}
+}
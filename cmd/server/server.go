@@ -11,6 +11,7 @@ import (
 	"smart_school_be/internal/repository"
 	"smart_school_be/internal/service"
 	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -38,6 +39,24 @@ type Server struct {
 	GradeHandler              *handler.GradeHandler
 	ViolationHandler          handler.ViolationHandler
 	FinanceHandler            *handler.FinanceHandler
+	TrashHandler              *handler.TrashHandler
+	BackupHandler             *handler.BackupHandler
+	DocumentHandler           *handler.DocumentHandler
+	StudentMutationHandler    *handler.StudentMutationHandler
+	StudentCardHandler        *handler.StudentCardHandler
+	AdmissionHandler          *handler.AdmissionHandler
+	StudentTimelineHandler    *handler.StudentTimelineHandler
+	HealthHandler             *handler.HealthHandler
+	HouseholdHandler          *handler.HouseholdHandler
+	TahfidzHandler            *handler.TahfidzHandler
+	DormitoryHandler          *handler.DormitoryHandler
+	AnnouncementHandler       *handler.AnnouncementHandler
+	LeavePermitHandler        *handler.LeavePermitHandler
+	FamilyImportHandler       *handler.FamilyImportHandler
+	ParentPortalHandler       *handler.ParentPortalHandler
+	ContactHandler            *handler.ContactHandler
+	StaffAttendanceHandler    *handler.StaffAttendanceHandler
+	TeacherWorkloadHandler    *handler.TeacherWorkloadHandler
 	AuthService               service.AuthService
 }
 
@@ -56,6 +75,14 @@ func NewServer() *Server {
 	// Set Gin mode dari config
 	gin.SetMode(cfg.ServerMode)
 
+	// Kunci default kartu pelajar tidak boleh dipakai di production
+	if cfg.StudentCardSecret == "" || cfg.StudentCardSecret == config.DefaultStudentCardSecret {
+		if cfg.ServerMode == gin.ReleaseMode {
+			log.Fatal("STUDENT_CARD_SECRET must be set in release mode")
+		}
+		log.Println("WARNING: STUDENT_CARD_SECRET is empty or uses the public default key, student card QR tokens can be forged")
+	}
+
 	// Initialize database
 	db, err := database.NewDB(cfg)
 	if err != nil {
@@ -80,12 +107,27 @@ func NewServer() *Server {
 	gradeRepo := repository.NewGradeRepository(db)
 	violationRepo := repository.NewViolationRepository(db)
 	donorRepo, donationRepo := repository.NewFinanceRepository(db) // Assuming NewFinanceRepository returns both
+	trashRepo := repository.NewTrashRepository(db)
+	backupRepo := repository.NewBackupRepository(db)
+	documentRepo := repository.NewDocumentRepository(db)
+	studentMutationRepo := repository.NewStudentMutationRepository(db)
+	studentTimelineRepo := repository.NewStudentTimelineRepository(db)
+	admissionRepo := repository.NewAdmissionRepository(db)
+	householdRepo := repository.NewHouseholdRepository(db)
+	healthRepo := repository.NewHealthRepository(db)
+	dormitoryRepo := repository.NewDormitoryRepository(db)
+	tahfidzRepo := repository.NewTahfidzRepository(db)
+	leavePermitRepo := repository.NewLeavePermitRepository(db)
+	announcementRepo := repository.NewAnnouncementRepository(db)
+	contactPreferenceRepo := repository.NewContactPreferenceRepository(db)
+	staffAttendanceRepo := repository.NewStaffAttendanceRepository(db)
 
 	// Initialize utils
 	encryptionUtil, err := utils.NewEncryptionUtil(cfg.EncryptionKey)
 	if err != nil {
 		log.Fatal("Failed to create encryption util:", err)
 	}
+	identityValidator := validation.NewIdentityValidator(cfg.IdentityValidationMode)
 
 	// Initialize converters
 	parentConverter := converter.NewParentConverter(encryptionUtil)
@@ -99,21 +141,28 @@ func NewServer() *Server {
 	permissionService := service.NewPermissionService(permissionRepo)
 	parentService := service.NewParentService(
 		parentRepo,
+		guardianRepo,
+		studentRepo,
 		userRepo,
 		encryptionUtil,
 		parentConverter,
+		identityValidator,
 	)
 	guardianService := service.NewGuardianService(
 		guardianRepo,
+		parentRepo,
+		studentRepo,
 		userRepo,
 		encryptionUtil,
 		guardianConverter,
+		identityValidator,
 	)
 	employeeService := service.NewEmployeeService(
 		employeeRepo,
 		userRepo,
 		encryptionUtil,
 		employeeConverter,
+		identityValidator,
 	)
 	authService := service.NewAuthService(
 		userRepo,
@@ -131,8 +180,11 @@ func NewServer() *Server {
 		parentRepo,
 		guardianRepo,
 		userRepo,
+		classroomRepo,
+		academicYearRepo,
 		encryptionUtil,
 		studentConverter,
+		identityValidator,
 	)
 	dashboardService := service.NewDashboardService(
 		dashboardRepo,
@@ -162,10 +214,28 @@ func NewServer() *Server {
 		teachingAssignmentRepo,
 		employeeRepo,
 	)
-	attendanceService := service.NewAttendanceService(attendanceRepo, scheduleRepo, studentRepo)
+	contactRoutingService := service.NewContactRoutingService(contactPreferenceRepo, studentRepo, parentRepo, guardianRepo)
+	attendanceService := service.NewAttendanceService(attendanceRepo, scheduleRepo, studentRepo, contactRoutingService)
 	gradeService := service.NewGradeService(gradeRepo)
 	violationService := service.NewViolationService(violationRepo, studentRepo)
 	financeService := service.NewFinanceService(donorRepo, donationRepo, employeeRepo, baseURL)
+	trashService := service.NewTrashService(trashRepo)
+	backupService := service.NewBackupService(backupRepo, utils.UploadDir)
+	documentService := service.NewDocumentService(documentRepo, studentRepo, employeeRepo, classroomRepo, baseURL)
+	studentMutationService := service.NewStudentMutationService(studentMutationRepo, studentRepo, classroomRepo, documentRepo, baseURL)
+	studentCardService := service.NewStudentCardService(studentRepo, classroomRepo, cfg.SchoolName, cfg.StudentCardSecret, baseURL)
+	studentTimelineService := service.NewStudentTimelineService(studentTimelineRepo, studentRepo, academicYearRepo)
+	admissionService := service.NewAdmissionService(admissionRepo, academicYearRepo, classroomRepo, studentRepo, parentRepo, documentRepo, encryptionUtil, identityValidator, baseURL)
+	householdService := service.NewHouseholdService(householdRepo, studentRepo, encryptionUtil)
+	healthService := service.NewHealthService(healthRepo, studentRepo, employeeRepo, encryptionUtil)
+	dormitoryService := service.NewDormitoryService(dormitoryRepo, studentRepo, employeeRepo, academicYearRepo)
+	tahfidzService := service.NewTahfidzService(tahfidzRepo, studentRepo, employeeRepo, academicYearRepo)
+	leavePermitService := service.NewLeavePermitService(leavePermitRepo, studentRepo, dormitoryRepo, employeeRepo, academicYearRepo)
+	announcementService := service.NewAnnouncementService(announcementRepo, classroomRepo, studentRepo, contactRoutingService)
+	parentPortalService := service.NewParentPortalService(studentRepo, scheduleRepo, attendanceRepo, gradeRepo, violationRepo, announcementRepo)
+	familyImportService := service.NewFamilyImportService(studentRepo, parentRepo, guardianRepo, encryptionUtil, identityValidator)
+	staffAttendanceService := service.NewStaffAttendanceService(staffAttendanceRepo, employeeRepo)
+	teacherWorkloadService := service.NewTeacherWorkloadService(scheduleRepo, teachingAssignmentRepo, attendanceRepo, academicYearRepo, cfg.LessonPeriodMinutes, cfg.TeacherMinimumJP)
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userService)
@@ -186,6 +256,24 @@ func NewServer() *Server {
 	gradeHandler := handler.NewGradeHandler(gradeService)
 	violationHandler := handler.NewViolationHandler(violationService)
 	financeHandler := handler.NewFinanceHandler(financeService)
+	trashHandler := handler.NewTrashHandler(trashService)
+	backupHandler := handler.NewBackupHandler(backupService)
+	documentHandler := handler.NewDocumentHandler(documentService)
+	studentMutationHandler := handler.NewStudentMutationHandler(studentMutationService)
+	studentCardHandler := handler.NewStudentCardHandler(studentCardService)
+	studentTimelineHandler := handler.NewStudentTimelineHandler(studentTimelineService)
+	admissionHandler := handler.NewAdmissionHandler(admissionService)
+	householdHandler := handler.NewHouseholdHandler(householdService)
+	healthHandler := handler.NewHealthHandler(healthService)
+	dormitoryHandler := handler.NewDormitoryHandler(dormitoryService)
+	tahfidzHandler := handler.NewTahfidzHandler(tahfidzService)
+	leavePermitHandler := handler.NewLeavePermitHandler(leavePermitService)
+	announcementHandler := handler.NewAnnouncementHandler(announcementService)
+	parentPortalHandler := handler.NewParentPortalHandler(parentPortalService)
+	familyImportHandler := handler.NewFamilyImportHandler(familyImportService)
+	contactHandler := handler.NewContactHandler(contactRoutingService)
+	staffAttendanceHandler := handler.NewStaffAttendanceHandler(staffAttendanceService)
+	teacherWorkloadHandler := handler.NewTeacherWorkloadHandler(teacherWorkloadService)
 
 	// Setup router with middleware
 	router := setupRouter(cfg, authService)
@@ -211,6 +299,24 @@ func NewServer() *Server {
 		GradeHandler:              gradeHandler,
 		ViolationHandler:          violationHandler,
 		FinanceHandler:            financeHandler,
+		TrashHandler:              trashHandler,
+		BackupHandler:             backupHandler,
+		DocumentHandler:           documentHandler,
+		StudentMutationHandler:    studentMutationHandler,
+		StudentCardHandler:        studentCardHandler,
+		AdmissionHandler:          admissionHandler,
+		StudentTimelineHandler:    studentTimelineHandler,
+		HealthHandler:             healthHandler,
+		HouseholdHandler:          householdHandler,
+		TahfidzHandler:            tahfidzHandler,
+		DormitoryHandler:          dormitoryHandler,
+		AnnouncementHandler:       announcementHandler,
+		LeavePermitHandler:        leavePermitHandler,
+		FamilyImportHandler:       familyImportHandler,
+		ParentPortalHandler:       parentPortalHandler,
+		ContactHandler:            contactHandler,
+		StaffAttendanceHandler:    staffAttendanceHandler,
+		TeacherWorkloadHandler:    teacherWorkloadHandler,
 		AuthService:               authService,
 	}
 }
@@ -255,6 +361,24 @@ func (s *Server) Start() error {
 		s.GradeHandler,
 		s.ViolationHandler,
 		s.FinanceHandler,
+		s.TrashHandler,
+		s.BackupHandler,
+		s.DocumentHandler,
+		s.StudentMutationHandler,
+		s.StudentCardHandler,
+		s.StudentTimelineHandler,
+		s.AdmissionHandler,
+		s.HouseholdHandler,
+		s.HealthHandler,
+		s.DormitoryHandler,
+		s.TahfidzHandler,
+		s.LeavePermitHandler,
+		s.AnnouncementHandler,
+		s.ParentPortalHandler,
+		s.FamilyImportHandler,
+		s.ContactHandler,
+		s.StaffAttendanceHandler,
+		s.TeacherWorkloadHandler,
 	)
 
 	// Start server
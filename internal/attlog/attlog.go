@@ -0,0 +1,299 @@
+// Package attlog membaca ekspor log mesin fingerprint: file attlog.dat (format ZKTeco/Solution,
+// dipisah tab atau spasi) dan ekspor CSV dengan baris judul. Tanggal bertipe dd/mm/yyyy
+// dibaca sebagai format Indonesia (hari dulu).
+package attlog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Punch adalah satu rekaman sidik jari/kartu dari mesin
+type Punch struct {
+	DeviceUserID string
+	Time         time.Time
+	Line         int
+}
+
+// LineError adalah baris yang tidak bisa dibaca
+type LineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Day adalah rekaman satu user mesin dalam satu hari: rekaman pertama dianggap masuk,
+// rekaman terakhir dianggap pulang
+type Day struct {
+	DeviceUserID string
+	Date         string // YYYY-MM-DD
+	First        time.Time
+	Last         time.Time
+	Punches      int
+}
+
+var dateTimeLayouts = []string{
+	"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05",
+	"2006/01/02 15:04:05", "2006/01/02 15:04",
+	"02/01/2006 15:04:05", "02/01/2006 15:04", "2/1/2006 15:04:05", "2/1/2006 15:04",
+	"02-01-2006 15:04:05", "02-01-2006 15:04",
+}
+
+var dateLayouts = []string{"2006-01-02", "2006/01/02", "02/01/2006", "2/1/2006", "02-01-2006"}
+
+var timeLayouts = []string{"15:04:05", "15:04"}
+
+// Kolom CSV yang dikenali (judul dinormalisasi: huruf kecil tanpa spasi/tanda baca)
+var (
+	idHeaders       = []string{"userid", "acno", "no", "pin", "enno", "id", "badgenumber", "iduser", "nomor"}
+	dateTimeHeaders = []string{"datetime", "checktime", "waktu", "time"}
+	dateHeaders     = []string{"date", "tanggal", "tgl"}
+	timeHeaders     = []string{"time", "jam"}
+)
+
+// Parse membaca seluruh isi file. Baris yang rusak dikembalikan sebagai LineError, bukan error;
+// error hanya untuk file yang sama sekali tidak bisa dibaca.
+func Parse(r io.Reader, loc *time.Location) ([]Punch, []LineError, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(lines) > 0 {
+		lines[0] = strings.TrimPrefix(lines[0], "\ufeff")
+	}
+
+	first := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return nil, nil, fmt.Errorf("file is empty")
+	}
+
+	if isHeader(lines[first]) {
+		return parseCSV(lines, first, loc)
+	}
+	punches, errs := parseAttlog(lines, loc)
+	return punches, errs, nil
+}
+
+// Daily mengelompokkan rekaman per user mesin per tanggal, diurutkan berdasarkan user lalu tanggal
+func Daily(punches []Punch) []Day {
+	byKey := map[string]*Day{}
+	for _, punch := range punches {
+		date := punch.Time.Format("2006-01-02")
+		key := punch.DeviceUserID + "|" + date
+		day, ok := byKey[key]
+		if !ok {
+			day = &Day{DeviceUserID: punch.DeviceUserID, Date: date, First: punch.Time, Last: punch.Time}
+			byKey[key] = day
+		}
+		if punch.Time.Before(day.First) {
+			day.First = punch.Time
+		}
+		if punch.Time.After(day.Last) {
+			day.Last = punch.Time
+		}
+		day.Punches++
+	}
+
+	days := make([]Day, 0, len(byKey))
+	for _, day := range byKey {
+		days = append(days, *day)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		if days[i].DeviceUserID != days[j].DeviceUserID {
+			return days[i].DeviceUserID < days[j].DeviceUserID
+		}
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
+
+// parseAttlog membaca baris "<id>\t<yyyy-mm-dd hh:mm:ss>\t<verify>\t<status>..." atau versi dipisah spasi
+func parseAttlog(lines []string, loc *time.Location) ([]Punch, []LineError) {
+	var punches []Punch
+	var errs []LineError
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lineNum := i + 1
+
+		var id, value string
+		if parts := strings.Split(line, "\t"); len(parts) >= 2 {
+			id, value = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		} else if fields := strings.Fields(line); len(fields) >= 3 {
+			id, value = fields[0], fields[1]+" "+fields[2]
+		} else {
+			errs = append(errs, LineError{lineNum, "expected device user ID and date/time"})
+			continue
+		}
+
+		id = NormalizeID(id)
+		t, err := parseDateTime(value, loc)
+		if id == "" || err != nil {
+			errs = append(errs, LineError{lineNum, fmt.Sprintf("invalid record %q", strings.TrimSpace(line))})
+			continue
+		}
+		punches = append(punches, Punch{DeviceUserID: id, Time: t, Line: lineNum})
+	}
+	return punches, errs
+}
+
+func parseCSV(lines []string, headerIndex int, loc *time.Location) ([]Punch, []LineError, error) {
+	header := lines[headerIndex]
+	reader := csv.NewReader(strings.NewReader(strings.Join(lines[headerIndex:], "\n")))
+	reader.Comma = detectDelimiter(header)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, label := range records[0] {
+		key := normalizeHeader(label)
+		if _, ok := columns[key]; !ok {
+			columns[key] = i
+		}
+	}
+	idCol := findColumn(columns, idHeaders)
+	dateCol := findColumn(columns, dateHeaders)
+	timeCol, dateTimeCol := -1, -1
+	if dateCol >= 0 {
+		timeCol = findColumn(columns, timeHeaders)
+	} else {
+		dateTimeCol = findColumn(columns, dateTimeHeaders)
+	}
+	if idCol < 0 || (dateTimeCol < 0 && (dateCol < 0 || timeCol < 0)) {
+		return nil, nil, fmt.Errorf("CSV must have a device user ID column and a date/time column (or separate date and time columns)")
+	}
+
+	var punches []Punch
+	var errs []LineError
+	for i, record := range records[1:] {
+		lineNum := headerIndex + i + 2
+		cell := func(col int) string {
+			if col < len(record) {
+				return strings.TrimSpace(record[col])
+			}
+			return ""
+		}
+		id := NormalizeID(cell(idCol))
+		if id == "" {
+			if strings.TrimSpace(strings.Join(record, "")) != "" {
+				errs = append(errs, LineError{lineNum, "device user ID is empty"})
+			}
+			continue
+		}
+
+		var t time.Time
+		var err error
+		if dateTimeCol >= 0 {
+			t, err = parseDateTime(cell(dateTimeCol), loc)
+		} else {
+			t, err = parseDateAndTime(cell(dateCol), cell(timeCol), loc)
+		}
+		if err != nil {
+			errs = append(errs, LineError{lineNum, err.Error()})
+			continue
+		}
+		punches = append(punches, Punch{DeviceUserID: id, Time: t, Line: lineNum})
+	}
+	return punches, errs, nil
+}
+
+// NormalizeID menyamakan ID user mesin, mis. "00012" dan "12" dianggap sama
+func NormalizeID(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	if trimmed := strings.TrimLeft(id, "0"); trimmed != "" {
+		return trimmed
+	}
+	return "0"
+}
+
+// isHeader menganggap baris pertama sebagai judul CSV jika tidak diawali angka (ID user)
+func isHeader(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return false
+	}
+	c := trimmed[0]
+	return !(c >= '0' && c <= '9')
+}
+
+func detectDelimiter(header string) rune {
+	best, count := ',', strings.Count(header, ",")
+	for _, d := range []rune{';', '\t'} {
+		if n := strings.Count(header, string(d)); n > count {
+			best, count = d, n
+		}
+	}
+	return best
+}
+
+func normalizeHeader(label string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func findColumn(columns map[string]int, keys []string) int {
+	for _, key := range keys {
+		if col, ok := columns[key]; ok {
+			return col
+		}
+	}
+	return -1
+}
+
+func parseDateTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date/time %q", value)
+}
+
+func parseDateAndTime(dateValue, timeValue string, loc *time.Location) (time.Time, error) {
+	var date time.Time
+	var err error
+	for _, layout := range dateLayouts {
+		if date, err = time.ParseInLocation(layout, dateValue, loc); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", dateValue)
+	}
+	for _, layout := range timeLayouts {
+		if clock, err := time.Parse(layout, timeValue); err == nil {
+			return date.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute + time.Duration(clock.Second())*time.Second), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", timeValue)
+}
@@ -0,0 +1,96 @@
+package attlog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var wib = time.FixedZone("WIB", 7*3600)
+
+func TestParseAttlog(t *testing.T) {
+	input := "        1\t2026-10-01 07:02:33\t1\t0\t1\t0\n" +
+		"      012\t2026-10-01 06:55:00\t1\t0\t1\t0\n" +
+		"\n" +
+		"12 2026-10-01 15:31:10 1 1\n" +
+		"garbage\n"
+
+	punches, errs, err := Parse(strings.NewReader(input), wib)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(punches) != 3 {
+		t.Fatalf("expected 3 punches, got %d: %+v", len(punches), punches)
+	}
+	if punches[1].DeviceUserID != "12" || punches[2].DeviceUserID != "12" {
+		t.Errorf("device IDs should be normalized, got %q and %q", punches[1].DeviceUserID, punches[2].DeviceUserID)
+	}
+	want := time.Date(2026, 10, 1, 7, 2, 33, 0, wib)
+	if !punches[0].Time.Equal(want) {
+		t.Errorf("time = %v, want %v", punches[0].Time, want)
+	}
+	if len(errs) != 1 || errs[0].Line != 5 {
+		t.Errorf("expected error on line 5, got %+v", errs)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "\ufeffAC-No.,No.,Name,Time,State\n" +
+		"7,1,Ahmad,01/10/2026 07:15,C/In\n" +
+		"7,1,Ahmad,01/10/2026 16:05,C/Out\n" +
+		"8,2,Budi,bad,C/In\n"
+
+	punches, errs, err := Parse(strings.NewReader(input), wib)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(punches) != 2 || punches[0].DeviceUserID != "7" {
+		t.Fatalf("unexpected punches: %+v", punches)
+	}
+	if want := time.Date(2026, 10, 1, 7, 15, 0, 0, wib); !punches[0].Time.Equal(want) {
+		t.Errorf("dd/mm/yyyy should be read day first, got %v", punches[0].Time)
+	}
+	if len(errs) != 1 || errs[0].Line != 4 {
+		t.Errorf("expected error on line 4, got %+v", errs)
+	}
+}
+
+func TestParseCSVSeparateDateAndTime(t *testing.T) {
+	input := "User ID;Tanggal;Jam\n0005;2026-10-02;06:50:12\n"
+
+	punches, _, err := Parse(strings.NewReader(input), wib)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(punches) != 1 || punches[0].DeviceUserID != "5" {
+		t.Fatalf("unexpected punches: %+v", punches)
+	}
+	if want := time.Date(2026, 10, 2, 6, 50, 12, 0, wib); !punches[0].Time.Equal(want) {
+		t.Errorf("time = %v, want %v", punches[0].Time, want)
+	}
+
+	if _, _, err := Parse(strings.NewReader("Name,Dept\nAhmad,TU\n"), wib); err == nil {
+		t.Error("expected error for CSV without ID and time columns")
+	}
+}
+
+func TestDaily(t *testing.T) {
+	punches := []Punch{
+		{DeviceUserID: "2", Time: time.Date(2026, 10, 1, 16, 0, 0, 0, wib)},
+		{DeviceUserID: "2", Time: time.Date(2026, 10, 1, 7, 0, 0, 0, wib)},
+		{DeviceUserID: "2", Time: time.Date(2026, 10, 1, 12, 0, 0, 0, wib)},
+		{DeviceUserID: "1", Time: time.Date(2026, 10, 2, 7, 30, 0, 0, wib)},
+	}
+
+	days := Daily(punches)
+	if len(days) != 2 || days[0].DeviceUserID != "1" {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+	day := days[1]
+	if day.Punches != 3 || day.First.Hour() != 7 || day.Last.Hour() != 16 {
+		t.Errorf("unexpected day summary: %+v", day)
+	}
+	if !days[0].First.Equal(days[0].Last) {
+		t.Error("single punch should be both first and last")
+	}
+}
@@ -53,8 +53,26 @@ type Config struct {
 	// Auto migration & seeding
 	AutoMigrate bool
 	AutoSeed    bool
+
+	// Soft delete
+	TrashRetentionDays int
+
+	// Kartu pelajar
+	SchoolName        string
+	StudentCardSecret string
+
+	// Validasi NIK/NISN/No KK: strict (tolak) atau warn (simpan dan catat di log)
+	IdentityValidationMode string
+
+	// Beban mengajar: durasi 1 JP (menit) dan beban minimum guru per minggu (JP)
+	LessonPeriodMinutes int
+	TeacherMinimumJP    int
 }
 
+// DefaultStudentCardSecret hanya untuk development; nilainya publik di repo sehingga token QR
+// kartu pelajar yang ditandatangani dengan kunci ini bisa dipalsukan
+const DefaultStudentCardSecret = "very-secret-card-key-change-in-production"
+
 func LoadConfig() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -105,6 +123,18 @@ func LoadConfig() *Config {
 		// Auto migration settings
 		AutoMigrate: getEnvAsBool("AUTO_MIGRATE", true),
 		AutoSeed:    getEnvAsBool("AUTO_SEED", true),
+
+		// Soft delete
+		TrashRetentionDays: getEnvAsInt("TRASH_RETENTION_DAYS", 30),
+
+		// Kartu pelajar
+		SchoolName:        getEnv("SCHOOL_NAME", "Smart School"),
+		StudentCardSecret: getEnv("STUDENT_CARD_SECRET", DefaultStudentCardSecret),
+
+		IdentityValidationMode: getEnv("IDENTITY_VALIDATION_MODE", "strict"),
+
+		LessonPeriodMinutes: getEnvAsInt("LESSON_PERIOD_MINUTES", 45),
+		TeacherMinimumJP:    getEnvAsInt("TEACHER_MINIMUM_JP", 24),
 	}
 }
 
@@ -0,0 +1,235 @@
+// Package contact berisi kebijakan routing kontak keluarga: siapa yang dihubungi tentang
+// seorang siswa untuk kategori pesan tertentu, lewat kanal apa, dan kapan boleh dikirim.
+// Pengumuman, notifikasi ketidakhadiran, dan fitur pesan lain memakai kebijakan yang sama.
+package contact
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kanal pengiriman
+const (
+	ChannelWhatsApp = "WHATSAPP"
+	ChannelSMS      = "SMS"
+	ChannelEmail    = "EMAIL"
+)
+
+// Kategori pesan. Kategori EMERGENCY tidak bisa di-opt-out dan mengabaikan jam tenang.
+const (
+	CategoryAnnouncement = "ANNOUNCEMENT"
+	CategoryAttendance   = "ATTENDANCE"
+	CategoryAcademic     = "ACADEMIC"
+	CategoryFinance      = "FINANCE"
+	CategoryDiscipline   = "DISCIPLINE"
+	CategoryHealth       = "HEALTH"
+	CategoryEmergency    = "EMERGENCY"
+)
+
+// Alasan sebuah kandidat tidak dipilih
+const (
+	SkipDeceased      = "deceased"
+	SkipOptedOut      = "opted_out"
+	SkipNoAddress     = "no_contact_address"
+	SkipLowerPriority = "lower_priority"
+)
+
+// DefaultChannel dan DefaultLanguage dipakai untuk kontak yang belum mengatur preferensi
+const (
+	DefaultChannel  = ChannelWhatsApp
+	DefaultLanguage = "id"
+)
+
+var Channels = []string{ChannelWhatsApp, ChannelSMS, ChannelEmail}
+
+var Categories = []string{
+	CategoryAnnouncement, CategoryAttendance, CategoryAcademic, CategoryFinance,
+	CategoryDiscipline, CategoryHealth, CategoryEmergency,
+}
+
+var Languages = []string{"id", "en", "ar"}
+
+// Preference adalah preferensi komunikasi satu kontak (orang tua atau wali)
+type Preference struct {
+	Channel    string
+	Language   string
+	QuietStart string // "HH:MM", kosong = tanpa jam tenang
+	QuietEnd   string
+	OptOut     []string // Kategori yang tidak ingin diterima
+}
+
+// Candidate adalah satu kontak keluarga seorang siswa beserta perannya
+type Candidate struct {
+	Type         string // parent, guardian
+	ID           string
+	Name         string
+	Relationship string // FATHER, MOTHER, ... atau hubungan wali
+	Phone        string
+	Email        string
+	Primary      bool // Kontak utama siswa
+	Guardian     bool // Ditunjuk sebagai wali siswa
+	Deceased     bool
+	Preference   *Preference // nil = preferensi default
+}
+
+// Route adalah hasil routing untuk satu kandidat
+type Route struct {
+	Type         string     `json:"contact_type"`
+	ID           string     `json:"contact_id"`
+	Name         string     `json:"name"`
+	Relationship string     `json:"relationship,omitempty"`
+	Primary      bool       `json:"is_primary"`
+	Guardian     bool       `json:"is_guardian"`
+	Channel      string     `json:"channel,omitempty"`
+	Address      string     `json:"address,omitempty"`
+	Language     string     `json:"language,omitempty"`
+	DeliverAfter *time.Time `json:"deliver_after,omitempty"` // Diisi jika sekarang dalam jam tenang
+	SkipReason   string     `json:"skip_reason,omitempty"`
+}
+
+func IsChannel(value string) bool    { return contains(Channels, value) }
+func IsCategory(value string) bool   { return contains(Categories, value) }
+func IsLanguage(value string) bool   { return contains(Languages, value) }
+func Broadcast(category string) bool { return category == CategoryEmergency }
+
+// ParseClock memvalidasi jam format HH:MM dan mengembalikan menit sejak tengah malam
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Resolve mengurutkan kandidat (kontak utama, wali, ayah/ibu, lainnya) lalu memilih penerima.
+// Kategori EMERGENCY dikirim ke semua kandidat yang bisa dihubungi; kategori lain hanya ke
+// kandidat pertama yang bisa dihubungi. Kandidat yang tidak dipilih dikembalikan sebagai skipped.
+func Resolve(candidates []Candidate, category string, now time.Time) (recipients, skipped []Route) {
+	ordered := make([]Candidate, len(candidates))
+	copy(ordered, candidates)
+	sort.SliceStable(ordered, func(i, j int) bool { return rank(ordered[i]) < rank(ordered[j]) })
+
+	emergency := category == CategoryEmergency
+	for _, c := range ordered {
+		pref := c.Preference
+		if pref == nil {
+			pref = &Preference{}
+		}
+		route := Route{
+			Type:         c.Type,
+			ID:           c.ID,
+			Name:         c.Name,
+			Relationship: c.Relationship,
+			Primary:      c.Primary,
+			Guardian:     c.Guardian,
+			Language:     pref.Language,
+		}
+		if route.Language == "" {
+			route.Language = DefaultLanguage
+		}
+
+		switch {
+		case c.Deceased:
+			route.SkipReason = SkipDeceased
+		case !emergency && contains(pref.OptOut, category):
+			route.SkipReason = SkipOptedOut
+		default:
+			route.Channel, route.Address = pickChannel(c, pref.Channel)
+			if route.Channel == "" {
+				route.SkipReason = SkipNoAddress
+			} else if len(recipients) > 0 && !Broadcast(category) {
+				route.SkipReason = SkipLowerPriority
+			}
+		}
+		if route.SkipReason != "" {
+			skipped = append(skipped, route)
+			continue
+		}
+
+		if !emergency {
+			route.DeliverAfter = quietUntil(pref.QuietStart, pref.QuietEnd, now)
+		}
+		recipients = append(recipients, route)
+	}
+	return recipients, skipped
+}
+
+// rank menentukan urutan prioritas kandidat
+func rank(c Candidate) int {
+	switch {
+	case c.Primary:
+		return 0
+	case c.Guardian:
+		return 1
+	case c.Relationship == "FATHER" || c.Relationship == "MOTHER":
+		return 2
+	}
+	return 3
+}
+
+// pickChannel memakai kanal pilihan jika alamatnya tersedia, jika tidak jatuh ke kanal lain
+// dengan urutan WhatsApp, SMS, Email
+func pickChannel(c Candidate, preferred string) (string, string) {
+	address := func(channel string) string {
+		if channel == ChannelEmail {
+			return c.Email
+		}
+		return c.Phone
+	}
+	if preferred == "" {
+		preferred = DefaultChannel
+	}
+	if a := address(preferred); a != "" {
+		return preferred, a
+	}
+	for _, channel := range Channels {
+		if a := address(channel); a != "" {
+			return channel, a
+		}
+	}
+	return "", ""
+}
+
+// quietUntil mengembalikan akhir jam tenang jika now berada di dalamnya. Jendela boleh
+// melewati tengah malam, mis. 21:00-06:00.
+func quietUntil(start, end string, now time.Time) *time.Time {
+	if start == "" || end == "" {
+		return nil
+	}
+	startMin, err1 := ParseClock(start)
+	endMin, err2 := ParseClock(end)
+	if err1 != nil || err2 != nil || startMin == endMin {
+		return nil
+	}
+
+	current := now.Hour()*60 + now.Minute()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endToday := midnight.Add(time.Duration(endMin) * time.Minute)
+
+	if startMin < endMin {
+		if current >= startMin && current < endMin {
+			return &endToday
+		}
+		return nil
+	}
+	// Jendela melewati tengah malam
+	if current >= startMin {
+		next := endToday.AddDate(0, 0, 1)
+		return &next
+	}
+	if current < endMin {
+		return &endToday
+	}
+	return nil
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
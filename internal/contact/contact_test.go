@@ -0,0 +1,112 @@
+package contact
+
+import (
+	"testing"
+	"time"
+)
+
+func at(hour, minute int) time.Time {
+	return time.Date(2026, 10, 19, hour, minute, 0, 0, time.UTC)
+}
+
+func TestResolveOrderAndSinglePrimary(t *testing.T) {
+	candidates := []Candidate{
+		{Type: "parent", ID: "mother", Relationship: "MOTHER", Phone: "0812"},
+		{Type: "guardian", ID: "uncle", Relationship: "Paman", Phone: "0813", Guardian: true},
+		{Type: "parent", ID: "father", Relationship: "FATHER", Phone: "0814", Primary: true},
+	}
+
+	recipients, skipped := Resolve(candidates, CategoryAttendance, at(10, 0))
+	if len(recipients) != 1 || recipients[0].ID != "father" {
+		t.Fatalf("expected primary contact only, got %+v", recipients)
+	}
+	if len(skipped) != 2 || skipped[0].ID != "uncle" || skipped[1].ID != "mother" {
+		t.Fatalf("unexpected skipped order: %+v", skipped)
+	}
+	for _, s := range skipped {
+		if s.SkipReason != SkipLowerPriority {
+			t.Errorf("%s: reason %q, want %q", s.ID, s.SkipReason, SkipLowerPriority)
+		}
+	}
+}
+
+func TestResolveOptOutFallsThrough(t *testing.T) {
+	candidates := []Candidate{
+		{Type: "parent", ID: "father", Relationship: "FATHER", Phone: "0814", Primary: true,
+			Preference: &Preference{OptOut: []string{CategoryAnnouncement}}},
+		{Type: "parent", ID: "mother", Relationship: "MOTHER", Email: "ibu@example.com"},
+		{Type: "parent", ID: "late", Relationship: "FATHER", Phone: "0815", Deceased: true},
+	}
+
+	recipients, skipped := Resolve(candidates, CategoryAnnouncement, at(10, 0))
+	if len(recipients) != 1 || recipients[0].ID != "mother" {
+		t.Fatalf("expected mother as recipient, got %+v", recipients)
+	}
+	if recipients[0].Channel != ChannelEmail || recipients[0].Address != "ibu@example.com" {
+		t.Errorf("expected email fallback, got %s %s", recipients[0].Channel, recipients[0].Address)
+	}
+	reasons := map[string]string{}
+	for _, s := range skipped {
+		reasons[s.ID] = s.SkipReason
+	}
+	if reasons["father"] != SkipOptedOut || reasons["late"] != SkipDeceased {
+		t.Errorf("unexpected skip reasons: %v", reasons)
+	}
+}
+
+func TestResolveEmergencyBroadcastIgnoresOptOutAndQuietHours(t *testing.T) {
+	pref := &Preference{OptOut: []string{CategoryEmergency}, QuietStart: "21:00", QuietEnd: "06:00"}
+	candidates := []Candidate{
+		{Type: "parent", ID: "father", Relationship: "FATHER", Phone: "0814", Preference: pref},
+		{Type: "parent", ID: "mother", Relationship: "MOTHER", Phone: "0812", Preference: pref},
+		{Type: "guardian", ID: "none", Relationship: "Paman"},
+	}
+
+	recipients, skipped := Resolve(candidates, CategoryEmergency, at(23, 0))
+	if len(recipients) != 2 {
+		t.Fatalf("expected 2 recipients, got %+v", recipients)
+	}
+	for _, r := range recipients {
+		if r.DeliverAfter != nil {
+			t.Errorf("%s: emergency must not wait for quiet hours", r.ID)
+		}
+	}
+	if len(skipped) != 1 || skipped[0].SkipReason != SkipNoAddress {
+		t.Errorf("expected contact without address to be skipped, got %+v", skipped)
+	}
+}
+
+func TestResolveQuietHours(t *testing.T) {
+	tests := []struct {
+		start, end string
+		now        time.Time
+		want       *time.Time
+	}{
+		{"21:00", "06:00", at(23, 30), ptr(time.Date(2026, 10, 20, 6, 0, 0, 0, time.UTC))},
+		{"21:00", "06:00", at(5, 59), ptr(at(6, 0))},
+		{"21:00", "06:00", at(6, 0), nil},
+		{"12:00", "13:00", at(12, 30), ptr(at(13, 0))},
+		{"12:00", "13:00", at(14, 0), nil},
+		{"", "", at(23, 0), nil},
+	}
+	for _, tt := range tests {
+		candidates := []Candidate{{Type: "parent", ID: "p", Phone: "0812",
+			Preference: &Preference{QuietStart: tt.start, QuietEnd: tt.end}}}
+		recipients, _ := Resolve(candidates, CategoryAttendance, tt.now)
+		got := recipients[0].DeliverAfter
+		if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+			t.Errorf("%s-%s at %s: got %v, want %v", tt.start, tt.end, tt.now.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	if m, err := ParseClock("06:30"); err != nil || m != 390 {
+		t.Errorf("ParseClock(06:30) = %d, %v", m, err)
+	}
+	if _, err := ParseClock("25:00"); err == nil {
+		t.Error("expected error for invalid hour")
+	}
+}
+
+func ptr(t time.Time) *time.Time { return &t }
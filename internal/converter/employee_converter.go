@@ -51,6 +51,8 @@ func (c *employeeConverter) ToEmployeeDetailResponse(employee *domain.Employee)
 		DateOfBirth:      employee.DateOfBirth,
 		JoinDate:         employee.JoinDate,
 		EmploymentStatus: employee.EmploymentStatus, // Direct assign pointer
+		DeviceUserID:     employee.DeviceUserID,
+		WorkShiftID:      employee.WorkShiftID,
 		CreatedAt:        employee.CreatedAt,
 		UpdatedAt:        employee.UpdatedAt,
 	}
@@ -1,7 +1,6 @@
 package converter
 
 import (
-	"fmt"
 	"log"
 	"os"
 	"smart_school_be/internal/model/domain"
@@ -75,61 +74,31 @@ func (c *studentConverter) ToStudentDetailResponse(student *domain.Student) *res
 		}
 	}
 
-	var (
-		birthCertURL, familyCardURL, parentStmtURL, studentStmtURL *string
-		healthInsURL, diplomaURL, gradCertURL, finHardshipURL      *string
-	)
-
-	// Helper to generate URL
-	generateURL := func(path *string) *string {
-		if path != nil && *path != "" {
-			url := fmt.Sprintf("%s/api/v1/files/%s", c.baseURL, *path)
-			return &url
-		}
-		return nil
-	}
-
-	birthCertURL = generateURL(student.BirthCertificateFile)
-	familyCardURL = generateURL(student.FamilyCardFile)
-	parentStmtURL = generateURL(student.ParentStatementFile)
-	studentStmtURL = generateURL(student.StudentStatementFile)
-	healthInsURL = generateURL(student.HealthInsuranceFile)
-	diplomaURL = generateURL(student.DiplomaCertificateFile)
-	gradCertURL = generateURL(student.GraduationCertificateFile)
-	finHardshipURL = generateURL(student.FinancialHardshipLetterFile)
-
 	return &response.StudentDetailResponse{
-		ID:                             student.ID,
-		FullName:                       student.FullName,
-		NoKK:                           decryptedNoKK, // <-- Data plaintext
-		NIK:                            decryptedNIK,  // <-- Data plaintext
-		NISN:                           student.NISN,
-		NIM:                            student.NIM,
-		Gender:                         student.Gender,
-		PlaceOfBirth:                   student.PlaceOfBirth,
-		DateOfBirth:                    student.DateOfBirth,
-		Address:                        student.Address,
-		RT:                             student.RT,
-		RW:                             student.RW,
-		SubDistrict:                    student.SubDistrict,
-		District:                       student.District,
-		City:                           student.City,
-		Province:                       student.Province,
-		PostalCode:                     student.PostalCode,
-		Status:                         student.Status,
-		EntryYear:                      student.EntryYear,
-		ExitYear:                       student.ExitYear,
-		BirthCertificateFileURL:        birthCertURL,
-		FamilyCardFileURL:              familyCardURL,
-		ParentStatementFileURL:         parentStmtURL,
-		StudentStatementFileURL:        studentStmtURL,
-		HealthInsuranceFileURL:         healthInsURL,
-		DiplomaCertificateFileURL:      diplomaURL,
-		GraduationCertificateFileURL:   gradCertURL,
-		FinancialHardshipLetterFileURL: finHardshipURL,
-		CreatedAt:                      student.CreatedAt,
-		UpdatedAt:                      student.UpdatedAt,
-		Parents:                        parentResponses,
+		ID:           student.ID,
+		FullName:     student.FullName,
+		NoKK:         decryptedNoKK, // <-- Data plaintext
+		NIK:          decryptedNIK,  // <-- Data plaintext
+		NISN:         student.NISN,
+		NIM:          student.NIM,
+		Gender:       student.Gender,
+		PlaceOfBirth: student.PlaceOfBirth,
+		DateOfBirth:  student.DateOfBirth,
+		Address:      student.Address,
+		RT:           student.RT,
+		RW:           student.RW,
+		SubDistrict:  student.SubDistrict,
+		District:     student.District,
+		City:         student.City,
+		Province:     student.Province,
+		PostalCode:   student.PostalCode,
+		Status:       student.Status,
+		EntryYear:    student.EntryYear,
+		ExitYear:     student.ExitYear,
+		PhotoURL:     response.GenerateFileURL(student.Photo, c.baseURL),
+		CreatedAt:    student.CreatedAt,
+		UpdatedAt:    student.UpdatedAt,
+		Parents:      parentResponses,
 	}
 }
 
@@ -170,6 +139,7 @@ func (c *studentConverter) ToStudentListResponse(student *domain.Student) *respo
 		Level:     level,
 		Status:    status,
 		Email:     email,
+		PhotoURL:  response.GenerateFileURL(student.Photo, c.baseURL),
 	}
 }
 
@@ -65,6 +65,9 @@ func seedPermissions(db *gorm.DB) error {
 		{Name: "students.manage_parents", Description: "Manage student parents relationship"},
 		{Name: "students.manage_guardian", Description: "Set or remove student guardian"},
 		{Name: "students.manage_account", Description: "Link or unlink student user account"},
+		{Name: "students.mutate", Description: "Record student transfers, dropout, graduation and re-admission"},
+		{Name: "students.merge", Description: "Find and merge duplicate student records"},
+		{Name: "students.print_card", Description: "Print student ID cards"},
 
 		// ===== Parents =====
 		{Name: "parents.create", Description: "Create new parent"},
@@ -72,6 +75,7 @@ func seedPermissions(db *gorm.DB) error {
 		{Name: "parents.update", Description: "Update parent data"},
 		{Name: "parents.delete", Description: "Delete parent"},
 		{Name: "parents.manage_account", Description: "Link or unlink parent user account"},
+		{Name: "parents.import", Description: "Import parents and guardians from Excel"},
 
 		// ===== Guardians =====
 		{Name: "guardians.create", Description: "Create new guardian"},
@@ -127,6 +131,64 @@ func seedPermissions(db *gorm.DB) error {
 		{Name: "grades.view_assessments", Description: "View assessment"},
 		{Name: "grades.manage_scores", Description: "Manage scores"},
 		{Name: "grades.view_scores", Description: "View scores"},
+
+		// ===== Trash =====
+		{Name: "trash.manage", Description: "View and restore soft-deleted records"},
+
+		// ===== Backup =====
+		{Name: "backup.manage", Description: "Export and restore whole-school backup archives"},
+
+		// ===== Documents =====
+		{Name: "documents.read", Description: "View student and employee documents"},
+		{Name: "documents.upload", Description: "Upload student and employee documents"},
+		{Name: "documents.verify", Description: "Verify or reject uploaded documents"},
+		{Name: "documents.delete", Description: "Delete uploaded documents"},
+		{Name: "document_types.manage", Description: "Manage document types"},
+
+		// ===== Admissions (PPDB) =====
+		{Name: "admissions.read", Description: "View admission waves and applicants"},
+		{Name: "admissions.manage", Description: "Manage admission waves and quotas"},
+		{Name: "admissions.review", Description: "Verify, test, accept or reject applicants"},
+		{Name: "admissions.convert", Description: "Convert accepted applicants into students"},
+
+		// ===== Health (UKS) =====
+		{Name: "health.read", Description: "View health records and clinic visits of all students"},
+		{Name: "health.read_homeroom", Description: "View health records of students in own homeroom class"},
+		{Name: "health.manage", Description: "Update health records and record clinic visits"},
+
+		// ===== Dormitories (Asrama) =====
+		{Name: "dormitories.read", Description: "View all dormitories, rooms, occupants and occupancy reports"},
+		{Name: "dormitories.read_own", Description: "View rooms and occupants supervised by the musyrif"},
+		{Name: "dormitories.manage", Description: "Manage dormitories and rooms"},
+		{Name: "dormitories.assign", Description: "Assign, move and check out boarding students"},
+
+		// ===== Tahfidz =====
+		{Name: "tahfidz.read", Description: "View tahfidz sessions, progress and exports"},
+		{Name: "tahfidz.record", Description: "Record setoran and murajaah sessions"},
+		{Name: "tahfidz.manage", Description: "Manage tahfidz targets"},
+
+		// ===== Leave Permits (Perizinan) =====
+		{Name: "leave_permits.read", Description: "View all boarding leave permits and overdue returns"},
+		{Name: "leave_permits.create", Description: "Request leave permits and record guardian confirmation on behalf of guardians"},
+		{Name: "leave_permits.approve", Description: "Approve or reject leave permits of any student"},
+		{Name: "leave_permits.approve_own", Description: "Approve or reject leave permits of students in rooms supervised by the musyrif"},
+		{Name: "leave_permits.gate", Description: "Record check-out and check-in at the gate"},
+
+		// ===== Announcements =====
+		{Name: "announcements.read", Description: "View school and classroom announcements"},
+		{Name: "announcements.manage", Description: "Create, update and delete announcements"},
+
+		// ===== Contacts =====
+		{Name: "contacts.read", Description: "View family contact preferences and message routing"},
+		{Name: "contacts.manage", Description: "Manage family contact preferences and primary contacts"},
+
+		// ===== Staff Attendance =====
+		{Name: "staff_attendance.read", Description: "View staff attendance, leave and monthly recap"},
+		{Name: "staff_attendance.manage", Description: "Manage work shifts, staff leave, manual attendance and device log import"},
+		{Name: "staff_attendance.clock", Description: "Clock in and out as an employee"},
+
+		// ===== Teacher Workload =====
+		{Name: "teacher_workload.read", Description: "View and export teacher workload and teaching hours report"},
 	}
 
 	for _, permission := range permissions {
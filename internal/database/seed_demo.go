@@ -0,0 +1,1011 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// DemoSize menentukan besar sekolah demo yang dibuat oleh SeedDemoData
+type DemoSize struct {
+	Levels            []string // Tingkat kelas, mis. 7, 8, 9
+	ClassesPerLevel   int
+	StudentsPerClass  int
+	AttendanceWeeks   int // Jumlah minggu riwayat presensi ke belakang dari hari ini
+	ViolationsPerTen  int // Rata-rata pelanggaran per 10 siswa
+	DonationsPerMonth int
+}
+
+// DemoSizes adalah preset ukuran yang bisa dipilih dari command line
+var DemoSizes = map[string]DemoSize{
+	"small":  {Levels: []string{"7"}, ClassesPerLevel: 2, StudentsPerClass: 12, AttendanceWeeks: 2, ViolationsPerTen: 2, DonationsPerMonth: 3},
+	"medium": {Levels: []string{"7", "8", "9"}, ClassesPerLevel: 2, StudentsPerClass: 24, AttendanceWeeks: 4, ViolationsPerTen: 2, DonationsPerMonth: 6},
+	"large":  {Levels: []string{"7", "8", "9"}, ClassesPerLevel: 4, StudentsPerClass: 32, AttendanceWeeks: 8, ViolationsPerTen: 3, DonationsPerMonth: 12},
+}
+
+// DemoOptions adalah parameter untuk SeedDemoData
+type DemoOptions struct {
+	Size DemoSize
+	Seed int64
+	Now  time.Time // Acuan "hari ini" untuk riwayat presensi dan tahun ajaran
+}
+
+type demoRegion struct {
+	City       string
+	Province   string
+	NIKPrefix  string // 6 digit kode wilayah (provinsi + kab/kota + kecamatan)
+	District   string
+	PostalCode string
+}
+
+var demoRegions = []demoRegion{
+	{City: "Surakarta", Province: "Jawa Tengah", NIKPrefix: "337201", District: "Laweyan", PostalCode: "57141"},
+	{City: "Surakarta", Province: "Jawa Tengah", NIKPrefix: "337204", District: "Jebres", PostalCode: "57126"},
+	{City: "Sukoharjo", Province: "Jawa Tengah", NIKPrefix: "331101", District: "Weru", PostalCode: "57562"},
+	{City: "Karanganyar", Province: "Jawa Tengah", NIKPrefix: "331311", District: "Karanganyar", PostalCode: "57711"},
+	{City: "Boyolali", Province: "Jawa Tengah", NIKPrefix: "330917", District: "Boyolali", PostalCode: "57311"},
+	{City: "Klaten", Province: "Jawa Tengah", NIKPrefix: "331019", District: "Klaten Tengah", PostalCode: "57411"},
+	{City: "Bogor", Province: "Jawa Barat", NIKPrefix: "327106", District: "Bogor Tengah", PostalCode: "16121"},
+	{City: "Yogyakarta", Province: "DI Yogyakarta", NIKPrefix: "347106", District: "Gondokusuman", PostalCode: "55221"},
+}
+
+var (
+	demoMaleNames   = []string{"Ahmad", "Muhammad", "Fajar", "Rizki", "Bagas", "Dimas", "Hafidz", "Ilham", "Yusuf", "Zaki", "Arif", "Farhan", "Naufal", "Raka", "Umar", "Hasan", "Fikri", "Galih", "Akbar", "Wahyu"}
+	demoFemaleNames = []string{"Aisyah", "Fatimah", "Nabila", "Zahra", "Salsabila", "Khadijah", "Alya", "Putri", "Annisa", "Hana", "Nurul", "Rahma", "Safira", "Laila", "Maryam", "Dewi", "Intan", "Kirana", "Aulia", "Syifa"}
+	demoFamilyNames = []string{"Pratama", "Saputra", "Wibowo", "Hidayat", "Nugroho", "Santoso", "Kurniawan", "Setiawan", "Rahmawati", "Permana", "Firmansyah", "Hakim", "Maulana", "Ramadhan", "Susanto", "Utomo"}
+	demoStreets     = []string{"Jl. Slamet Riyadi", "Jl. Veteran", "Jl. Adi Sucipto", "Jl. Kapten Mulyadi", "Jl. Ki Hajar Dewantara", "Jl. Sam Ratulangi", "Jl. Dr. Radjiman", "Jl. Yos Sudarso"}
+	demoOccupations = []string{"Wiraswasta", "Karyawan Swasta", "PNS", "Petani", "Pedagang", "Guru", "Buruh", "TNI/Polri", "Ibu Rumah Tangga"}
+	demoEducations  = []string{"SD", "SMP", "SMA", "D3", "S1", "S2"}
+	demoIncomes     = []string{"< 1 juta", "1 - 3 juta", "3 - 5 juta", "5 - 10 juta", "> 10 juta"}
+)
+
+type demoSubject struct {
+	Code         string
+	Name         string
+	Type         string
+	WeeklyBlocks int // Jumlah blok pertemuan per minggu (1 blok = 2 JP)
+}
+
+var demoSubjects = []demoSubject{
+	{Code: "PAI", Name: "Pendidikan Agama Islam", Type: "Umum", WeeklyBlocks: 2},
+	{Code: "PKN", Name: "Pendidikan Pancasila", Type: "Umum", WeeklyBlocks: 1},
+	{Code: "IND", Name: "Bahasa Indonesia", Type: "Umum", WeeklyBlocks: 2},
+	{Code: "MTK", Name: "Matematika", Type: "Umum", WeeklyBlocks: 2},
+	{Code: "IPA", Name: "Ilmu Pengetahuan Alam", Type: "Umum", WeeklyBlocks: 2},
+	{Code: "IPS", Name: "Ilmu Pengetahuan Sosial", Type: "Umum", WeeklyBlocks: 2},
+	{Code: "ING", Name: "Bahasa Inggris", Type: "Umum", WeeklyBlocks: 2},
+	{Code: "PJOK", Name: "Pendidikan Jasmani", Type: "Umum", WeeklyBlocks: 1},
+	{Code: "SBD", Name: "Seni Budaya", Type: "Umum", WeeklyBlocks: 1},
+	{Code: "ARB", Name: "Bahasa Arab", Type: "Muatan Lokal", WeeklyBlocks: 2},
+	{Code: "TFZ", Name: "Tahfidz Al-Quran", Type: "Muatan Lokal", WeeklyBlocks: 2},
+}
+
+// Slot jadwal harian (Senin-Jumat). 5 hari x 4 slot = 20 blok per minggu per kelas.
+var demoTimeSlots = [][2]string{
+	{"07:00", "08:20"},
+	{"08:20", "09:40"},
+	{"10:00", "11:20"},
+	{"11:20", "12:40"},
+}
+
+// demoGenerator menyimpan state selama seeding agar nilai unik tidak bentrok
+type demoGenerator struct {
+	tx        *gorm.DB
+	enc       utils.EncryptionUtil
+	rnd       *rand.Rand
+	opts      DemoOptions
+	usedNIK   map[string]bool
+	usedNISN  map[string]bool
+	usedPhone map[string]bool
+}
+
+// SeedDemoData membuat satu sekolah demo yang saling konsisten dalam satu transaksi.
+// Hanya boleh dijalankan pada database tanpa data siswa.
+func SeedDemoData(db *gorm.DB, enc utils.EncryptionUtil, opts DemoOptions) error {
+	log.Printf("Seeding demo school (seed %d)...", opts.Seed)
+
+	var studentCount int64
+	if err := db.Unscoped().Model(&domain.Student{}).Count(&studentCount).Error; err != nil {
+		return err
+	}
+	if studentCount > 0 {
+		return fmt.Errorf("demo seeding requires an empty students table (found %d students)", studentCount)
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		g := &demoGenerator{
+			tx:        tx,
+			enc:       enc,
+			rnd:       rand.New(rand.NewSource(opts.Seed)),
+			opts:      opts,
+			usedNIK:   make(map[string]bool),
+			usedNISN:  make(map[string]bool),
+			usedPhone: make(map[string]bool),
+		}
+		return g.run()
+	})
+}
+
+func (g *demoGenerator) run() error {
+	year, err := g.seedAcademicYear()
+	if err != nil {
+		return err
+	}
+
+	subjects, err := g.seedSubjects()
+	if err != nil {
+		return err
+	}
+
+	teachersBySubject, staff, err := g.seedEmployees(subjects)
+	if err != nil {
+		return err
+	}
+
+	classrooms, err := g.seedClassrooms(year, subjects, teachersBySubject)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := g.seedTeachingAssignments(classrooms, subjects, teachersBySubject)
+	if err != nil {
+		return err
+	}
+
+	schedules, err := g.seedSchedules(classrooms, subjects, assignments)
+	if err != nil {
+		return err
+	}
+
+	studentsByClass, err := g.seedStudents(year, classrooms)
+	if err != nil {
+		return err
+	}
+
+	if err := g.seedAttendance(year, schedules, assignments, studentsByClass); err != nil {
+		return err
+	}
+
+	if err := g.seedAssessments(year, assignments, studentsByClass); err != nil {
+		return err
+	}
+
+	if err := g.seedViolations(year, studentsByClass); err != nil {
+		return err
+	}
+
+	if err := g.seedDonations(year, staff); err != nil {
+		return err
+	}
+
+	log.Println("Demo school seeded successfully")
+	return nil
+}
+
+// --- Helpers ---
+
+func (g *demoGenerator) pick(list []string) string {
+	return list[g.rnd.Intn(len(list))]
+}
+
+func (g *demoGenerator) digits(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('0' + g.rnd.Intn(10))
+	}
+	return string(b)
+}
+
+func (g *demoGenerator) personName(gender string) string {
+	if gender == "female" {
+		return g.pick(demoFemaleNames) + " " + g.pick(demoFamilyNames)
+	}
+	return g.pick(demoMaleNames) + " " + g.pick(demoFamilyNames)
+}
+
+func (g *demoGenerator) randomGender() string {
+	if g.rnd.Intn(2) == 0 {
+		return "female"
+	}
+	return "male"
+}
+
+// nik membuat NIK 16 digit yang strukturnya valid: kode wilayah, tanggal lahir
+// (tanggal + 40 untuk perempuan), dan nomor urut 4 digit.
+func (g *demoGenerator) nik(region demoRegion, dob time.Time, gender string) string {
+	day := dob.Day()
+	if gender == "female" {
+		day += 40
+	}
+	for {
+		nik := fmt.Sprintf("%s%02d%02d%02d%04d", region.NIKPrefix, day, int(dob.Month()), dob.Year()%100, 1+g.rnd.Intn(9999))
+		if !g.usedNIK[nik] {
+			g.usedNIK[nik] = true
+			return nik
+		}
+	}
+}
+
+func (g *demoGenerator) noKK(region demoRegion) string {
+	return region.NIKPrefix + g.digits(10)
+}
+
+func (g *demoGenerator) phone() string {
+	for {
+		phone := "08" + g.pick([]string{"12", "13", "21", "52", "56", "57", "77", "78", "95"}) + g.digits(8)
+		if !g.usedPhone[phone] {
+			g.usedPhone[phone] = true
+			return phone
+		}
+	}
+}
+
+func (g *demoGenerator) nisn() string {
+	for {
+		nisn := "00" + g.digits(8)
+		if !g.usedNISN[nisn] {
+			g.usedNISN[nisn] = true
+			return nisn
+		}
+	}
+}
+
+func (g *demoGenerator) birthDate(year int) time.Time {
+	return time.Date(year, time.Month(1+g.rnd.Intn(12)), 1+g.rnd.Intn(28), 0, 0, 0, 0, time.UTC)
+}
+
+// encryptNIK mengembalikan NIK terenkripsi dan blind index-nya, sama seperti service
+func (g *demoGenerator) encryptNIK(nik string) (string, string, error) {
+	hash, err := g.enc.Hash(nik)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to hash NIK: %w", err)
+	}
+	encrypted, err := g.enc.Encrypt(nik)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt NIK: %w", err)
+	}
+	return encrypted, hash, nil
+}
+
+// --- Academic structure ---
+
+func (g *demoGenerator) seedAcademicYear() (*domain.AcademicYear, error) {
+	startYear := g.opts.Now.Year()
+	if g.opts.Now.Month() < time.July {
+		startYear--
+	}
+	name := fmt.Sprintf("%d/%d", startYear, startYear+1)
+
+	var year domain.AcademicYear
+	err := g.tx.Where("name = ?", name).First(&year).Error
+	if err == nil {
+		log.Printf("Using existing academic year %s", name)
+		return &year, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	// Tahun ajaran demo menjadi ACTIVE hanya jika belum ada yang aktif
+	var activeCount int64
+	if err := g.tx.Model(&domain.AcademicYear{}).Where("status = ?", "ACTIVE").Count(&activeCount).Error; err != nil {
+		return nil, err
+	}
+	status := "INACTIVE"
+	if activeCount == 0 {
+		status = "ACTIVE"
+	}
+
+	year = domain.AcademicYear{
+		Name:      name,
+		Status:    status,
+		StartDate: utils.Date(time.Date(startYear, time.July, 14, 0, 0, 0, 0, time.UTC)),
+		EndDate:   utils.Date(time.Date(startYear+1, time.June, 30, 0, 0, 0, 0, time.UTC)),
+	}
+	if err := g.tx.Create(&year).Error; err != nil {
+		return nil, fmt.Errorf("failed to create academic year: %w", err)
+	}
+	log.Printf("Created academic year %s (%s)", name, status)
+	return &year, nil
+}
+
+func (g *demoGenerator) seedSubjects() ([]domain.Subject, error) {
+	subjects := make([]domain.Subject, 0, len(demoSubjects))
+	for _, ds := range demoSubjects {
+		subject := domain.Subject{Code: ds.Code, Name: ds.Name, Type: ds.Type}
+		if err := g.tx.Where("code = ?", ds.Code).FirstOrCreate(&subject).Error; err != nil {
+			return nil, fmt.Errorf("failed to create subject %s: %w", ds.Code, err)
+		}
+		subjects = append(subjects, subject)
+	}
+	log.Printf("Prepared %d subjects", len(subjects))
+	return subjects, nil
+}
+
+// seedEmployees membuat guru per mapel (cukup untuk jadwal tanpa bentrok) dan staf TU
+func (g *demoGenerator) seedEmployees(subjects []domain.Subject) (map[string][]domain.Employee, []domain.Employee, error) {
+	totalClasses := len(g.opts.Size.Levels) * g.opts.Size.ClassesPerLevel
+	teachersBySubject := make(map[string][]domain.Employee)
+	var staff []domain.Employee
+	nipSeq := 1
+
+	createEmployee := func(jobTitle, status string) (*domain.Employee, error) {
+		gender := g.randomGender()
+		region := demoRegions[g.rnd.Intn(len(demoRegions))]
+		dob := g.birthDate(g.opts.Now.Year() - 25 - g.rnd.Intn(30))
+		encryptedNIK, nikHash, err := g.encryptNIK(g.nik(region, dob, gender))
+		if err != nil {
+			return nil, err
+		}
+		join := utils.Date(time.Date(g.opts.Now.Year()-1-g.rnd.Intn(15), time.July, 1, 0, 0, 0, 0, time.UTC))
+		birth := utils.Date(dob)
+		address := fmt.Sprintf("%s No. %d, %s", g.pick(demoStreets), 1+g.rnd.Intn(200), region.City)
+
+		employee := &domain.Employee{
+			FullName:         g.personName(gender),
+			NIP:              utils.StringPtr(fmt.Sprintf("%d%04d", join.ToTime().Year(), nipSeq)),
+			JobTitle:         utils.StringPtr(jobTitle),
+			NIK:              encryptedNIK,
+			NIKHash:          nikHash,
+			Gender:           utils.StringPtr(gender),
+			PhoneNumber:      utils.StringPtr(g.phone()),
+			Address:          utils.StringPtr(address),
+			DateOfBirth:      &birth,
+			JoinDate:         &join,
+			EmploymentStatus: utils.StringPtr(status),
+		}
+		nipSeq++
+		if err := g.tx.Create(employee).Error; err != nil {
+			return nil, fmt.Errorf("failed to create employee: %w", err)
+		}
+		return employee, nil
+	}
+
+	for i, ds := range demoSubjects {
+		// Satu guru maksimal mengajar 12 blok per minggu agar jadwal selalu muat
+		needed := int(math.Ceil(float64(ds.WeeklyBlocks*totalClasses) / 12))
+		for j := 0; j < needed; j++ {
+			teacher, err := createEmployee("Guru "+subjects[i].Name, g.pick([]string{"PNS", "GTY", "GTT"}))
+			if err != nil {
+				return nil, nil, err
+			}
+			teachersBySubject[subjects[i].ID] = append(teachersBySubject[subjects[i].ID], *teacher)
+		}
+	}
+
+	for _, title := range []string{"Kepala Tata Usaha", "Staf Keuangan", "Musyrif Asrama"} {
+		employee, err := createEmployee(title, "PTY")
+		if err != nil {
+			return nil, nil, err
+		}
+		staff = append(staff, *employee)
+	}
+
+	log.Printf("Created %d employees", nipSeq-1)
+	return teachersBySubject, staff, nil
+}
+
+func (g *demoGenerator) seedClassrooms(year *domain.AcademicYear, subjects []domain.Subject, teachersBySubject map[string][]domain.Employee) ([]domain.Classroom, error) {
+	// Wali kelas diambil bergiliran dari semua guru (urut mapel agar hasil seed tetap sama)
+	var homeroomPool []domain.Employee
+	for _, subject := range subjects {
+		homeroomPool = append(homeroomPool, teachersBySubject[subject.ID]...)
+	}
+	g.rnd.Shuffle(len(homeroomPool), func(i, j int) { homeroomPool[i], homeroomPool[j] = homeroomPool[j], homeroomPool[i] })
+
+	var classrooms []domain.Classroom
+	for _, level := range g.opts.Size.Levels {
+		for i := 0; i < g.opts.Size.ClassesPerLevel; i++ {
+			classroom := domain.Classroom{
+				AcademicYearID: year.ID,
+				Name:           fmt.Sprintf("%s%c", level, 'A'+i),
+				Level:          level,
+				Description:    "Kelas demo",
+			}
+			if len(homeroomPool) > 0 {
+				teacherID := homeroomPool[len(classrooms)%len(homeroomPool)].ID
+				classroom.HomeroomTeacherID = &teacherID
+			}
+			if err := g.tx.Create(&classroom).Error; err != nil {
+				return nil, fmt.Errorf("failed to create classroom: %w", err)
+			}
+			classrooms = append(classrooms, classroom)
+		}
+	}
+	log.Printf("Created %d classrooms", len(classrooms))
+	return classrooms, nil
+}
+
+func (g *demoGenerator) seedTeachingAssignments(classrooms []domain.Classroom, subjects []domain.Subject, teachersBySubject map[string][]domain.Employee) ([]domain.TeachingAssignment, error) {
+	var assignments []domain.TeachingAssignment
+	for ci, classroom := range classrooms {
+		for _, subject := range subjects {
+			teachers := teachersBySubject[subject.ID]
+			// Guru dibagi rata: kelas ke-n diajar guru ke-(n mod jumlah guru)
+			assignment := domain.TeachingAssignment{
+				ClassroomID: classroom.ID,
+				SubjectID:   subject.ID,
+				TeacherID:   teachers[ci%len(teachers)].ID,
+			}
+			if err := g.tx.Create(&assignment).Error; err != nil {
+				return nil, fmt.Errorf("failed to create teaching assignment: %w", err)
+			}
+			assignments = append(assignments, assignment)
+		}
+	}
+	log.Printf("Created %d teaching assignments", len(assignments))
+	return assignments, nil
+}
+
+// seedSchedules menyusun jadwal mingguan tanpa bentrok kelas maupun guru (greedy)
+func (g *demoGenerator) seedSchedules(classrooms []domain.Classroom, subjects []domain.Subject, assignments []domain.TeachingAssignment) ([]domain.Schedule, error) {
+	// subjects sejajar dengan demoSubjects (lihat seedSubjects)
+	weeklyBlocks := make(map[string]int)
+	for i, subject := range subjects {
+		weeklyBlocks[subject.ID] = demoSubjects[i].WeeklyBlocks
+	}
+
+	type slotKey struct {
+		day  int
+		slot int
+	}
+	teacherBusy := make(map[string]map[slotKey]bool)
+	var schedules []domain.Schedule
+
+	for _, classroom := range classrooms {
+		classBusy := make(map[slotKey]bool)
+
+		// Kumpulkan blok yang harus dijadwalkan untuk kelas ini
+		var blocks []domain.TeachingAssignment
+		for _, a := range assignments {
+			if a.ClassroomID != classroom.ID {
+				continue
+			}
+			for i := 0; i < weeklyBlocks[a.SubjectID]; i++ {
+				blocks = append(blocks, a)
+			}
+		}
+		g.rnd.Shuffle(len(blocks), func(i, j int) { blocks[i], blocks[j] = blocks[j], blocks[i] })
+
+		for _, block := range blocks {
+			if teacherBusy[block.TeacherID] == nil {
+				teacherBusy[block.TeacherID] = make(map[slotKey]bool)
+			}
+			placed := false
+			for day := 1; day <= 5 && !placed; day++ {
+				for slot := range demoTimeSlots {
+					key := slotKey{day, slot}
+					if classBusy[key] || teacherBusy[block.TeacherID][key] {
+						continue
+					}
+					classBusy[key] = true
+					teacherBusy[block.TeacherID][key] = true
+					schedules = append(schedules, domain.Schedule{
+						TeachingAssignmentID: block.ID,
+						DayOfWeek:            day,
+						StartTime:            demoTimeSlots[slot][0],
+						EndTime:              demoTimeSlots[slot][1],
+					})
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				log.Printf("Warning: no free slot for assignment %s in classroom %s", block.ID, classroom.Name)
+			}
+		}
+	}
+
+	if err := g.tx.CreateInBatches(&schedules, 200).Error; err != nil {
+		return nil, fmt.Errorf("failed to create schedules: %w", err)
+	}
+	log.Printf("Created %d schedules", len(schedules))
+	return schedules, nil
+}
+
+// --- People ---
+
+type demoFamily struct {
+	NoKK    string
+	Region  demoRegion
+	Address string
+	RT      string
+	RW      string
+	Father  domain.Parent
+	Mother  domain.Parent
+}
+
+func (g *demoGenerator) seedParent(gender string, family *demoFamily, birthYear int) (domain.Parent, error) {
+	dob := g.birthDate(birthYear)
+	encryptedNIK, nikHash, err := g.encryptNIK(g.nik(family.Region, dob, gender))
+	if err != nil {
+		return domain.Parent{}, err
+	}
+	birth := utils.Date(dob)
+	parent := domain.Parent{
+		FullName:       g.personName(gender),
+		NIK:            &encryptedNIK,
+		NIKHash:        &nikHash,
+		Gender:         utils.StringPtr(gender),
+		PlaceOfBirth:   utils.StringPtr(family.Region.City),
+		DateOfBirth:    &birth,
+		LifeStatus:     utils.StringPtr("alive"),
+		MaritalStatus:  utils.StringPtr("married"),
+		PhoneNumber:    utils.StringPtr(g.phone()),
+		EducationLevel: utils.StringPtr(g.pick(demoEducations)),
+		Occupation:     utils.StringPtr(g.pick(demoOccupations)),
+		IncomeRange:    utils.StringPtr(g.pick(demoIncomes)),
+		Address:        utils.StringPtr(family.Address),
+		RT:             utils.StringPtr(family.RT),
+		RW:             utils.StringPtr(family.RW),
+		District:       utils.StringPtr(family.Region.District),
+		City:           utils.StringPtr(family.Region.City),
+		Province:       utils.StringPtr(family.Region.Province),
+		PostalCode:     utils.StringPtr(family.Region.PostalCode),
+	}
+	if err := g.tx.Create(&parent).Error; err != nil {
+		return domain.Parent{}, fmt.Errorf("failed to create parent: %w", err)
+	}
+	return parent, nil
+}
+
+func (g *demoGenerator) newFamily(childBirthYear int) (*demoFamily, error) {
+	region := demoRegions[g.rnd.Intn(len(demoRegions))]
+	family := &demoFamily{
+		NoKK:    g.noKK(region),
+		Region:  region,
+		Address: fmt.Sprintf("%s No. %d", g.pick(demoStreets), 1+g.rnd.Intn(200)),
+		RT:      fmt.Sprintf("%03d", 1+g.rnd.Intn(12)),
+		RW:      fmt.Sprintf("%03d", 1+g.rnd.Intn(8)),
+	}
+	var err error
+	if family.Father, err = g.seedParent("male", family, childBirthYear-28-g.rnd.Intn(10)); err != nil {
+		return nil, err
+	}
+	if family.Mother, err = g.seedParent("female", family, childBirthYear-25-g.rnd.Intn(8)); err != nil {
+		return nil, err
+	}
+	return family, nil
+}
+
+func (g *demoGenerator) seedStudents(year *domain.AcademicYear, classrooms []domain.Classroom) (map[string][]domain.Student, error) {
+	startYear := year.StartDate.ToTime().Year()
+	studentsByClass := make(map[string][]domain.Student)
+	var families []*demoFamily
+	nimSeq := 1
+	total := 0
+
+	for _, classroom := range classrooms {
+		var level int
+		fmt.Sscanf(classroom.Level, "%d", &level)
+		// Kelas 7 masuk tahun ini, kelas 8 tahun lalu, dst.
+		entryYear := startYear - (level - 7)
+		birthYear := entryYear - 12
+
+		for i := 0; i < g.opts.Size.StudentsPerClass; i++ {
+			// Sekitar 10% siswa adalah adik/kakak dari siswa lain (berbagi KK & orang tua)
+			var family *demoFamily
+			if len(families) > 0 && g.rnd.Intn(10) == 0 {
+				family = families[g.rnd.Intn(len(families))]
+			} else {
+				var err error
+				if family, err = g.newFamily(birthYear); err != nil {
+					return nil, err
+				}
+				families = append(families, family)
+			}
+
+			gender := g.randomGender()
+			dob := g.birthDate(birthYear)
+			encryptedNIK, nikHash, err := g.encryptNIK(g.nik(family.Region, dob, gender))
+			if err != nil {
+				return nil, err
+			}
+			encryptedNoKK, err := g.enc.Encrypt(family.NoKK)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt NoKK: %w", err)
+			}
+			noKKHash, err := g.enc.Hash(family.NoKK)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash NoKK: %w", err)
+			}
+
+			birth := utils.Date(dob)
+			nisn := g.nisn()
+			nim := fmt.Sprintf("%d%04d", entryYear, nimSeq)
+			nimSeq++
+			entry := fmt.Sprintf("%d", entryYear)
+			familyName := lastWord(family.Father.FullName)
+
+			firstName := g.pick(demoMaleNames)
+			if gender == "female" {
+				firstName = g.pick(demoFemaleNames)
+			}
+
+			student := domain.Student{
+				FullName:     firstName + " " + familyName,
+				NoKK:         encryptedNoKK,
+				NoKKHash:     &noKKHash,
+				NIK:          &encryptedNIK,
+				NIKHash:      &nikHash,
+				NISN:         &nisn,
+				NIM:          &nim,
+				Gender:       gender,
+				PlaceOfBirth: utils.StringPtr(family.Region.City),
+				DateOfBirth:  &birth,
+				Address:      utils.StringPtr(family.Address),
+				RT:           utils.StringPtr(family.RT),
+				RW:           utils.StringPtr(family.RW),
+				District:     utils.StringPtr(family.Region.District),
+				City:         utils.StringPtr(family.Region.City),
+				Province:     utils.StringPtr(family.Region.Province),
+				PostalCode:   utils.StringPtr(family.Region.PostalCode),
+				Status:       "ACTIVE",
+				EntryYear:    &entry,
+			}
+
+			// Wali: umumnya ayah (parent), sebagian kecil wali dari pihak lain (guardian)
+			if g.rnd.Intn(100) < 15 {
+				guardian, err := g.seedGuardian(family)
+				if err != nil {
+					return nil, err
+				}
+				student.GuardianID = &guardian.ID
+				student.GuardianType = utils.StringPtr("guardian")
+			} else {
+				student.GuardianID = &family.Father.ID
+				student.GuardianType = utils.StringPtr("parent")
+			}
+
+			// Kelompokkan saudara kandung ke household yang sama (berdasarkan No KK)
+			household := domain.Household{}
+			if err := g.tx.Where(domain.Household{NoKKHash: noKKHash}).
+				Attrs(domain.Household{NoKK: encryptedNoKK}).
+				FirstOrCreate(&household).Error; err != nil {
+				return nil, fmt.Errorf("failed to create household: %w", err)
+			}
+			student.HouseholdID = &household.ID
+
+			if err := g.tx.Create(&student).Error; err != nil {
+				return nil, fmt.Errorf("failed to create student: %w", err)
+			}
+
+			links := []domain.StudentParent{
+				{StudentID: student.ID, ParentID: family.Father.ID, RelationshipType: "FATHER"},
+				{StudentID: student.ID, ParentID: family.Mother.ID, RelationshipType: "MOTHER"},
+			}
+			if err := g.tx.Create(&links).Error; err != nil {
+				return nil, fmt.Errorf("failed to link parents: %w", err)
+			}
+
+			placement := domain.StudentClassroom{ClassroomID: classroom.ID, StudentID: student.ID, Status: "ACTIVE"}
+			if err := g.tx.Create(&placement).Error; err != nil {
+				return nil, fmt.Errorf("failed to place student: %w", err)
+			}
+
+			studentsByClass[classroom.ID] = append(studentsByClass[classroom.ID], student)
+			total++
+		}
+	}
+
+	log.Printf("Created %d students from %d families", total, len(families))
+	return studentsByClass, nil
+}
+
+func (g *demoGenerator) seedGuardian(family *demoFamily) (*domain.Guardian, error) {
+	gender := g.randomGender()
+	dob := g.birthDate(g.opts.Now.Year() - 35 - g.rnd.Intn(25))
+	encryptedNIK, nikHash, err := g.encryptNIK(g.nik(family.Region, dob, gender))
+	if err != nil {
+		return nil, err
+	}
+	relationship := "Paman"
+	if gender == "female" {
+		relationship = "Bibi"
+	}
+	if g.rnd.Intn(3) == 0 {
+		relationship = "Kakek/Nenek"
+	}
+
+	guardian := &domain.Guardian{
+		FullName:              g.personName(gender),
+		NIK:                   &encryptedNIK,
+		NIKHash:               &nikHash,
+		Gender:                utils.StringPtr(gender),
+		PhoneNumber:           utils.StringPtr(g.phone()),
+		Address:               utils.StringPtr(family.Address),
+		RT:                    utils.StringPtr(family.RT),
+		RW:                    utils.StringPtr(family.RW),
+		District:              utils.StringPtr(family.Region.District),
+		City:                  utils.StringPtr(family.Region.City),
+		Province:              utils.StringPtr(family.Region.Province),
+		PostalCode:            utils.StringPtr(family.Region.PostalCode),
+		RelationshipToStudent: utils.StringPtr(relationship),
+	}
+	if err := g.tx.Create(guardian).Error; err != nil {
+		return nil, fmt.Errorf("failed to create guardian: %w", err)
+	}
+	return guardian, nil
+}
+
+func lastWord(s string) string {
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] == ' ' {
+			return s[i+1:]
+		}
+	}
+	return s
+}
+
+// --- Activity history ---
+
+// seedAttendance membuat sesi presensi untuk setiap jadwal selama beberapa minggu terakhir
+func (g *demoGenerator) seedAttendance(year *domain.AcademicYear, schedules []domain.Schedule, assignments []domain.TeachingAssignment, studentsByClass map[string][]domain.Student) error {
+	classByAssignment := make(map[string]string)
+	for _, a := range assignments {
+		classByAssignment[a.ID] = a.ClassroomID
+	}
+
+	today := time.Date(g.opts.Now.Year(), g.opts.Now.Month(), g.opts.Now.Day(), 0, 0, 0, 0, time.UTC)
+	from := today.AddDate(0, 0, -7*g.opts.Size.AttendanceWeeks)
+	if yearStart := year.StartDate.ToTime(); from.Before(yearStart) {
+		from = yearStart
+	}
+
+	var sessions []domain.AttendanceSession
+	var details []domain.AttendanceDetail
+	for date := from; date.Before(today); date = date.AddDate(0, 0, 1) {
+		// time.Weekday: Minggu = 0; schedule.DayOfWeek: Senin = 1
+		weekday := int(date.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		for _, schedule := range schedules {
+			if schedule.DayOfWeek != weekday {
+				continue
+			}
+			session := domain.AttendanceSession{
+				ID:         utils.GenerateUUID(),
+				ScheduleID: schedule.ID,
+				Date:       utils.Date(date),
+				Topic:      fmt.Sprintf("Pertemuan %s", date.Format("02/01")),
+			}
+			sessions = append(sessions, session)
+
+			for _, student := range studentsByClass[classByAssignment[schedule.TeachingAssignmentID]] {
+				details = append(details, domain.AttendanceDetail{
+					AttendanceSessionID: session.ID,
+					StudentID:           student.ID,
+					Status:              g.attendanceStatus(),
+				})
+			}
+		}
+	}
+
+	if len(sessions) == 0 {
+		return nil
+	}
+	if err := g.tx.CreateInBatches(&sessions, 200).Error; err != nil {
+		return fmt.Errorf("failed to create attendance sessions: %w", err)
+	}
+	if err := g.tx.CreateInBatches(&details, 1000).Error; err != nil {
+		return fmt.Errorf("failed to create attendance details: %w", err)
+	}
+	log.Printf("Created %d attendance sessions with %d details", len(sessions), len(details))
+	return nil
+}
+
+func (g *demoGenerator) attendanceStatus() string {
+	n := g.rnd.Intn(100)
+	switch {
+	case n < 92:
+		return "PRESENT"
+	case n < 95:
+		return "SICK"
+	case n < 98:
+		return "PERMISSION"
+	default:
+		return "ABSENT"
+	}
+}
+
+func (g *demoGenerator) seedAssessments(year *domain.AcademicYear, assignments []domain.TeachingAssignment, studentsByClass map[string][]domain.Student) error {
+	yearStart := year.StartDate.ToTime()
+	elapsedDays := int(g.opts.Now.Sub(yearStart).Hours() / 24)
+	if elapsedDays < 14 {
+		return nil
+	}
+
+	kinds := []struct {
+		Type  string
+		Title string
+	}{
+		{"ASSIGNMENT", "Tugas 1"},
+		{"QUIZ", "Kuis 1"},
+		{"MID_EXAM", "Penilaian Tengah Semester"},
+	}
+
+	var assessments []domain.Assessment
+	var scores []domain.StudentScore
+	for _, a := range assignments {
+		// Setiap siswa punya "kemampuan" dasar per mapel agar nilainya konsisten
+		for i, kind := range kinds {
+			offset := elapsedDays * (i + 1) / (len(kinds) + 1)
+			assessment := domain.Assessment{
+				ID:                   utils.GenerateUUID(),
+				TeachingAssignmentID: a.ID,
+				Title:                kind.Title,
+				Type:                 kind.Type,
+				MaxScore:             100,
+				Date:                 utils.Date(yearStart.AddDate(0, 0, offset)),
+			}
+			assessments = append(assessments, assessment)
+
+			for _, student := range studentsByClass[a.ClassroomID] {
+				score := math.Round(math.Max(40, math.Min(100, 78+g.rnd.NormFloat64()*10)))
+				scores = append(scores, domain.StudentScore{
+					AssessmentID: assessment.ID,
+					StudentID:    student.ID,
+					Score:        score,
+				})
+			}
+		}
+	}
+
+	if err := g.tx.CreateInBatches(&assessments, 200).Error; err != nil {
+		return fmt.Errorf("failed to create assessments: %w", err)
+	}
+	if err := g.tx.CreateInBatches(&scores, 1000).Error; err != nil {
+		return fmt.Errorf("failed to create scores: %w", err)
+	}
+	log.Printf("Created %d assessments with %d scores", len(assessments), len(scores))
+	return nil
+}
+
+func (g *demoGenerator) seedViolations(year *domain.AcademicYear, studentsByClass map[string][]domain.Student) error {
+	catalog := []struct {
+		Category string
+		Types    []struct {
+			Name   string
+			Points int
+		}
+	}{
+		{"Kedisiplinan", []struct {
+			Name   string
+			Points int
+		}{{"Terlambat masuk kelas", 5}, {"Tidak mengikuti apel pagi", 5}, {"Keluar kelas tanpa izin", 10}}},
+		{"Kerapian", []struct {
+			Name   string
+			Points int
+		}{{"Seragam tidak lengkap", 5}, {"Rambut tidak rapi", 5}}},
+		{"Ibadah", []struct {
+			Name   string
+			Points int
+		}{{"Tidak sholat berjamaah", 10}, {"Tidak mengikuti kajian", 10}}},
+	}
+
+	var types []domain.ViolationType
+	for _, c := range catalog {
+		category := domain.ViolationCategory{ID: utils.GenerateUUID(), Name: c.Category}
+		if err := g.tx.Where("name = ?", c.Category).FirstOrCreate(&category).Error; err != nil {
+			return fmt.Errorf("failed to create violation category: %w", err)
+		}
+		for _, t := range c.Types {
+			violationType := domain.ViolationType{ID: utils.GenerateUUID(), CategoryID: category.ID, Name: t.Name, DefaultPoints: t.Points}
+			if err := g.tx.Where("category_id = ? AND name = ?", category.ID, t.Name).FirstOrCreate(&violationType).Error; err != nil {
+				return fmt.Errorf("failed to create violation type: %w", err)
+			}
+			types = append(types, violationType)
+		}
+	}
+
+	var students []domain.Student
+	for _, list := range studentsByClass {
+		students = append(students, list...)
+	}
+	if len(students) == 0 {
+		return nil
+	}
+
+	yearStart := year.StartDate.ToTime()
+	elapsed := g.opts.Now.Sub(yearStart)
+	if elapsed <= 0 {
+		return nil
+	}
+
+	count := len(students) * g.opts.Size.ViolationsPerTen / 10
+	violations := make([]domain.StudentViolation, 0, count)
+	for i := 0; i < count; i++ {
+		violationType := types[g.rnd.Intn(len(types))]
+		violations = append(violations, domain.StudentViolation{
+			ID:              utils.GenerateUUID(),
+			StudentID:       students[g.rnd.Intn(len(students))].ID,
+			ViolationTypeID: violationType.ID,
+			ViolationDate:   yearStart.Add(time.Duration(g.rnd.Int63n(int64(elapsed)))),
+			Points:          violationType.DefaultPoints,
+			ActionTaken:     "Teguran lisan",
+		})
+	}
+	if len(violations) > 0 {
+		if err := g.tx.CreateInBatches(&violations, 200).Error; err != nil {
+			return fmt.Errorf("failed to create violations: %w", err)
+		}
+	}
+	log.Printf("Created %d violations", len(violations))
+	return nil
+}
+
+func (g *demoGenerator) seedDonations(year *domain.AcademicYear, staff []domain.Employee) error {
+	if len(staff) == 0 {
+		return nil
+	}
+	receiver := staff[0]
+
+	donors := make([]domain.Donor, 0, 10)
+	for i := 0; i < 10; i++ {
+		region := demoRegions[g.rnd.Intn(len(demoRegions))]
+		donor := domain.Donor{
+			Name:    g.personName(g.randomGender()),
+			Phone:   utils.StringPtr(g.phone()),
+			Address: utils.StringPtr(fmt.Sprintf("%s, %s", g.pick(demoStreets), region.City)),
+		}
+		if i == 0 {
+			donor.Name = "Hamba Allah"
+			donor.Phone = nil
+		}
+		if err := g.tx.Create(&donor).Error; err != nil {
+			return fmt.Errorf("failed to create donor: %w", err)
+		}
+		donors = append(donors, donor)
+	}
+
+	yearStart := year.StartDate.ToTime()
+	months := int(g.opts.Now.Sub(yearStart).Hours()/24/30) + 1
+
+	created := 0
+	for m := 0; m < months; m++ {
+		for i := 0; i < g.opts.Size.DonationsPerMonth; i++ {
+			date := yearStart.AddDate(0, m, g.rnd.Intn(28))
+			if date.After(g.opts.Now) {
+				continue
+			}
+
+			donation := domain.Donation{
+				DonorID:    donors[g.rnd.Intn(len(donors))].ID,
+				EmployeeID: receiver.ID,
+				Date:       date,
+			}
+			switch g.rnd.Intn(3) {
+			case 0:
+				donation.Type = "GOODS"
+				donation.PaymentMethod = "GOODS"
+				donation.Items = []domain.DonationItem{
+					{ItemName: "Beras", Quantity: float64(10 * (1 + g.rnd.Intn(10))), Unit: "kg", EstimatedValue: 14000},
+				}
+			default:
+				donation.Type = "MONEY"
+				donation.PaymentMethod = g.pick([]string{"CASH", "TRANSFER", "QRIS"})
+				donation.TotalAmount = float64(50000 * (1 + g.rnd.Intn(40)))
+			}
+			donation.Description = utils.StringPtr("Infaq pembangunan dan operasional pondok")
+
+			if err := g.tx.Create(&donation).Error; err != nil {
+				return fmt.Errorf("failed to create donation: %w", err)
+			}
+			created++
+		}
+	}
+	log.Printf("Created %d donors and %d donations", len(donors), created)
+	return nil
+}
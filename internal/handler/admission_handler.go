@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type AdmissionHandler struct {
+	admissionService service.AdmissionService
+}
+
+func NewAdmissionHandler(admissionService service.AdmissionService) *AdmissionHandler {
+	return &AdmissionHandler{admissionService: admissionService}
+}
+
+// --- Admission Waves ---
+
+func (h *AdmissionHandler) CreateWave(c *gin.Context) {
+	var req request.AdmissionWaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	wave, err := h.admissionService.CreateWave(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Admission wave created successfully", wave)
+}
+
+// GetWaves menampilkan semua gelombang, opsional ?academic_year_id=
+func (h *AdmissionHandler) GetWaves(c *gin.Context) {
+	waves, err := h.admissionService.GetWaves(c.Query("academic_year_id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Admission waves retrieved successfully", waves)
+}
+
+func (h *AdmissionHandler) GetOpenWaves(c *gin.Context) {
+	waves, err := h.admissionService.GetOpenWaves()
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Open admission waves retrieved successfully", waves)
+}
+
+func (h *AdmissionHandler) UpdateWave(c *gin.Context) {
+	var req request.AdmissionWaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	wave, err := h.admissionService.UpdateWave(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Admission wave updated successfully", wave)
+}
+
+func (h *AdmissionHandler) DeleteWave(c *gin.Context) {
+	if err := h.admissionService.DeleteWave(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Admission wave deleted successfully", nil)
+}
+
+// --- Applicants ---
+
+// Apply menerima formulir pendaftaran publik. Bisa JSON biasa, atau multipart dengan JSON di field
+// "data" dan file dokumen di field bernama kode jenis dokumen (mis. "birth_certificate").
+func (h *AdmissionHandler) Apply(c *gin.Context) {
+	var req request.ApplicantCreateRequest
+	files := map[string]*multipart.FileHeader{}
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		form, err := c.MultipartForm()
+		if err != nil {
+			BadRequestError(c, "Invalid multipart form", err.Error())
+			return
+		}
+		if err := json.Unmarshal([]byte(c.PostForm("data")), &req); err != nil {
+			BadRequestError(c, "Invalid request payload", "field \"data\" must contain the application JSON")
+			return
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			BadRequestError(c, "Invalid request payload", err.Error())
+			return
+		}
+		for field, headers := range form.File {
+			if len(headers) > 0 {
+				files[field] = headers[0]
+			}
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	result, err := h.admissionService.Apply(req, files)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Application submitted successfully", result)
+}
+
+func (h *AdmissionHandler) CheckStatus(c *gin.Context) {
+	var req request.ApplicantStatusCheckRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.admissionService.CheckStatus(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Application status retrieved successfully", result)
+}
+
+func (h *AdmissionHandler) GetApplicants(c *gin.Context) {
+	var filter request.ApplicantFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	result, err := h.admissionService.GetApplicants(filter, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Applicants retrieved successfully", result)
+}
+
+func (h *AdmissionHandler) GetApplicantByID(c *gin.Context) {
+	applicant, err := h.admissionService.GetApplicantByID(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Applicant retrieved successfully", applicant)
+}
+
+func (h *AdmissionHandler) UpdateStatus(c *gin.Context) {
+	var req request.ApplicantStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	updatedBy, _ := userID.(string)
+
+	applicant, err := h.admissionService.UpdateStatus(c.Param("id"), req, updatedBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Applicant status updated successfully", applicant)
+}
+
+func (h *AdmissionHandler) ConvertToStudent(c *gin.Context) {
+	var req request.ApplicantConvertRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequestError(c, "Invalid request payload", err.Error())
+			return
+		}
+	}
+
+	result, err := h.admissionService.ConvertToStudent(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Applicant converted to student successfully", result)
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AnnouncementHandler struct {
+	announcementService service.AnnouncementService
+}
+
+func NewAnnouncementHandler(announcementService service.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{announcementService: announcementService}
+}
+
+func (h *AnnouncementHandler) CreateAnnouncement(c *gin.Context) {
+	var req request.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdBy, _ := userID.(string)
+
+	announcement, err := h.announcementService.CreateAnnouncement(req, createdBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Announcement created successfully", announcement)
+}
+
+// GetAnnouncements menangani GET /announcements?classroom_id=
+func (h *AnnouncementHandler) GetAnnouncements(c *gin.Context) {
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	announcements, err := h.announcementService.GetAnnouncements(c.Query("classroom_id"), pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Announcements retrieved successfully", announcements)
+}
+
+func (h *AnnouncementHandler) GetAnnouncementByID(c *gin.Context) {
+	announcement, err := h.announcementService.GetAnnouncementByID(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Announcement retrieved successfully", announcement)
+}
+
+func (h *AnnouncementHandler) UpdateAnnouncement(c *gin.Context) {
+	var req request.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	announcement, err := h.announcementService.UpdateAnnouncement(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Announcement updated successfully", announcement)
+}
+
+func (h *AnnouncementHandler) DeleteAnnouncement(c *gin.Context) {
+	if err := h.announcementService.DeleteAnnouncement(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Announcement deleted successfully", nil)
+}
+
+// GetRecipients menangani GET /announcements/:id/recipients (pratinjau penerima per siswa)
+func (h *AnnouncementHandler) GetRecipients(c *gin.Context) {
+	recipients, err := h.announcementService.GetRecipients(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Announcement recipients resolved successfully", recipients)
+}
@@ -117,3 +117,14 @@ func (h *AttendanceHandler) Delete(c *gin.Context) {
 	}
 	SuccessResponse(c, "Attendance session deleted successfully", nil)
 }
+
+// GetAbsenceAlerts menangani GET /attendances/absence-alerts?date=YYYY-MM-DD (default: hari ini)
+func (h *AttendanceHandler) GetAbsenceAlerts(c *gin.Context) {
+	alerts, err := h.service.GetAbsenceAlerts(c.Query("date"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Absence alerts retrieved successfully", alerts)
+}
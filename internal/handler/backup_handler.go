@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BackupHandler struct {
+	backupService service.BackupService
+}
+
+func NewBackupHandler(backupService service.BackupService) *BackupHandler {
+	return &BackupHandler{backupService: backupService}
+}
+
+// Export menangani GET /backup/export.
+// Arsip ditulis ke file sementara dulu agar error bisa dikirim sebagai JSON.
+func (h *BackupHandler) Export(c *gin.Context) {
+	tmp, err := os.CreateTemp("", "backup_*.zip")
+	if err != nil {
+		InternalServerError(c, "Failed to create temporary file")
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = h.backupService.Export(tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("backup_%s.zip", time.Now().Format("20060102_150405"))
+	c.FileAttachment(tmp.Name(), filename)
+}
+
+// Import menangani POST /backup/import (multipart: file, remap_ids)
+func (h *BackupHandler) Import(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		BadRequestError(c, "Backup file is required", err.Error())
+		return
+	}
+
+	remapIDs, _ := strconv.ParseBool(c.PostForm("remap_ids"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		BadRequestError(c, "Failed to open backup file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	res, err := h.backupService.Import(file, fileHeader.Size, service.BackupImportOptions{RemapIDs: remapIDs})
+	if err != nil {
+		if res != nil {
+			log.Printf("Backup import partially completed: %+v", res)
+		}
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Backup restored successfully", res)
+}
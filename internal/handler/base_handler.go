@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
 	"smart_school_be/internal/model/response"
 
 	"github.com/gin-gonic/gin"
@@ -78,3 +79,18 @@ func BadRequestError(c *gin.Context, message string, errorData interface{}) {
 func ForbiddenError(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusForbidden, message, response.SimpleError{Message: message})
 }
+
+// currentUser mengambil user login dari context untuk pengecekan akses yang dilakukan di service
+func currentUser(c *gin.Context) (*domain.User, bool) {
+	user, exists := c.Get("user")
+	if !exists {
+		UnauthorizedError(c, "User not found")
+		return nil, false
+	}
+	userDomain, ok := user.(*domain.User)
+	if !ok {
+		InternalServerError(c, "Invalid user object")
+		return nil, false
+	}
+	return userDomain, true
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContactHandler struct {
+	contactRoutingService service.ContactRoutingService
+}
+
+func NewContactHandler(contactRoutingService service.ContactRoutingService) *ContactHandler {
+	return &ContactHandler{contactRoutingService: contactRoutingService}
+}
+
+// GetPreference menangani GET /contacts/:type/:id/preference (type: parent | guardian)
+func (h *ContactHandler) GetPreference(c *gin.Context) {
+	preference, err := h.contactRoutingService.GetPreference(c.Param("type"), c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Contact preference retrieved successfully", preference)
+}
+
+// UpdatePreference menangani PUT /contacts/:type/:id/preference
+func (h *ContactHandler) UpdatePreference(c *gin.Context) {
+	var req request.ContactPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	preference, err := h.contactRoutingService.UpdatePreference(c.Param("type"), c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Contact preference updated successfully", preference)
+}
+
+// GetMyPreference menangani GET /me/contact-preference untuk akun orang tua/wali
+func (h *ContactHandler) GetMyPreference(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	currentUserID, _ := userID.(string)
+
+	preference, err := h.contactRoutingService.GetMyPreference(currentUserID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Contact preference retrieved successfully", preference)
+}
+
+// UpdateMyPreference menangani PUT /me/contact-preference
+func (h *ContactHandler) UpdateMyPreference(c *gin.Context) {
+	var req request.ContactPreferenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	currentUserID, _ := userID.(string)
+
+	preference, err := h.contactRoutingService.UpdateMyPreference(currentUserID, req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Contact preference updated successfully", preference)
+}
+
+// SetPrimaryContact menangani PUT /contacts/students/:id/primary
+func (h *ContactHandler) SetPrimaryContact(c *gin.Context) {
+	var req request.StudentPrimaryContactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	if err := h.contactRoutingService.SetPrimaryContact(c.Param("id"), req); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Primary contact set successfully", nil)
+}
+
+// ClearPrimaryContact menangani DELETE /contacts/students/:id/primary
+func (h *ContactHandler) ClearPrimaryContact(c *gin.Context) {
+	if err := h.contactRoutingService.ClearPrimaryContact(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Primary contact cleared successfully", nil)
+}
+
+// ResolveStudent menangani GET /contacts/students/:id/route?category=ATTENDANCE
+func (h *ContactHandler) ResolveStudent(c *gin.Context) {
+	category := c.Query("category")
+	if category == "" {
+		BadRequestError(c, "category query parameter is required", nil)
+		return
+	}
+
+	route, err := h.contactRoutingService.ResolveStudent(c.Param("id"), category)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Contact route resolved successfully", route)
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentHandler struct {
+	documentService service.DocumentService
+}
+
+func NewDocumentHandler(documentService service.DocumentService) *DocumentHandler {
+	return &DocumentHandler{documentService: documentService}
+}
+
+// --- Document Types ---
+
+func (h *DocumentHandler) GetTypes(c *gin.Context) {
+	types, err := h.documentService.GetTypes(c.Query("owner_type"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document types retrieved successfully", types)
+}
+
+func (h *DocumentHandler) CreateType(c *gin.Context) {
+	var req request.DocumentTypeCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	docType, err := h.documentService.CreateType(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Document type created successfully", docType)
+}
+
+func (h *DocumentHandler) UpdateType(c *gin.Context) {
+	var req request.DocumentTypeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	docType, err := h.documentService.UpdateType(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document type updated successfully", docType)
+}
+
+func (h *DocumentHandler) DeleteType(c *gin.Context) {
+	if err := h.documentService.DeleteType(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document type deleted successfully", nil)
+}
+
+// --- Documents per pemilik (siswa / pegawai) ---
+
+func (h *DocumentHandler) GetStudentDocuments(c *gin.Context) {
+	h.getOwnerDocuments(c, domain.DocumentOwnerStudent)
+}
+
+func (h *DocumentHandler) GetEmployeeDocuments(c *gin.Context) {
+	h.getOwnerDocuments(c, domain.DocumentOwnerEmployee)
+}
+
+func (h *DocumentHandler) GetStudentDocumentHistory(c *gin.Context) {
+	h.getDocumentHistory(c, domain.DocumentOwnerStudent)
+}
+
+func (h *DocumentHandler) GetEmployeeDocumentHistory(c *gin.Context) {
+	h.getDocumentHistory(c, domain.DocumentOwnerEmployee)
+}
+
+func (h *DocumentHandler) UploadStudentDocument(c *gin.Context) {
+	h.uploadDocument(c, domain.DocumentOwnerStudent)
+}
+
+func (h *DocumentHandler) UploadEmployeeDocument(c *gin.Context) {
+	h.uploadDocument(c, domain.DocumentOwnerEmployee)
+}
+
+func (h *DocumentHandler) getOwnerDocuments(c *gin.Context, ownerType string) {
+	docs, err := h.documentService.GetOwnerDocuments(ownerType, c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Documents retrieved successfully", docs)
+}
+
+func (h *DocumentHandler) getDocumentHistory(c *gin.Context, ownerType string) {
+	docs, err := h.documentService.GetDocumentHistory(ownerType, c.Param("id"), c.Query("document_type_id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document history retrieved successfully", docs)
+}
+
+func (h *DocumentHandler) uploadDocument(c *gin.Context, ownerType string) {
+	var req request.DocumentUploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		BadRequestError(c, "File is required", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	uploadedBy, _ := userID.(string)
+
+	doc, err := h.documentService.UploadDocument(ownerType, c.Param("id"), req, file, uploadedBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Document uploaded successfully", doc)
+}
+
+// --- Verifikasi & laporan ---
+
+func (h *DocumentHandler) VerifyDocument(c *gin.Context) {
+	var req request.DocumentVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	verifiedBy, _ := userID.(string)
+
+	doc, err := h.documentService.VerifyDocument(c.Param("id"), req, verifiedBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document verification saved successfully", doc)
+}
+
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	if err := h.documentService.DeleteDocument(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document deleted successfully", nil)
+}
+
+func (h *DocumentHandler) GetClassroomCompleteness(c *gin.Context) {
+	classroomID := c.Query("classroom_id")
+	if classroomID == "" {
+		BadRequestError(c, "classroom_id is required", nil)
+		return
+	}
+
+	report, err := h.documentService.GetClassroomCompleteness(classroomID)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Document completeness retrieved successfully", report)
+}
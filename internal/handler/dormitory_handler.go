@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DormitoryHandler struct {
+	dormitoryService service.DormitoryService
+}
+
+func NewDormitoryHandler(dormitoryService service.DormitoryService) *DormitoryHandler {
+	return &DormitoryHandler{dormitoryService: dormitoryService}
+}
+
+// --- Dormitories ---
+
+func (h *DormitoryHandler) CreateDormitory(c *gin.Context) {
+	var req request.DormitoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	dormitory, err := h.dormitoryService.CreateDormitory(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Dormitory created successfully", dormitory)
+}
+
+func (h *DormitoryHandler) GetDormitories(c *gin.Context) {
+	dormitories, err := h.dormitoryService.GetDormitories()
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dormitories retrieved successfully", dormitories)
+}
+
+func (h *DormitoryHandler) GetDormitoryByID(c *gin.Context) {
+	dormitory, err := h.dormitoryService.GetDormitoryByID(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dormitory retrieved successfully", dormitory)
+}
+
+func (h *DormitoryHandler) UpdateDormitory(c *gin.Context) {
+	var req request.DormitoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	dormitory, err := h.dormitoryService.UpdateDormitory(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dormitory updated successfully", dormitory)
+}
+
+func (h *DormitoryHandler) DeleteDormitory(c *gin.Context) {
+	if err := h.dormitoryService.DeleteDormitory(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dormitory deleted successfully", nil)
+}
+
+// GetOccupancyReport menangani GET /dormitories/occupancy?academic_year_id=
+func (h *DormitoryHandler) GetOccupancyReport(c *gin.Context) {
+	report, err := h.dormitoryService.GetOccupancyReport(c.Query("academic_year_id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dormitory occupancy report retrieved successfully", report)
+}
+
+// --- Rooms ---
+
+func (h *DormitoryHandler) CreateRoom(c *gin.Context) {
+	var req request.DormRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	room, err := h.dormitoryService.CreateRoom(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Dorm room created successfully", room)
+}
+
+// GetRooms menangani GET /dorm-rooms?dormitory_id=&academic_year_id=
+func (h *DormitoryHandler) GetRooms(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	rooms, err := h.dormitoryService.GetRooms(c.Query("dormitory_id"), c.Query("academic_year_id"), viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dorm rooms retrieved successfully", rooms)
+}
+
+func (h *DormitoryHandler) UpdateRoom(c *gin.Context) {
+	var req request.DormRoomRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	room, err := h.dormitoryService.UpdateRoom(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dorm room updated successfully", room)
+}
+
+func (h *DormitoryHandler) DeleteRoom(c *gin.Context) {
+	if err := h.dormitoryService.DeleteRoom(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dorm room deleted successfully", nil)
+}
+
+// --- Assignments ---
+
+// GetRoomOccupants menangani GET /dorm-rooms/:id/occupants?academic_year_id=
+func (h *DormitoryHandler) GetRoomOccupants(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	occupants, err := h.dormitoryService.GetRoomOccupants(c.Param("id"), c.Query("academic_year_id"), viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Dorm room occupants retrieved successfully", occupants)
+}
+
+func (h *DormitoryHandler) AssignStudents(c *gin.Context) {
+	var req request.DormAssignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdBy, _ := userID.(string)
+
+	assignments, err := h.dormitoryService.AssignStudents(c.Param("id"), req, createdBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Students assigned to dorm room successfully", assignments)
+}
+
+func (h *DormitoryHandler) MoveStudent(c *gin.Context) {
+	var req request.DormMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdBy, _ := userID.(string)
+
+	assignment, err := h.dormitoryService.MoveStudent(req, createdBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student moved to another dorm room successfully", assignment)
+}
+
+func (h *DormitoryHandler) Checkout(c *gin.Context) {
+	var req request.DormCheckoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BadRequestError(c, "Invalid request payload", err.Error())
+			return
+		}
+	}
+
+	assignment, err := h.dormitoryService.Checkout(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student checked out of dorm room successfully", assignment)
+}
+
+// GetStudentHistory menangani GET /students/:id/dorm-history
+func (h *DormitoryHandler) GetStudentHistory(c *gin.Context) {
+	history, err := h.dormitoryService.GetStudentHistory(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student dorm history retrieved successfully", history)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FamilyImportHandler struct {
+	familyImportService service.FamilyImportService
+}
+
+func NewFamilyImportHandler(familyImportService service.FamilyImportService) *FamilyImportHandler {
+	return &FamilyImportHandler{familyImportService: familyImportService}
+}
+
+// DownloadImportTemplate menangani GET /parents/import/template
+func (h *FamilyImportHandler) DownloadImportTemplate(c *gin.Context) {
+	buffer, err := h.familyImportService.GetImportTemplate()
+	if err != nil {
+		InternalServerError(c, "Failed to generate import template")
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=template_import_orang_tua_wali.xlsx")
+	c.Data(200, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buffer.Bytes())
+}
+
+// ImportFamilies menangani POST /parents/import (multipart: file, mode, overwrite_guardian)
+func (h *FamilyImportHandler) ImportFamilies(c *gin.Context) {
+	var req request.FamilyImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		BadRequestError(c, "Excel file is required", err.Error())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		BadRequestError(c, "Failed to open uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	res, err := h.familyImportService.ImportFamilies(file, req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if res.InvalidRows > 0 && !res.DryRun {
+		BadRequestError(c, "Import has invalid rows, nothing was saved", res)
+		return
+	}
+	if res.DryRun {
+		SuccessResponse(c, "Import validated (dry run), nothing was saved", res)
+		return
+	}
+	CreatedResponse(c, "Parents and guardians imported successfully", res)
+}
@@ -101,3 +101,23 @@ func (h *GradeHandler) DeleteAssessment(c *gin.Context) {
 
 	SuccessResponse(c, "Assessment deleted successfully", nil)
 }
+
+// PublishAssessment menangani POST /grades/assessments/:id/publish
+func (h *GradeHandler) PublishAssessment(c *gin.Context) {
+	if err := h.service.SetAssessmentPublished(c.Param("id"), true); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Assessment published successfully", nil)
+}
+
+// UnpublishAssessment menangani DELETE /grades/assessments/:id/publish
+func (h *GradeHandler) UnpublishAssessment(c *gin.Context) {
+	if err := h.service.SetAssessmentPublished(c.Param("id"), false); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Assessment unpublished successfully", nil)
+}
@@ -75,7 +75,13 @@ func (h *GuardianHandler) UpdateGuardian(c *gin.Context) {
 func (h *GuardianHandler) DeleteGuardian(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.guardianService.DeleteGuardian(id)
+	var req request.GuardianDeleteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	err := h.guardianService.DeleteGuardian(id, req)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -117,3 +123,14 @@ func (h *GuardianHandler) UnlinkUser(c *gin.Context) {
 
 	SuccessResponse(c, "Guardian unlinked from user successfully", nil)
 }
+
+// GetWards menangani GET /guardians/:id/wards
+func (h *GuardianHandler) GetWards(c *gin.Context) {
+	wards, err := h.guardianService.GetWards(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Wards retrieved successfully", wards)
+}
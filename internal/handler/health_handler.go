@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthHandler struct {
+	healthService service.HealthService
+}
+
+func NewHealthHandler(healthService service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// GetProfile menangani GET /students/:id/health
+func (h *HealthHandler) GetProfile(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.healthService.GetProfile(c.Param("id"), viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Health profile retrieved successfully", profile)
+}
+
+// UpdateProfile menangani PUT /students/:id/health
+func (h *HealthHandler) UpdateProfile(c *gin.Context) {
+	var req request.HealthProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	updatedBy, _ := userID.(string)
+
+	profile, err := h.healthService.UpdateProfile(c.Param("id"), req, updatedBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Health profile updated successfully", profile)
+}
+
+// GetStudentVisits menangani GET /students/:id/clinic-visits
+func (h *HealthHandler) GetStudentVisits(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	visits, err := h.healthService.GetStudentVisits(c.Param("id"), viewer, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Clinic visits retrieved successfully", visits)
+}
+
+// GetVisits menangani GET /clinic-visits?student_id=&outcome=&date_from=&date_to=
+func (h *HealthHandler) GetVisits(c *gin.Context) {
+	var filter request.ClinicVisitFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	visits, err := h.healthService.GetVisits(filter, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Clinic visits retrieved successfully", visits)
+}
+
+func (h *HealthHandler) GetVisitByID(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	visit, err := h.healthService.GetVisitByID(c.Param("id"), viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Clinic visit retrieved successfully", visit)
+}
+
+func (h *HealthHandler) CreateVisit(c *gin.Context) {
+	var req request.ClinicVisitCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	handledBy, _ := userID.(string)
+
+	visit, err := h.healthService.CreateVisit(req, handledBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Clinic visit recorded successfully", visit)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HouseholdHandler struct {
+	householdService service.HouseholdService
+}
+
+func NewHouseholdHandler(householdService service.HouseholdService) *HouseholdHandler {
+	return &HouseholdHandler{householdService: householdService}
+}
+
+// GetStudentSiblings menangani GET /students/:id/siblings
+func (h *HouseholdHandler) GetStudentSiblings(c *gin.Context) {
+	siblings, err := h.householdService.GetStudentSiblings(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student siblings retrieved successfully", siblings)
+}
+
+// GetHouseholds menangani GET /households?min_children=2&page=&limit=
+func (h *HouseholdHandler) GetHouseholds(c *gin.Context) {
+	minChildren := 0
+	if raw := c.Query("min_children"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			BadRequestError(c, "Invalid query parameters", "min_children must be a non-negative number")
+			return
+		}
+		minChildren = value
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	households, err := h.householdService.GetHouseholds(minChildren, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Households retrieved successfully", households)
+}
+
+func (h *HouseholdHandler) GetHouseholdByID(c *gin.Context) {
+	household, err := h.householdService.GetHouseholdByID(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Household retrieved successfully", household)
+}
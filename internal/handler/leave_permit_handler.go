@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LeavePermitHandler struct {
+	leavePermitService service.LeavePermitService
+}
+
+func NewLeavePermitHandler(leavePermitService service.LeavePermitService) *LeavePermitHandler {
+	return &LeavePermitHandler{leavePermitService: leavePermitService}
+}
+
+func (h *LeavePermitHandler) CreatePermit(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.LeavePermitCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	permit, err := h.leavePermitService.CreatePermit(req, viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Leave permit requested successfully", permit)
+}
+
+// GetPermits menangani GET /leave-permits?student_id=&status=&date=
+func (h *LeavePermitHandler) GetPermits(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var filter request.LeavePermitFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	permits, err := h.leavePermitService.GetPermits(filter, pagination, viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Leave permits retrieved successfully", permits)
+}
+
+// GetOverduePermits menangani GET /leave-permits/overdue
+func (h *LeavePermitHandler) GetOverduePermits(c *gin.Context) {
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	permits, err := h.leavePermitService.GetOverduePermits(pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Overdue leave permits retrieved successfully", permits)
+}
+
+func (h *LeavePermitHandler) GetPermitByID(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	permit, err := h.leavePermitService.GetPermitByID(c.Param("id"), viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Leave permit retrieved successfully", permit)
+}
+
+// ConfirmGuardian menangani POST /leave-permits/:id/confirm-guardian
+func (h *LeavePermitHandler) ConfirmGuardian(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.LeaveGuardianConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	permit, err := h.leavePermitService.ConfirmGuardian(c.Param("id"), req, viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Leave permit confirmed by guardian", permit)
+}
+
+func (h *LeavePermitHandler) ApprovePermit(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.LeavePermitApproveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	permit, err := h.leavePermitService.ApprovePermit(c.Param("id"), req, viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Leave permit approved successfully", permit)
+}
+
+func (h *LeavePermitHandler) RejectPermit(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.LeavePermitRejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	permit, err := h.leavePermitService.RejectPermit(c.Param("id"), req, viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Leave permit rejected successfully", permit)
+}
+
+func (h *LeavePermitHandler) CancelPermit(c *gin.Context) {
+	viewer, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	permit, err := h.leavePermitService.CancelPermit(c.Param("id"), viewer)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Leave permit cancelled successfully", permit)
+}
+
+// CheckOut menangani POST /leave-permits/:id/check-out (petugas gerbang)
+func (h *LeavePermitHandler) CheckOut(c *gin.Context) {
+	var req request.LeavePermitCheckOutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	checkedOutBy, _ := userID.(string)
+
+	permit, err := h.leavePermitService.CheckOut(c.Param("id"), req, checkedOutBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student checked out successfully", permit)
+}
+
+// CheckIn menangani POST /leave-permits/:id/check-in (petugas gerbang)
+func (h *LeavePermitHandler) CheckIn(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	checkedInBy, _ := userID.(string)
+
+	permit, err := h.leavePermitService.CheckIn(c.Param("id"), checkedInBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student checked in successfully", permit)
+}
@@ -78,7 +78,13 @@ func (h *ParentHandler) UpdateParent(c *gin.Context) {
 func (h *ParentHandler) DeleteParent(c *gin.Context) {
 	id := c.Param("id")
 
-	err := h.parentService.DeleteParent(id)
+	var req request.GuardianDeleteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	err := h.parentService.DeleteParent(id, req)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -126,3 +132,14 @@ func (h *ParentHandler) UnlinkUser(c *gin.Context) {
 
 	SuccessResponse(c, "Parent unlinked from user successfully", nil)
 }
+
+// GetWards menangani GET /parents/:id/wards
+func (h *ParentHandler) GetWards(c *gin.Context) {
+	wards, err := h.parentService.GetWards(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Wards retrieved successfully", wards)
+}
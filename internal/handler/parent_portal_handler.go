@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ParentPortalHandler struct {
+	portalService service.ParentPortalService
+}
+
+func NewParentPortalHandler(portalService service.ParentPortalService) *ParentPortalHandler {
+	return &ParentPortalHandler{portalService: portalService}
+}
+
+func portalUserID(c *gin.Context) string {
+	userID, _ := c.Get("user_id")
+	id, _ := userID.(string)
+	return id
+}
+
+// GetChildren menangani GET /me/children
+func (h *ParentPortalHandler) GetChildren(c *gin.Context) {
+	children, err := h.portalService.GetChildren(portalUserID(c))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Children retrieved successfully", children)
+}
+
+// GetSchedule menangani GET /me/children/:id/schedule
+func (h *ParentPortalHandler) GetSchedule(c *gin.Context) {
+	schedules, err := h.portalService.GetSchedule(portalUserID(c), c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Schedule retrieved successfully", schedules)
+}
+
+// GetAttendance menangani GET /me/children/:id/attendance?date_from=&date_to=
+func (h *ParentPortalHandler) GetAttendance(c *gin.Context) {
+	var filter request.PortalAttendanceFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	attendance, err := h.portalService.GetAttendance(portalUserID(c), c.Param("id"), filter, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Attendance retrieved successfully", attendance)
+}
+
+// GetScores menangani GET /me/children/:id/scores
+func (h *ParentPortalHandler) GetScores(c *gin.Context) {
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	scores, err := h.portalService.GetScores(portalUserID(c), c.Param("id"), pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Scores retrieved successfully", scores)
+}
+
+// GetViolations menangani GET /me/children/:id/violations
+func (h *ParentPortalHandler) GetViolations(c *gin.Context) {
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	violations, err := h.portalService.GetViolations(portalUserID(c), c.Param("id"), pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Violations retrieved successfully", violations)
+}
+
+// GetAnnouncements menangani GET /me/children/:id/announcements
+func (h *ParentPortalHandler) GetAnnouncements(c *gin.Context) {
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	announcements, err := h.portalService.GetAnnouncements(portalUserID(c), c.Param("id"), pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Announcements retrieved successfully", announcements)
+}
@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"fmt"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StaffAttendanceHandler struct {
+	staffAttendanceService service.StaffAttendanceService
+}
+
+func NewStaffAttendanceHandler(staffAttendanceService service.StaffAttendanceService) *StaffAttendanceHandler {
+	return &StaffAttendanceHandler{staffAttendanceService: staffAttendanceService}
+}
+
+// ===== Shift kerja =====
+
+func (h *StaffAttendanceHandler) GetShifts(c *gin.Context) {
+	shifts, err := h.staffAttendanceService.GetShifts()
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Work shifts retrieved successfully", shifts)
+}
+
+func (h *StaffAttendanceHandler) CreateShift(c *gin.Context) {
+	var req request.WorkShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	shift, err := h.staffAttendanceService.CreateShift(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Work shift created successfully", shift)
+}
+
+func (h *StaffAttendanceHandler) UpdateShift(c *gin.Context) {
+	var req request.WorkShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	shift, err := h.staffAttendanceService.UpdateShift(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Work shift updated successfully", shift)
+}
+
+func (h *StaffAttendanceHandler) DeleteShift(c *gin.Context) {
+	if err := h.staffAttendanceService.DeleteShift(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Work shift deleted successfully", nil)
+}
+
+// UpdateEmployeeSettings menangani PUT /staff-attendance/employees/:id/settings
+func (h *StaffAttendanceHandler) UpdateEmployeeSettings(c *gin.Context) {
+	var req request.EmployeeAttendanceSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	settings, err := h.staffAttendanceService.UpdateEmployeeSettings(c.Param("id"), req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Employee attendance settings updated successfully", settings)
+}
+
+// ===== Kehadiran =====
+
+// ClockIn menangani POST /staff-attendance/clock-in untuk pegawai yang sedang login
+func (h *StaffAttendanceHandler) ClockIn(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	attendance, err := h.staffAttendanceService.ClockIn(userIDStr)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Clocked in successfully", attendance)
+}
+
+// ClockOut menangani POST /staff-attendance/clock-out untuk pegawai yang sedang login
+func (h *StaffAttendanceHandler) ClockOut(c *gin.Context) {
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	attendance, err := h.staffAttendanceService.ClockOut(userIDStr)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Clocked out successfully", attendance)
+}
+
+// RecordManual menangani PUT /staff-attendance (input/koreksi oleh admin)
+func (h *StaffAttendanceHandler) RecordManual(c *gin.Context) {
+	var req request.StaffAttendanceManualRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	attendance, err := h.staffAttendanceService.RecordManual(req, userIDStr)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Staff attendance recorded successfully", attendance)
+}
+
+// GetDaily menangani GET /staff-attendance/daily?date=
+func (h *StaffAttendanceHandler) GetDaily(c *gin.Context) {
+	daily, err := h.staffAttendanceService.GetDaily(c.Query("date"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Staff daily attendance retrieved successfully", daily)
+}
+
+// ImportDeviceLog menangani POST /staff-attendance/import (multipart: file, mode)
+func (h *StaffAttendanceHandler) ImportDeviceLog(c *gin.Context) {
+	var req request.StaffAttendanceImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		BadRequestError(c, "Attendance log file is required", err.Error())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		BadRequestError(c, "Failed to open uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	res, err := h.staffAttendanceService.ImportDeviceLog(file, req, userIDStr)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if res.DryRun {
+		SuccessResponse(c, "Attendance log validated (dry run), nothing was saved", res)
+		return
+	}
+	SuccessResponse(c, "Attendance log imported successfully", res)
+}
+
+// ===== Cuti/izin =====
+
+// GetLeaves menangani GET /staff-attendance/leaves?month=&employee_id=
+func (h *StaffAttendanceHandler) GetLeaves(c *gin.Context) {
+	var filter request.StaffLeaveFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	leaves, err := h.staffAttendanceService.GetLeaves(filter)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Staff leaves retrieved successfully", leaves)
+}
+
+func (h *StaffAttendanceHandler) CreateLeave(c *gin.Context) {
+	var req request.StaffLeaveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+	userID, _ := c.Get("user_id")
+	userIDStr, _ := userID.(string)
+
+	leave, err := h.staffAttendanceService.CreateLeave(req, userIDStr)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Staff leave recorded successfully", leave)
+}
+
+func (h *StaffAttendanceHandler) DeleteLeave(c *gin.Context) {
+	if err := h.staffAttendanceService.DeleteLeave(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Staff leave deleted successfully", nil)
+}
+
+// ===== Rekap =====
+
+// GetMonthlyRecap menangani GET /staff-attendance/recap?month=YYYY-MM
+func (h *StaffAttendanceHandler) GetMonthlyRecap(c *gin.Context) {
+	var req request.StaffAttendanceRecapRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	recap, err := h.staffAttendanceService.GetMonthlyRecap(req.Month)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Staff attendance recap retrieved successfully", recap)
+}
+
+// ExportRecap menangani GET /staff-attendance/recap/export?month=YYYY-MM
+func (h *StaffAttendanceHandler) ExportRecap(c *gin.Context) {
+	var req request.StaffAttendanceRecapRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	month := req.Month
+	if month == "" {
+		month = time.Now().Format("2006-01")
+	}
+	filename := fmt.Sprintf("rekap_kehadiran_pegawai_%s.xlsx", month)
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Header("Expires", "0")
+	c.Header("Cache-Control", "must-revalidate")
+	c.Header("Pragma", "public")
+
+	c.Status(200)
+	if err := h.staffAttendanceService.ExportRecap(c.Writer, req.Month); err != nil {
+		if !c.Writer.Written() {
+			// Belum ada byte terkirim, kembalikan error JSON biasa
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Description")
+			c.Writer.Header().Del("Content-Transfer-Encoding")
+			c.Writer.Header().Del("Content-Type")
+			HandleError(c, err)
+			return
+		}
+		c.Error(err)
+	}
+}
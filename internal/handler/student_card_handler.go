@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StudentCardHandler struct {
+	cardService service.StudentCardService
+}
+
+func NewStudentCardHandler(cardService service.StudentCardService) *StudentCardHandler {
+	return &StudentCardHandler{cardService: cardService}
+}
+
+// UploadPhoto menangani PUT /students/:id/photo (multipart: photo)
+func (h *StudentCardHandler) UploadPhoto(c *gin.Context) {
+	file, err := c.FormFile("photo")
+	if err != nil {
+		BadRequestError(c, "Photo is required", err.Error())
+		return
+	}
+
+	res, err := h.cardService.UploadPhoto(c.Param("id"), file)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student photo uploaded successfully", res)
+}
+
+func (h *StudentCardHandler) DeletePhoto(c *gin.Context) {
+	if err := h.cardService.DeletePhoto(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student photo deleted successfully", nil)
+}
+
+// GetStudentCard menangani GET /students/:id/id-card?layout=card|sheet
+func (h *StudentCardHandler) GetStudentCard(c *gin.Context) {
+	id := c.Param("id")
+
+	buffer, err := h.cardService.GenerateStudentCard(id, c.Query("layout"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=kartu_pelajar_%s.pdf", id))
+	c.Data(200, "application/pdf", buffer.Bytes())
+}
+
+// GetClassroomCards menangani GET /classrooms/:id/id-cards?layout=sheet|card
+func (h *StudentCardHandler) GetClassroomCards(c *gin.Context) {
+	id := c.Param("id")
+
+	buffer, err := h.cardService.GenerateClassroomCards(id, c.Query("layout"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=kartu_pelajar_kelas_%s.pdf", id))
+	c.Data(200, "application/pdf", buffer.Bytes())
+}
+
+// VerifyCard menangani GET /student-cards/verify?token=... (hasil scan QR, tanpa login)
+func (h *StudentCardHandler) VerifyCard(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		BadRequestError(c, "token is required", nil)
+		return
+	}
+
+	res, err := h.cardService.VerifyCard(token)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student card is valid", res)
+}
@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"smart_school_be/internal/model/request"
 	"smart_school_be/internal/service"
-	"smart_school_be/internal/utils"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -26,62 +27,7 @@ func (h *StudentHandler) CreateStudent(c *gin.Context) {
 		return
 	}
 
-	// List file yang akan diupload
-	fileKeys := []struct {
-		Key      string
-		FieldPtr *string
-	}{
-		{"birth_certificate_file", nil},
-		{"family_card_file", nil},
-		{"parent_statement_file", nil},
-		{"student_statement_file", nil},
-		{"health_insurance_file", nil},
-		{"diploma_certificate_file", nil},
-		{"graduation_certificate_file", nil},
-		{"financial_hardship_letter_file", nil},
-	}
-
-	uploadedPaths := make(map[string]string)
-
-	// Clean up jika terjadi error di tengah jalan
-	defer func() {
-		if c.IsAborted() {
-			for _, path := range uploadedPaths {
-				utils.RemoveFile(path)
-			}
-		}
-	}()
-
-	for _, fk := range fileKeys {
-		file, err := c.FormFile(fk.Key)
-		if err == nil {
-			// Simpan file
-			path, errSave := utils.SaveUploadedFile(c, file, "students", fk.Key)
-			if errSave != nil {
-				// Hapus file yang sudah terlanjur terupload
-				for _, p := range uploadedPaths {
-					utils.RemoveFile(p)
-				}
-				BadRequestError(c, fmt.Sprintf("Failed to upload %s", fk.Key), errSave.Error())
-				return
-			}
-			uploadedPaths[fk.Key] = path
-		}
-	}
-
-	// Masukkan ke struct
-	filesToCheck := service.StudentFiles{
-		BirthCertificateFile:        uploadedPaths["birth_certificate_file"],
-		FamilyCardFile:              uploadedPaths["family_card_file"],
-		ParentStatementFile:         uploadedPaths["parent_statement_file"],
-		StudentStatementFile:        uploadedPaths["student_statement_file"],
-		HealthInsuranceFile:         uploadedPaths["health_insurance_file"],
-		DiplomaCertificateFile:      uploadedPaths["diploma_certificate_file"],
-		GraduationCertificateFile:   uploadedPaths["graduation_certificate_file"],
-		FinancialHardshipLetterFile: uploadedPaths["financial_hardship_letter_file"],
-	}
-
-	student, err := h.studentService.CreateStudent(req, filesToCheck)
+	student, err := h.studentService.CreateStudent(req)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -91,11 +37,14 @@ func (h *StudentHandler) CreateStudent(c *gin.Context) {
 }
 
 func (h *StudentHandler) GetAllStudents(c *gin.Context) {
-	searchQuery := c.Query("q")
-	classroomID := c.Query("classroom_id")
+	var filter request.StudentFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid filter", err.Error())
+		return
+	}
 	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
 
-	students, err := h.studentService.GetAllStudents(searchQuery, classroomID, pagination)
+	students, err := h.studentService.GetAllStudents(filter, pagination)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -126,48 +75,7 @@ func (h *StudentHandler) UpdateStudent(c *gin.Context) {
 		return
 	}
 
-	// File upload loop
-	fileKeys := []string{
-		"birth_certificate_file",
-		"family_card_file",
-		"parent_statement_file",
-		"student_statement_file",
-		"health_insurance_file",
-		"diploma_certificate_file",
-		"graduation_certificate_file",
-		"financial_hardship_letter_file",
-	}
-
-	uploadedPaths := make(map[string]string)
-
-	for _, key := range fileKeys {
-		file, err := c.FormFile(key)
-		if err == nil {
-			path, errSave := utils.SaveUploadedFile(c, file, "students", key)
-			if errSave != nil {
-				// Cleanup newly uploaded files
-				for _, p := range uploadedPaths {
-					utils.RemoveFile(p)
-				}
-				BadRequestError(c, fmt.Sprintf("Failed to upload %s", key), errSave.Error())
-				return
-			}
-			uploadedPaths[key] = path
-		}
-	}
-
-	filesToUpdate := service.StudentFiles{
-		BirthCertificateFile:        uploadedPaths["birth_certificate_file"],
-		FamilyCardFile:              uploadedPaths["family_card_file"],
-		ParentStatementFile:         uploadedPaths["parent_statement_file"],
-		StudentStatementFile:        uploadedPaths["student_statement_file"],
-		HealthInsuranceFile:         uploadedPaths["health_insurance_file"],
-		DiplomaCertificateFile:      uploadedPaths["diploma_certificate_file"],
-		GraduationCertificateFile:   uploadedPaths["graduation_certificate_file"],
-		FinancialHardshipLetterFile: uploadedPaths["financial_hardship_letter_file"],
-	}
-
-	student, err := h.studentService.UpdateStudent(id, req, filesToUpdate)
+	student, err := h.studentService.UpdateStudent(id, req)
 	if err != nil {
 		HandleError(c, err)
 		return
@@ -277,10 +185,19 @@ func (h *StudentHandler) UnlinkUser(c *gin.Context) {
 	SuccessResponse(c, "Student unlinked from user successfully", nil)
 }
 
+// exportColumns membaca ?columns=nisn,full_name,... (kosong berarti kolom default)
+func exportColumns(c *gin.Context) []string {
+	raw := strings.TrimSpace(c.Query("columns"))
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
 func (h *StudentHandler) ExportExcel(c *gin.Context) {
-	buffer, err := h.studentService.ExportStudentsToExcel()
-	if err != nil {
-		InternalServerError(c, "Failed to generate excel file")
+	var filter request.StudentFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid filter", err.Error())
 		return
 	}
 
@@ -296,14 +213,32 @@ func (h *StudentHandler) ExportExcel(c *gin.Context) {
 	c.Header("Cache-Control", "must-revalidate")
 	c.Header("Pragma", "public")
 
-	// Kirim binary data
-	c.Data(200, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buffer.Bytes())
+	// File ditulis langsung ke response (streaming)
+	c.Status(200)
+	if err := h.studentService.ExportStudentsToExcel(c.Writer, filter, exportColumns(c)); err != nil {
+		if !c.Writer.Written() {
+			// Belum ada byte terkirim, kembalikan error JSON biasa
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Description")
+			c.Writer.Header().Del("Content-Transfer-Encoding")
+			c.Writer.Header().Del("Content-Type")
+			HandleError(c, err)
+			return
+		}
+		c.Error(err)
+	}
 }
 
 func (h *StudentHandler) ExportPDF(c *gin.Context) {
-	buffer, err := h.studentService.ExportStudentsToPdf()
+	var filter request.StudentFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid filter", err.Error())
+		return
+	}
+
+	buffer, err := h.studentService.ExportStudentsToPdf(filter, exportColumns(c))
 	if err != nil {
-		InternalServerError(c, "Failed to generate PDF file")
+		HandleError(c, err)
 		return
 	}
 
@@ -332,3 +267,98 @@ func (h *StudentHandler) ExportStudentBiodata(c *gin.Context) {
 	c.Header("Content-Type", "application/pdf")
 	c.Data(200, "application/pdf", buffer.Bytes())
 }
+
+// DownloadImportTemplate menangani GET /students/import/template
+func (h *StudentHandler) DownloadImportTemplate(c *gin.Context) {
+	buffer, err := h.studentService.GetImportTemplate()
+	if err != nil {
+		InternalServerError(c, "Failed to generate import template")
+		return
+	}
+
+	c.Header("Content-Disposition", "attachment; filename=template_import_siswa.xlsx")
+	c.Data(200, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buffer.Bytes())
+}
+
+// ImportStudents menangani POST /students/import (multipart: file, mode, include_parents, assign_classroom, academic_year_id)
+func (h *StudentHandler) ImportStudents(c *gin.Context) {
+	var req request.StudentImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		BadRequestError(c, "Excel file is required", err.Error())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		BadRequestError(c, "Failed to open uploaded file", err.Error())
+		return
+	}
+	defer file.Close()
+
+	res, err := h.studentService.ImportStudents(file, req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	if res.InvalidRows > 0 && !res.DryRun {
+		BadRequestError(c, "Import has invalid rows, nothing was saved", res)
+		return
+	}
+	if res.DryRun {
+		SuccessResponse(c, "Import validated (dry run), nothing was saved", res)
+		return
+	}
+	CreatedResponse(c, "Students imported successfully", res)
+}
+
+// FindDuplicates menangani GET /students/duplicates?min_score=60&limit=100
+func (h *StudentHandler) FindDuplicates(c *gin.Context) {
+	minScore, limit := 0, 100
+	if v := c.Query("min_score"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 || parsed > 100 {
+			BadRequestError(c, "min_score must be a number between 0 and 100", nil)
+			return
+		}
+		minScore = parsed
+	}
+	if v := c.Query("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			BadRequestError(c, "Invalid limit", nil)
+			return
+		}
+		limit = parsed
+	}
+
+	candidates, err := h.studentService.FindDuplicateStudents(minScore, limit)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Duplicate candidates retrieved successfully", candidates)
+}
+
+// MergeStudents menangani POST /students/merge
+func (h *StudentHandler) MergeStudents(c *gin.Context) {
+	var req request.StudentMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	res, err := h.studentService.MergeStudents(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Students merged successfully", res)
+}
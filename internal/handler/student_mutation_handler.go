@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StudentMutationHandler struct {
+	mutationService service.StudentMutationService
+}
+
+func NewStudentMutationHandler(mutationService service.StudentMutationService) *StudentMutationHandler {
+	return &StudentMutationHandler{mutationService: mutationService}
+}
+
+func (h *StudentMutationHandler) CreateMutation(c *gin.Context) {
+	var req request.StudentMutationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdBy, _ := userID.(string)
+
+	mutation, err := h.mutationService.CreateMutation(c.Param("id"), req, createdBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Student mutation recorded successfully", mutation)
+}
+
+func (h *StudentMutationHandler) GetStudentMutations(c *gin.Context) {
+	mutations, err := h.mutationService.GetStudentMutations(c.Param("id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student mutations retrieved successfully", mutations)
+}
+
+// GetMonthlyReport menampilkan laporan mutasi ?year=2026&month=10 (default: bulan berjalan)
+func (h *StudentMutationHandler) GetMonthlyReport(c *gin.Context) {
+	now := time.Now()
+	year, month := now.Year(), int(now.Month())
+
+	if v := c.Query("year"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			BadRequestError(c, "Invalid year", err.Error())
+			return
+		}
+		year = parsed
+	}
+	if v := c.Query("month"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			BadRequestError(c, "Invalid month", err.Error())
+			return
+		}
+		month = parsed
+	}
+
+	report, err := h.mutationService.GetMonthlyReport(year, month)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student mutation report retrieved successfully", report)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StudentTimelineHandler struct {
+	timelineService service.StudentTimelineService
+}
+
+func NewStudentTimelineHandler(timelineService service.StudentTimelineService) *StudentTimelineHandler {
+	return &StudentTimelineHandler{timelineService: timelineService}
+}
+
+// GetTimeline menangani GET /students/:id/timeline?academic_year_id=&types=attendance,score&page=&limit=
+func (h *StudentTimelineHandler) GetTimeline(c *gin.Context) {
+	var req request.StudentTimelineRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid filter", err.Error())
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	timeline, err := h.timelineService.GetTimeline(c.Param("id"), req, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Student timeline retrieved successfully", timeline)
+}
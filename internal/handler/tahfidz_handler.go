@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"fmt"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TahfidzHandler struct {
+	tahfidzService service.TahfidzService
+}
+
+func NewTahfidzHandler(tahfidzService service.TahfidzService) *TahfidzHandler {
+	return &TahfidzHandler{tahfidzService: tahfidzService}
+}
+
+// GetSurahs menampilkan daftar surah beserta jumlah ayat (referensi form setoran)
+func (h *TahfidzHandler) GetSurahs(c *gin.Context) {
+	SuccessResponse(c, "Surahs retrieved successfully", h.tahfidzService.GetSurahs())
+}
+
+func (h *TahfidzHandler) CreateSession(c *gin.Context) {
+	var req request.TahfidzSessionCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	userID, _ := c.Get("user_id")
+	createdBy, _ := userID.(string)
+
+	session, err := h.tahfidzService.CreateSession(req, createdBy)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	CreatedResponse(c, "Tahfidz session recorded successfully", session)
+}
+
+// GetSessions menangani GET /tahfidz/sessions?student_id=&academic_year_id=&type=&examiner_id=
+func (h *TahfidzHandler) GetSessions(c *gin.Context) {
+	var filter request.TahfidzSessionFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	sessions, err := h.tahfidzService.GetSessions(filter, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Tahfidz sessions retrieved successfully", sessions)
+}
+
+func (h *TahfidzHandler) DeleteSession(c *gin.Context) {
+	if err := h.tahfidzService.DeleteSession(c.Param("id")); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Tahfidz session deleted successfully", nil)
+}
+
+func (h *TahfidzHandler) SetTargets(c *gin.Context) {
+	var req request.TahfidzTargetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		BadRequestError(c, "Invalid request payload", err.Error())
+		return
+	}
+
+	saved, err := h.tahfidzService.SetTargets(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Tahfidz targets saved successfully", gin.H{"saved": saved})
+}
+
+// GetProgress menangani GET /students/:id/tahfidz/progress?academic_year_id=
+func (h *TahfidzHandler) GetProgress(c *gin.Context) {
+	progress, err := h.tahfidzService.GetProgress(c.Param("id"), c.Query("academic_year_id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Tahfidz progress retrieved successfully", progress)
+}
+
+// GetReportCardSection menangani GET /students/:id/tahfidz/report-card?academic_year_id=
+func (h *TahfidzHandler) GetReportCardSection(c *gin.Context) {
+	section, err := h.tahfidzService.GetReportCardSection(c.Param("id"), c.Query("academic_year_id"))
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Tahfidz report card section retrieved successfully", section)
+}
+
+// ExportExcel menangani GET /tahfidz/export?academic_year_id=&classroom_id=
+func (h *TahfidzHandler) ExportExcel(c *gin.Context) {
+	var req request.TahfidzExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("rekap_tahfidz_%s.xlsx", time.Now().Format("20060102_150405"))
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Header("Expires", "0")
+	c.Header("Cache-Control", "must-revalidate")
+	c.Header("Pragma", "public")
+
+	c.Status(200)
+	if err := h.tahfidzService.ExportToExcel(c.Writer, req); err != nil {
+		if !c.Writer.Written() {
+			// Belum ada byte terkirim, kembalikan error JSON biasa
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Description")
+			c.Writer.Header().Del("Content-Transfer-Encoding")
+			c.Writer.Header().Del("Content-Type")
+			HandleError(c, err)
+			return
+		}
+		c.Error(err)
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TeacherWorkloadHandler struct {
+	teacherWorkloadService service.TeacherWorkloadService
+}
+
+func NewTeacherWorkloadHandler(teacherWorkloadService service.TeacherWorkloadService) *TeacherWorkloadHandler {
+	return &TeacherWorkloadHandler{teacherWorkloadService: teacherWorkloadService}
+}
+
+// GetReport menangani GET /teacher-workload?academic_year_id=&teacher_id=&month=&minimum_jp=
+func (h *TeacherWorkloadHandler) GetReport(c *gin.Context) {
+	var req request.TeacherWorkloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	report, err := h.teacherWorkloadService.GetReport(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Teacher workload report retrieved successfully", report)
+}
+
+// ExportExcel menangani GET /teacher-workload/export/excel dengan filter yang sama
+func (h *TeacherWorkloadHandler) ExportExcel(c *gin.Context) {
+	var req request.TeacherWorkloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("beban_mengajar_guru_%s.xlsx", time.Now().Format("20060102_150405"))
+
+	c.Header("Content-Description", "File Transfer")
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Transfer-Encoding", "binary")
+	c.Header("Expires", "0")
+	c.Header("Cache-Control", "must-revalidate")
+	c.Header("Pragma", "public")
+
+	c.Status(200)
+	if err := h.teacherWorkloadService.ExportToExcel(c.Writer, req); err != nil {
+		if !c.Writer.Written() {
+			// Belum ada byte terkirim, kembalikan error JSON biasa
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Description")
+			c.Writer.Header().Del("Content-Transfer-Encoding")
+			c.Writer.Header().Del("Content-Type")
+			HandleError(c, err)
+			return
+		}
+		c.Error(err)
+	}
+}
+
+// ExportPDF menangani GET /teacher-workload/export/pdf dengan filter yang sama
+func (h *TeacherWorkloadHandler) ExportPDF(c *gin.Context) {
+	var req request.TeacherWorkloadRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		BadRequestError(c, "Invalid query parameters", err.Error())
+		return
+	}
+
+	buffer, err := h.teacherWorkloadService.ExportToPdf(req)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("beban_mengajar_guru_%s.pdf", time.Now().Format("20060102_150405"))
+
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Length", fmt.Sprintf("%d", buffer.Len()))
+
+	c.Data(200, "application/pdf", buffer.Bytes())
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	trashService service.TrashService
+}
+
+func NewTrashHandler(trashService service.TrashService) *TrashHandler {
+	return &TrashHandler{trashService: trashService}
+}
+
+// GetTrash menangani GET /trash/:type
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	entityType := c.Param("type")
+	pagination := request.NewPaginationRequest(c.Query("page"), c.Query("limit"))
+
+	res, err := h.trashService.GetTrash(entityType, pagination)
+	if err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Trash retrieved successfully", res)
+}
+
+// Restore menangani POST /trash/:type/:id/restore
+func (h *TrashHandler) Restore(c *gin.Context) {
+	entityType := c.Param("type")
+	id := c.Param("id")
+
+	if err := h.trashService.Restore(entityType, id); err != nil {
+		HandleError(c, err)
+		return
+	}
+
+	SuccessResponse(c, "Record restored successfully", nil)
+}
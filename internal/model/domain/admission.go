@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status pendaftar PPDB: submitted → verified → tested → accepted/rejected
+const (
+	ApplicantStatusSubmitted = "submitted"
+	ApplicantStatusVerified  = "verified"
+	ApplicantStatusTested    = "tested"
+	ApplicantStatusAccepted  = "accepted"
+	ApplicantStatusRejected  = "rejected"
+)
+
+// DocumentOwnerApplicant dipakai untuk dokumen yang diupload pendaftar.
+// Saat dikonversi menjadi siswa, dokumen dipindah ke owner_type student.
+const DocumentOwnerApplicant = "applicant"
+
+// AdmissionWave adalah satu gelombang pendaftaran dengan kuota penerimaan
+type AdmissionWave struct {
+	ID             string     `gorm:"type:char(36);primaryKey" json:"id"`
+	AcademicYearID string     `gorm:"type:char(36);not null;index" json:"academic_year_id"`
+	Name           string     `gorm:"type:varchar(100);not null" json:"name"`
+	StartDate      utils.Date `gorm:"type:date;not null" json:"start_date"`
+	EndDate        utils.Date `gorm:"type:date;not null" json:"end_date"`
+	Quota          int        `gorm:"not null;default:0" json:"quota"` // 0 = tanpa batas
+	IsOpen         bool       `gorm:"not null;default:true" json:"is_open"`
+	Description    *string    `gorm:"type:text" json:"description"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Relationships
+	AcademicYear *AcademicYear `gorm:"foreignKey:AcademicYearID" json:"academic_year,omitempty"`
+}
+
+func (w *AdmissionWave) BeforeCreate(tx *gorm.DB) (err error) {
+	if w.ID == "" {
+		w.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// AcceptsApplications mengembalikan true jika gelombang dibuka dan tanggal berada dalam periode pendaftaran
+func (w *AdmissionWave) AcceptsApplications(now time.Time) bool {
+	today := now.Format("2006-01-02")
+	return w.IsOpen && today >= w.StartDate.ToTime().Format("2006-01-02") && today <= w.EndDate.ToTime().Format("2006-01-02")
+}
+
+// Applicant adalah calon siswa. Field mengikuti Student agar bisa dikonversi tanpa input ulang.
+type Applicant struct {
+	ID                 string     `gorm:"type:char(36);primaryKey" json:"id"`
+	AdmissionWaveID    string     `gorm:"type:char(36);not null;index:idx_applicants_wave_status,priority:1" json:"admission_wave_id"`
+	RegistrationNumber string     `gorm:"type:varchar(30);not null;uniqueIndex" json:"registration_number"`
+	Status             string     `gorm:"type:enum('submitted','verified','tested','accepted','rejected');not null;default:'submitted';index:idx_applicants_wave_status,priority:2" json:"status"`
+	FullName           string     `gorm:"type:varchar(100);not null" json:"full_name"`
+	NoKK               string     `gorm:"type:text" json:"no_kk,omitempty"` // akan dienkripsi
+	NIK                *string    `gorm:"type:text" json:"nik,omitempty"`   // akan dienkripsi
+	NIKHash            *string    `gorm:"type:varchar(64);index" json:"-"`  // Tidak unik: satu anak bisa mendaftar ulang di gelombang lain
+	NISN               *string    `gorm:"type:varchar(20)" json:"nisn"`
+	Gender             string     `gorm:"type:varchar(10);not null" json:"gender"`
+	PlaceOfBirth       *string    `gorm:"type:varchar(100)" json:"place_of_birth"`
+	DateOfBirth        utils.Date `gorm:"type:date;not null" json:"date_of_birth"`
+	Address            *string    `gorm:"type:text" json:"address"`
+	RT                 *string    `gorm:"type:varchar(3)" json:"rt"`
+	RW                 *string    `gorm:"type:varchar(3)" json:"rw"`
+	SubDistrict        *string    `gorm:"type:varchar(100)" json:"sub_district"`
+	District           *string    `gorm:"type:varchar(100)" json:"district"`
+	City               *string    `gorm:"type:varchar(100)" json:"city"`
+	Province           *string    `gorm:"type:varchar(100)" json:"province"`
+	PostalCode         *string    `gorm:"type:varchar(5)" json:"postal_code"`
+	PreviousSchool     *string    `gorm:"type:varchar(150)" json:"previous_school"`
+	PhoneNumber        *string    `gorm:"type:varchar(20)" json:"phone_number"`
+	Email              *string    `gorm:"type:varchar(100)" json:"email"`
+	TestScore          *float64   `gorm:"type:decimal(5,2)" json:"test_score"`
+	Notes              *string    `gorm:"type:text" json:"notes"`
+	StudentID          *string    `gorm:"type:char(36)" json:"student_id"`
+	ConvertedAt        *time.Time `json:"converted_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	// Relationships
+	AdmissionWave *AdmissionWave       `gorm:"foreignKey:AdmissionWaveID" json:"admission_wave,omitempty"`
+	Parents       []ApplicantParent    `gorm:"foreignKey:ApplicantID" json:"parents,omitempty"`
+	StatusLogs    []ApplicantStatusLog `gorm:"foreignKey:ApplicantID" json:"status_logs,omitempty"`
+}
+
+func (a *Applicant) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// ApplicantParent adalah data ayah/ibu pendaftar, field mengikuti Parent
+type ApplicantParent struct {
+	ID               string      `gorm:"type:char(36);primaryKey" json:"id"`
+	ApplicantID      string      `gorm:"type:char(36);not null;index" json:"applicant_id"`
+	RelationshipType string      `gorm:"type:varchar(50);not null" json:"relationship_type"` // FATHER / MOTHER
+	FullName         string      `gorm:"type:varchar(100);not null" json:"full_name"`
+	NIK              *string     `gorm:"type:text" json:"nik,omitempty"` // Akan dienkripsi
+	NIKHash          *string     `gorm:"type:varchar(64)" json:"-"`
+	Gender           *string     `gorm:"type:varchar(10)" json:"gender"`
+	PlaceOfBirth     *string     `gorm:"type:varchar(100)" json:"place_of_birth"`
+	DateOfBirth      *utils.Date `gorm:"type:date" json:"date_of_birth"`
+	LifeStatus       *string     `gorm:"type:varchar(10);default:'alive'" json:"life_status"`
+	PhoneNumber      *string     `gorm:"type:varchar(20)" json:"phone_number"`
+	Email            *string     `gorm:"type:varchar(100)" json:"email"`
+	EducationLevel   *string     `gorm:"type:varchar(50)" json:"education_level"`
+	Occupation       *string     `gorm:"type:varchar(100)" json:"occupation"`
+	IncomeRange      *string     `gorm:"type:varchar(50)" json:"income_range"`
+	ParentID         *string     `gorm:"type:char(36)" json:"parent_id"` // Diisi saat konversi
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+func (p *ApplicantParent) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == "" {
+		p.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// ApplicantStatusLog adalah riwayat perpindahan status pendaftar
+type ApplicantStatusLog struct {
+	ID          string    `gorm:"type:char(36);primaryKey" json:"id"`
+	ApplicantID string    `gorm:"type:char(36);not null;index" json:"applicant_id"`
+	FromStatus  *string   `gorm:"type:varchar(20)" json:"from_status"`
+	ToStatus    string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	Notes       *string   `gorm:"type:text" json:"notes"`
+	CreatedBy   *string   `gorm:"type:char(36)" json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (l *ApplicantStatusLog) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == "" {
+		l.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// RegistrationCounter menyimpan nomor urut pendaftaran terakhir per prefix (mis. "PPDB-2026-").
+// Baris dikunci (SELECT ... FOR UPDATE) saat pendaftar dibuat agar pendaftaran bersamaan tidak
+// mendapat nomor yang sama.
+type RegistrationCounter struct {
+	Prefix     string    `gorm:"type:varchar(20);primaryKey" json:"prefix"`
+	LastNumber int       `gorm:"not null;default:0" json:"last_number"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
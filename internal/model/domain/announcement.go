@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Announcement adalah pengumuman sekolah. ClassroomID nil berarti untuk seluruh sekolah.
+type Announcement struct {
+	ID          string     `gorm:"type:char(36);primaryKey" json:"id"`
+	Title       string     `gorm:"type:varchar(200);not null" json:"title"`
+	Body        string     `gorm:"type:text;not null" json:"body"`
+	ClassroomID *string    `gorm:"type:char(36);index" json:"classroom_id"`
+	PublishedAt time.Time  `gorm:"not null;index" json:"published_at"` // Bisa dijadwalkan ke depan
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedBy   *string    `gorm:"type:char(36)" json:"created_by"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	Classroom *Classroom `gorm:"foreignKey:ClassroomID" json:"classroom,omitempty"`
+}
+
+func (a *Announcement) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = utils.GenerateUUID()
+	}
+	return
+}
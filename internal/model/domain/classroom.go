@@ -8,15 +8,16 @@ import (
 )
 
 type Classroom struct {
-	ID                string    `gorm:"primaryKey;type:char(36)" json:"id"`
-	AcademicYearID    string    `gorm:"type:char(36);not null" json:"academic_year_id"`
-	HomeroomTeacherID *string   `gorm:"type:char(36)" json:"homeroom_teacher_id"`
-	Name              string    `gorm:"type:varchar(50);not null" json:"name"`
-	Level             string    `gorm:"type:varchar(10);not null" json:"level"`
-	Major             string    `gorm:"type:varchar(50)" json:"major"`
-	Description       string    `gorm:"type:text" json:"description"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                string         `gorm:"primaryKey;type:char(36)" json:"id"`
+	AcademicYearID    string         `gorm:"type:char(36);not null" json:"academic_year_id"`
+	HomeroomTeacherID *string        `gorm:"type:char(36)" json:"homeroom_teacher_id"`
+	Name              string         `gorm:"type:varchar(50);not null" json:"name"`
+	Level             string         `gorm:"type:varchar(10);not null" json:"level"`
+	Major             string         `gorm:"type:varchar(50)" json:"major"`
+	Description       string         `gorm:"type:text" json:"description"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
 	TotalStudents int64 `gorm:"->"` // Menampung hasil subquery COUNT
 
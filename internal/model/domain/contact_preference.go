@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContactPreference adalah preferensi komunikasi satu orang tua/wali (polimorfik seperti wali siswa)
+type ContactPreference struct {
+	ID               string    `gorm:"type:char(36);primaryKey" json:"id"`
+	ContactType      string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_contact_preference" json:"contact_type"` // parent, guardian
+	ContactID        string    `gorm:"type:char(36);not null;uniqueIndex:idx_contact_preference" json:"contact_id"`
+	PreferredChannel string    `gorm:"type:varchar(20);not null;default:'WHATSAPP'" json:"preferred_channel"` // WHATSAPP, SMS, EMAIL
+	Language         string    `gorm:"type:varchar(5);not null;default:'id'" json:"language"`
+	QuietHoursStart  *string   `gorm:"type:varchar(5)" json:"quiet_hours_start"` // HH:MM
+	QuietHoursEnd    *string   `gorm:"type:varchar(5)" json:"quiet_hours_end"`
+	OptOutCategories string    `gorm:"type:varchar(255);not null;default:''" json:"-"` // Dipisah koma
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+func (p *ContactPreference) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == "" {
+		p.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// OptOutList mengembalikan kategori yang di-opt-out sebagai slice
+func (p *ContactPreference) OptOutList() []string {
+	if p.OptOutCategories == "" {
+		return []string{}
+	}
+	return strings.Split(p.OptOutCategories, ",")
+}
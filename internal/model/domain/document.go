@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Pemilik dokumen (polimorfik, seperti guardian_type di students)
+const (
+	DocumentOwnerStudent  = "student"
+	DocumentOwnerEmployee = "employee"
+)
+
+// Status verifikasi dokumen
+const (
+	DocumentStatusPending  = "pending"
+	DocumentStatusVerified = "verified"
+	DocumentStatusRejected = "rejected"
+)
+
+// DocumentType adalah jenis dokumen yang didefinisikan admin (mis. akta kelahiran, kartu vaksin)
+type DocumentType struct {
+	ID               string         `gorm:"type:char(36);primaryKey" json:"id"`
+	Code             string         `gorm:"type:varchar(50);not null;uniqueIndex:uq_document_types_owner_code" json:"code"`
+	Name             string         `gorm:"type:varchar(100);not null" json:"name"`
+	OwnerType        string         `gorm:"type:varchar(20);not null;uniqueIndex:uq_document_types_owner_code" json:"owner_type"`
+	IsRequired       bool           `gorm:"not null;default:false" json:"is_required"`
+	AllowedMimeTypes string         `gorm:"type:varchar(255);not null" json:"allowed_mime_types"` // Dipisah koma
+	MaxSizeKB        int            `gorm:"not null;default:2048" json:"max_size_kb"`
+	Description      *string        `gorm:"type:text" json:"description"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+func (dt *DocumentType) BeforeCreate(tx *gorm.DB) (err error) {
+	if dt.ID == "" {
+		dt.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// MimeTypes mengembalikan daftar MIME type yang diizinkan
+func (dt *DocumentType) MimeTypes() []string {
+	var result []string
+	for _, m := range strings.Split(dt.AllowedMimeTypes, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// Document adalah satu file yang diupload untuk siswa atau pegawai.
+// Upload ulang membuat baris baru dan menandai versi lama IsCurrent = false (riwayat upload).
+type Document struct {
+	ID             string         `gorm:"type:char(36);primaryKey" json:"id"`
+	DocumentTypeID string         `gorm:"type:char(36);not null;index:idx_documents_owner,priority:3" json:"document_type_id"`
+	OwnerType      string         `gorm:"type:varchar(20);not null;index:idx_documents_owner,priority:1" json:"owner_type"`
+	OwnerID        string         `gorm:"type:char(36);not null;index:idx_documents_owner,priority:2" json:"owner_id"`
+	FilePath       string         `gorm:"type:varchar(255);not null" json:"file_path"`
+	OriginalName   *string        `gorm:"type:varchar(255)" json:"original_name"`
+	MimeType       *string        `gorm:"type:varchar(100)" json:"mime_type"`
+	SizeBytes      int64          `gorm:"not null;default:0" json:"size_bytes"`
+	Version        int            `gorm:"not null;default:1" json:"version"`
+	IsCurrent      bool           `gorm:"not null;default:true" json:"is_current"`
+	Status         string         `gorm:"type:enum('pending','verified','rejected');not null;default:'pending'" json:"status"`
+	Notes          *string        `gorm:"type:text" json:"notes"`
+	UploadedBy     *string        `gorm:"type:char(36)" json:"uploaded_by"`
+	VerifiedBy     *string        `gorm:"type:char(36)" json:"verified_by"`
+	VerifiedAt     *time.Time     `json:"verified_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relationships
+	DocumentType *DocumentType `gorm:"foreignKey:DocumentTypeID" json:"document_type,omitempty"`
+}
+
+func (d *Document) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == "" {
+		d.ID = utils.GenerateUUID()
+	}
+	return
+}
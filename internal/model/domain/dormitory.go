@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status penempatan kamar: ACTIVE (sedang menempati), MOVED (pindah kamar), ENDED (keluar asrama / akhir tahun)
+const (
+	DormAssignmentActive = "ACTIVE"
+	DormAssignmentMoved  = "MOVED"
+	DormAssignmentEnded  = "ENDED"
+)
+
+// Dormitory adalah gedung asrama; gender menentukan santri yang boleh ditempatkan
+type Dormitory struct {
+	ID          string    `gorm:"type:char(36);primaryKey" json:"id"`
+	Name        string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Gender      string    `gorm:"type:enum('male','female');not null" json:"gender"`
+	Description *string   `gorm:"type:text" json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Rooms []DormRoom `gorm:"foreignKey:DormitoryID" json:"rooms,omitempty"`
+}
+
+func (d *Dormitory) BeforeCreate(tx *gorm.DB) (err error) {
+	if d.ID == "" {
+		d.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// DormRoom adalah kamar asrama dengan kapasitas dan musyrif (pembina) yang bertanggung jawab
+type DormRoom struct {
+	ID          string    `gorm:"type:char(36);primaryKey" json:"id"`
+	DormitoryID string    `gorm:"type:char(36);not null;uniqueIndex:uq_dorm_rooms_dormitory_name,priority:1" json:"dormitory_id"`
+	Name        string    `gorm:"type:varchar(50);not null;uniqueIndex:uq_dorm_rooms_dormitory_name,priority:2" json:"name"`
+	Floor       *int      `json:"floor"`
+	Capacity    int       `gorm:"not null" json:"capacity"`
+	MusyrifID   *string   `gorm:"type:char(36);index" json:"musyrif_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	Occupied int64 `gorm:"->" json:"-"` // Hasil subquery jumlah penghuni aktif
+
+	// Relationships
+	Dormitory *Dormitory `gorm:"foreignKey:DormitoryID" json:"dormitory,omitempty"`
+	Musyrif   *Employee  `gorm:"foreignKey:MusyrifID" json:"musyrif,omitempty"`
+}
+
+func (r *DormRoom) BeforeCreate(tx *gorm.DB) (err error) {
+	if r.ID == "" {
+		r.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// DormAssignment adalah penempatan santri di satu kamar untuk satu tahun ajaran
+type DormAssignment struct {
+	ID             string      `gorm:"type:char(36);primaryKey" json:"id"`
+	DormRoomID     string      `gorm:"type:char(36);not null;index:idx_dorm_assignments_room_status,priority:1" json:"dorm_room_id"`
+	StudentID      string      `gorm:"type:char(36);not null;index:idx_dorm_assignments_student_year,priority:1" json:"student_id"`
+	AcademicYearID string      `gorm:"type:char(36);not null;index:idx_dorm_assignments_student_year,priority:2" json:"academic_year_id"`
+	Status         string      `gorm:"type:enum('ACTIVE','MOVED','ENDED');not null;default:'ACTIVE';index:idx_dorm_assignments_room_status,priority:2;index:idx_dorm_assignments_student_year,priority:3" json:"status"`
+	StartDate      utils.Date  `gorm:"type:date;not null" json:"start_date"`
+	EndDate        *utils.Date `gorm:"type:date" json:"end_date"`
+	Notes          *string     `gorm:"type:text" json:"notes"`
+	CreatedBy      *string     `gorm:"type:char(36)" json:"created_by"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+
+	// Relationships
+	DormRoom     *DormRoom     `gorm:"foreignKey:DormRoomID" json:"dorm_room,omitempty"`
+	Student      *Student      `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	AcademicYear *AcademicYear `gorm:"foreignKey:AcademicYearID" json:"academic_year,omitempty"`
+}
+
+func (a *DormAssignment) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = utils.GenerateUUID()
+	}
+	return
+}
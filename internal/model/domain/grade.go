@@ -16,6 +16,7 @@ type Assessment struct {
 	MaxScore             int        `gorm:"type:int;default:100" json:"max_score"`
 	Date                 utils.Date `gorm:"type:date;not null" json:"date"`
 	Description          string     `gorm:"type:text" json:"description"`
+	PublishedAt          *time.Time `json:"published_at"` // Nil = nilai belum tampil di portal orang tua
 	CreatedAt            time.Time  `json:"created_at"`
 	UpdatedAt            time.Time  `json:"updated_at"`
 
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tindak lanjut kunjungan UKS
+const (
+	ClinicOutcomeReturnedToClass = "returned_to_class"
+	ClinicOutcomeRested          = "rested"
+	ClinicOutcomeSentHome        = "sent_home"
+	ClinicOutcomeReferred        = "referred"
+)
+
+// StudentHealthProfile adalah data kesehatan siswa. Semua kolom medis disimpan terenkripsi.
+type StudentHealthProfile struct {
+	ID                 string    `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID          string    `gorm:"type:char(36);not null;uniqueIndex" json:"student_id"`
+	BloodType          *string   `gorm:"type:text" json:"blood_type"`          // Akan dienkripsi
+	Allergies          *string   `gorm:"type:text" json:"allergies"`           // Akan dienkripsi
+	ChronicConditions  *string   `gorm:"type:text" json:"chronic_conditions"`  // Akan dienkripsi
+	RegularMedications *string   `gorm:"type:text" json:"regular_medications"` // Akan dienkripsi
+	Notes              *string   `gorm:"type:text" json:"notes"`               // Akan dienkripsi
+	UpdatedBy          *string   `gorm:"type:char(36)" json:"updated_by"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+func (p *StudentHealthProfile) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == "" {
+		p.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// ClinicVisit adalah satu kunjungan siswa ke UKS
+type ClinicVisit struct {
+	ID              string    `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID       string    `gorm:"type:char(36);not null;index:idx_clinic_visits_student_visited,priority:1" json:"student_id"`
+	VisitedAt       time.Time `gorm:"not null;index:idx_clinic_visits_student_visited,priority:2;index" json:"visited_at"`
+	Complaint       string    `gorm:"type:text;not null" json:"complaint"` // Akan dienkripsi
+	Treatment       *string   `gorm:"type:text" json:"treatment"`          // Akan dienkripsi
+	MedicationGiven *string   `gorm:"type:text" json:"medication_given"`   // Akan dienkripsi
+	Outcome         string    `gorm:"type:enum('returned_to_class','rested','sent_home','referred');not null;default:'returned_to_class'" json:"outcome"`
+	ReferredTo      *string   `gorm:"type:varchar(150)" json:"referred_to"` // Puskesmas / rumah sakit rujukan
+	// MarkAttendanceSick adalah pilihan petugas saat memulangkan siswa; hanya kunjungan dengan flag ini
+	// yang mengisi SICK pada sesi absensi (termasuk sesi yang baru dibuat setelah kunjungan)
+	MarkAttendanceSick bool      `gorm:"not null;default:false" json:"mark_attendance_sick"`
+	AttendanceMarked   int       `gorm:"not null;default:0" json:"attendance_marked"`
+	HandledBy          *string   `gorm:"type:char(36)" json:"handled_by"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+
+	// Relationships
+	Student *Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+func (v *ClinicVisit) BeforeCreate(tx *gorm.DB) (err error) {
+	if v.ID == "" {
+		v.ID = utils.GenerateUUID()
+	}
+	return
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Household adalah satu keluarga berdasarkan Kartu Keluarga. Siswa dengan No KK yang sama
+// otomatis masuk household yang sama (lewat blind index NoKKHash), sehingga saudara kandung bisa ditemukan.
+type Household struct {
+	ID        string    `gorm:"type:char(36);primaryKey" json:"id"`
+	NoKKHash  string    `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"`
+	NoKK      string    `gorm:"type:text" json:"no_kk,omitempty"` // akan dienkripsi
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Students []Student `gorm:"foreignKey:HouseholdID" json:"students,omitempty"`
+}
+
+func (h *Household) BeforeCreate(tx *gorm.DB) (err error) {
+	if h.ID == "" {
+		h.ID = utils.GenerateUUID()
+	}
+	return
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status perizinan pulang santri
+const (
+	LeavePermitPending    = "PENDING"
+	LeavePermitApproved   = "APPROVED"
+	LeavePermitRejected   = "REJECTED"
+	LeavePermitCancelled  = "CANCELLED"
+	LeavePermitCheckedOut = "CHECKED_OUT"
+	LeavePermitReturned   = "RETURNED"
+)
+
+// Pengaju izin: petugas sekolah atau akun orang tua/wali yang tertaut ke santri
+const (
+	LeaveRequesterStaff  = "staff"
+	LeaveRequesterParent = "parent"
+)
+
+// LeavePermit adalah izin pulang/keluar asrama satu santri
+type LeavePermit struct {
+	ID                       string     `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID                string     `gorm:"type:char(36);not null;index:idx_leave_permits_student_departure,priority:1" json:"student_id"`
+	AcademicYearID           string     `gorm:"type:char(36);not null" json:"academic_year_id"`
+	Reason                   string     `gorm:"type:text;not null" json:"reason"`
+	Destination              *string    `gorm:"type:varchar(255)" json:"destination"`
+	DepartureAt              time.Time  `gorm:"not null;index:idx_leave_permits_student_departure,priority:2" json:"departure_at"`
+	ExpectedReturnAt         time.Time  `gorm:"not null;index:idx_leave_permits_status_return,priority:2" json:"expected_return_at"`
+	Status                   string     `gorm:"type:enum('PENDING','APPROVED','REJECTED','CANCELLED','CHECKED_OUT','RETURNED');not null;default:'PENDING';index:idx_leave_permits_status_return,priority:1" json:"status"`
+	RequesterType            string     `gorm:"type:enum('staff','parent');not null" json:"requester_type"`
+	RequestedBy              *string    `gorm:"type:char(36)" json:"requested_by"`
+	GuardianConfirmedAt      *time.Time `json:"guardian_confirmed_at"`
+	GuardianConfirmedBy      *string    `gorm:"type:char(36)" json:"guardian_confirmed_by"`
+	GuardianConfirmationNote *string    `gorm:"type:varchar(255)" json:"guardian_confirmation_note"` // Mis. "dikonfirmasi via telepon"
+	ReviewedBy               *string    `gorm:"type:char(36)" json:"reviewed_by"`
+	ReviewedAt               *time.Time `json:"reviewed_at"`
+	ReviewNotes              *string    `gorm:"type:text" json:"review_notes"`
+	CheckedOutAt             *time.Time `json:"checked_out_at"`
+	CheckedOutBy             *string    `gorm:"type:char(36)" json:"checked_out_by"`
+	PickedUpBy               *string    `gorm:"type:varchar(100)" json:"picked_up_by"` // Nama penjemput di gerbang
+	CheckedInAt              *time.Time `json:"checked_in_at"`
+	CheckedInBy              *string    `gorm:"type:char(36)" json:"checked_in_by"`
+	CreatedAt                time.Time  `json:"created_at"`
+	UpdatedAt                time.Time  `json:"updated_at"`
+
+	// Relationships
+	Student *Student `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+}
+
+func (p *LeavePermit) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == "" {
+		p.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// IsOverdue bernilai true jika santri sudah keluar dan melewati batas waktu kembali
+func (p *LeavePermit) IsOverdue(now time.Time) bool {
+	return p.Status == LeavePermitCheckedOut && now.After(p.ExpectedReturnAt)
+}
+
+// LateMinutes menghitung keterlambatan kembali (0 jika tepat waktu atau belum jatuh tempo)
+func (p *LeavePermit) LateMinutes(now time.Time) int {
+	returnedAt := now
+	switch p.Status {
+	case LeavePermitReturned:
+		if p.CheckedInAt == nil {
+			return 0
+		}
+		returnedAt = *p.CheckedInAt
+	case LeavePermitCheckedOut:
+	default:
+		return 0
+	}
+	if !returnedAt.After(p.ExpectedReturnAt) {
+		return 0
+	}
+	return int(returnedAt.Sub(p.ExpectedReturnAt).Minutes())
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Sumber data kehadiran pegawai
+const (
+	StaffAttendanceSourceSelf   = "self"   // Clock-in/out dari akun pegawai
+	StaffAttendanceSourceManual = "manual" // Diinput/dikoreksi admin
+	StaffAttendanceSourceDevice = "device" // Import log mesin fingerprint
+)
+
+// Jenis cuti/izin pegawai
+const (
+	StaffLeaveSick       = "SICK"
+	StaffLeaveAnnual     = "ANNUAL"
+	StaffLeavePermission = "PERMISSION"
+	StaffLeaveDuty       = "DUTY" // Dinas luar
+	StaffLeaveMaternity  = "MATERNITY"
+)
+
+var StaffLeaveTypes = []string{StaffLeaveSick, StaffLeaveAnnual, StaffLeavePermission, StaffLeaveDuty, StaffLeaveMaternity}
+
+// WorkShift adalah jam kerja pegawai. Pegawai tanpa shift memakai shift default (IsDefault).
+type WorkShift struct {
+	ID                         string    `gorm:"type:char(36);primaryKey" json:"id"`
+	Name                       string    `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	StartTime                  string    `gorm:"type:varchar(5);not null" json:"start_time"` // HH:MM
+	EndTime                    string    `gorm:"type:varchar(5);not null" json:"end_time"`
+	LateToleranceMinutes       int       `gorm:"not null;default:0" json:"late_tolerance_minutes"`
+	EarlyLeaveToleranceMinutes int       `gorm:"not null;default:0" json:"early_leave_tolerance_minutes"`
+	WorkDays                   string    `gorm:"type:varchar(20);not null;default:'1,2,3,4,5,6'" json:"work_days"` // ISO: 1=Senin ... 7=Minggu
+	IsDefault                  bool      `gorm:"not null;default:false" json:"is_default"`
+	CreatedAt                  time.Time `json:"created_at"`
+	UpdatedAt                  time.Time `json:"updated_at"`
+}
+
+func (s *WorkShift) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == "" {
+		s.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// IsWorkDay memeriksa apakah tanggal tsb termasuk hari kerja shift
+func (s *WorkShift) IsWorkDay(date time.Time) bool {
+	weekday := int(date.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	for _, day := range strings.Split(s.WorkDays, ",") {
+		if n, err := strconv.Atoi(strings.TrimSpace(day)); err == nil && n == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// LateMinutes menghitung keterlambatan dari jam masuk shift; 0 jika masih dalam toleransi
+func (s *WorkShift) LateMinutes(clockIn time.Time) int {
+	start := clockOn(clockIn, s.StartTime)
+	late := int(clockIn.Sub(start).Minutes())
+	if late <= s.LateToleranceMinutes {
+		return 0
+	}
+	return late
+}
+
+// EarlyLeaveMinutes menghitung pulang cepat dari jam pulang shift; 0 jika masih dalam toleransi
+func (s *WorkShift) EarlyLeaveMinutes(clockOut time.Time) int {
+	end := clockOn(clockOut, s.EndTime)
+	early := int(end.Sub(clockOut).Minutes())
+	if early <= s.EarlyLeaveToleranceMinutes {
+		return 0
+	}
+	return early
+}
+
+// clockOn menggabungkan tanggal dari t dengan jam HH:MM
+func clockOn(t time.Time, clock string) time.Time {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), parsed.Hour(), parsed.Minute(), 0, 0, t.Location())
+}
+
+// StaffAttendance adalah kehadiran harian satu pegawai. Jam shift disalin saat dihitung agar
+// perubahan shift tidak mengubah rekap bulan lalu.
+type StaffAttendance struct {
+	ID                string     `gorm:"type:char(36);primaryKey" json:"id"`
+	EmployeeID        string     `gorm:"type:char(36);not null;uniqueIndex:idx_staff_attendance_day,priority:1" json:"employee_id"`
+	Date              utils.Date `gorm:"type:date;not null;uniqueIndex:idx_staff_attendance_day,priority:2;index" json:"date"`
+	ClockInAt         *time.Time `json:"clock_in_at"`
+	ClockOutAt        *time.Time `json:"clock_out_at"`
+	WorkShiftID       *string    `gorm:"type:char(36)" json:"work_shift_id"`
+	ShiftStart        *string    `gorm:"type:varchar(5)" json:"shift_start"`
+	ShiftEnd          *string    `gorm:"type:varchar(5)" json:"shift_end"`
+	LateMinutes       int        `gorm:"not null;default:0" json:"late_minutes"`
+	EarlyLeaveMinutes int        `gorm:"not null;default:0" json:"early_leave_minutes"`
+	Source            string     `gorm:"type:varchar(10);not null" json:"source"` // self, manual, device
+	Notes             *string    `gorm:"type:varchar(255)" json:"notes"`
+	RecordedBy        *string    `gorm:"type:char(36)" json:"recorded_by"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+
+	// Relationships
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+func (a *StaffAttendance) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// Evaluate menyalin jam shift dan menghitung ulang terlambat/pulang cepat
+func (a *StaffAttendance) Evaluate(shift *WorkShift) {
+	a.LateMinutes, a.EarlyLeaveMinutes = 0, 0
+	if shift == nil {
+		a.WorkShiftID, a.ShiftStart, a.ShiftEnd = nil, nil, nil
+		return
+	}
+	a.WorkShiftID, a.ShiftStart, a.ShiftEnd = &shift.ID, &shift.StartTime, &shift.EndTime
+	if a.ClockInAt != nil {
+		a.LateMinutes = shift.LateMinutes(*a.ClockInAt)
+	}
+	if a.ClockOutAt != nil {
+		a.EarlyLeaveMinutes = shift.EarlyLeaveMinutes(*a.ClockOutAt)
+	}
+}
+
+// StaffLeave adalah cuti/izin pegawai yang sudah disetujui untuk rentang tanggal
+type StaffLeave struct {
+	ID         string     `gorm:"type:char(36);primaryKey" json:"id"`
+	EmployeeID string     `gorm:"type:char(36);not null;index:idx_staff_leaves_employee_dates,priority:1" json:"employee_id"`
+	LeaveType  string     `gorm:"type:enum('SICK','ANNUAL','PERMISSION','DUTY','MATERNITY');not null" json:"leave_type"`
+	StartDate  utils.Date `gorm:"type:date;not null;index:idx_staff_leaves_employee_dates,priority:2" json:"start_date"`
+	EndDate    utils.Date `gorm:"type:date;not null" json:"end_date"`
+	Reason     *string    `gorm:"type:text" json:"reason"`
+	RecordedBy *string    `gorm:"type:char(36)" json:"recorded_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Relationships
+	Employee *Employee `gorm:"foreignKey:EmployeeID" json:"employee,omitempty"`
+}
+
+func (l *StaffLeave) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == "" {
+		l.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// Covers memeriksa apakah tanggal (YYYY-MM-DD) berada dalam rentang cuti
+func (l *StaffLeave) Covers(date string) bool {
+	start := time.Time(l.StartDate).Format(utils.DateLayout)
+	end := time.Time(l.EndDate).Format(utils.DateLayout)
+	return date >= start && date <= end
+}
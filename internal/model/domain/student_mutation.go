@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Status siswa
+const (
+	StudentStatusActive      = "ACTIVE"
+	StudentStatusGraduated   = "GRADUATED"
+	StudentStatusDropout     = "DROPOUT"
+	StudentStatusTransferred = "TRANSFERRED"
+)
+
+// Jenis mutasi siswa
+const (
+	MutationTransferIn  = "TRANSFER_IN"
+	MutationTransferOut = "TRANSFER_OUT"
+	MutationDropout     = "DROPOUT"
+	MutationGraduate    = "GRADUATE"
+	MutationReadmission = "READMISSION"
+)
+
+// StudentMutation adalah satu perubahan status siswa (laporan mutasi).
+// Status siswa hanya boleh berubah lewat mutasi agar riwayatnya tercatat.
+type StudentMutation struct {
+	ID             string     `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID      string     `gorm:"type:char(36);not null;index" json:"student_id"`
+	Type           string     `gorm:"type:enum('TRANSFER_IN','TRANSFER_OUT','DROPOUT','GRADUATE','READMISSION');not null" json:"type"`
+	EffectiveDate  utils.Date `gorm:"type:date;not null;index" json:"effective_date"`
+	Reason         string     `gorm:"type:text;not null" json:"reason"`
+	SchoolName     *string    `gorm:"type:varchar(150)" json:"school_name"`
+	PreviousStatus string     `gorm:"type:varchar(20);not null" json:"previous_status"`
+	NewStatus      string     `gorm:"type:varchar(20);not null" json:"new_status"`
+	ClassroomID    *string    `gorm:"type:char(36)" json:"classroom_id"`
+	CreatedBy      *string    `gorm:"type:char(36)" json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Relationships
+	Student   *Student   `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Classroom *Classroom `gorm:"foreignKey:ClassroomID" json:"classroom,omitempty"`
+	Documents []Document `gorm:"many2many:student_mutation_documents;joinForeignKey:StudentMutationID;joinReferences:DocumentID" json:"documents,omitempty"`
+}
+
+func (m *StudentMutation) BeforeCreate(tx *gorm.DB) (err error) {
+	if m.ID == "" {
+		m.ID = utils.GenerateUUID()
+	}
+	return
+}
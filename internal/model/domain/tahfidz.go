@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Jenis sesi tahfidz: setoran (hafalan baru) dan murajaah (mengulang hafalan)
+const (
+	TahfidzTypeSetoran  = "setoran"
+	TahfidzTypeMurajaah = "murajaah"
+)
+
+// Predikat kualitas bacaan; rasib berarti belum lulus dan harus mengulang
+const (
+	TahfidzGradeMumtaz       = "mumtaz"
+	TahfidzGradeJayyidJiddan = "jayyid_jiddan"
+	TahfidzGradeJayyid       = "jayyid"
+	TahfidzGradeMaqbul       = "maqbul"
+	TahfidzGradeRasib        = "rasib"
+)
+
+// TahfidzSession adalah satu setoran/murajaah yang diuji oleh seorang ustadz (employee)
+type TahfidzSession struct {
+	ID             string     `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID      string     `gorm:"type:char(36);not null;index:idx_tahfidz_sessions_student_date,priority:1" json:"student_id"`
+	AcademicYearID string     `gorm:"type:char(36);not null;index" json:"academic_year_id"`
+	SessionDate    utils.Date `gorm:"type:date;not null;index:idx_tahfidz_sessions_student_date,priority:2" json:"session_date"`
+	Type           string     `gorm:"type:enum('setoran','murajaah');not null" json:"type"`
+	StartSurah     int        `gorm:"type:tinyint unsigned;not null" json:"start_surah"`
+	StartAyah      int        `gorm:"type:smallint unsigned;not null" json:"start_ayah"`
+	EndSurah       int        `gorm:"type:tinyint unsigned;not null" json:"end_surah"`
+	EndAyah        int        `gorm:"type:smallint unsigned;not null" json:"end_ayah"`
+	AyahFrom       int        `gorm:"type:smallint unsigned;not null" json:"-"` // Indeks ayat global
+	AyahTo         int        `gorm:"type:smallint unsigned;not null" json:"-"`
+	AyahCount      int        `gorm:"type:smallint unsigned;not null" json:"ayah_count"`
+	JuzStart       int        `gorm:"type:tinyint unsigned;not null" json:"juz_start"`
+	JuzEnd         int        `gorm:"type:tinyint unsigned;not null" json:"juz_end"`
+	Grade          string     `gorm:"type:enum('mumtaz','jayyid_jiddan','jayyid','maqbul','rasib');not null" json:"grade"`
+	ExaminerID     *string    `gorm:"type:char(36);index" json:"examiner_id"`
+	Notes          *string    `gorm:"type:text" json:"notes"`
+	CreatedBy      *string    `gorm:"type:char(36)" json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Relationships
+	Student  *Student  `gorm:"foreignKey:StudentID" json:"student,omitempty"`
+	Examiner *Employee `gorm:"foreignKey:ExaminerID" json:"examiner,omitempty"`
+}
+
+func (t *TahfidzSession) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == "" {
+		t.ID = utils.GenerateUUID()
+	}
+	return
+}
+
+// Passed mengembalikan true jika setoran diterima (bukan rasib) sehingga dihitung sebagai hafalan
+func (t *TahfidzSession) Passed() bool {
+	return t.Grade != TahfidzGradeRasib
+}
+
+// TahfidzTarget adalah target hafalan santri (dalam juz) untuk satu tahun ajaran
+type TahfidzTarget struct {
+	ID             string    `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID      string    `gorm:"type:char(36);not null;uniqueIndex:uq_tahfidz_targets_student_year,priority:1" json:"student_id"`
+	AcademicYearID string    `gorm:"type:char(36);not null;uniqueIndex:uq_tahfidz_targets_student_year,priority:2" json:"academic_year_id"`
+	TargetJuz      float64   `gorm:"type:decimal(4,2);not null" json:"target_juz"`
+	Notes          *string   `gorm:"type:text" json:"notes"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (t *TahfidzTarget) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == "" {
+		t.ID = utils.GenerateUUID()
+	}
+	return
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 type ViolationCategory struct {
@@ -26,15 +28,16 @@ type ViolationType struct {
 }
 
 type StudentViolation struct {
-	ID              string    `gorm:"type:char(36);primaryKey" json:"id"`
-	StudentID       string    `gorm:"type:char(36);not null;index" json:"student_id"`
-	ViolationTypeID string    `gorm:"type:char(36);not null;index" json:"violation_type_id"`
-	ViolationDate   time.Time `gorm:"type:datetime;not null" json:"violation_date"`
-	Points          int       `gorm:"not null" json:"points"`
-	ActionTaken     string    `gorm:"type:text" json:"action_taken"`
-	Notes           string    `gorm:"type:text" json:"notes"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              string         `gorm:"type:char(36);primaryKey" json:"id"`
+	StudentID       string         `gorm:"type:char(36);not null;index" json:"student_id"`
+	ViolationTypeID string         `gorm:"type:char(36);not null;index" json:"violation_type_id"`
+	ViolationDate   time.Time      `gorm:"type:datetime;not null" json:"violation_date"`
+	Points          int            `gorm:"not null" json:"points"`
+	ActionTaken     string         `gorm:"type:text" json:"action_taken"`
+	Notes           string         `gorm:"type:text" json:"notes"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	Student       *Student       `gorm:"foreignKey:StudentID" json:"student,omitempty"`
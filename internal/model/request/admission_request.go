@@ -0,0 +1,80 @@
+package request
+
+import "smart_school_be/internal/utils"
+
+// DTO untuk membuat / mengubah gelombang PPDB
+type AdmissionWaveRequest struct {
+	AcademicYearID string     `json:"academic_year_id" binding:"required"`
+	Name           string     `json:"name" binding:"required"`
+	StartDate      utils.Date `json:"start_date" binding:"required"`
+	EndDate        utils.Date `json:"end_date" binding:"required"`
+	Quota          int        `json:"quota" binding:"min=0"` // 0 = tanpa batas
+	IsOpen         *bool      `json:"is_open"`               // Default: true
+	Description    *string    `json:"description"`
+}
+
+// DTO orang tua pada formulir pendaftaran (field mengikuti ParentCreateRequest)
+type ApplicantParentRequest struct {
+	FullName       string      `json:"full_name" binding:"required"`
+	NIK            *string     `json:"nik"`
+	PlaceOfBirth   *string     `json:"place_of_birth"`
+	DateOfBirth    *utils.Date `json:"date_of_birth"`
+	LifeStatus     *string     `json:"life_status" binding:"omitempty,oneof=alive deceased"`
+	PhoneNumber    *string     `json:"phone_number"`
+	Email          *string     `json:"email" binding:"omitempty,email"`
+	EducationLevel *string     `json:"education_level"`
+	Occupation     *string     `json:"occupation"`
+	IncomeRange    *string     `json:"income_range"`
+}
+
+// DTO formulir pendaftaran publik. Untuk upload dokumen, kirim sebagai multipart dengan
+// JSON ini di field "data" dan file di field bernama kode jenis dokumen (mis. "birth_certificate").
+type ApplicantCreateRequest struct {
+	AdmissionWaveID string                  `json:"admission_wave_id" binding:"required"`
+	FullName        string                  `json:"full_name" binding:"required"`
+	NoKK            string                  `json:"no_kk"`
+	NIK             string                  `json:"nik"`
+	NISN            string                  `json:"nisn"`
+	Gender          string                  `json:"gender" binding:"required,oneof=male female"`
+	PlaceOfBirth    string                  `json:"place_of_birth"`
+	DateOfBirth     utils.Date              `json:"date_of_birth" binding:"required"`
+	Address         string                  `json:"address"`
+	RT              string                  `json:"rt"`
+	RW              string                  `json:"rw"`
+	SubDistrict     string                  `json:"sub_district"`
+	District        string                  `json:"district"`
+	City            string                  `json:"city"`
+	Province        string                  `json:"province"`
+	PostalCode      string                  `json:"postal_code"`
+	PreviousSchool  string                  `json:"previous_school"`
+	PhoneNumber     string                  `json:"phone_number" binding:"required"`
+	Email           string                  `json:"email" binding:"omitempty,email"`
+	Father          *ApplicantParentRequest `json:"father"`
+	Mother          *ApplicantParentRequest `json:"mother"`
+}
+
+// DTO cek status pendaftaran publik (tanggal lahir dipakai sebagai verifikasi sederhana)
+type ApplicantStatusCheckRequest struct {
+	RegistrationNumber string `form:"registration_number" binding:"required"`
+	DateOfBirth        string `form:"date_of_birth" binding:"required"` // YYYY-MM-DD
+}
+
+// Filter daftar pendaftar untuk panitia
+type ApplicantFilterRequest struct {
+	AdmissionWaveID string `form:"admission_wave_id"`
+	Status          string `form:"status" binding:"omitempty,oneof=submitted verified tested accepted rejected"`
+	Search          string `form:"q"`
+}
+
+// DTO perpindahan status pendaftar
+type ApplicantStatusRequest struct {
+	Status    string   `json:"status" binding:"required,oneof=verified tested accepted rejected"`
+	TestScore *float64 `json:"test_score" binding:"omitempty,min=0,max=100"` // Wajib saat status tested
+	Notes     string   `json:"notes"`                                        // Wajib saat status rejected
+}
+
+// DTO konversi pendaftar yang diterima menjadi siswa
+type ApplicantConvertRequest struct {
+	ClassroomID string `json:"classroom_id"` // Opsional, harus kelas di tahun ajaran gelombang
+	NIM         string `json:"nim"`
+}
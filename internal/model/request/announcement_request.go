@@ -0,0 +1,12 @@
+package request
+
+import "time"
+
+// DTO pengumuman. ClassroomID kosong = untuk seluruh sekolah, PublishedAt kosong = tayang sekarang.
+type AnnouncementRequest struct {
+	Title       string     `json:"title" binding:"required,max=200"`
+	Body        string     `json:"body" binding:"required"`
+	ClassroomID *string    `json:"classroom_id"`
+	PublishedAt *time.Time `json:"published_at"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
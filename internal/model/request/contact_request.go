@@ -0,0 +1,16 @@
+package request
+
+// DTO untuk mengatur preferensi komunikasi orang tua/wali
+type ContactPreferenceRequest struct {
+	PreferredChannel string   `json:"preferred_channel" binding:"required,oneof=WHATSAPP SMS EMAIL"`
+	Language         string   `json:"language" binding:"omitempty,oneof=id en ar"` // Default: id
+	QuietHoursStart  *string  `json:"quiet_hours_start"`                           // HH:MM, harus diisi bersama quiet_hours_end
+	QuietHoursEnd    *string  `json:"quiet_hours_end"`
+	OptOutCategories []string `json:"opt_out_categories"` // ANNOUNCEMENT, ATTENDANCE, ACADEMIC, FINANCE, DISCIPLINE, HEALTH
+}
+
+// DTO untuk menetapkan kontak utama siswa
+type StudentPrimaryContactRequest struct {
+	ContactType string `json:"contact_type" binding:"required,oneof=parent guardian"`
+	ContactID   string `json:"contact_id" binding:"required"`
+}
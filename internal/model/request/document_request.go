@@ -0,0 +1,33 @@
+package request
+
+// DTO untuk membuat jenis dokumen
+type DocumentTypeCreateRequest struct {
+	Code             string   `json:"code" binding:"required,max=50"`
+	Name             string   `json:"name" binding:"required,max=100"`
+	OwnerType        string   `json:"owner_type" binding:"required,oneof=student employee"`
+	IsRequired       bool     `json:"is_required"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`                    // Default: pdf, jpeg, png
+	MaxSizeKB        int      `json:"max_size_kb" binding:"omitempty,min=1"` // Default: 2048
+	Description      *string  `json:"description"`
+}
+
+// DTO untuk update jenis dokumen (kode dan pemilik tidak bisa diubah)
+type DocumentTypeUpdateRequest struct {
+	Name             *string  `json:"name" binding:"omitempty,max=100"`
+	IsRequired       *bool    `json:"is_required"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+	MaxSizeKB        *int     `json:"max_size_kb" binding:"omitempty,min=1"`
+	Description      *string  `json:"description"`
+}
+
+// DTO upload dokumen (multipart, file dikirim di field "file")
+type DocumentUploadRequest struct {
+	DocumentTypeID string `form:"document_type_id" binding:"required"`
+	Notes          string `form:"notes"`
+}
+
+// DTO verifikasi dokumen
+type DocumentVerifyRequest struct {
+	Status string `json:"status" binding:"required,oneof=verified rejected"`
+	Notes  string `json:"notes"` // Wajib jika ditolak
+}
@@ -0,0 +1,36 @@
+package request
+
+import "smart_school_be/internal/utils"
+
+type DormitoryRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Gender      string  `json:"gender" binding:"required,oneof=male female"`
+	Description *string `json:"description"`
+}
+
+type DormRoomRequest struct {
+	Name      string  `json:"name" binding:"required"`
+	Floor     *int    `json:"floor"`
+	Capacity  int     `json:"capacity" binding:"required,min=1"`
+	MusyrifID *string `json:"musyrif_id"` // ID employee; kosongkan untuk melepas musyrif
+}
+
+// DTO penempatan santri ke kamar. AcademicYearID default: tahun ajaran aktif.
+type DormAssignRequest struct {
+	StudentIDs     []string    `json:"student_ids" binding:"required,min=1"`
+	AcademicYearID string      `json:"academic_year_id"`
+	StartDate      *utils.Date `json:"start_date"` // Default: hari ini
+	Notes          *string     `json:"notes"`
+}
+
+// DTO pindah kamar di tahun ajaran yang sama
+type DormMoveRequest struct {
+	StudentID      string  `json:"student_id" binding:"required"`
+	ToRoomID       string  `json:"to_room_id" binding:"required"`
+	AcademicYearID string  `json:"academic_year_id"`
+	Notes          *string `json:"notes"`
+}
+
+type DormCheckoutRequest struct {
+	Notes *string `json:"notes"`
+}
@@ -0,0 +1,32 @@
+package request
+
+import "time"
+
+// DTO profil kesehatan siswa. Field kosong/null akan menghapus isinya.
+type HealthProfileRequest struct {
+	BloodType          *string `json:"blood_type" binding:"omitempty,oneof=A A+ A- B B+ B- AB AB+ AB- O O+ O-"`
+	Allergies          *string `json:"allergies"`
+	ChronicConditions  *string `json:"chronic_conditions"`
+	RegularMedications *string `json:"regular_medications"`
+	Notes              *string `json:"notes"`
+}
+
+// DTO pencatatan kunjungan UKS
+type ClinicVisitCreateRequest struct {
+	StudentID       string     `json:"student_id" binding:"required"`
+	VisitedAt       *time.Time `json:"visited_at"` // Default: sekarang
+	Complaint       string     `json:"complaint" binding:"required"`
+	Treatment       *string    `json:"treatment"`
+	MedicationGiven *string    `json:"medication_given"`
+	Outcome         string     `json:"outcome" binding:"required,oneof=returned_to_class rested sent_home referred"`
+	ReferredTo      *string    `json:"referred_to"` // Wajib jika outcome = referred
+	// Hanya untuk outcome sent_home: tandai absensi sisa hari itu sebagai SICK
+	MarkAttendanceSick bool `json:"mark_attendance_sick"`
+}
+
+type ClinicVisitFilterRequest struct {
+	StudentID string `form:"student_id"`
+	Outcome   string `form:"outcome" binding:"omitempty,oneof=returned_to_class rested sent_home referred"`
+	DateFrom  string `form:"date_from"` // YYYY-MM-DD
+	DateTo    string `form:"date_to"`   // YYYY-MM-DD
+}
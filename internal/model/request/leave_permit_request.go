@@ -0,0 +1,35 @@
+package request
+
+import "time"
+
+// DTO pengajuan izin pulang santri (oleh petugas atau akun orang tua/wali)
+type LeavePermitCreateRequest struct {
+	StudentID        string    `json:"student_id" binding:"required"`
+	Reason           string    `json:"reason" binding:"required"`
+	Destination      *string   `json:"destination"`
+	DepartureAt      time.Time `json:"departure_at" binding:"required"`
+	ExpectedReturnAt time.Time `json:"expected_return_at" binding:"required"`
+}
+
+type LeavePermitFilterRequest struct {
+	StudentID string `form:"student_id"`
+	Status    string `form:"status" binding:"omitempty,oneof=PENDING APPROVED REJECTED CANCELLED CHECKED_OUT RETURNED"`
+	Date      string `form:"date"` // YYYY-MM-DD, izin yang mencakup tanggal ini
+}
+
+// DTO konfirmasi wali. Note diisi petugas jika konfirmasi diterima di luar aplikasi (mis. telepon).
+type LeaveGuardianConfirmRequest struct {
+	Note *string `json:"note"`
+}
+
+type LeavePermitApproveRequest struct {
+	Notes *string `json:"notes"`
+}
+
+type LeavePermitRejectRequest struct {
+	Notes string `json:"notes" binding:"required"`
+}
+
+type LeavePermitCheckOutRequest struct {
+	PickedUpBy *string `json:"picked_up_by"`
+}
@@ -0,0 +1,6 @@
+package request
+
+type PortalAttendanceFilterRequest struct {
+	DateFrom string `form:"date_from"` // YYYY-MM-DD
+	DateTo   string `form:"date_to"`   // YYYY-MM-DD
+}
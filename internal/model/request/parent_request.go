@@ -49,3 +49,9 @@ type ParentUpdateRequest struct {
 	Province       *string     `json:"province"`
 	PostalCode     *string     `json:"postal_code"`
 }
+
+// DTO untuk import orang tua/wali dari Excel (multipart form)
+type FamilyImportRequest struct {
+	Mode              string `form:"mode" binding:"omitempty,oneof=dry_run commit"` // default: dry_run
+	OverwriteGuardian bool   `form:"overwrite_guardian"`                            // Ganti wali siswa yang sudah terisi
+}
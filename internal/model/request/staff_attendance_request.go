@@ -0,0 +1,49 @@
+package request
+
+// DTO shift kerja. Jam dalam format HH:MM, WorkDays berisi hari ISO dipisah koma (1=Senin ... 7=Minggu).
+type WorkShiftRequest struct {
+	Name                       string `json:"name" binding:"required,max=100"`
+	StartTime                  string `json:"start_time" binding:"required"`
+	EndTime                    string `json:"end_time" binding:"required"`
+	LateToleranceMinutes       int    `json:"late_tolerance_minutes" binding:"min=0"`
+	EarlyLeaveToleranceMinutes int    `json:"early_leave_tolerance_minutes" binding:"min=0"`
+	WorkDays                   string `json:"work_days" binding:"required"`
+	IsDefault                  bool   `json:"is_default"`
+}
+
+// DTO pemetaan pegawai ke ID user mesin fingerprint & shift kerja. Nilai kosong/null melepas pemetaan.
+type EmployeeAttendanceSettingsRequest struct {
+	DeviceUserID *string `json:"device_user_id"`
+	WorkShiftID  *string `json:"work_shift_id"`
+}
+
+// DTO input/koreksi kehadiran oleh admin. Jam dalam format HH:MM pada tanggal tsb.
+type StaffAttendanceManualRequest struct {
+	EmployeeID string  `json:"employee_id" binding:"required"`
+	Date       string  `json:"date" binding:"required"` // YYYY-MM-DD
+	ClockIn    *string `json:"clock_in"`
+	ClockOut   *string `json:"clock_out"`
+	Notes      *string `json:"notes"`
+}
+
+type StaffLeaveRequest struct {
+	EmployeeID string  `json:"employee_id" binding:"required"`
+	LeaveType  string  `json:"leave_type" binding:"required,oneof=SICK ANNUAL PERMISSION DUTY MATERNITY"`
+	StartDate  string  `json:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate    string  `json:"end_date" binding:"required"`
+	Reason     *string `json:"reason"`
+}
+
+type StaffLeaveFilterRequest struct {
+	Month      string `form:"month"` // YYYY-MM, default bulan ini
+	EmployeeID string `form:"employee_id"`
+}
+
+// DTO import log mesin fingerprint (multipart: file, mode)
+type StaffAttendanceImportRequest struct {
+	Mode string `form:"mode" binding:"omitempty,oneof=dry_run commit"` // default: dry_run
+}
+
+type StaffAttendanceRecapRequest struct {
+	Month string `form:"month"` // YYYY-MM, default bulan ini
+}
@@ -8,3 +8,12 @@ type StudentSetGuardianRequest struct {
 	// GuardianType adalah nama tabel: 'parent' atau 'guardian'
 	GuardianType string `json:"guardian_type" binding:"required,oneof=parent guardian"`
 }
+
+// GuardianDeleteRequest menentukan nasib siswa yang walinya menunjuk ke parent/guardian yang dihapus
+// (query string pada DELETE /parents/:id dan /guardians/:id).
+// block (default) menolak penghapusan, nullify mengosongkan wali, reassign memindahkan ke wali lain.
+type GuardianDeleteRequest struct {
+	OnWards        string `form:"on_wards" binding:"omitempty,oneof=block nullify reassign"`
+	ReassignToType string `form:"reassign_to_type" binding:"omitempty,oneof=parent guardian"`
+	ReassignToID   string `form:"reassign_to_id"`
+}
@@ -0,0 +1,13 @@
+package request
+
+import "smart_school_be/internal/utils"
+
+// DTO untuk mencatat mutasi siswa (pindah masuk/keluar, putus sekolah, lulus, diterima kembali)
+type StudentMutationRequest struct {
+	Type          string     `json:"type" binding:"required,oneof=TRANSFER_IN TRANSFER_OUT DROPOUT GRADUATE READMISSION"`
+	EffectiveDate utils.Date `json:"effective_date" binding:"required"`
+	Reason        string     `json:"reason" binding:"required"`
+	SchoolName    string     `json:"school_name"`  // Wajib untuk pindah masuk/keluar
+	ClassroomID   string     `json:"classroom_id"` // Opsional, hanya untuk pindah masuk / diterima kembali
+	DocumentIDs   []string   `json:"document_ids"` // Dokumen siswa yang sudah diupload sebagai bukti
+}
@@ -47,3 +47,41 @@ type StudentUpdateRequest struct {
 	EntryYear    *string     `json:"entry_year" form:"entry_year"`         // Changed to pointer for nullable
 	ExitYear     *string     `json:"exit_year" form:"exit_year"`           // Changed to pointer for nullable
 }
+
+// Filter daftar dan export siswa (query string)
+type StudentFilterRequest struct {
+	Search          string `form:"q"`
+	ClassroomID     string `form:"classroom_id"`
+	Status          string `form:"status" binding:"omitempty,oneof=ACTIVE GRADUATED DROPOUT TRANSFERRED"`
+	Gender          string `form:"gender" binding:"omitempty,oneof=male female"`
+	EntryYear       string `form:"entry_year" binding:"omitempty,len=4,numeric"`
+	ExitYear        string `form:"exit_year" binding:"omitempty,len=4,numeric"`
+	City            string `form:"city"`
+	Province        string `form:"province"`
+	AcademicYearID  string `form:"academic_year_id"`
+	HasGuardian     *bool  `form:"has_guardian"`
+	HasUser         *bool  `form:"has_user"`
+	MissingDocument string `form:"missing_document"` // Kode jenis dokumen atau "required"
+	Sort            string `form:"sort" binding:"omitempty,oneof=full_name nisn nim gender date_of_birth city status entry_year exit_year created_at updated_at"`
+	Order           string `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+// DTO untuk Import Siswa dari Excel (multipart, file dikirim di field "file")
+type StudentImportRequest struct {
+	Mode            string `form:"mode" binding:"omitempty,oneof=dry_run commit"` // default: dry_run
+	IncludeParents  bool   `form:"include_parents"`
+	AssignClassroom bool   `form:"assign_classroom"`
+	AcademicYearID  string `form:"academic_year_id"` // Default: tahun ajaran aktif
+}
+
+// DTO untuk menggabungkan dua data siswa yang ganda
+type StudentMergeRequest struct {
+	SurvivorID  string `json:"survivor_id" binding:"required"`  // Data yang dipertahankan
+	DuplicateID string `json:"duplicate_id" binding:"required"` // Data yang digabungkan lalu dipindah ke trash
+}
+
+// StudentTimelineRequest adalah filter timeline siswa (types dipisah koma, mis. "attendance,violation")
+type StudentTimelineRequest struct {
+	AcademicYearID string `form:"academic_year_id"`
+	Types          string `form:"types"`
+}
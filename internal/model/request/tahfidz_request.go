@@ -0,0 +1,39 @@
+package request
+
+import "smart_school_be/internal/utils"
+
+// DTO pencatatan setoran/murajaah. AcademicYearID default: tahun ajaran aktif,
+// ExaminerID default: employee milik user yang mencatat.
+type TahfidzSessionCreateRequest struct {
+	StudentID      string     `json:"student_id" binding:"required"`
+	AcademicYearID string     `json:"academic_year_id"`
+	SessionDate    utils.Date `json:"session_date" binding:"required"`
+	Type           string     `json:"type" binding:"required,oneof=setoran murajaah"`
+	StartSurah     int        `json:"start_surah" binding:"required,min=1,max=114"`
+	StartAyah      int        `json:"start_ayah" binding:"required,min=1"`
+	EndSurah       int        `json:"end_surah" binding:"required,min=1,max=114"`
+	EndAyah        int        `json:"end_ayah" binding:"required,min=1"`
+	Grade          string     `json:"grade" binding:"required,oneof=mumtaz jayyid_jiddan jayyid maqbul rasib"`
+	ExaminerID     string     `json:"examiner_id"`
+	Notes          *string    `json:"notes"`
+}
+
+type TahfidzSessionFilterRequest struct {
+	StudentID      string `form:"student_id"`
+	AcademicYearID string `form:"academic_year_id"`
+	Type           string `form:"type" binding:"omitempty,oneof=setoran murajaah"`
+	ExaminerID     string `form:"examiner_id"`
+}
+
+// DTO target hafalan; satu target bisa diterapkan ke banyak santri sekaligus
+type TahfidzTargetRequest struct {
+	StudentIDs     []string `json:"student_ids" binding:"required,min=1"`
+	AcademicYearID string   `json:"academic_year_id"`
+	TargetJuz      float64  `json:"target_juz" binding:"required,gt=0,lte=30"`
+	Notes          *string  `json:"notes"`
+}
+
+type TahfidzExportRequest struct {
+	AcademicYearID string `form:"academic_year_id"`
+	ClassroomID    string `form:"classroom_id"`
+}
@@ -0,0 +1,10 @@
+package request
+
+// DTO laporan beban mengajar. Tanpa academic_year_id memakai tahun ajaran aktif; tanpa month
+// realisasi dihitung untuk semua bulan tahun ajaran sampai hari ini.
+type TeacherWorkloadRequest struct {
+	AcademicYearID string `form:"academic_year_id"`
+	TeacherID      string `form:"teacher_id"`
+	Month          string `form:"month"`                                // YYYY-MM
+	MinimumJP      *int   `form:"minimum_jp" binding:"omitempty,min=0"` // Default dari konfigurasi (TEACHER_MINIMUM_JP)
+}
@@ -0,0 +1,202 @@
+package response
+
+import (
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/utils"
+	"time"
+)
+
+type AdmissionWaveResponse struct {
+	ID               string     `json:"id"`
+	AcademicYearID   string     `json:"academic_year_id"`
+	AcademicYearName string     `json:"academic_year_name,omitempty"`
+	Name             string     `json:"name"`
+	StartDate        utils.Date `json:"start_date"`
+	EndDate          utils.Date `json:"end_date"`
+	Quota            int        `json:"quota"`
+	IsOpen           bool       `json:"is_open"`
+	AcceptingNow     bool       `json:"accepting_now"` // Dibuka dan hari ini dalam periode pendaftaran
+	Description      *string    `json:"description"`
+	TotalApplicants  *int64     `json:"total_applicants,omitempty"`
+	TotalAccepted    *int64     `json:"total_accepted,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type ApplicantParentResponse struct {
+	ID               string      `json:"id"`
+	RelationshipType string      `json:"relationship_type"`
+	FullName         string      `json:"full_name"`
+	NIK              string      `json:"nik,omitempty"`
+	Gender           *string     `json:"gender"`
+	PlaceOfBirth     *string     `json:"place_of_birth"`
+	DateOfBirth      *utils.Date `json:"date_of_birth"`
+	LifeStatus       *string     `json:"life_status"`
+	PhoneNumber      *string     `json:"phone_number"`
+	Email            *string     `json:"email"`
+	EducationLevel   *string     `json:"education_level"`
+	Occupation       *string     `json:"occupation"`
+	IncomeRange      *string     `json:"income_range"`
+	ParentID         *string     `json:"parent_id"`
+}
+
+type ApplicantStatusLogResponse struct {
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Notes      *string   `json:"notes"`
+	CreatedBy  *string   `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ApplicantListResponse struct {
+	ID                 string     `json:"id"`
+	RegistrationNumber string     `json:"registration_number"`
+	AdmissionWaveID    string     `json:"admission_wave_id"`
+	AdmissionWaveName  string     `json:"admission_wave_name,omitempty"`
+	FullName           string     `json:"full_name"`
+	Gender             string     `json:"gender"`
+	DateOfBirth        utils.Date `json:"date_of_birth"`
+	PreviousSchool     *string    `json:"previous_school"`
+	PhoneNumber        *string    `json:"phone_number"`
+	Status             string     `json:"status"`
+	TestScore          *float64   `json:"test_score"`
+	StudentID          *string    `json:"student_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type ApplicantDetailResponse struct {
+	ApplicantListResponse
+	NoKK         string                       `json:"no_kk,omitempty"`
+	NIK          string                       `json:"nik,omitempty"`
+	NISN         *string                      `json:"nisn"`
+	PlaceOfBirth *string                      `json:"place_of_birth"`
+	Address      *string                      `json:"address"`
+	RT           *string                      `json:"rt"`
+	RW           *string                      `json:"rw"`
+	SubDistrict  *string                      `json:"sub_district"`
+	District     *string                      `json:"district"`
+	City         *string                      `json:"city"`
+	Province     *string                      `json:"province"`
+	PostalCode   *string                      `json:"postal_code"`
+	Email        *string                      `json:"email"`
+	Notes        *string                      `json:"notes"`
+	ConvertedAt  *time.Time                   `json:"converted_at"`
+	Parents      []ApplicantParentResponse    `json:"parents"`
+	Documents    []DocumentResponse           `json:"documents"`
+	StatusLogs   []ApplicantStatusLogResponse `json:"status_logs"`
+}
+
+// ApplicantStatusCheckResponse adalah hasil cek status publik (tanpa data pribadi selain nama)
+type ApplicantStatusCheckResponse struct {
+	RegistrationNumber string    `json:"registration_number"`
+	FullName           string    `json:"full_name"`
+	AdmissionWaveName  string    `json:"admission_wave_name"`
+	Status             string    `json:"status"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ApplicantConvertResponse adalah hasil konversi pendaftar menjadi siswa
+type ApplicantConvertResponse struct {
+	ApplicantID    string   `json:"applicant_id"`
+	StudentID      string   `json:"student_id"`
+	ClassroomID    *string  `json:"classroom_id"`
+	ParentIDs      []string `json:"parent_ids"`
+	LinkedParents  []string `json:"linked_parents"` // Orang tua yang sudah terdaftar (cocok NIK/No HP), tidak dibuat ulang
+	MovedDocuments int      `json:"moved_documents"`
+}
+
+func FromDomainAdmissionWave(w *domain.AdmissionWave) AdmissionWaveResponse {
+	res := AdmissionWaveResponse{
+		ID:             w.ID,
+		AcademicYearID: w.AcademicYearID,
+		Name:           w.Name,
+		StartDate:      w.StartDate,
+		EndDate:        w.EndDate,
+		Quota:          w.Quota,
+		IsOpen:         w.IsOpen,
+		AcceptingNow:   w.AcceptsApplications(time.Now()),
+		Description:    w.Description,
+		CreatedAt:      w.CreatedAt,
+	}
+	if w.AcademicYear != nil {
+		res.AcademicYearName = w.AcademicYear.Name
+	}
+	return res
+}
+
+func FromDomainApplicantList(a *domain.Applicant) ApplicantListResponse {
+	res := ApplicantListResponse{
+		ID:                 a.ID,
+		RegistrationNumber: a.RegistrationNumber,
+		AdmissionWaveID:    a.AdmissionWaveID,
+		FullName:           a.FullName,
+		Gender:             a.Gender,
+		DateOfBirth:        a.DateOfBirth,
+		PreviousSchool:     a.PreviousSchool,
+		PhoneNumber:        a.PhoneNumber,
+		Status:             a.Status,
+		TestScore:          a.TestScore,
+		StudentID:          a.StudentID,
+		CreatedAt:          a.CreatedAt,
+	}
+	if a.AdmissionWave != nil {
+		res.AdmissionWaveName = a.AdmissionWave.Name
+	}
+	return res
+}
+
+// FromDomainApplicantDetail menyusun detail pendaftar. NIK/No KK sudah didekripsi oleh service
+// (decrypted berisi key "nik", "no_kk" dan "parent:<id>").
+func FromDomainApplicantDetail(a *domain.Applicant, decrypted map[string]string, documents []domain.Document, baseURL string) ApplicantDetailResponse {
+	res := ApplicantDetailResponse{
+		ApplicantListResponse: FromDomainApplicantList(a),
+		NoKK:                  decrypted["no_kk"],
+		NIK:                   decrypted["nik"],
+		NISN:                  a.NISN,
+		PlaceOfBirth:          a.PlaceOfBirth,
+		Address:               a.Address,
+		RT:                    a.RT,
+		RW:                    a.RW,
+		SubDistrict:           a.SubDistrict,
+		District:              a.District,
+		City:                  a.City,
+		Province:              a.Province,
+		PostalCode:            a.PostalCode,
+		Email:                 a.Email,
+		Notes:                 a.Notes,
+		ConvertedAt:           a.ConvertedAt,
+		Parents:               []ApplicantParentResponse{},
+		Documents:             []DocumentResponse{},
+		StatusLogs:            []ApplicantStatusLogResponse{},
+	}
+	for _, p := range a.Parents {
+		res.Parents = append(res.Parents, ApplicantParentResponse{
+			ID:               p.ID,
+			RelationshipType: p.RelationshipType,
+			FullName:         p.FullName,
+			NIK:              decrypted["parent:"+p.ID],
+			Gender:           p.Gender,
+			PlaceOfBirth:     p.PlaceOfBirth,
+			DateOfBirth:      p.DateOfBirth,
+			LifeStatus:       p.LifeStatus,
+			PhoneNumber:      p.PhoneNumber,
+			Email:            p.Email,
+			EducationLevel:   p.EducationLevel,
+			Occupation:       p.Occupation,
+			IncomeRange:      p.IncomeRange,
+			ParentID:         p.ParentID,
+		})
+	}
+	for i := range documents {
+		res.Documents = append(res.Documents, FromDomainDocument(&documents[i], baseURL))
+	}
+	for _, l := range a.StatusLogs {
+		res.StatusLogs = append(res.StatusLogs, ApplicantStatusLogResponse{
+			FromStatus: l.FromStatus,
+			ToStatus:   l.ToStatus,
+			Notes:      l.Notes,
+			CreatedBy:  l.CreatedBy,
+			CreatedAt:  l.CreatedAt,
+		})
+	}
+	return res
+}
@@ -0,0 +1,14 @@
+package response
+
+import "time"
+
+type AnnouncementResponse struct {
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	ClassroomID   *string    `json:"classroom_id"`
+	ClassroomName *string    `json:"classroom_name"`
+	PublishedAt   time.Time  `json:"published_at"`
+	ExpiresAt     *time.Time `json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
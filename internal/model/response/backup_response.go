@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+// BackupManifest disimpan sebagai manifest.json di dalam arsip backup
+type BackupManifest struct {
+	FormatVersion int                   `json:"format_version"`
+	SchemaVersion uint                  `json:"schema_version"`
+	CreatedAt     time.Time             `json:"created_at"`
+	Entities      []BackupManifestEntry `json:"entities"`
+	Files         []BackupManifestEntry `json:"files"`
+}
+
+// BackupManifestEntry adalah satu file di dalam arsip beserta checksum-nya
+type BackupManifestEntry struct {
+	Path   string `json:"path"`
+	Table  string `json:"table,omitempty"`
+	Rows   int    `json:"rows,omitempty"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+type BackupImportResponse struct {
+	SchemaVersion uint           `json:"schema_version"`
+	Tables        map[string]int `json:"tables"`
+	RemappedIDs   int            `json:"remapped_ids"`
+	MergedRows    int            `json:"merged_rows"`
+	FilesRestored int            `json:"files_restored"`
+	FilesSkipped  int            `json:"files_skipped"`
+}
@@ -0,0 +1,49 @@
+package response
+
+import (
+	"smart_school_be/internal/contact"
+	"time"
+)
+
+type ContactPreferenceResponse struct {
+	ContactType      string     `json:"contact_type"`
+	ContactID        string     `json:"contact_id"`
+	ContactName      string     `json:"contact_name"`
+	PreferredChannel string     `json:"preferred_channel"`
+	Language         string     `json:"language"`
+	QuietHoursStart  *string    `json:"quiet_hours_start"`
+	QuietHoursEnd    *string    `json:"quiet_hours_end"`
+	OptOutCategories []string   `json:"opt_out_categories"`
+	IsDefault        bool       `json:"is_default"` // true = belum pernah diatur, nilai di atas adalah default
+	UpdatedAt        *time.Time `json:"updated_at"`
+}
+
+// ContactRoutingResponse adalah hasil "siapa yang dihubungi tentang siswa X untuk kategori Y"
+type ContactRoutingResponse struct {
+	StudentID   string          `json:"student_id"`
+	StudentName string          `json:"student_name"`
+	Category    string          `json:"category"`
+	Recipients  []contact.Route `json:"recipients"`
+	Skipped     []contact.Route `json:"skipped"`
+}
+
+type AnnouncementRecipientsResponse struct {
+	AnnouncementID string                   `json:"announcement_id"`
+	Title          string                   `json:"title"`
+	SendAt         time.Time                `json:"send_at"`
+	TotalStudents  int                      `json:"total_students"`
+	Unreachable    int                      `json:"unreachable"` // Siswa tanpa satu pun penerima
+	Students       []ContactRoutingResponse `json:"students"`
+}
+
+type AbsenceAlertItem struct {
+	ContactRoutingResponse
+	AbsentSessions int `json:"absent_sessions"`
+}
+
+type AbsenceAlertResponse struct {
+	Date        string             `json:"date"`
+	Total       int                `json:"total"`
+	Unreachable int                `json:"unreachable"`
+	Students    []AbsenceAlertItem `json:"students"`
+}
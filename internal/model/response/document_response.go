@@ -0,0 +1,120 @@
+package response
+
+import (
+	"smart_school_be/internal/model/domain"
+	"time"
+)
+
+type DocumentTypeResponse struct {
+	ID               string   `json:"id"`
+	Code             string   `json:"code"`
+	Name             string   `json:"name"`
+	OwnerType        string   `json:"owner_type"`
+	IsRequired       bool     `json:"is_required"`
+	AllowedMimeTypes []string `json:"allowed_mime_types"`
+	MaxSizeKB        int      `json:"max_size_kb"`
+	Description      *string  `json:"description"`
+}
+
+type DocumentResponse struct {
+	ID             string     `json:"id"`
+	DocumentTypeID string     `json:"document_type_id"`
+	DocumentType   string     `json:"document_type,omitempty"`
+	OwnerType      string     `json:"owner_type"`
+	OwnerID        string     `json:"owner_id"`
+	FileURL        string     `json:"file_url"`
+	OriginalName   *string    `json:"original_name"`
+	MimeType       *string    `json:"mime_type"`
+	SizeBytes      int64      `json:"size_bytes"`
+	Version        int        `json:"version"`
+	IsCurrent      bool       `json:"is_current"`
+	Status         string     `json:"status"`
+	Notes          *string    `json:"notes"`
+	UploadedBy     *string    `json:"uploaded_by"`
+	VerifiedBy     *string    `json:"verified_by"`
+	VerifiedAt     *time.Time `json:"verified_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// DocumentChecklistItem adalah status satu jenis dokumen untuk seorang pemilik.
+// State: missing, pending, verified, rejected
+type DocumentChecklistItem struct {
+	DocumentType DocumentTypeResponse `json:"document_type"`
+	State        string               `json:"state"`
+	Document     *DocumentResponse    `json:"document"`
+}
+
+type OwnerDocumentsResponse struct {
+	OwnerType       string                  `json:"owner_type"`
+	OwnerID         string                  `json:"owner_id"`
+	MissingRequired int                     `json:"missing_required"`
+	Items           []DocumentChecklistItem `json:"items"`
+}
+
+// StudentDocumentCompleteness adalah ringkasan kelengkapan dokumen wajib satu siswa.
+// Dokumen yang ditolak dihitung sebagai belum lengkap.
+type StudentDocumentCompleteness struct {
+	StudentID string   `json:"student_id"`
+	FullName  string   `json:"full_name"`
+	NISN      *string  `json:"nisn"`
+	Complete  bool     `json:"complete"`
+	Missing   []string `json:"missing"`
+	Rejected  []string `json:"rejected"`
+	Pending   []string `json:"pending"`
+}
+
+type DocumentTypeCompleteness struct {
+	DocumentTypeID string `json:"document_type_id"`
+	Name           string `json:"name"`
+	Missing        int    `json:"missing"`
+	Rejected       int    `json:"rejected"`
+	Pending        int    `json:"pending"`
+	Verified       int    `json:"verified"`
+}
+
+type DocumentCompletenessResponse struct {
+	ClassroomID      string                        `json:"classroom_id"`
+	ClassroomName    string                        `json:"classroom_name"`
+	TotalStudents    int                           `json:"total_students"`
+	CompleteStudents int                           `json:"complete_students"`
+	Types            []DocumentTypeCompleteness    `json:"types"`
+	Students         []StudentDocumentCompleteness `json:"students"`
+}
+
+func FromDomainDocumentType(dt *domain.DocumentType) DocumentTypeResponse {
+	return DocumentTypeResponse{
+		ID:               dt.ID,
+		Code:             dt.Code,
+		Name:             dt.Name,
+		OwnerType:        dt.OwnerType,
+		IsRequired:       dt.IsRequired,
+		AllowedMimeTypes: dt.MimeTypes(),
+		MaxSizeKB:        dt.MaxSizeKB,
+		Description:      dt.Description,
+	}
+}
+
+func FromDomainDocument(d *domain.Document, baseURL string) DocumentResponse {
+	res := DocumentResponse{
+		ID:             d.ID,
+		DocumentTypeID: d.DocumentTypeID,
+		OwnerType:      d.OwnerType,
+		OwnerID:        d.OwnerID,
+		FileURL:        GenerateFileURL(&d.FilePath, baseURL),
+		OriginalName:   d.OriginalName,
+		MimeType:       d.MimeType,
+		SizeBytes:      d.SizeBytes,
+		Version:        d.Version,
+		IsCurrent:      d.IsCurrent,
+		Status:         d.Status,
+		Notes:          d.Notes,
+		UploadedBy:     d.UploadedBy,
+		VerifiedBy:     d.VerifiedBy,
+		VerifiedAt:     d.VerifiedAt,
+		CreatedAt:      d.CreatedAt,
+	}
+	if d.DocumentType != nil {
+		res.DocumentType = d.DocumentType.Name
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package response
+
+import "smart_school_be/internal/utils"
+
+type DormitoryResponse struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Gender      string             `json:"gender"`
+	Description *string            `json:"description"`
+	Rooms       []DormRoomResponse `json:"rooms,omitempty"`
+}
+
+type DormRoomResponse struct {
+	ID            string  `json:"id"`
+	DormitoryID   string  `json:"dormitory_id"`
+	DormitoryName string  `json:"dormitory_name"`
+	Name          string  `json:"name"`
+	Floor         *int    `json:"floor"`
+	Capacity      int     `json:"capacity"`
+	Occupied      int64   `json:"occupied"`
+	Available     int64   `json:"available"`
+	MusyrifID     *string `json:"musyrif_id"`
+	MusyrifName   *string `json:"musyrif_name"`
+}
+
+type DormOccupantResponse struct {
+	AssignmentID string     `json:"assignment_id"`
+	StudentID    string     `json:"student_id"`
+	FullName     string     `json:"full_name"`
+	NISN         *string    `json:"nisn"`
+	Gender       string     `json:"gender"`
+	StartDate    utils.Date `json:"start_date"`
+	Notes        *string    `json:"notes"`
+}
+
+type DormRoomOccupantsResponse struct {
+	Room           DormRoomResponse       `json:"room"`
+	AcademicYearID string                 `json:"academic_year_id"`
+	Occupants      []DormOccupantResponse `json:"occupants"`
+}
+
+// DormAssignmentResponse adalah satu baris riwayat kamar santri
+type DormAssignmentResponse struct {
+	ID               string      `json:"id"`
+	StudentID        string      `json:"student_id"`
+	DormitoryID      string      `json:"dormitory_id"`
+	DormitoryName    string      `json:"dormitory_name"`
+	DormRoomID       string      `json:"dorm_room_id"`
+	DormRoomName     string      `json:"dorm_room_name"`
+	AcademicYearID   string      `json:"academic_year_id"`
+	AcademicYearName string      `json:"academic_year_name,omitempty"`
+	Status           string      `json:"status"`
+	StartDate        utils.Date  `json:"start_date"`
+	EndDate          *utils.Date `json:"end_date"`
+	Notes            *string     `json:"notes"`
+}
+
+type DormOccupancyDormitory struct {
+	ID            string             `json:"id"`
+	Name          string             `json:"name"`
+	Gender        string             `json:"gender"`
+	Capacity      int                `json:"capacity"`
+	Occupied      int64              `json:"occupied"`
+	Available     int64              `json:"available"`
+	OccupancyRate float64            `json:"occupancy_rate"` // Persen 0-100
+	Rooms         []DormRoomResponse `json:"rooms"`
+}
+
+type DormOccupancyReport struct {
+	AcademicYearID string                   `json:"academic_year_id"`
+	Capacity       int                      `json:"capacity"`
+	Occupied       int64                    `json:"occupied"`
+	Available      int64                    `json:"available"`
+	OccupancyRate  float64                  `json:"occupancy_rate"`
+	Dormitories    []DormOccupancyDormitory `json:"dormitories"`
+}
@@ -31,6 +31,8 @@ type EmployeeDetailResponse struct {
 	DateOfBirth      *utils.Date         `json:"date_of_birth,omitempty"`
 	JoinDate         *utils.Date         `json:"join_date,omitempty"`
 	EmploymentStatus *string             `json:"employment_status,omitempty"` // Changed to pointer
+	DeviceUserID     *string             `json:"device_user_id,omitempty"`    // ID user di mesin fingerprint
+	WorkShiftID      *string             `json:"work_shift_id,omitempty"`
 	CreatedAt        time.Time           `json:"created_at"`
 	UpdatedAt        time.Time           `json:"updated_at"`
 	// Kita bisa tambahkan UserInfo (dari user_id) di sini nanti jika perlu
@@ -0,0 +1,27 @@
+package response
+
+type FamilyImportRowResult struct {
+	Row          int      `json:"row"`
+	Type         string   `json:"type"` // parent, guardian
+	FullName     string   `json:"full_name"`
+	StudentName  string   `json:"student_name,omitempty"`
+	Status       string   `json:"status"`           // valid, invalid, imported
+	Action       string   `json:"action,omitempty"` // create, use_existing, use_row
+	PersonID     string   `json:"person_id,omitempty"`
+	Relationship string   `json:"relationship,omitempty"`
+	SetGuardian  bool     `json:"set_guardian"`
+	Errors       []string `json:"errors,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+type FamilyImportResponse struct {
+	DryRun            bool                    `json:"dry_run"`
+	TotalRows         int                     `json:"total_rows"`
+	ValidRows         int                     `json:"valid_rows"`
+	InvalidRows       int                     `json:"invalid_rows"`
+	CreatedParents    int                     `json:"created_parents"`
+	CreatedGuardians  int                     `json:"created_guardians"`
+	LinkedStudents    int                     `json:"linked_students"`
+	AssignedGuardians int                     `json:"assigned_guardians"`
+	Rows              []FamilyImportRowResult `json:"rows"`
+}
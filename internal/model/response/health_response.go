@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+type HealthProfileResponse struct {
+	StudentID          string     `json:"student_id"`
+	StudentName        string     `json:"student_name"`
+	BloodType          *string    `json:"blood_type"`
+	Allergies          *string    `json:"allergies"`
+	ChronicConditions  *string    `json:"chronic_conditions"`
+	RegularMedications *string    `json:"regular_medications"`
+	Notes              *string    `json:"notes"`
+	UpdatedBy          *string    `json:"updated_by"`
+	UpdatedAt          *time.Time `json:"updated_at"` // null jika profil belum pernah diisi
+}
+
+type ClinicVisitResponse struct {
+	ID                 string    `json:"id"`
+	StudentID          string    `json:"student_id"`
+	StudentName        string    `json:"student_name"`
+	VisitedAt          time.Time `json:"visited_at"`
+	Complaint          string    `json:"complaint"`
+	Treatment          *string   `json:"treatment"`
+	MedicationGiven    *string   `json:"medication_given"`
+	Outcome            string    `json:"outcome"`
+	ReferredTo         *string   `json:"referred_to"`
+	MarkAttendanceSick bool      `json:"mark_attendance_sick"`
+	AttendanceMarked   int       `json:"attendance_marked"` // Jumlah sesi absensi yang ditandai SICK
+	HandledBy          *string   `json:"handled_by"`
+	CreatedAt          time.Time `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package response
+
+import "smart_school_be/internal/utils"
+
+// HouseholdStudent adalah anak dalam satu household beserta kelas aktifnya
+type HouseholdStudent struct {
+	ID            string      `json:"id"`
+	FullName      string      `json:"full_name"`
+	NISN          *string     `json:"nisn"`
+	Gender        string      `json:"gender"`
+	DateOfBirth   *utils.Date `json:"date_of_birth"`
+	Status        string      `json:"status"`
+	ClassroomID   *string     `json:"classroom_id"`
+	ClassroomName *string     `json:"classroom_name"`
+	BirthOrder    int         `json:"birth_order"` // Urutan lahir di antara anak yang terdaftar (1 = tertua)
+}
+
+// HouseholdParent adalah orang tua yang tertaut ke salah satu anak dalam household
+type HouseholdParent struct {
+	ID               string  `json:"id"`
+	FullName         string  `json:"full_name"`
+	RelationshipType string  `json:"relationship_type"`
+	PhoneNumber      *string `json:"phone_number"`
+}
+
+type HouseholdResponse struct {
+	ID           string             `json:"id"`
+	NoKK         string             `json:"no_kk"`
+	StudentCount int                `json:"student_count"`
+	Students     []HouseholdStudent `json:"students"`
+	Parents      []HouseholdParent  `json:"parents"`
+}
+
+// StudentSiblingsResponse adalah saudara kandung siswa yang terdaftar di sekolah (satu No KK)
+type StudentSiblingsResponse struct {
+	StudentID    string             `json:"student_id"`
+	HouseholdID  *string            `json:"household_id"`
+	BirthOrder   int                `json:"birth_order"`
+	SiblingCount int                `json:"sibling_count"`
+	Siblings     []HouseholdStudent `json:"siblings"`
+}
@@ -0,0 +1,30 @@
+package response
+
+import "time"
+
+type LeavePermitResponse struct {
+	ID                       string     `json:"id"`
+	StudentID                string     `json:"student_id"`
+	StudentName              string     `json:"student_name"`
+	NISN                     *string    `json:"nisn"`
+	AcademicYearID           string     `json:"academic_year_id"`
+	Reason                   string     `json:"reason"`
+	Destination              *string    `json:"destination"`
+	DepartureAt              time.Time  `json:"departure_at"`
+	ExpectedReturnAt         time.Time  `json:"expected_return_at"`
+	Status                   string     `json:"status"`
+	RequesterType            string     `json:"requester_type"`
+	RequestedBy              *string    `json:"requested_by"`
+	GuardianConfirmed        bool       `json:"guardian_confirmed"`
+	GuardianConfirmedAt      *time.Time `json:"guardian_confirmed_at"`
+	GuardianConfirmationNote *string    `json:"guardian_confirmation_note"`
+	ReviewedBy               *string    `json:"reviewed_by"`
+	ReviewedAt               *time.Time `json:"reviewed_at"`
+	ReviewNotes              *string    `json:"review_notes"`
+	CheckedOutAt             *time.Time `json:"checked_out_at"`
+	PickedUpBy               *string    `json:"picked_up_by"`
+	CheckedInAt              *time.Time `json:"checked_in_at"`
+	IsOverdue                bool       `json:"is_overdue"`
+	LateMinutes              int        `json:"late_minutes"`
+	CreatedAt                time.Time  `json:"created_at"`
+}
@@ -0,0 +1,41 @@
+package response
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+)
+
+// PortalChildResponse adalah ringkasan anak yang tertaut ke akun orang tua/wali
+type PortalChildResponse struct {
+	ID            string  `json:"id"`
+	FullName      string  `json:"full_name"`
+	NISN          *string `json:"nisn"`
+	NIM           *string `json:"nim"`
+	Gender        string  `json:"gender"`
+	Status        string  `json:"status"`
+	ClassroomID   *string `json:"classroom_id"`
+	ClassroomName *string `json:"classroom_name"`
+}
+
+type PortalAttendanceResponse struct {
+	SessionID   string     `json:"session_id"`
+	Date        utils.Date `json:"date"`
+	StartTime   string     `json:"start_time"`
+	EndTime     string     `json:"end_time"`
+	SubjectName string     `json:"subject_name"`
+	Topic       string     `json:"topic"`
+	Status      string     `json:"status"`
+	Notes       string     `json:"notes"`
+}
+
+type PortalScoreResponse struct {
+	AssessmentID string     `json:"assessment_id"`
+	Title        string     `json:"title"`
+	Type         string     `json:"type"`
+	SubjectName  string     `json:"subject_name"`
+	Date         utils.Date `json:"date"`
+	MaxScore     int        `json:"max_score"`
+	Score        float64    `json:"score"`
+	Feedback     string     `json:"feedback"`
+	PublishedAt  *time.Time `json:"published_at"`
+}
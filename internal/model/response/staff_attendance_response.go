@@ -0,0 +1,133 @@
+package response
+
+import (
+	"smart_school_be/internal/attlog"
+	"time"
+)
+
+// Status kehadiran harian pegawai (diturunkan, tidak disimpan)
+const (
+	StaffStatusPresent    = "PRESENT"
+	StaffStatusLate       = "LATE"
+	StaffStatusLeave      = "LEAVE"
+	StaffStatusAbsent     = "ABSENT"
+	StaffStatusNotYet     = "NOT_CLOCKED_IN" // Hari ini, belum ada rekaman
+	StaffStatusOff        = "OFF"            // Bukan hari kerja
+	StaffStatusNoSchedule = "NO_SHIFT"       // Belum ada shift (pegawai maupun default)
+)
+
+type StaffAttendanceResponse struct {
+	ID                string     `json:"id"`
+	EmployeeID        string     `json:"employee_id"`
+	Date              string     `json:"date"`
+	ClockInAt         *time.Time `json:"clock_in_at"`
+	ClockOutAt        *time.Time `json:"clock_out_at"`
+	ShiftStart        *string    `json:"shift_start"`
+	ShiftEnd          *string    `json:"shift_end"`
+	LateMinutes       int        `json:"late_minutes"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"`
+	Source            string     `json:"source"`
+	Notes             *string    `json:"notes"`
+}
+
+// StaffDailyItem adalah status satu pegawai pada satu tanggal
+type StaffDailyItem struct {
+	EmployeeID string                   `json:"employee_id"`
+	FullName   string                   `json:"full_name"`
+	NIP        *string                  `json:"nip,omitempty"`
+	Status     string                   `json:"status"`
+	LeaveType  string                   `json:"leave_type,omitempty"`
+	Attendance *StaffAttendanceResponse `json:"attendance,omitempty"`
+}
+
+type StaffDailyResponse struct {
+	Date      string           `json:"date"`
+	Summary   map[string]int   `json:"summary"` // Jumlah pegawai per status
+	Employees []StaffDailyItem `json:"employees"`
+}
+
+type WorkShiftResponse struct {
+	ID                         string `json:"id"`
+	Name                       string `json:"name"`
+	StartTime                  string `json:"start_time"`
+	EndTime                    string `json:"end_time"`
+	LateToleranceMinutes       int    `json:"late_tolerance_minutes"`
+	EarlyLeaveToleranceMinutes int    `json:"early_leave_tolerance_minutes"`
+	WorkDays                   string `json:"work_days"`
+	IsDefault                  bool   `json:"is_default"`
+}
+
+type EmployeeAttendanceSettingsResponse struct {
+	EmployeeID   string  `json:"employee_id"`
+	FullName     string  `json:"full_name"`
+	DeviceUserID *string `json:"device_user_id"`
+	WorkShiftID  *string `json:"work_shift_id"`
+}
+
+type StaffLeaveResponse struct {
+	ID           string    `json:"id"`
+	EmployeeID   string    `json:"employee_id"`
+	EmployeeName string    `json:"employee_name,omitempty"`
+	LeaveType    string    `json:"leave_type"`
+	StartDate    string    `json:"start_date"`
+	EndDate      string    `json:"end_date"`
+	Reason       *string   `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// StaffImportDayResult adalah hasil satu hari satu pegawai dari log mesin
+type StaffImportDayResult struct {
+	DeviceUserID      string     `json:"device_user_id"`
+	EmployeeID        string     `json:"employee_id"`
+	FullName          string     `json:"full_name"`
+	Date              string     `json:"date"`
+	Punches           int        `json:"punches"`
+	ClockInAt         *time.Time `json:"clock_in_at"`
+	ClockOutAt        *time.Time `json:"clock_out_at"`
+	LateMinutes       int        `json:"late_minutes"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"`
+	Action            string     `json:"action"` // create, update, unchanged, skip_manual
+}
+
+// StaffImportUnmapped adalah ID user mesin yang belum dipetakan ke pegawai
+type StaffImportUnmapped struct {
+	DeviceUserID string `json:"device_user_id"`
+	Punches      int    `json:"punches"`
+	FirstSeen    string `json:"first_seen"`
+	LastSeen     string `json:"last_seen"`
+}
+
+type StaffAttendanceImportResponse struct {
+	DryRun        bool                   `json:"dry_run"`
+	TotalPunches  int                    `json:"total_punches"`
+	InvalidLines  []attlog.LineError     `json:"invalid_lines"`
+	Unmapped      []StaffImportUnmapped  `json:"unmapped"`
+	Created       int                    `json:"created"`
+	Updated       int                    `json:"updated"`
+	Unchanged     int                    `json:"unchanged"`
+	SkippedManual int                    `json:"skipped_manual"`
+	Days          []StaffImportDayResult `json:"days"`
+}
+
+// StaffRecapItem adalah rekap bulanan satu pegawai
+type StaffRecapItem struct {
+	EmployeeID        string         `json:"employee_id"`
+	FullName          string         `json:"full_name"`
+	NIP               *string        `json:"nip,omitempty"`
+	WorkDays          int            `json:"work_days"`
+	Present           int            `json:"present"`
+	Late              int            `json:"late"`
+	LateMinutes       int            `json:"late_minutes"`
+	EarlyLeave        int            `json:"early_leave"`
+	EarlyLeaveMinutes int            `json:"early_leave_minutes"`
+	MissingClockOut   int            `json:"missing_clock_out"`
+	Leave             map[string]int `json:"leave"` // Hari kerja per jenis cuti
+	Absent            int            `json:"absent"`
+}
+
+type StaffRecapResponse struct {
+	Month     string           `json:"month"`
+	From      string           `json:"from"`
+	To        string           `json:"to"` // Dibatasi sampai hari ini untuk bulan berjalan
+	Employees []StaffRecapItem `json:"employees"`
+}
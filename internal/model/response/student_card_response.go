@@ -0,0 +1,18 @@
+package response
+
+// StudentPhotoResponse dikembalikan setelah pas foto siswa diunggah
+type StudentPhotoResponse struct {
+	StudentID string `json:"student_id"`
+	PhotoURL  string `json:"photo_url"`
+}
+
+// StudentCardVerifyResponse adalah hasil scan QR kartu pelajar.
+// Sengaja ringkas karena endpoint verifikasi bisa diakses tanpa login.
+type StudentCardVerifyResponse struct {
+	StudentID string  `json:"student_id"`
+	FullName  string  `json:"full_name"`
+	NISN      *string `json:"nisn"`
+	ClassName string  `json:"class_name"`
+	Status    string  `json:"status"`
+	Active    bool    `json:"active"` // Kartu hanya berlaku untuk siswa berstatus ACTIVE
+}
@@ -0,0 +1,30 @@
+package response
+
+import "smart_school_be/internal/utils"
+
+// DuplicateStudentInfo adalah ringkasan siswa pada pasangan kandidat duplikat
+type DuplicateStudentInfo struct {
+	ID           string      `json:"id"`
+	FullName     string      `json:"full_name"`
+	NISN         *string     `json:"nisn"`
+	Gender       string      `json:"gender"`
+	PlaceOfBirth *string     `json:"place_of_birth"`
+	DateOfBirth  *utils.Date `json:"date_of_birth"`
+	Status       string      `json:"status"`
+}
+
+// StudentDuplicateCandidate adalah pasangan siswa yang kemungkinan data ganda (skor 0-100)
+type StudentDuplicateCandidate struct {
+	Score    int                  `json:"score"`
+	Reasons  []string             `json:"reasons"`
+	StudentA DuplicateStudentInfo `json:"student_a"`
+	StudentB DuplicateStudentInfo `json:"student_b"`
+}
+
+type StudentMergeResponse struct {
+	SurvivorID   string           `json:"survivor_id"`
+	DuplicateID  string           `json:"duplicate_id"`
+	FilledFields []string         `json:"filled_fields"` // Kolom survivor yang dilengkapi dari duplikat
+	Moved        map[string]int64 `json:"moved"`
+	Dropped      map[string]int64 `json:"dropped"` // Baris duplikat yang dibuang karena survivor sudah punya
+}
@@ -0,0 +1,19 @@
+package response
+
+type StudentImportRowResult struct {
+	Row       int      `json:"row"`
+	FullName  string   `json:"full_name"`
+	Status    string   `json:"status"` // valid, invalid, created
+	StudentID string   `json:"student_id,omitempty"`
+	Errors    []string `json:"errors,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+}
+
+type StudentImportResponse struct {
+	DryRun      bool                     `json:"dry_run"`
+	TotalRows   int                      `json:"total_rows"`
+	ValidRows   int                      `json:"valid_rows"`
+	InvalidRows int                      `json:"invalid_rows"`
+	Created     int                      `json:"created"`
+	Rows        []StudentImportRowResult `json:"rows"`
+}
@@ -0,0 +1,74 @@
+package response
+
+import (
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/utils"
+	"time"
+)
+
+type StudentMutationResponse struct {
+	ID             string                   `json:"id"`
+	StudentID      string                   `json:"student_id"`
+	StudentName    string                   `json:"student_name,omitempty"`
+	Gender         string                   `json:"gender,omitempty"`
+	Type           string                   `json:"type"`
+	EffectiveDate  utils.Date               `json:"effective_date"`
+	Reason         string                   `json:"reason"`
+	SchoolName     *string                  `json:"school_name"`
+	PreviousStatus string                   `json:"previous_status"`
+	NewStatus      string                   `json:"new_status"`
+	Classroom      *SimpleClassroomResponse `json:"classroom"`
+	Documents      []DocumentResponse       `json:"documents"`
+	CreatedBy      *string                  `json:"created_by"`
+	CreatedAt      time.Time                `json:"created_at"`
+}
+
+type SimpleClassroomResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// StudentMutationSummary adalah jumlah mutasi per jenis, dipisah laki-laki/perempuan
+type StudentMutationSummary struct {
+	Type   string `json:"type"`
+	Male   int    `json:"male"`
+	Female int    `json:"female"`
+	Total  int    `json:"total"`
+}
+
+// StudentMutationReportResponse adalah laporan mutasi siswa untuk satu bulan
+type StudentMutationReportResponse struct {
+	Year      int                       `json:"year"`
+	Month     int                       `json:"month"`
+	Incoming  int                       `json:"incoming"` // Pindah masuk + diterima kembali
+	Outgoing  int                       `json:"outgoing"` // Pindah keluar + putus sekolah + lulus
+	Summary   []StudentMutationSummary  `json:"summary"`
+	Mutations []StudentMutationResponse `json:"mutations"`
+}
+
+func FromDomainStudentMutation(m *domain.StudentMutation, baseURL string) StudentMutationResponse {
+	res := StudentMutationResponse{
+		ID:             m.ID,
+		StudentID:      m.StudentID,
+		Type:           m.Type,
+		EffectiveDate:  m.EffectiveDate,
+		Reason:         m.Reason,
+		SchoolName:     m.SchoolName,
+		PreviousStatus: m.PreviousStatus,
+		NewStatus:      m.NewStatus,
+		Documents:      []DocumentResponse{},
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+	}
+	if m.Student != nil {
+		res.StudentName = m.Student.FullName
+		res.Gender = m.Student.Gender
+	}
+	if m.Classroom != nil {
+		res.Classroom = &SimpleClassroomResponse{ID: m.Classroom.ID, Name: m.Classroom.Name}
+	}
+	for i := range m.Documents {
+		res.Documents = append(res.Documents, FromDomainDocument(&m.Documents[i], baseURL))
+	}
+	return res
+}
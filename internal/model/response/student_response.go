@@ -1,8 +1,8 @@
 package response
 
 import (
-	"time"
 	"smart_school_be/internal/utils"
+	"time"
 )
 
 // StudentListResponse adalah DTO untuk tampilan list (ringkas)
@@ -19,6 +19,7 @@ type StudentListResponse struct {
 	Level     string  `json:"level"`      // e.g. "X"
 	Status    string  `json:"status"`     // e.g. "ACTIVE", "GRADUATED"
 	Email     string  `json:"email"`      // from User account
+	PhotoURL  string  `json:"photo_url"`
 }
 
 // ParentRelationshipResponse adalah DTO untuk menampilkan relasi orang tua
@@ -42,36 +43,29 @@ type GuardianInfoResponse struct {
 
 // StudentDetailResponse adalah DTO untuk tampilan detail (lengkap)
 type StudentDetailResponse struct {
-	ID                             string      `json:"id"`
-	FullName                       string      `json:"full_name"`
-	NoKK                           string      `json:"no_kk,omitempty"` // Akan berisi plaintext
-	NIK                            string      `json:"nik,omitempty"`   // Akan berisi plaintext
-	NISN                           *string     `json:"nisn"`
-	NIM                            *string     `json:"nim"`
-	Gender                         string      `json:"gender"`
-	PlaceOfBirth                   *string     `json:"place_of_birth"`
-	DateOfBirth                    *utils.Date `json:"date_of_birth"`
-	Address                        *string     `json:"address"`
-	RT                             *string     `json:"rt"`
-	RW                             *string     `json:"rw"`
-	SubDistrict                    *string     `json:"sub_district"`
-	District                       *string     `json:"district"`
-	City                           *string     `json:"city"`
-	Province                       *string     `json:"province"`
-	PostalCode                     *string     `json:"postal_code"`
-	Status                         string      `json:"status"`
-	EntryYear                      *string     `json:"entry_year"`
-	ExitYear                       *string     `json:"exit_year"`
-	BirthCertificateFileURL        *string     `json:"birth_certificate_file_url"`
-	FamilyCardFileURL              *string     `json:"family_card_file_url"`
-	ParentStatementFileURL         *string     `json:"parent_statement_file_url"`
-	StudentStatementFileURL        *string     `json:"student_statement_file_url"`
-	HealthInsuranceFileURL         *string     `json:"health_insurance_file_url"`
-	DiplomaCertificateFileURL      *string     `json:"diploma_certificate_file_url"`
-	GraduationCertificateFileURL   *string     `json:"graduation_certificate_file_url"`
-	FinancialHardshipLetterFileURL *string     `json:"financial_hardship_letter_file_url"`
-	CreatedAt                      time.Time   `json:"created_at"`
-	UpdatedAt                      time.Time   `json:"updated_at"`
+	ID           string      `json:"id"`
+	FullName     string      `json:"full_name"`
+	NoKK         string      `json:"no_kk,omitempty"` // Akan berisi plaintext
+	NIK          string      `json:"nik,omitempty"`   // Akan berisi plaintext
+	NISN         *string     `json:"nisn"`
+	NIM          *string     `json:"nim"`
+	Gender       string      `json:"gender"`
+	PlaceOfBirth *string     `json:"place_of_birth"`
+	DateOfBirth  *utils.Date `json:"date_of_birth"`
+	Address      *string     `json:"address"`
+	RT           *string     `json:"rt"`
+	RW           *string     `json:"rw"`
+	SubDistrict  *string     `json:"sub_district"`
+	District     *string     `json:"district"`
+	City         *string     `json:"city"`
+	Province     *string     `json:"province"`
+	PostalCode   *string     `json:"postal_code"`
+	Status       string      `json:"status"`
+	EntryYear    *string     `json:"entry_year"`
+	ExitYear     *string     `json:"exit_year"`
+	PhotoURL     string      `json:"photo_url"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
 
 	// Relasi M:N ke Parents (Sudah ada)
 	Parents []ParentRelationshipResponse `json:"parents,omitempty"`
@@ -81,3 +75,30 @@ type StudentDetailResponse struct {
 
 	User *UserLinkedResponse `json:"user"`
 }
+
+// GuardianWardResponse adalah siswa yang walinya menunjuk ke parent/guardian tertentu
+type GuardianWardResponse struct {
+	ID       string  `json:"id"`
+	FullName string  `json:"full_name"`
+	NISN     *string `json:"nisn"`
+	NIM      *string `json:"nim"`
+	Gender   string  `json:"gender"`
+	Status   string  `json:"status"`
+}
+
+// GuardianIntegrityIssue adalah satu pointer wali yang rusak beserta tindakan perbaikannya
+type GuardianIntegrityIssue struct {
+	StudentID      string  `json:"student_id"`
+	FullName       string  `json:"full_name"`
+	StudentDeleted bool    `json:"student_deleted"`
+	GuardianType   *string `json:"guardian_type"`
+	GuardianID     *string `json:"guardian_id"`
+	Reason         string  `json:"reason"`
+	Action         string  `json:"action"` // reassign_parent atau nullify
+	ReassignedToID *string `json:"reassigned_to_id,omitempty"`
+}
+
+type GuardianIntegrityReport struct {
+	Fixed  bool                     `json:"fixed"`
+	Issues []GuardianIntegrityIssue `json:"issues"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import "time"
+
+// StudentTimelineEventResponse adalah satu kejadian di timeline siswa.
+// Kolom yang tidak relevan untuk jenis kejadian tertentu bernilai null.
+type StudentTimelineEventResponse struct {
+	Type          string    `json:"type"` // attendance, score, violation, classroom, status
+	ID            string    `json:"id"`   // ID baris sumber (attendance detail, score, violation, penempatan, mutasi)
+	OccurredAt    time.Time `json:"occurred_at"`
+	Title         string    `json:"title"`    // Mapel, judul penilaian, jenis pelanggaran, nama kelas, atau jenis mutasi
+	Subtitle      *string   `json:"subtitle"` // Catatan absensi, mapel, tindakan, tahun ajaran, atau alasan mutasi
+	Status        *string   `json:"status"`   // Status absensi, jenis penilaian, status penempatan, atau status baru siswa
+	Score         *float64  `json:"score"`
+	MaxScore      *int      `json:"max_score"`
+	Points        *int      `json:"points"`
+	ClassroomName *string   `json:"classroom_name"`
+}
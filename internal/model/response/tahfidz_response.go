@@ -0,0 +1,79 @@
+package response
+
+import (
+	"smart_school_be/internal/utils"
+	"time"
+)
+
+type TahfidzSessionResponse struct {
+	ID             string     `json:"id"`
+	StudentID      string     `json:"student_id"`
+	StudentName    string     `json:"student_name"`
+	AcademicYearID string     `json:"academic_year_id"`
+	SessionDate    utils.Date `json:"session_date"`
+	Type           string     `json:"type"`
+	StartSurah     int        `json:"start_surah"`
+	StartSurahName string     `json:"start_surah_name"`
+	StartAyah      int        `json:"start_ayah"`
+	EndSurah       int        `json:"end_surah"`
+	EndSurahName   string     `json:"end_surah_name"`
+	EndAyah        int        `json:"end_ayah"`
+	Range          string     `json:"range"` // mis. "Al-Mulk 1 - Al-Mulk 15"
+	AyahCount      int        `json:"ayah_count"`
+	JuzStart       int        `json:"juz_start"`
+	JuzEnd         int        `json:"juz_end"`
+	Grade          string     `json:"grade"`
+	ExaminerID     *string    `json:"examiner_id"`
+	ExaminerName   string     `json:"examiner_name"`
+	Notes          *string    `json:"notes"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type TahfidzJuzProgress struct {
+	Juz            int     `json:"juz"`
+	MemorizedAyahs int     `json:"memorized_ayahs"`
+	TotalAyahs     int     `json:"total_ayahs"`
+	Percent        float64 `json:"percent"`
+}
+
+// TahfidzYearSummary adalah capaian dalam satu tahun ajaran
+type TahfidzYearSummary struct {
+	NewAyahs      int     `json:"new_ayahs"`      // Ayat baru yang dihafal di tahun ini
+	JuzEquivalent float64 `json:"juz_equivalent"` // Ayat baru dikonversi ke juz
+	SetoranCount  int     `json:"setoran_count"`
+	MurajaahCount int     `json:"murajaah_count"`
+	RasibCount    int     `json:"rasib_count"` // Setoran/murajaah yang belum lulus
+	AverageGrade  string  `json:"average_grade"`
+}
+
+type TahfidzProgressResponse struct {
+	StudentID        string               `json:"student_id"`
+	StudentName      string               `json:"student_name"`
+	AcademicYearID   string               `json:"academic_year_id"`
+	AcademicYearName string               `json:"academic_year_name"`
+	MemorizedAyahs   int                  `json:"memorized_ayahs"` // Kumulatif sampai akhir tahun ajaran
+	JuzEquivalent    float64              `json:"juz_equivalent"`
+	JuzCompleted     []int                `json:"juz_completed"`
+	Juz              []TahfidzJuzProgress `json:"juz"` // Hanya juz yang sudah mulai dihafal
+	Year             TahfidzYearSummary   `json:"year"`
+	TargetJuz        *float64             `json:"target_juz"`
+	TargetPercent    float64              `json:"target_percent"`   // Capaian tahun ini terhadap target
+	ExpectedPercent  float64              `json:"expected_percent"` // Porsi tahun ajaran yang sudah berjalan
+	Pace             string               `json:"pace"`             // no_target, ahead, on_track, behind
+	LastSessionDate  *utils.Date          `json:"last_session_date"`
+}
+
+// TahfidzReportCardSection adalah ringkasan tahfidz yang bisa disisipkan ke rapor
+type TahfidzReportCardSection struct {
+	Title            string   `json:"title"`
+	AcademicYearName string   `json:"academic_year_name"`
+	TargetJuz        *float64 `json:"target_juz"`
+	AchievedJuz      float64  `json:"achieved_juz"`
+	TotalJuz         float64  `json:"total_juz"`
+	JuzCompleted     []int    `json:"juz_completed"`
+	AverageGrade     string   `json:"average_grade"`
+	SetoranCount     int      `json:"setoran_count"`
+	MurajaahCount    int      `json:"murajaah_count"`
+	Pace             string   `json:"pace"`
+	Remarks          string   `json:"remarks"`
+}
@@ -0,0 +1,53 @@
+package response
+
+// TeacherWorkloadAssignment adalah beban mingguan satu penugasan mengajar (kelas + mapel)
+type TeacherWorkloadAssignment struct {
+	TeachingAssignmentID string `json:"teaching_assignment_id"`
+	ClassroomName        string `json:"classroom_name"`
+	SubjectName          string `json:"subject_name"`
+	WeeklySessions       int    `json:"weekly_sessions"`
+	WeeklyJP             int    `json:"weekly_jp"`
+}
+
+// TeacherWorkloadMonth membandingkan pertemuan terjadwal dengan sesi absensi yang benar-benar diisi
+type TeacherWorkloadMonth struct {
+	Month              string  `json:"month"` // YYYY-MM
+	ScheduledSessions  int     `json:"scheduled_sessions"`
+	ScheduledJP        int     `json:"scheduled_jp"`
+	RealizedSessions   int     `json:"realized_sessions"`
+	RealizedJP         int     `json:"realized_jp"`
+	RealizationPercent float64 `json:"realization_percent"`
+}
+
+type TeacherWorkloadItem struct {
+	TeacherID          string                      `json:"teacher_id"`
+	FullName           string                      `json:"full_name"`
+	NIP                *string                     `json:"nip,omitempty"`
+	WeeklySessions     int                         `json:"weekly_sessions"`
+	WeeklyJP           int                         `json:"weekly_jp"`
+	ShortfallJP        int                         `json:"shortfall_jp"`
+	Status             string                      `json:"status"` // BELOW_MINIMUM, MEETS_MINIMUM
+	Assignments        []TeacherWorkloadAssignment `json:"assignments"`
+	Months             []TeacherWorkloadMonth      `json:"months"`
+	ScheduledJP        int                         `json:"scheduled_jp"` // Total seluruh bulan laporan
+	RealizedJP         int                         `json:"realized_jp"`
+	RealizationPercent float64                     `json:"realization_percent"`
+}
+
+type TeacherWorkloadSummary struct {
+	Teachers         int `json:"teachers"`
+	BelowMinimum     int `json:"below_minimum"`
+	TotalWeeklyJP    int `json:"total_weekly_jp"`
+	InvalidSchedules int `json:"invalid_schedules"` // Jadwal dengan jam tidak valid, tidak dihitung
+}
+
+type TeacherWorkloadResponse struct {
+	AcademicYearID   string                 `json:"academic_year_id"`
+	AcademicYearName string                 `json:"academic_year_name"`
+	PeriodMinutes    int                    `json:"period_minutes"`
+	MinimumJP        int                    `json:"minimum_jp"`
+	From             string                 `json:"from"` // Rentang realisasi
+	To               string                 `json:"to"`
+	Summary          TeacherWorkloadSummary `json:"summary"`
+	Teachers         []TeacherWorkloadItem  `json:"teachers"`
+}
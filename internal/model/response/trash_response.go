@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type TrashItemResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Label     string    `json:"label"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type TrashPurgeResponse struct {
+	Cutoff time.Time        `json:"cutoff"`
+	Purged map[string]int64 `json:"purged"`
+	Kept   map[string]int64 `json:"kept,omitempty"` // Ditahan karena masih punya data turunan
+}
@@ -0,0 +1,103 @@
+package quran
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMetadataTotals(t *testing.T) {
+	if len(surahs) != 114 {
+		t.Fatalf("expected 114 surahs, got %d", len(surahs))
+	}
+	total := 0
+	for i, s := range surahs {
+		if s.Number != i+1 {
+			t.Fatalf("surah at position %d has number %d", i+1, s.Number)
+		}
+		total += s.Ayahs
+	}
+	if total != TotalAyahs {
+		t.Fatalf("expected %d ayahs, got %d", TotalAyahs, total)
+	}
+
+	sum := 0
+	for juz := 1; juz <= TotalJuz; juz++ {
+		first, last := JuzBounds(juz)
+		if last < first {
+			t.Fatalf("juz %d has invalid bounds %d-%d", juz, first, last)
+		}
+		sum += last - first + 1
+	}
+	if sum != TotalAyahs {
+		t.Fatalf("juz bounds cover %d ayahs, want %d", sum, TotalAyahs)
+	}
+}
+
+func TestIndexAndJuzOf(t *testing.T) {
+	tests := []struct {
+		surah, ayah, index, juz int
+	}{
+		{1, 1, 1, 1},
+		{2, 141, 148, 1},
+		{2, 142, 149, 2},
+		{78, 1, 5673, 30},
+		{114, 6, TotalAyahs, 30},
+	}
+	for _, tt := range tests {
+		index, err := Index(tt.surah, tt.ayah)
+		if err != nil {
+			t.Fatalf("Index(%d, %d): %v", tt.surah, tt.ayah, err)
+		}
+		if index != tt.index {
+			t.Errorf("Index(%d, %d) = %d, want %d", tt.surah, tt.ayah, index, tt.index)
+		}
+		if juz := JuzOf(index); juz != tt.juz {
+			t.Errorf("JuzOf(%d:%d) = %d, want %d", tt.surah, tt.ayah, juz, tt.juz)
+		}
+	}
+
+	if _, err := Index(1, 8); err == nil {
+		t.Error("expected error for ayah beyond surah length")
+	}
+	if _, err := Index(115, 1); err == nil {
+		t.Error("expected error for invalid surah")
+	}
+	if _, _, err := Range(2, 10, 2, 5); err == nil {
+		t.Error("expected error for reversed range")
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	var c Coverage
+
+	// Juz 30 lengkap: An-Naba' 1 sampai An-Nas 6
+	from, to, err := Range(78, 1, 114, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if added := c.Add(from, to); added != to-from+1 {
+		t.Fatalf("added %d ayahs, want %d", added, to-from+1)
+	}
+	// Rentang tumpang tindih tidak dihitung dua kali
+	if added := c.Add(from, from+10); added != 0 {
+		t.Fatalf("overlapping range added %d ayahs", added)
+	}
+
+	completed := c.CompletedJuz()
+	if len(completed) != 1 || completed[0] != 30 {
+		t.Fatalf("CompletedJuz() = %v, want [30]", completed)
+	}
+
+	// Setengah juz 29 (secara jumlah ayat)
+	first, last := JuzBounds(29)
+	half := (last - first + 1) / 2
+	c.Add(first, first+half-1)
+	covered, total := c.JuzAyahs(29)
+	if covered != half {
+		t.Fatalf("juz 29 covered %d, want %d", covered, half)
+	}
+	want := 1 + float64(half)/float64(total)
+	if got := c.JuzEquivalent(); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("JuzEquivalent() = %f, want %f", got, want)
+	}
+}
@@ -11,6 +11,7 @@ type AcademicYearRepository interface {
 	Create(academicYear *domain.AcademicYear) error
 	FindAll() ([]domain.AcademicYear, error)
 	FindByID(id string) (*domain.AcademicYear, error)
+	FindActive() (*domain.AcademicYear, error)
 	Update(academicYear *domain.AcademicYear) error
 	Delete(id string) error
 
@@ -47,6 +48,16 @@ func (r *academicYearRepository) FindByID(id string) (*domain.AcademicYear, erro
 	return &academicYear, err
 }
 
+// FindActive mengambil tahun ajaran yang sedang ACTIVE, nil jika belum ada
+func (r *academicYearRepository) FindActive() (*domain.AcademicYear, error) {
+	var academicYear domain.AcademicYear
+	err := r.db.First(&academicYear, "status = ?", "ACTIVE").Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &academicYear, err
+}
+
 func (r *academicYearRepository) Update(academicYear *domain.AcademicYear) error {
 	return r.db.Save(academicYear).Error
 }
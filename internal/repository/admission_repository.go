@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"smart_school_be/internal/model/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplicantFilter adalah filter daftar pendaftar untuk panitia PPDB
+type ApplicantFilter struct {
+	AdmissionWaveID string
+	Status          string
+	Search          string // Nama atau nomor pendaftaran
+}
+
+// ApplicantConversion adalah hasil konversi pendaftar menjadi siswa.
+// Record disimpan dengan cara yang sama seperti import siswa; ParentLinks memetakan
+// ID applicant_parents ke ID parents yang dibuat/ditautkan.
+type ApplicantConversion struct {
+	Record      StudentImportRecord
+	ParentLinks map[string]string
+}
+
+type AdmissionRepository interface {
+	// Admission Waves
+	CreateWave(wave *domain.AdmissionWave) error
+	FindWaveByID(id string) (*domain.AdmissionWave, error)
+	FindWaves(academicYearID string) ([]domain.AdmissionWave, error)
+	FindOpenWaves(now time.Time) ([]domain.AdmissionWave, error)
+	UpdateWave(wave *domain.AdmissionWave) error
+	DeleteWave(id string) error
+	CountApplicants(waveID string, statuses ...string) (int64, error)
+
+	// Applicants
+	// CreateApplicant mengalokasikan nomor pendaftaran <numberPrefix><urut 4 digit> di dalam transaksi penyimpanan
+	// yang sama dengan data pendaftar dan dokumennya
+	CreateApplicant(applicant *domain.Applicant, numberPrefix string, documents []*domain.Document) error
+	FindApplicantByID(id string) (*domain.Applicant, error)
+	FindApplicantByRegistrationNumber(number string) (*domain.Applicant, error)
+	FindPendingApplicantByNIKHash(waveID, hash string) (*domain.Applicant, error)
+	FindApplicants(filter ApplicantFilter, limit, offset int) ([]domain.Applicant, int64, error)
+	// UpdateStatus mengembalikan false (tanpa menyimpan) jika status accepted dan kuota gelombang sudah penuh
+	UpdateStatus(applicant *domain.Applicant, log *domain.ApplicantStatusLog) (bool, error)
+	Convert(applicant *domain.Applicant, conversion ApplicantConversion) error
+}
+
+type admissionRepository struct {
+	db *gorm.DB
+}
+
+func NewAdmissionRepository(db *gorm.DB) AdmissionRepository {
+	return &admissionRepository{db: db}
+}
+
+// --- Admission Waves ---
+
+func (r *admissionRepository) CreateWave(wave *domain.AdmissionWave) error {
+	return r.db.Omit(clause.Associations).Create(wave).Error
+}
+
+func (r *admissionRepository) FindWaveByID(id string) (*domain.AdmissionWave, error) {
+	var wave domain.AdmissionWave
+	err := r.db.Preload("AcademicYear").First(&wave, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &wave, err
+}
+
+func (r *admissionRepository) FindWaves(academicYearID string) ([]domain.AdmissionWave, error) {
+	var waves []domain.AdmissionWave
+	query := r.db.Preload("AcademicYear")
+	if academicYearID != "" {
+		query = query.Where("academic_year_id = ?", academicYearID)
+	}
+	err := query.Order("start_date DESC, name ASC").Find(&waves).Error
+	return waves, err
+}
+
+// FindOpenWaves mengembalikan gelombang yang sedang menerima pendaftaran
+func (r *admissionRepository) FindOpenWaves(now time.Time) ([]domain.AdmissionWave, error) {
+	var waves []domain.AdmissionWave
+	today := now.Format("2006-01-02")
+	err := r.db.Preload("AcademicYear").
+		Where("is_open = ? AND start_date <= ? AND end_date >= ?", true, today, today).
+		Order("start_date ASC, name ASC").
+		Find(&waves).Error
+	return waves, err
+}
+
+func (r *admissionRepository) UpdateWave(wave *domain.AdmissionWave) error {
+	return r.db.Omit(clause.Associations).Save(wave).Error
+}
+
+func (r *admissionRepository) DeleteWave(id string) error {
+	return r.db.Delete(&domain.AdmissionWave{}, "id = ?", id).Error
+}
+
+// CountApplicants menghitung pendaftar di satu gelombang, opsional dibatasi status tertentu
+func (r *admissionRepository) CountApplicants(waveID string, statuses ...string) (int64, error) {
+	var count int64
+	query := r.db.Model(&domain.Applicant{}).Where("admission_wave_id = ?", waveID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// --- Applicants ---
+
+// CreateApplicant menyimpan pendaftar, orang tuanya, log status awal dan dokumen upload dalam satu
+// transaksi, sehingga kegagalan di tengah tidak meninggalkan pendaftar tanpa dokumen.
+// Nomor pendaftaran diambil dari registration_counters yang dikunci sampai transaksi selesai.
+func (r *admissionRepository) CreateApplicant(applicant *domain.Applicant, numberPrefix string, documents []*domain.Document) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		seq, err := nextRegistrationSequence(tx, numberPrefix)
+		if err != nil {
+			return err
+		}
+		applicant.RegistrationNumber = fmt.Sprintf("%s%04d", numberPrefix, seq)
+
+		if err := tx.Omit(clause.Associations).Create(applicant).Error; err != nil {
+			return err
+		}
+		for i := range applicant.Parents {
+			applicant.Parents[i].ApplicantID = applicant.ID
+		}
+		if len(applicant.Parents) > 0 {
+			if err := tx.Create(&applicant.Parents).Error; err != nil {
+				return err
+			}
+		}
+		log := domain.ApplicantStatusLog{ApplicantID: applicant.ID, ToStatus: applicant.Status}
+		if err := tx.Create(&log).Error; err != nil {
+			return err
+		}
+
+		// Pendaftar baru, jadi setiap dokumen adalah versi pertama
+		for _, doc := range documents {
+			doc.OwnerID = applicant.ID
+			doc.Version = 1
+			doc.IsCurrent = true
+			if err := tx.Omit(clause.Associations).Create(doc).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *admissionRepository) FindApplicantByID(id string) (*domain.Applicant, error) {
+	var applicant domain.Applicant
+	err := r.db.Preload("AdmissionWave.AcademicYear").
+		Preload("Parents", func(db *gorm.DB) *gorm.DB {
+			return db.Order("relationship_type ASC")
+		}).
+		Preload("StatusLogs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&applicant, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &applicant, err
+}
+
+func (r *admissionRepository) FindApplicantByRegistrationNumber(number string) (*domain.Applicant, error) {
+	var applicant domain.Applicant
+	err := r.db.Preload("AdmissionWave").First(&applicant, "registration_number = ?", number).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &applicant, err
+}
+
+// FindPendingApplicantByNIKHash mencari pendaftaran yang belum ditolak dengan NIK yang sama di satu gelombang
+func (r *admissionRepository) FindPendingApplicantByNIKHash(waveID, hash string) (*domain.Applicant, error) {
+	var applicant domain.Applicant
+	err := r.db.Where("admission_wave_id = ? AND nik_hash = ? AND status <> ?", waveID, hash, domain.ApplicantStatusRejected).
+		First(&applicant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &applicant, err
+}
+
+func (r *admissionRepository) FindApplicants(filter ApplicantFilter, limit, offset int) ([]domain.Applicant, int64, error) {
+	var applicants []domain.Applicant
+	var total int64
+
+	query := r.db.Model(&domain.Applicant{})
+	if filter.AdmissionWaveID != "" {
+		query = query.Where("admission_wave_id = ?", filter.AdmissionWaveID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("full_name LIKE ? OR registration_number LIKE ?", search, search)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("AdmissionWave").
+		Order("created_at ASC, registration_number ASC").
+		Limit(limit).Offset(offset).
+		Find(&applicants).Error
+	return applicants, total, err
+}
+
+// nextRegistrationSequence menaikkan counter prefix dengan SELECT ... FOR UPDATE sehingga
+// pendaftaran bersamaan menunggu giliran dan tidak mendapat nomor yang sama
+func nextRegistrationSequence(tx *gorm.DB, prefix string) (int, error) {
+	var count int64
+	if err := tx.Model(&domain.RegistrationCounter{}).Where("prefix = ?", prefix).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		// Counter baru dimulai dari nomor terbesar yang sudah ada. Jika transaksi lain membuat baris
+		// yang sama bersamaan, insert ini menunggu lalu tidak menyisipkan apa-apa.
+		last, err := lastRegistrationSequence(tx, prefix)
+		if err != nil {
+			return 0, err
+		}
+		counter := domain.RegistrationCounter{Prefix: prefix, LastNumber: last}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&counter).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	var counter domain.RegistrationCounter
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&counter, "prefix = ?", prefix).Error; err != nil {
+		return 0, err
+	}
+	counter.LastNumber++
+	err := tx.Model(&domain.RegistrationCounter{}).Where("prefix = ?", prefix).
+		Update("last_number", counter.LastNumber).Error
+	return counter.LastNumber, err
+}
+
+// lastRegistrationSequence mengembalikan nomor urut terbesar dengan prefix tertentu (0 jika belum ada).
+// Diurutkan berdasarkan panjang dulu agar nomor di atas 9999 tetap dianggap lebih besar.
+func lastRegistrationSequence(tx *gorm.DB, prefix string) (int, error) {
+	var numbers []string
+	err := tx.Model(&domain.Applicant{}).
+		Where("registration_number LIKE ?", prefix+"%").
+		Order("LENGTH(registration_number) DESC, registration_number DESC").
+		Limit(1).
+		Pluck("registration_number", &numbers).Error
+	if err != nil || len(numbers) == 0 {
+		return 0, err
+	}
+	seq := 0
+	fmt.Sscanf(strings.TrimPrefix(numbers[0], prefix), "%d", &seq)
+	return seq, nil
+}
+
+// UpdateStatus menyimpan status (beserta nilai tes/catatan) dan riwayatnya dalam satu transaksi
+// Untuk status accepted, baris gelombang dikunci lalu kuota dihitung ulang di dalam transaksi agar
+// dua panitia yang menerima bersamaan tidak melewati kuota.
+func (r *admissionRepository) UpdateStatus(applicant *domain.Applicant, log *domain.ApplicantStatusLog) (bool, error) {
+	saved := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if applicant.Status == domain.ApplicantStatusAccepted {
+			var wave domain.AdmissionWave
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wave, "id = ?", applicant.AdmissionWaveID).Error
+			if err != nil {
+				return err
+			}
+			if wave.Quota > 0 {
+				var accepted int64
+				err := tx.Model(&domain.Applicant{}).
+					Where("admission_wave_id = ? AND status = ? AND id <> ?", wave.ID, domain.ApplicantStatusAccepted, applicant.ID).
+					Count(&accepted).Error
+				if err != nil {
+					return err
+				}
+				if accepted >= int64(wave.Quota) {
+					return nil
+				}
+			}
+		}
+
+		err := tx.Model(&domain.Applicant{}).Where("id = ?", applicant.ID).
+			Select("status", "test_score", "notes").
+			Updates(map[string]interface{}{
+				"status":     applicant.Status,
+				"test_score": applicant.TestScore,
+				"notes":      applicant.Notes,
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(log).Error; err != nil {
+			return err
+		}
+		saved = true
+		return nil
+	})
+	return saved, err
+}
+
+// Convert membuat siswa, orang tua baru, relasi orang tua dan penempatan kelas, lalu memindahkan
+// dokumen pendaftar ke siswa dan menandai pendaftar sudah dikonversi. Semua dalam satu transaksi.
+func (r *admissionRepository) Convert(applicant *domain.Applicant, conversion ApplicantConversion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		record := conversion.Record
+		for _, parent := range record.NewParents {
+			if err := tx.Create(parent).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := assignHousehold(tx, record.Student); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(record.Student).Error; err != nil {
+			return err
+		}
+
+		if len(record.Parents) > 0 {
+			if err := tx.Create(&record.Parents).Error; err != nil {
+				return err
+			}
+		}
+
+		if record.ClassroomID != "" {
+			placement := domain.StudentClassroom{
+				ClassroomID: record.ClassroomID,
+				StudentID:   record.Student.ID,
+				Status:      "ACTIVE",
+			}
+			if err := tx.Create(&placement).Error; err != nil {
+				return err
+			}
+		}
+
+		for applicantParentID, parentID := range conversion.ParentLinks {
+			err := tx.Model(&domain.ApplicantParent{}).Where("id = ?", applicantParentID).
+				Update("parent_id", parentID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err := tx.Unscoped().Model(&domain.Document{}).
+			Where("owner_type = ? AND owner_id = ?", domain.DocumentOwnerApplicant, applicant.ID).
+			Updates(map[string]interface{}{
+				"owner_type": domain.DocumentOwnerStudent,
+				"owner_id":   record.Student.ID,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		err = tx.Model(&domain.Applicant{}).Where("id = ?", applicant.ID).
+			Updates(map[string]interface{}{
+				"student_id":   record.Student.ID,
+				"converted_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		applicant.StudentID = &record.Student.ID
+		applicant.ConvertedAt = &now
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"testing"
+
+	"smart_school_be/internal/model/domain"
+)
+
+var registrationDDL = []string{
+	`CREATE TABLE applicants (id TEXT PRIMARY KEY, registration_number TEXT NOT NULL UNIQUE)`,
+	`CREATE TABLE registration_counters (prefix TEXT PRIMARY KEY, last_number INTEGER NOT NULL DEFAULT 0, updated_at DATETIME)`,
+}
+
+func TestNextRegistrationSequenceSeedsFromExistingApplicants(t *testing.T) {
+	db := newTestDB(t, registrationDDL...)
+	// "PPDB-2026-9999" lebih besar dari "PPDB-2026-10000" jika dibandingkan sebagai string
+	for i, number := range []string{"PPDB-2026-9999", "PPDB-2026-10000", "PPDB-2025-0042"} {
+		if err := db.Exec("INSERT INTO applicants (id, registration_number) VALUES (?, ?)", i, number).Error; err != nil {
+			t.Fatalf("seed applicant: %v", err)
+		}
+	}
+
+	seq, err := nextRegistrationSequence(db, "PPDB-2026-")
+	if err != nil {
+		t.Fatalf("next sequence: %v", err)
+	}
+	if seq != 10001 {
+		t.Errorf("seq = %d, want 10001", seq)
+	}
+
+	seq, err = nextRegistrationSequence(db, "PPDB-2026-")
+	if err != nil || seq != 10002 {
+		t.Errorf("second seq = %d, %v; want 10002", seq, err)
+	}
+
+	var counter domain.RegistrationCounter
+	if err := db.First(&counter, "prefix = ?", "PPDB-2026-").Error; err != nil {
+		t.Fatalf("load counter: %v", err)
+	}
+	if counter.LastNumber != 10002 {
+		t.Errorf("counter = %d, want 10002", counter.LastNumber)
+	}
+}
+
+func TestNextRegistrationSequenceStartsAtOne(t *testing.T) {
+	db := newTestDB(t, registrationDDL...)
+
+	seq, err := nextRegistrationSequence(db, "PPDB-2027-")
+	if err != nil || seq != 1 {
+		t.Errorf("seq = %d, %v; want 1", seq, err)
+	}
+}
+
+func TestUpdateStatusRechecksQuota(t *testing.T) {
+	db := newTestDB(t,
+		`CREATE TABLE admission_waves (id TEXT PRIMARY KEY, name TEXT, quota INTEGER NOT NULL DEFAULT 0)`,
+		`CREATE TABLE applicants (id TEXT PRIMARY KEY, admission_wave_id TEXT, status TEXT, test_score REAL, notes TEXT, updated_at DATETIME)`,
+		`CREATE TABLE applicant_status_logs (id TEXT PRIMARY KEY, applicant_id TEXT, from_status TEXT, to_status TEXT, notes TEXT, created_by TEXT, created_at DATETIME)`,
+		`INSERT INTO admission_waves (id, name, quota) VALUES ('w1', 'Gelombang 1', 1)`,
+		`INSERT INTO applicants (id, admission_wave_id, status) VALUES ('a1', 'w1', 'accepted'), ('a2', 'w1', 'tested')`,
+	)
+	repo := NewAdmissionRepository(db)
+
+	applicant := &domain.Applicant{ID: "a2", AdmissionWaveID: "w1", Status: domain.ApplicantStatusAccepted}
+	saved, err := repo.UpdateStatus(applicant, &domain.ApplicantStatusLog{ApplicantID: "a2", ToStatus: domain.ApplicantStatusAccepted})
+	if err != nil {
+		t.Fatalf("update status: %v", err)
+	}
+	if saved {
+		t.Fatal("accepting over the quota must not be saved")
+	}
+
+	var status string
+	db.Table("applicants").Where("id = ?", "a2").Pluck("status", &status)
+	if status != domain.ApplicantStatusTested {
+		t.Errorf("status = %q, want %q", status, domain.ApplicantStatusTested)
+	}
+	var logs int64
+	db.Table("applicant_status_logs").Count(&logs)
+	if logs != 0 {
+		t.Errorf("expected no status log, got %d", logs)
+	}
+
+	// Kuota dinaikkan: penerimaan berikutnya tersimpan
+	db.Exec(`UPDATE admission_waves SET quota = 2 WHERE id = 'w1'`)
+	saved, err = repo.UpdateStatus(applicant, &domain.ApplicantStatusLog{ApplicantID: "a2", ToStatus: domain.ApplicantStatusAccepted})
+	if err != nil || !saved {
+		t.Fatalf("accept within quota = %v, %v; want saved", saved, err)
+	}
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AnnouncementRepository interface {
+	Create(announcement *domain.Announcement) error
+	FindByID(id string) (*domain.Announcement, error)
+	FindAll(classroomID string, limit, offset int) ([]domain.Announcement, int64, error)
+	Update(announcement *domain.Announcement) error
+	Delete(id string) error
+	// FindVisible mengambil pengumuman yang sedang tayang untuk seluruh sekolah atau kelas-kelas tertentu
+	FindVisible(classroomIDs []string, now time.Time, limit, offset int) ([]domain.Announcement, int64, error)
+}
+
+type announcementRepository struct {
+	db *gorm.DB
+}
+
+func NewAnnouncementRepository(db *gorm.DB) AnnouncementRepository {
+	return &announcementRepository{db: db}
+}
+
+func (r *announcementRepository) Create(announcement *domain.Announcement) error {
+	return r.db.Omit("Classroom").Create(announcement).Error
+}
+
+func (r *announcementRepository) FindByID(id string) (*domain.Announcement, error) {
+	var announcement domain.Announcement
+	err := r.db.Preload("Classroom").First(&announcement, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &announcement, err
+}
+
+func (r *announcementRepository) FindAll(classroomID string, limit, offset int) ([]domain.Announcement, int64, error) {
+	var announcements []domain.Announcement
+	var total int64
+
+	query := r.db.Model(&domain.Announcement{})
+	if classroomID != "" {
+		query = query.Where("classroom_id = ?", classroomID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Classroom").
+		Order("published_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&announcements).Error
+	return announcements, total, err
+}
+
+func (r *announcementRepository) Update(announcement *domain.Announcement) error {
+	return r.db.Omit("Classroom").Save(announcement).Error
+}
+
+func (r *announcementRepository) Delete(id string) error {
+	return r.db.Delete(&domain.Announcement{}, "id = ?", id).Error
+}
+
+func (r *announcementRepository) FindVisible(classroomIDs []string, now time.Time, limit, offset int) ([]domain.Announcement, int64, error) {
+	var announcements []domain.Announcement
+	var total int64
+
+	query := r.db.Model(&domain.Announcement{}).
+		Where("published_at <= ? AND (expires_at IS NULL OR expires_at > ?)", now, now)
+	if len(classroomIDs) > 0 {
+		query = query.Where("(classroom_id IS NULL OR classroom_id IN ?)", classroomIDs)
+	} else {
+		query = query.Where("classroom_id IS NULL")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Classroom").
+		Order("published_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&announcements).Error
+	return announcements, total, err
+}
@@ -16,6 +16,18 @@ type AttendanceRepository interface {
 	// Update logic jika guru ingin mengedit absen
 	UpdateSession(session *domain.AttendanceSession, newDetails []domain.AttendanceDetail) error
 	DeleteSession(id string) error
+	// FindClinicSentHome mengembalikan siswa yang dipulangkan UKS dengan pilihan tandai SICK
+	// pada tanggal tsb sebelum jam endTime
+	FindClinicSentHome(date time.Time, endTime string, studentIDs []string) (map[string]bool, error)
+	// FindStudentSessions mengambil sesi absensi yang memuat siswa, Details hanya berisi baris milik siswa tsb
+	FindStudentSessions(studentID string, dateFrom, dateTo *time.Time, limit, offset int) ([]domain.AttendanceSession, int64, error)
+	// FindOnLeave mengembalikan siswa yang sedang izin pulang asrama di rentang jam pelajaran tsb.
+	// Izin yang sudah keluar tapi belum kembali dianggap berlanjut sampai sekarang.
+	FindOnLeave(date time.Time, startTime, endTime string, studentIDs []string) (map[string]bool, error)
+	// FindAbsentStudents mengembalikan siswa berstatus ABSENT pada tanggal tsb beserta jumlah sesinya
+	FindAbsentStudents(date time.Time) (map[string]int, error)
+	// FindSessionDates mengambil sesi (tanpa detail) jadwal-jadwal tsb di rentang tanggal, untuk menghitung realisasi mengajar
+	FindSessionDates(scheduleIDs []string, from, to time.Time) ([]domain.AttendanceSession, error)
 }
 
 type attendanceRepository struct {
@@ -141,3 +153,107 @@ func (r *attendanceRepository) UpdateSession(session *domain.AttendanceSession,
 func (r *attendanceRepository) DeleteSession(id string) error {
 	return r.db.Delete(&domain.AttendanceSession{}, "id = ?", id).Error
 }
+
+func (r *attendanceRepository) FindStudentSessions(studentID string, dateFrom, dateTo *time.Time, limit, offset int) ([]domain.AttendanceSession, int64, error) {
+	var sessions []domain.AttendanceSession
+	var total int64
+
+	query := r.db.Model(&domain.AttendanceSession{}).
+		Joins("JOIN attendance_details ad ON ad.attendance_session_id = attendance_sessions.id AND ad.student_id = ?", studentID)
+	if dateFrom != nil {
+		query = query.Where("attendance_sessions.date >= ?", dateFrom.Format("2006-01-02"))
+	}
+	if dateTo != nil {
+		query = query.Where("attendance_sessions.date <= ?", dateTo.Format("2006-01-02"))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Schedule.TeachingAssignment.Subject").
+		Preload("Details", "student_id = ?", studentID).
+		Order("attendance_sessions.date DESC").
+		Limit(limit).Offset(offset).
+		Find(&sessions).Error
+	return sessions, total, err
+}
+
+func (r *attendanceRepository) FindOnLeave(date time.Time, startTime, endTime string, studentIDs []string) (map[string]bool, error) {
+	onLeave := map[string]bool{}
+	if len(studentIDs) == 0 {
+		return onLeave, nil
+	}
+
+	day := date.Format("2006-01-02")
+	var ids []string
+	err := r.db.Model(&domain.LeavePermit{}).
+		Where("student_id IN ? AND status IN ?", studentIDs,
+			[]string{domain.LeavePermitApproved, domain.LeavePermitCheckedOut, domain.LeavePermitReturned}).
+		Where("COALESCE(checked_out_at, departure_at) < TIMESTAMP(?, ?)", day, endTime).
+		Where("COALESCE(checked_in_at, IF(status = ?, GREATEST(expected_return_at, ?), expected_return_at)) > TIMESTAMP(?, ?)",
+			domain.LeavePermitCheckedOut, time.Now(), day, startTime).
+		Distinct().
+		Pluck("student_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		onLeave[id] = true
+	}
+	return onLeave, nil
+}
+
+func (r *attendanceRepository) FindClinicSentHome(date time.Time, endTime string, studentIDs []string) (map[string]bool, error) {
+	sentHome := map[string]bool{}
+	if len(studentIDs) == 0 {
+		return sentHome, nil
+	}
+
+	var ids []string
+	err := r.db.Model(&domain.ClinicVisit{}).
+		Where("student_id IN ? AND outcome = ? AND mark_attendance_sick = ? AND DATE(visited_at) = ? AND TIME(visited_at) < ?",
+			studentIDs, domain.ClinicOutcomeSentHome, true, date.Format("2006-01-02"), endTime).
+		Distinct().
+		Pluck("student_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		sentHome[id] = true
+	}
+	return sentHome, nil
+}
+
+func (r *attendanceRepository) FindAbsentStudents(date time.Time) (map[string]int, error) {
+	var rows []struct {
+		StudentID string
+		Sessions  int
+	}
+	err := r.db.Table("attendance_details ad").
+		Select("ad.student_id, COUNT(*) AS sessions").
+		Joins("JOIN attendance_sessions s ON s.id = ad.attendance_session_id").
+		Where("s.date = ? AND ad.status = ?", date.Format("2006-01-02"), "ABSENT").
+		Group("ad.student_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	absent := make(map[string]int, len(rows))
+	for _, row := range rows {
+		absent[row.StudentID] = row.Sessions
+	}
+	return absent, nil
+}
+
+func (r *attendanceRepository) FindSessionDates(scheduleIDs []string, from, to time.Time) ([]domain.AttendanceSession, error) {
+	var sessions []domain.AttendanceSession
+	if len(scheduleIDs) == 0 {
+		return sessions, nil
+	}
+	err := r.db.Select("id", "schedule_id", "date").
+		Where("schedule_id IN ? AND date BETWEEN ? AND ?", scheduleIDs, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Find(&sessions).Error
+	return sessions, err
+}
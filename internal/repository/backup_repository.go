@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BackupTable mendeskripsikan satu tabel yang ikut di arsip backup
+type BackupTable struct {
+	Name       string
+	RefColumns []string // Kolom berisi ID tabel lain yang harus ikut di-remap
+	KeyColumns []string // Primary key komposit untuk tabel pivot (tanpa kolom id)
+	NaturalKey string   // Kolom unik yang dipakai untuk menggabungkan data saat remap (mis. roles.name)
+}
+
+// BackupTables adalah daftar tabel dalam urutan restore (induk sebelum anak).
+// Tambahkan tabel baru di sini setiap kali ada migration yang membuat tabel.
+var BackupTables = []BackupTable{
+	{Name: "users", NaturalKey: "username"},
+	{Name: "roles", NaturalKey: "name"},
+	{Name: "permissions", NaturalKey: "name"},
+	{Name: "role_permission", KeyColumns: []string{"role_id", "permission_id"}, RefColumns: []string{"role_id", "permission_id"}},
+	{Name: "user_role", KeyColumns: []string{"user_id", "role_id"}, RefColumns: []string{"user_id", "role_id"}},
+	{Name: "user_permission", KeyColumns: []string{"user_id", "permission_id"}, RefColumns: []string{"user_id", "permission_id"}},
+	{Name: "academic_years"},
+	{Name: "work_shifts", NaturalKey: "name"},
+	{Name: "employees", RefColumns: []string{"user_id", "work_shift_id"}},
+	{Name: "subjects", NaturalKey: "code"},
+	{Name: "parents", RefColumns: []string{"user_id"}},
+	{Name: "guardians", RefColumns: []string{"user_id"}},
+	{Name: "households", NaturalKey: "no_kk_hash"},
+	// guardian_id/primary_contact_id bisa menunjuk ke parents atau guardians; UUID unik global jadi cukup satu peta ID
+	{Name: "students", RefColumns: []string{"user_id", "guardian_id", "primary_contact_id", "household_id"}},
+	{Name: "student_parent", KeyColumns: []string{"student_id", "parent_id"}, RefColumns: []string{"student_id", "parent_id"}},
+	{Name: "classrooms", RefColumns: []string{"academic_year_id", "homeroom_teacher_id"}},
+	{Name: "student_classrooms", RefColumns: []string{"classroom_id", "student_id"}},
+	{Name: "teaching_assignments", RefColumns: []string{"classroom_id", "subject_id", "teacher_id"}},
+	{Name: "schedules", RefColumns: []string{"teaching_assignment_id"}},
+	{Name: "attendance_sessions", RefColumns: []string{"schedule_id"}},
+	{Name: "attendance_details", RefColumns: []string{"attendance_session_id", "student_id"}},
+	{Name: "assessments", RefColumns: []string{"teaching_assignment_id"}},
+	{Name: "student_scores", RefColumns: []string{"assessment_id", "student_id"}},
+	{Name: "violation_categories"},
+	{Name: "violation_types", RefColumns: []string{"category_id"}},
+	{Name: "student_violations", RefColumns: []string{"student_id", "violation_type_id"}},
+	{Name: "student_health_profiles", RefColumns: []string{"student_id", "updated_by"}},
+	{Name: "clinic_visits", RefColumns: []string{"student_id", "handled_by"}},
+	{Name: "dormitories", NaturalKey: "name"},
+	{Name: "dorm_rooms", RefColumns: []string{"dormitory_id", "musyrif_id"}},
+	{Name: "dorm_assignments", RefColumns: []string{"dorm_room_id", "student_id", "academic_year_id", "created_by"}},
+	{Name: "tahfidz_sessions", RefColumns: []string{"student_id", "academic_year_id", "examiner_id", "created_by"}},
+	{Name: "tahfidz_targets", RefColumns: []string{"student_id", "academic_year_id"}},
+	{Name: "announcements", RefColumns: []string{"classroom_id", "created_by"}},
+	{Name: "leave_permits", RefColumns: []string{"student_id", "academic_year_id", "requested_by", "guardian_confirmed_by", "reviewed_by", "checked_out_by", "checked_in_by"}},
+	{Name: "contact_preferences", RefColumns: []string{"contact_id"}},
+	{Name: "staff_attendances", RefColumns: []string{"employee_id", "work_shift_id", "recorded_by"}},
+	{Name: "staff_leaves", RefColumns: []string{"employee_id", "recorded_by"}},
+	{Name: "finance_donors"},
+	{Name: "finance_donations", RefColumns: []string{"donor_id", "employee_id"}},
+	{Name: "finance_donation_items", RefColumns: []string{"donation_id"}},
+	// Pendaftar sebelum documents karena dokumen pendaftar memakai owner_id applicants
+	{Name: "admission_waves", RefColumns: []string{"academic_year_id"}},
+	{Name: "applicants", RefColumns: []string{"admission_wave_id", "student_id"}},
+	{Name: "applicant_parents", RefColumns: []string{"applicant_id", "parent_id"}},
+	{Name: "applicant_status_logs", RefColumns: []string{"applicant_id", "created_by"}},
+	{Name: "registration_counters", KeyColumns: []string{"prefix"}},
+	{Name: "document_types"},
+	// owner_id menunjuk ke students, employees atau applicants (polimorfik)
+	{Name: "documents", RefColumns: []string{"document_type_id", "owner_id", "uploaded_by", "verified_by"}},
+	{Name: "student_mutations", RefColumns: []string{"student_id", "classroom_id", "created_by"}},
+	{Name: "student_mutation_documents", KeyColumns: []string{"student_mutation_id", "document_id"}, RefColumns: []string{"student_mutation_id", "document_id"}},
+}
+
+type BackupRepository interface {
+	SchemaVersion() (uint, bool, error)
+	Snapshot(fn func(repo BackupRepository) error) error
+	Transaction(fn func(repo BackupRepository) error) error
+	StreamRows(table string, fn func(row map[string]interface{}) error) error
+	ExistingIDs(table string, ids []string) (map[string]bool, error)
+	FindIDsByNaturalKey(table, column string, values []interface{}) (map[string]string, error)
+	InsertRows(table BackupTable, rows []map[string]interface{}) error
+}
+
+type backupRepository struct {
+	db *gorm.DB
+}
+
+func NewBackupRepository(db *gorm.DB) BackupRepository {
+	return &backupRepository{db: db}
+}
+
+// SchemaVersion membaca versi migrasi dan flag dirty dari tabel schema_migrations milik golang-migrate
+func (r *backupRepository) SchemaVersion() (uint, bool, error) {
+	var row struct {
+		Version uint
+		Dirty   bool
+	}
+	err := r.db.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row).Error
+	return row.Version, row.Dirty, err
+}
+
+// Snapshot menjalankan fn di dalam transaksi read-only REPEATABLE READ
+// sehingga semua tabel dibaca dari snapshot yang sama.
+func (r *backupRepository) Snapshot(fn func(repo BackupRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&backupRepository{db: tx})
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+func (r *backupRepository) Transaction(fn func(repo BackupRepository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&backupRepository{db: tx})
+	})
+}
+
+// StreamRows membaca seluruh baris tabel (termasuk yang di-soft delete) satu per satu
+func (r *backupRepository) StreamRows(table string, fn func(row map[string]interface{}) error) error {
+	rows, err := r.db.Table(table).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := make(map[string]interface{})
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *backupRepository) ExistingIDs(table string, ids []string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	var found []string
+	if err := r.db.Table(table).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range found {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// FindIDsByNaturalKey mengembalikan peta nilai natural key -> id yang sudah ada di database
+func (r *backupRepository) FindIDsByNaturalKey(table, column string, values []interface{}) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(values) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		ID  string
+		Key string
+	}
+	err := r.db.Table(table).
+		Select("id, "+column+" AS `key`").
+		Where(column+" IN ?", values).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.Key] = row.ID
+	}
+	return result, nil
+}
+
+// InsertRows menyisipkan baris mentah. Tabel pivot memakai ON DUPLICATE KEY agar
+// relasi yang sudah ada tidak membatalkan restore.
+func (r *backupRepository) InsertRows(table BackupTable, rows []map[string]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	query := r.db.Table(table.Name)
+	if len(table.KeyColumns) > 0 {
+		query = query.Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns(table.KeyColumns[:1])})
+	}
+	return query.Create(&rows).Error
+}
@@ -13,6 +13,9 @@ type ClassroomRepository interface {
 	FindByID(id string) (*domain.Classroom, error)
 	Update(classroom *domain.Classroom) error
 	Delete(id string) error
+	// CountDependents menghitung teaching assignment dan riwayat siswa di kelas. Keduanya ikut
+	// terhapus (beserta jadwal, absensi dan nilai) lewat ON DELETE CASCADE saat kelas di-purge.
+	CountDependents(id string) (assignments int64, students int64, err error)
 
 	// Student Management
 	AddStudents(studentClassrooms []domain.StudentClassroom) error
@@ -37,7 +40,7 @@ func (r *classroomRepository) FindAll(academicYearID string) ([]domain.Classroom
 
 	// Query ini akan otomatis mengisi field TotalStudents di struct domain karena namanya cocok
 	query := r.db.Preload("AcademicYear").Preload("HomeroomTeacher").
-		Select("classrooms.*, (SELECT COUNT(*) FROM student_classrooms WHERE student_classrooms.classroom_id = classrooms.id AND student_classrooms.status = 'ACTIVE') as total_students")
+		Select("classrooms.*, (SELECT COUNT(*) FROM student_classrooms JOIN students ON students.id = student_classrooms.student_id AND students.deleted_at IS NULL WHERE student_classrooms.classroom_id = classrooms.id AND student_classrooms.status = 'ACTIVE') as total_students")
 
 	if academicYearID != "" {
 		query = query.Where("academic_year_id = ?", academicYearID)
@@ -51,7 +54,8 @@ func (r *classroomRepository) FindByID(id string) (*domain.Classroom, error) {
 	var classroom domain.Classroom
 	err := r.db.Preload("AcademicYear").
 		Preload("HomeroomTeacher").
-		Preload("StudentClassrooms").         // Load Pivot
+		// Load Pivot, kecuali siswa yang ada di trash
+		Preload("StudentClassrooms", "student_id IN (SELECT id FROM students WHERE deleted_at IS NULL)").
 		Preload("StudentClassrooms.Student"). // Load Student Data
 		First(&classroom, "id = ?", id).Error
 
@@ -69,6 +73,15 @@ func (r *classroomRepository) Delete(id string) error {
 	return r.db.Delete(&domain.Classroom{}, "id = ?", id).Error
 }
 
+func (r *classroomRepository) CountDependents(id string) (int64, int64, error) {
+	var assignments, students int64
+	if err := r.db.Model(&domain.TeachingAssignment{}).Where("classroom_id = ?", id).Count(&assignments).Error; err != nil {
+		return 0, 0, err
+	}
+	err := r.db.Model(&domain.StudentClassroom{}).Where("classroom_id = ?", id).Count(&students).Error
+	return assignments, students, err
+}
+
 func (r *classroomRepository) AddStudents(studentClassrooms []domain.StudentClassroom) error {
 	return r.db.Create(&studentClassrooms).Error
 }
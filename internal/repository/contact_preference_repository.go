@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type ContactPreferenceRepository interface {
+	FindByContact(contactType, contactID string) (*domain.ContactPreference, error)
+	// FindByContacts mengambil preferensi banyak kontak sekaligus, key: "<contact_type>:<contact_id>"
+	FindByContacts(parentIDs, guardianIDs []string) (map[string]domain.ContactPreference, error)
+	Save(preference *domain.ContactPreference) error
+}
+
+type contactPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewContactPreferenceRepository(db *gorm.DB) ContactPreferenceRepository {
+	return &contactPreferenceRepository{db: db}
+}
+
+func (r *contactPreferenceRepository) FindByContact(contactType, contactID string) (*domain.ContactPreference, error) {
+	var preference domain.ContactPreference
+	err := r.db.First(&preference, "contact_type = ? AND contact_id = ?", contactType, contactID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *contactPreferenceRepository) FindByContacts(parentIDs, guardianIDs []string) (map[string]domain.ContactPreference, error) {
+	result := map[string]domain.ContactPreference{}
+	if len(parentIDs) == 0 && len(guardianIDs) == 0 {
+		return result, nil
+	}
+
+	var preferences []domain.ContactPreference
+	query := r.db.Where("1 = 0")
+	if len(parentIDs) > 0 {
+		query = query.Or("contact_type = ? AND contact_id IN ?", "parent", parentIDs)
+	}
+	if len(guardianIDs) > 0 {
+		query = query.Or("contact_type = ? AND contact_id IN ?", "guardian", guardianIDs)
+	}
+	if err := query.Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	for _, preference := range preferences {
+		result[preference.ContactType+":"+preference.ContactID] = preference
+	}
+	return result, nil
+}
+
+func (r *contactPreferenceRepository) Save(preference *domain.ContactPreference) error {
+	return r.db.Save(preference).Error
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DocumentRepository interface {
+	// Document Types
+	CreateType(docType *domain.DocumentType) error
+	FindTypeByID(id string) (*domain.DocumentType, error)
+	FindTypeByCode(ownerType, code string) (*domain.DocumentType, error) // Termasuk yang ada di trash
+	FindTypes(ownerType string) ([]domain.DocumentType, error)
+	UpdateType(docType *domain.DocumentType) error
+	DeleteType(id string) error
+	// RestoreType mengeluarkan jenis dokumen dari trash sekaligus menyimpan field barunya
+	RestoreType(docType *domain.DocumentType) error
+
+	// Documents
+	CreateVersion(doc *domain.Document) error
+	FindByID(id string) (*domain.Document, error)
+	FindCurrentByOwner(ownerType, ownerID string) ([]domain.Document, error)
+	FindCurrentByOwners(ownerType string, ownerIDs []string) ([]domain.Document, error)
+	FindHistory(ownerType, ownerID, documentTypeID string) ([]domain.Document, error)
+	Update(doc *domain.Document) error
+	Delete(doc *domain.Document) error
+}
+
+type documentRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentRepository(db *gorm.DB) DocumentRepository {
+	return &documentRepository{db: db}
+}
+
+// --- Document Types ---
+
+func (r *documentRepository) CreateType(docType *domain.DocumentType) error {
+	return r.db.Create(docType).Error
+}
+
+func (r *documentRepository) FindTypeByID(id string) (*domain.DocumentType, error) {
+	var docType domain.DocumentType
+	err := r.db.First(&docType, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &docType, err
+}
+
+func (r *documentRepository) FindTypeByCode(ownerType, code string) (*domain.DocumentType, error) {
+	var docType domain.DocumentType
+	err := r.db.Unscoped().Where("owner_type = ? AND code = ?", ownerType, code).First(&docType).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &docType, err
+}
+
+func (r *documentRepository) FindTypes(ownerType string) ([]domain.DocumentType, error) {
+	var types []domain.DocumentType
+	query := r.db.Model(&domain.DocumentType{})
+	if ownerType != "" {
+		query = query.Where("owner_type = ?", ownerType)
+	}
+	err := query.Order("owner_type ASC, is_required DESC, name ASC").Find(&types).Error
+	return types, err
+}
+
+func (r *documentRepository) UpdateType(docType *domain.DocumentType) error {
+	return r.db.Save(docType).Error
+}
+
+func (r *documentRepository) DeleteType(id string) error {
+	return r.db.Delete(&domain.DocumentType{}, "id = ?", id).Error
+}
+
+func (r *documentRepository) RestoreType(docType *domain.DocumentType) error {
+	docType.DeletedAt = gorm.DeletedAt{}
+	return r.db.Unscoped().Save(docType).Error
+}
+
+// --- Documents ---
+
+// CreateVersion menyimpan upload baru sebagai versi terbaru: versi sebelumnya untuk
+// pemilik dan jenis dokumen yang sama ditandai is_current = false.
+func (r *documentRepository) CreateVersion(doc *domain.Document) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var previous []domain.Document
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("owner_type = ? AND owner_id = ? AND document_type_id = ?", doc.OwnerType, doc.OwnerID, doc.DocumentTypeID).
+			Find(&previous).Error
+		if err != nil {
+			return err
+		}
+
+		version := 0
+		for _, p := range previous {
+			if p.Version > version {
+				version = p.Version
+			}
+		}
+		doc.Version = version + 1
+		doc.IsCurrent = true
+
+		if len(previous) > 0 {
+			err := tx.Unscoped().Model(&domain.Document{}).
+				Where("owner_type = ? AND owner_id = ? AND document_type_id = ?", doc.OwnerType, doc.OwnerID, doc.DocumentTypeID).
+				Update("is_current", false).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit(clause.Associations).Create(doc).Error
+	})
+}
+
+func (r *documentRepository) FindByID(id string) (*domain.Document, error) {
+	var doc domain.Document
+	err := r.db.Preload("DocumentType", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).First(&doc, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &doc, err
+}
+
+func (r *documentRepository) FindCurrentByOwner(ownerType, ownerID string) ([]domain.Document, error) {
+	return r.FindCurrentByOwners(ownerType, []string{ownerID})
+}
+
+func (r *documentRepository) FindCurrentByOwners(ownerType string, ownerIDs []string) ([]domain.Document, error) {
+	var docs []domain.Document
+	if len(ownerIDs) == 0 {
+		return docs, nil
+	}
+	err := r.db.Where("owner_type = ? AND owner_id IN ? AND is_current = ?", ownerType, ownerIDs, true).
+		Order("created_at DESC").
+		Find(&docs).Error
+	return docs, err
+}
+
+func (r *documentRepository) FindHistory(ownerType, ownerID, documentTypeID string) ([]domain.Document, error) {
+	var docs []domain.Document
+	query := r.db.Preload("DocumentType", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("owner_type = ? AND owner_id = ?", ownerType, ownerID)
+	if documentTypeID != "" {
+		query = query.Where("document_type_id = ?", documentTypeID)
+	}
+	err := query.Order("document_type_id ASC, version DESC").Find(&docs).Error
+	return docs, err
+}
+
+func (r *documentRepository) Update(doc *domain.Document) error {
+	return r.db.Omit(clause.Associations).Save(doc).Error
+}
+
+// Delete menghapus (soft delete) dokumen. Jika yang dihapus adalah versi terbaru,
+// versi sebelumnya yang masih ada dijadikan versi terbaru lagi.
+func (r *documentRepository) Delete(doc *domain.Document) error {
+	// Update lewat tx.Model(doc) ikut mengubah doc.IsCurrent, jadi status awalnya disimpan dulu
+	wasCurrent := doc.IsCurrent
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(doc).Update("is_current", false).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(doc).Error; err != nil {
+			return err
+		}
+		if !wasCurrent {
+			return nil
+		}
+
+		var previous domain.Document
+		err := tx.Where("owner_type = ? AND owner_id = ? AND document_type_id = ?", doc.OwnerType, doc.OwnerID, doc.DocumentTypeID).
+			Order("version DESC").
+			First(&previous).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&previous).Update("is_current", true).Error
+	})
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+
+	"gorm.io/gorm"
+)
+
+// DormRoomFilter adalah filter daftar kamar. AcademicYearID dipakai untuk menghitung jumlah penghuni.
+type DormRoomFilter struct {
+	DormitoryID    string
+	MusyrifID      string
+	AcademicYearID string
+}
+
+type DormitoryRepository interface {
+	CreateDormitory(dormitory *domain.Dormitory) error
+	FindDormitories() ([]domain.Dormitory, error)
+	FindDormitoryByID(id string) (*domain.Dormitory, error)
+	FindDormitoryByName(name string) (*domain.Dormitory, error)
+	UpdateDormitory(dormitory *domain.Dormitory) error
+	DeleteDormitory(id string) error
+
+	CreateRoom(room *domain.DormRoom) error
+	FindRoomByID(id string) (*domain.DormRoom, error)
+	FindRoomByName(dormitoryID, name string) (*domain.DormRoom, error)
+	FindRooms(filter DormRoomFilter) ([]domain.DormRoom, error)
+	UpdateRoom(room *domain.DormRoom) error
+	DeleteRoom(id string) error
+
+	CountActiveAssignments(roomID, academicYearID string) (int64, error)
+	CountActiveAssignmentsInRoom(roomID string) (int64, error)
+	CountActiveAssignmentsInDormitory(dormitoryID string) (int64, error)
+	FindOccupants(roomID, academicYearID string) ([]domain.DormAssignment, error)
+	FindActiveAssignment(studentID, academicYearID string) (*domain.DormAssignment, error)
+	FindAssignmentByID(id string) (*domain.DormAssignment, error)
+	FindStudentAssignments(studentID string) ([]domain.DormAssignment, error)
+	CreateAssignments(assignments []domain.DormAssignment) error
+	// MoveAssignment menutup penempatan lama (MOVED) dan membuat penempatan baru dalam satu transaksi
+	MoveAssignment(current *domain.DormAssignment, next *domain.DormAssignment) error
+	EndAssignment(assignment *domain.DormAssignment) error
+}
+
+type dormitoryRepository struct {
+	db *gorm.DB
+}
+
+func NewDormitoryRepository(db *gorm.DB) DormitoryRepository {
+	return &dormitoryRepository{db: db}
+}
+
+// --- Dormitories ---
+
+func (r *dormitoryRepository) CreateDormitory(dormitory *domain.Dormitory) error {
+	return r.db.Omit("Rooms").Create(dormitory).Error
+}
+
+func (r *dormitoryRepository) FindDormitories() ([]domain.Dormitory, error) {
+	var dormitories []domain.Dormitory
+	err := r.db.Order("name ASC").Find(&dormitories).Error
+	return dormitories, err
+}
+
+func (r *dormitoryRepository) FindDormitoryByID(id string) (*domain.Dormitory, error) {
+	var dormitory domain.Dormitory
+	err := r.db.Preload("Rooms", func(db *gorm.DB) *gorm.DB { return db.Order("name ASC") }).
+		Preload("Rooms.Musyrif").
+		First(&dormitory, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &dormitory, err
+}
+
+func (r *dormitoryRepository) FindDormitoryByName(name string) (*domain.Dormitory, error) {
+	var dormitory domain.Dormitory
+	err := r.db.First(&dormitory, "name = ?", name).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &dormitory, err
+}
+
+func (r *dormitoryRepository) UpdateDormitory(dormitory *domain.Dormitory) error {
+	return r.db.Omit("Rooms").Save(dormitory).Error
+}
+
+func (r *dormitoryRepository) DeleteDormitory(id string) error {
+	return r.db.Delete(&domain.Dormitory{}, "id = ?", id).Error
+}
+
+// --- Rooms ---
+
+func (r *dormitoryRepository) CreateRoom(room *domain.DormRoom) error {
+	return r.db.Omit("Dormitory", "Musyrif").Create(room).Error
+}
+
+func (r *dormitoryRepository) FindRoomByID(id string) (*domain.DormRoom, error) {
+	var room domain.DormRoom
+	err := r.db.Preload("Dormitory").Preload("Musyrif").First(&room, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &room, err
+}
+
+func (r *dormitoryRepository) FindRoomByName(dormitoryID, name string) (*domain.DormRoom, error) {
+	var room domain.DormRoom
+	err := r.db.First(&room, "dormitory_id = ? AND name = ?", dormitoryID, name).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &room, err
+}
+
+// FindRooms mengambil kamar beserta jumlah penghuni aktif di tahun ajaran filter.AcademicYearID
+func (r *dormitoryRepository) FindRooms(filter DormRoomFilter) ([]domain.DormRoom, error) {
+	var rooms []domain.DormRoom
+
+	query := r.db.Model(&domain.DormRoom{}).
+		Preload("Dormitory").Preload("Musyrif").
+		Select("dorm_rooms.*, (SELECT COUNT(*) FROM dorm_assignments da JOIN students s ON s.id = da.student_id AND s.deleted_at IS NULL WHERE da.dorm_room_id = dorm_rooms.id AND da.status = ? AND da.academic_year_id = ?) AS occupied",
+			domain.DormAssignmentActive, filter.AcademicYearID).
+		Joins("JOIN dormitories ON dormitories.id = dorm_rooms.dormitory_id")
+	if filter.DormitoryID != "" {
+		query = query.Where("dorm_rooms.dormitory_id = ?", filter.DormitoryID)
+	}
+	if filter.MusyrifID != "" {
+		query = query.Where("dorm_rooms.musyrif_id = ?", filter.MusyrifID)
+	}
+
+	err := query.Order("dormitories.name ASC, dorm_rooms.name ASC").Find(&rooms).Error
+	return rooms, err
+}
+
+func (r *dormitoryRepository) UpdateRoom(room *domain.DormRoom) error {
+	return r.db.Omit("Dormitory", "Musyrif").Save(room).Error
+}
+
+func (r *dormitoryRepository) DeleteRoom(id string) error {
+	return r.db.Delete(&domain.DormRoom{}, "id = ?", id).Error
+}
+
+// --- Assignments ---
+
+// CountActiveAssignments menghitung penghuni kamar di satu tahun ajaran (siswa di trash tidak dihitung)
+func (r *dormitoryRepository) CountActiveAssignments(roomID, academicYearID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.DormAssignment{}).
+		Joins("JOIN students ON students.id = dorm_assignments.student_id AND students.deleted_at IS NULL").
+		Where("dorm_assignments.dorm_room_id = ? AND dorm_assignments.academic_year_id = ? AND dorm_assignments.status = ?", roomID, academicYearID, domain.DormAssignmentActive).
+		Count(&count).Error
+	return count, err
+}
+
+// CountActiveAssignmentsInRoom menghitung penghuni aktif di semua tahun ajaran
+func (r *dormitoryRepository) CountActiveAssignmentsInRoom(roomID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.DormAssignment{}).
+		Where("dorm_room_id = ? AND status = ?", roomID, domain.DormAssignmentActive).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *dormitoryRepository) CountActiveAssignmentsInDormitory(dormitoryID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.DormAssignment{}).
+		Joins("JOIN dorm_rooms ON dorm_rooms.id = dorm_assignments.dorm_room_id").
+		Where("dorm_rooms.dormitory_id = ? AND dorm_assignments.status = ?", dormitoryID, domain.DormAssignmentActive).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *dormitoryRepository) FindOccupants(roomID, academicYearID string) ([]domain.DormAssignment, error) {
+	var assignments []domain.DormAssignment
+	err := r.db.Joins("Student").
+		Where("dorm_assignments.dorm_room_id = ? AND dorm_assignments.academic_year_id = ? AND dorm_assignments.status = ?",
+			roomID, academicYearID, domain.DormAssignmentActive).
+		Order("Student.full_name ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *dormitoryRepository) FindActiveAssignment(studentID, academicYearID string) (*domain.DormAssignment, error) {
+	var assignment domain.DormAssignment
+	err := r.db.Preload("DormRoom.Dormitory").
+		First(&assignment, "student_id = ? AND academic_year_id = ? AND status = ?", studentID, academicYearID, domain.DormAssignmentActive).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &assignment, err
+}
+
+func (r *dormitoryRepository) FindAssignmentByID(id string) (*domain.DormAssignment, error) {
+	var assignment domain.DormAssignment
+	err := r.db.Preload("DormRoom.Dormitory").Preload("Student").First(&assignment, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &assignment, err
+}
+
+// FindStudentAssignments mengambil riwayat kamar siswa, terbaru lebih dulu
+func (r *dormitoryRepository) FindStudentAssignments(studentID string) ([]domain.DormAssignment, error) {
+	var assignments []domain.DormAssignment
+	err := r.db.Preload("DormRoom.Dormitory").Preload("AcademicYear").
+		Where("student_id = ?", studentID).
+		Order("start_date DESC, created_at DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *dormitoryRepository) CreateAssignments(assignments []domain.DormAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+	return r.db.Omit("DormRoom", "Student", "AcademicYear").Create(&assignments).Error
+}
+
+func (r *dormitoryRepository) MoveAssignment(current *domain.DormAssignment, next *domain.DormAssignment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.DormAssignment{}).Where("id = ?", current.ID).
+			Updates(map[string]interface{}{"status": current.Status, "end_date": current.EndDate}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("DormRoom", "Student", "AcademicYear").Create(next).Error
+	})
+}
+
+func (r *dormitoryRepository) EndAssignment(assignment *domain.DormAssignment) error {
+	return r.db.Model(&domain.DormAssignment{}).Where("id = ?", assignment.ID).
+		Updates(map[string]interface{}{"status": assignment.Status, "end_date": assignment.EndDate, "notes": assignment.Notes}).Error
+}
@@ -18,6 +18,9 @@ type EmployeeRepository interface {
 	Update(employee *domain.Employee) error
 	Delete(id string) error
 	SetUserID(employeeID string, userID *string) error // Untuk link/unlink user
+	FindByDeviceUserID(deviceUserID string) (*domain.Employee, error)
+	FindWithDeviceUserID() ([]domain.Employee, error)                                 // Pegawai yang sudah dipetakan ke mesin fingerprint
+	SetAttendanceSettings(employeeID string, deviceUserID, workShiftID *string) error // ID mesin & shift kerja
 }
 
 type employeeRepository struct {
@@ -47,7 +50,8 @@ func (r *employeeRepository) FindByID(id string) (*domain.Employee, error) {
 
 func (r *employeeRepository) FindByNIP(nip string) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.First(&employee, "nip = ?", nip).Error
+	// Unscoped: data di trash tetap memegang unique index, jadi ikut dicek
+	err := r.db.Unscoped().First(&employee, "nip = ?", nip).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -59,7 +63,7 @@ func (r *employeeRepository) FindByNIP(nip string) (*domain.Employee, error) {
 
 func (r *employeeRepository) FindByPhone(phone string) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.First(&employee, "phone_number = ?", phone).Error
+	err := r.db.Unscoped().First(&employee, "phone_number = ?", phone).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -83,7 +87,7 @@ func (r *employeeRepository) FindByUserID(userID string) (*domain.Employee, erro
 
 func (r *employeeRepository) FindByNIKHash(hash string) (*domain.Employee, error) {
 	var employee domain.Employee
-	err := r.db.First(&employee, "nik_hash = ?", hash).Error
+	err := r.db.Unscoped().First(&employee, "nik_hash = ?", hash).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // Not found
 	}
@@ -120,3 +124,25 @@ func (r *employeeRepository) SetUserID(employeeID string, userID *string) error
 	// GORM akan otomatis meng-set ke NULL jika userID adalah nil
 	return r.db.Model(&domain.Employee{}).Where("id = ?", employeeID).Update("user_id", userID).Error
 }
+
+func (r *employeeRepository) FindByDeviceUserID(deviceUserID string) (*domain.Employee, error) {
+	var employee domain.Employee
+	err := r.db.Where("device_user_id = ?", deviceUserID).First(&employee).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &employee, err
+}
+
+func (r *employeeRepository) FindWithDeviceUserID() ([]domain.Employee, error) {
+	var employees []domain.Employee
+	err := r.db.Where("device_user_id IS NOT NULL").Find(&employees).Error
+	return employees, err
+}
+
+func (r *employeeRepository) SetAttendanceSettings(employeeID string, deviceUserID, workShiftID *string) error {
+	return r.db.Model(&domain.Employee{}).Where("id = ?", employeeID).Updates(map[string]interface{}{
+		"device_user_id": deviceUserID,
+		"work_shift_id":  workShiftID,
+	}).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"smart_school_be/internal/model/domain"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -13,11 +14,15 @@ type GradeRepository interface {
 	FindAssessmentByID(id string) (*domain.Assessment, error)
 	GetAssessmentsByTeachingAssignment(teachingAssignmentID string, limit, offset int) ([]domain.Assessment, int64, error)
 	DeleteAssessment(id string) error
+	// SetAssessmentPublished mengisi/mengosongkan published_at; false jika assessment tidak ditemukan
+	SetAssessmentPublished(id string, publishedAt *time.Time) (bool, error)
 
 	// Scores
 	SaveStudentScore(score *domain.StudentScore) error
 	GetScoresByAssessmentID(assessmentID string) ([]domain.StudentScore, error)
 	GetScoreByAssessmentAndStudent(assessmentID, studentID string) (*domain.StudentScore, error) // Helper to check existence
+	// FindPublishedScoresByStudent mengambil nilai siswa dari assessment yang sudah dipublikasikan
+	FindPublishedScoresByStudent(studentID string, limit, offset int) ([]domain.StudentScore, int64, error)
 }
 
 type gradeRepository struct {
@@ -114,3 +119,27 @@ func (r *gradeRepository) GetScoreByAssessmentAndStudent(assessmentID, studentID
 func (r *gradeRepository) DeleteAssessment(id string) error {
 	return r.db.Delete(&domain.Assessment{}, "id = ?", id).Error
 }
+
+func (r *gradeRepository) SetAssessmentPublished(id string, publishedAt *time.Time) (bool, error) {
+	result := r.db.Model(&domain.Assessment{}).Where("id = ?", id).Update("published_at", publishedAt)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gradeRepository) FindPublishedScoresByStudent(studentID string, limit, offset int) ([]domain.StudentScore, int64, error) {
+	var scores []domain.StudentScore
+	var total int64
+	query := r.db.Model(&domain.StudentScore{}).
+		Joins("JOIN assessments ON assessments.id = student_scores.assessment_id").
+		Where("student_scores.student_id = ? AND assessments.published_at IS NOT NULL", studentID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("Assessment.TeachingAssignment.Subject").
+		Order("assessments.date DESC").
+		Limit(limit).Offset(offset).
+		Find(&scores).Error
+	return scores, total, err
+}
@@ -15,8 +15,12 @@ type GuardianRepository interface {
 	FindAll(search string) ([]domain.Guardian, error)
 	Update(guardian *domain.Guardian) error
 	Delete(id string) error
+	// DeleteReassigningWards memindahkan/mengosongkan wali siswa yang menunjuk ke guardian ini lalu menghapusnya
+	DeleteReassigningWards(id string, toType, toID *string) error
 	SetUserID(guardianID string, userID *string) error
 	FindByNIKHash(hash string) (*domain.Guardian, error)
+	FindByUserID(userID string) (*domain.Guardian, error)
+	FindByIDs(ids []string) ([]domain.Guardian, error)
 }
 
 type guardianRepository struct {
@@ -45,7 +49,8 @@ func (r *guardianRepository) FindByID(id string) (*domain.Guardian, error) {
 
 func (r *guardianRepository) FindByPhone(phone string) (*domain.Guardian, error) {
 	var guardian domain.Guardian
-	err := r.db.First(&guardian, "phone_number = ?", phone).Error
+	// Unscoped: data di trash tetap memegang unique index, jadi ikut dicek
+	err := r.db.Unscoped().First(&guardian, "phone_number = ?", phone).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -57,7 +62,7 @@ func (r *guardianRepository) FindByPhone(phone string) (*domain.Guardian, error)
 
 func (r *guardianRepository) FindByEmail(email string) (*domain.Guardian, error) {
 	var guardian domain.Guardian
-	err := r.db.First(&guardian, "email = ?", email).Error
+	err := r.db.Unscoped().First(&guardian, "email = ?", email).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -88,6 +93,15 @@ func (r *guardianRepository) Delete(id string) error {
 	return r.db.Delete(&domain.Guardian{}, "id = ?", id).Error
 }
 
+func (r *guardianRepository) DeleteReassigningWards(id string, toType, toID *string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := reassignGuardianPointer(tx, "guardian", id, toType, toID); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Guardian{}, "id = ?", id).Error
+	})
+}
+
 // SetUserID meng-update kolom user_id untuk guardian
 func (r *guardianRepository) SetUserID(guardianID string, userID *string) error {
 	// GORM akan otomatis meng-set ke NULL jika userID adalah nil
@@ -96,7 +110,7 @@ func (r *guardianRepository) SetUserID(guardianID string, userID *string) error
 
 func (r *guardianRepository) FindByNIKHash(hash string) (*domain.Guardian, error) {
 	var guardian domain.Guardian
-	err := r.db.First(&guardian, "nik_hash = ?", hash).Error
+	err := r.db.Unscoped().First(&guardian, "nik_hash = ?", hash).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // Not found
 	}
@@ -105,3 +119,24 @@ func (r *guardianRepository) FindByNIKHash(hash string) (*domain.Guardian, error
 	}
 	return &guardian, nil
 }
+
+func (r *guardianRepository) FindByUserID(userID string) (*domain.Guardian, error) {
+	var guardian domain.Guardian
+	err := r.db.First(&guardian, "user_id = ?", userID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &guardian, nil
+}
+
+func (r *guardianRepository) FindByIDs(ids []string) ([]domain.Guardian, error) {
+	var guardians []domain.Guardian
+	if len(ids) == 0 {
+		return guardians, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&guardians).Error
+	return guardians, err
+}
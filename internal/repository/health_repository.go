@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClinicVisitFilter adalah filter log kunjungan UKS
+type ClinicVisitFilter struct {
+	StudentID string
+	Outcome   string
+	DateFrom  *time.Time
+	DateTo    *time.Time // Inklusif (sampai akhir hari)
+}
+
+type HealthRepository interface {
+	FindProfileByStudentID(studentID string) (*domain.StudentHealthProfile, error)
+	SaveProfile(profile *domain.StudentHealthProfile) error
+
+	// CreateVisit menyimpan kunjungan; jika markSick, absensi siswa di sisa hari itu ditandai SICK
+	CreateVisit(visit *domain.ClinicVisit, classroomID string) error
+	FindVisitByID(id string) (*domain.ClinicVisit, error)
+	FindVisits(filter ClinicVisitFilter, limit, offset int) ([]domain.ClinicVisit, int64, error)
+}
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository(db *gorm.DB) HealthRepository {
+	return &healthRepository{db: db}
+}
+
+func (r *healthRepository) FindProfileByStudentID(studentID string) (*domain.StudentHealthProfile, error) {
+	var profile domain.StudentHealthProfile
+	err := r.db.First(&profile, "student_id = ?", studentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &profile, err
+}
+
+func (r *healthRepository) SaveProfile(profile *domain.StudentHealthProfile) error {
+	if profile.ID == "" {
+		return r.db.Create(profile).Error
+	}
+	return r.db.Save(profile).Error
+}
+
+func (r *healthRepository) CreateVisit(visit *domain.ClinicVisit, classroomID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if visit.MarkAttendanceSick && classroomID != "" {
+			marked, err := markClinicSick(tx, visit, classroomID)
+			if err != nil {
+				return err
+			}
+			visit.AttendanceMarked = marked
+		}
+		return tx.Omit("Student").Create(visit).Error
+	})
+}
+
+// markClinicSick menandai SICK pada sesi absensi kelas siswa di tanggal kunjungan yang jam pelajarannya
+// belum selesai saat siswa dipulangkan. Sesi yang belum dibuat guru akan terisi SICK otomatis
+// saat daftar absensi dibuka (lihat AttendanceRepository.FindClinicSentHome).
+func markClinicSick(tx *gorm.DB, visit *domain.ClinicVisit, classroomID string) (int, error) {
+	var sessions []domain.AttendanceSession
+	err := tx.Joins("JOIN schedules ON schedules.id = attendance_sessions.schedule_id").
+		Joins("JOIN teaching_assignments ON teaching_assignments.id = schedules.teaching_assignment_id").
+		Where("teaching_assignments.classroom_id = ? AND DATE(attendance_sessions.date) = ? AND schedules.end_time > ?",
+			classroomID, visit.VisitedAt.Format("2006-01-02"), visit.VisitedAt.Format("15:04:05")).
+		Find(&sessions).Error
+	if err != nil {
+		return 0, err
+	}
+
+	notes := "Dipulangkan dari UKS"
+	for _, session := range sessions {
+		result := tx.Model(&domain.AttendanceDetail{}).
+			Where("attendance_session_id = ? AND student_id = ?", session.ID, visit.StudentID).
+			Updates(map[string]interface{}{"status": "SICK", "notes": notes})
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		if result.RowsAffected == 0 {
+			detail := domain.AttendanceDetail{
+				AttendanceSessionID: session.ID,
+				StudentID:           visit.StudentID,
+				Status:              "SICK",
+				Notes:               notes,
+			}
+			if err := tx.Omit("Student").Create(&detail).Error; err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(sessions), nil
+}
+
+func (r *healthRepository) FindVisitByID(id string) (*domain.ClinicVisit, error) {
+	var visit domain.ClinicVisit
+	err := r.db.Preload("Student").First(&visit, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &visit, err
+}
+
+func (r *healthRepository) FindVisits(filter ClinicVisitFilter, limit, offset int) ([]domain.ClinicVisit, int64, error) {
+	var visits []domain.ClinicVisit
+	var total int64
+
+	query := r.db.Model(&domain.ClinicVisit{})
+	if filter.StudentID != "" {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if filter.DateFrom != nil {
+		query = query.Where("visited_at >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("visited_at < ?", filter.DateTo.AddDate(0, 0, 1))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Student").
+		Order("visited_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&visits).Error
+	return visits, total, err
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+
+	"gorm.io/gorm"
+)
+
+type HouseholdRepository interface {
+	FindByID(id string) (*domain.Household, error)
+	FindAll(minChildren int, limit, offset int) ([]domain.Household, int64, error)
+	FindSiblings(student *domain.Student) ([]domain.Student, error)
+	FindStudentsWithoutHousehold(afterID string, limit int) ([]domain.Student, error)
+	SetStudentHousehold(student *domain.Student) error
+}
+
+type householdRepository struct {
+	db *gorm.DB
+}
+
+func NewHouseholdRepository(db *gorm.DB) HouseholdRepository {
+	return &householdRepository{db: db}
+}
+
+// assignHousehold mengisi student.HouseholdID dari NoKKHash, membuat household baru jika belum ada.
+// Dipanggil di dalam transaksi setiap kali data siswa (termasuk No KK) disimpan.
+func assignHousehold(tx *gorm.DB, student *domain.Student) error {
+	if student.NoKKHash == nil || *student.NoKKHash == "" {
+		student.HouseholdID = nil
+		return nil
+	}
+
+	household := domain.Household{}
+	err := tx.Where(domain.Household{NoKKHash: *student.NoKKHash}).
+		Attrs(domain.Household{NoKK: student.NoKK}).
+		FirstOrCreate(&household).Error
+	if err != nil {
+		return err
+	}
+	student.HouseholdID = &household.ID
+	return nil
+}
+
+// removeEmptyHouseholds menghapus household yang tidak lagi punya siswa (termasuk yang di trash)
+func removeEmptyHouseholds(tx *gorm.DB) error {
+	return tx.Exec("DELETE FROM households WHERE NOT EXISTS (SELECT 1 FROM students s WHERE s.household_id = households.id)").Error
+}
+
+// preloadHouseholdStudents memuat anak beserta kelas aktif dan orang tuanya
+func preloadHouseholdStudents(db *gorm.DB) *gorm.DB {
+	return db.Preload("StudentClassrooms", "status = ?", "ACTIVE").
+		Preload("StudentClassrooms.Classroom").
+		Preload("Parents.Parent").
+		Order("date_of_birth ASC, full_name ASC")
+}
+
+func (r *householdRepository) FindByID(id string) (*domain.Household, error) {
+	var household domain.Household
+	err := r.db.Preload("Students", preloadHouseholdStudents).First(&household, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &household, err
+}
+
+// FindAll mengembalikan household dengan minimal minChildren siswa (mis. 2 untuk keluarga yang punya saudara kandung)
+func (r *householdRepository) FindAll(minChildren int, limit, offset int) ([]domain.Household, int64, error) {
+	var households []domain.Household
+	var total int64
+
+	query := r.db.Model(&domain.Household{})
+	if minChildren > 0 {
+		query = query.Where("(SELECT COUNT(*) FROM students s WHERE s.household_id = households.id AND s.deleted_at IS NULL) >= ?", minChildren)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Students", preloadHouseholdStudents).
+		Order("created_at ASC").
+		Limit(limit).Offset(offset).
+		Find(&households).Error
+	return households, total, err
+}
+
+// FindSiblings mengambil siswa lain di household yang sama
+func (r *householdRepository) FindSiblings(student *domain.Student) ([]domain.Student, error) {
+	var siblings []domain.Student
+	if student.HouseholdID == nil {
+		return siblings, nil
+	}
+	err := preloadHouseholdStudents(r.db).
+		Where("household_id = ? AND id <> ?", *student.HouseholdID, student.ID).
+		Find(&siblings).Error
+	return siblings, err
+}
+
+// FindStudentsWithoutHousehold mengambil siswa (termasuk di trash) yang punya No KK tapi belum dikelompokkan,
+// diurutkan per ID (keyset afterID) agar baris yang gagal diproses tidak diambil ulang.
+func (r *householdRepository) FindStudentsWithoutHousehold(afterID string, limit int) ([]domain.Student, error) {
+	var students []domain.Student
+	err := r.db.Unscoped().
+		Where("no_kk IS NOT NULL AND no_kk <> '' AND household_id IS NULL AND id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&students).Error
+	return students, err
+}
+
+// SetStudentHousehold menyimpan NoKKHash siswa dan menempatkannya ke household yang sesuai
+func (r *householdRepository) SetStudentHousehold(student *domain.Student) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignHousehold(tx, student); err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&domain.Student{}).Where("id = ?", student.ID).
+			Updates(map[string]interface{}{
+				"no_kk_hash":   student.NoKKHash,
+				"household_id": student.HouseholdID,
+			}).Error
+	})
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LeavePermitFilter adalah filter daftar perizinan pulang.
+// StudentIDs membatasi ke santri tertentu (akun orang tua), MusyrifID ke santri kamar binaan musyrif.
+type LeavePermitFilter struct {
+	StudentID  string
+	StudentIDs []string
+	MusyrifID  string
+	Status     string
+	Date       *time.Time // Izin yang mencakup tanggal ini
+	Overdue    bool
+	Now        time.Time
+}
+
+// activeLeaveStatuses adalah status izin yang masih berjalan (dipakai untuk cek bentrok)
+var activeLeaveStatuses = []string{domain.LeavePermitPending, domain.LeavePermitApproved, domain.LeavePermitCheckedOut}
+
+type LeavePermitRepository interface {
+	Create(permit *domain.LeavePermit) error
+	FindByID(id string) (*domain.LeavePermit, error)
+	FindAll(filter LeavePermitFilter, limit, offset int) ([]domain.LeavePermit, int64, error)
+	Update(permit *domain.LeavePermit) error
+	// HasOverlap mengecek izin aktif lain milik santri yang rentang waktunya beririsan
+	HasOverlap(studentID string, from, to time.Time, excludeID string) (bool, error)
+}
+
+type leavePermitRepository struct {
+	db *gorm.DB
+}
+
+func NewLeavePermitRepository(db *gorm.DB) LeavePermitRepository {
+	return &leavePermitRepository{db: db}
+}
+
+func (r *leavePermitRepository) Create(permit *domain.LeavePermit) error {
+	return r.db.Omit("Student").Create(permit).Error
+}
+
+func (r *leavePermitRepository) FindByID(id string) (*domain.LeavePermit, error) {
+	var permit domain.LeavePermit
+	err := r.db.Preload("Student").First(&permit, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &permit, err
+}
+
+func (r *leavePermitRepository) FindAll(filter LeavePermitFilter, limit, offset int) ([]domain.LeavePermit, int64, error) {
+	var permits []domain.LeavePermit
+	var total int64
+
+	query := r.db.Model(&domain.LeavePermit{})
+	if filter.StudentID != "" {
+		query = query.Where("leave_permits.student_id = ?", filter.StudentID)
+	}
+	if len(filter.StudentIDs) > 0 {
+		query = query.Where("leave_permits.student_id IN ?", filter.StudentIDs)
+	}
+	if filter.MusyrifID != "" {
+		query = query.Where("leave_permits.student_id IN (?)",
+			r.db.Table("dorm_assignments").
+				Select("dorm_assignments.student_id").
+				Joins("JOIN dorm_rooms ON dorm_rooms.id = dorm_assignments.dorm_room_id").
+				Where("dorm_assignments.status = ? AND dorm_assignments.academic_year_id = leave_permits.academic_year_id AND dorm_rooms.musyrif_id = ?",
+					domain.DormAssignmentActive, filter.MusyrifID))
+	}
+	if filter.Status != "" {
+		query = query.Where("leave_permits.status = ?", filter.Status)
+	}
+	if filter.Date != nil {
+		day := filter.Date.Format("2006-01-02")
+		query = query.Where("DATE(leave_permits.departure_at) <= ? AND DATE(COALESCE(leave_permits.checked_in_at, leave_permits.expected_return_at)) >= ?", day, day)
+	}
+	if filter.Overdue {
+		query = query.Where("leave_permits.status = ? AND leave_permits.expected_return_at < ?", domain.LeavePermitCheckedOut, filter.Now)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "leave_permits.departure_at DESC"
+	if filter.Overdue {
+		order = "leave_permits.expected_return_at ASC"
+	}
+	err := query.Preload("Student").
+		Order(order).
+		Limit(limit).Offset(offset).
+		Find(&permits).Error
+	return permits, total, err
+}
+
+func (r *leavePermitRepository) Update(permit *domain.LeavePermit) error {
+	return r.db.Omit("Student").Save(permit).Error
+}
+
+func (r *leavePermitRepository) HasOverlap(studentID string, from, to time.Time, excludeID string) (bool, error) {
+	var count int64
+	query := r.db.Model(&domain.LeavePermit{}).
+		Where("student_id = ? AND status IN ? AND departure_at < ? AND expected_return_at > ?",
+			studentID, activeLeaveStatuses, to, from)
+	if excludeID != "" {
+		query = query.Where("id <> ?", excludeID)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
@@ -15,9 +15,12 @@ type ParentRepository interface {
 	FindAll(search string, limit, offset int) ([]domain.Parent, int64, error)
 	Update(parent *domain.Parent) error
 	Delete(id string) error
+	// DeleteReassigningWards memindahkan/mengosongkan wali siswa yang menunjuk ke parent ini lalu menghapusnya
+	DeleteReassigningWards(id string, toType, toID *string) error
 	SetUserID(parentID string, userID *string) error
 	FindByNIKHash(hash string) (*domain.Parent, error)
 	FindByUserID(userID string) (*domain.Parent, error)
+	FindByIDs(ids []string) ([]domain.Parent, error)
 }
 
 type parentRepository struct {
@@ -47,7 +50,8 @@ func (r *parentRepository) FindByID(id string) (*domain.Parent, error) {
 
 func (r *parentRepository) FindByPhone(phone string) (*domain.Parent, error) {
 	var parent domain.Parent
-	err := r.db.First(&parent, "phone_number = ?", phone).Error
+	// Unscoped: data di trash tetap memegang unique index, jadi ikut dicek
+	err := r.db.Unscoped().First(&parent, "phone_number = ?", phone).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -59,7 +63,7 @@ func (r *parentRepository) FindByPhone(phone string) (*domain.Parent, error) {
 
 func (r *parentRepository) FindByEmail(email string) (*domain.Parent, error) {
 	var parent domain.Parent
-	err := r.db.First(&parent, "email = ?", email).Error
+	err := r.db.Unscoped().First(&parent, "email = ?", email).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -98,6 +102,15 @@ func (r *parentRepository) Delete(id string) error {
 	return r.db.Delete(&domain.Parent{}, "id = ?", id).Error
 }
 
+func (r *parentRepository) DeleteReassigningWards(id string, toType, toID *string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := reassignGuardianPointer(tx, "parent", id, toType, toID); err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Parent{}, "id = ?", id).Error
+	})
+}
+
 // SetUserID meng-update kolom user_id untuk parent
 func (r *parentRepository) SetUserID(parentID string, userID *string) error {
 	// GORM akan otomatis meng-set ke NULL jika userID adalah nil
@@ -106,7 +119,7 @@ func (r *parentRepository) SetUserID(parentID string, userID *string) error {
 
 func (r *parentRepository) FindByNIKHash(hash string) (*domain.Parent, error) {
 	var parent domain.Parent
-	err := r.db.First(&parent, "nik_hash = ?", hash).Error
+	err := r.db.Unscoped().First(&parent, "nik_hash = ?", hash).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // Not found
 	}
@@ -127,3 +140,12 @@ func (r *parentRepository) FindByUserID(userID string) (*domain.Parent, error) {
 	}
 	return &parent, nil
 }
+
+func (r *parentRepository) FindByIDs(ids []string) ([]domain.Parent, error) {
+	var parents []domain.Parent
+	if len(ids) == 0 {
+		return parents, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&parents).Error
+	return parents, err
+}
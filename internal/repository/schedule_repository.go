@@ -10,6 +10,8 @@ type ScheduleRepository interface {
 	Create(schedule *domain.Schedule) error
 	FindByClassroomID(classroomID string) ([]domain.Schedule, error)
 	FindByTeacherID(teacherID string) ([]domain.Schedule, error)
+	// FindByAcademicYear mengambil jadwal kelas di tahun ajaran tsb, opsional untuk satu guru
+	FindByAcademicYear(academicYearID, teacherID string) ([]domain.Schedule, error)
 	FindByTeachingAssignmentID(taID string) ([]domain.Schedule, error)
 	FindByID(id string) (*domain.Schedule, error)
 	Delete(id string) error
@@ -59,6 +61,22 @@ func (r *scheduleRepository) FindByTeacherID(teacherID string) ([]domain.Schedul
 	return schedules, err
 }
 
+func (r *scheduleRepository) FindByAcademicYear(academicYearID, teacherID string) ([]domain.Schedule, error) {
+	var schedules []domain.Schedule
+	query := r.db.Preload("TeachingAssignment").
+		Preload("TeachingAssignment.Subject").
+		Preload("TeachingAssignment.Teacher").
+		Preload("TeachingAssignment.Classroom").
+		Joins("JOIN teaching_assignments ta ON ta.id = schedules.teaching_assignment_id").
+		Joins("JOIN classrooms c ON c.id = ta.classroom_id").
+		Where("c.academic_year_id = ?", academicYearID)
+	if teacherID != "" {
+		query = query.Where("ta.teacher_id = ?", teacherID)
+	}
+	err := query.Order("day_of_week ASC, start_time ASC").Find(&schedules).Error
+	return schedules, err
+}
+
 func (r *scheduleRepository) FindByTeachingAssignmentID(taID string) ([]domain.Schedule, error) {
 	var schedules []domain.Schedule
 	err := r.db.Preload("TeachingAssignment").
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StaffAttendanceRepository interface {
+	// Shift kerja
+	CreateShift(shift *domain.WorkShift) error
+	FindShiftByID(id string) (*domain.WorkShift, error)
+	FindShiftByName(name string) (*domain.WorkShift, error)
+	FindShifts() ([]domain.WorkShift, error)
+	FindDefaultShift() (*domain.WorkShift, error)
+	UpdateShift(shift *domain.WorkShift) error
+	DeleteShift(id string) error
+	CountEmployeesByShift(shiftID string) (int64, error)
+
+	// Kehadiran harian
+	FindByEmployeeDate(employeeID string, date time.Time) (*domain.StaffAttendance, error)
+	FindByRange(from, to time.Time, employeeID string) ([]domain.StaffAttendance, error)
+	Save(attendance *domain.StaffAttendance) error
+	// SaveAll menyimpan hasil import log mesin dalam satu transaksi
+	SaveAll(attendances []domain.StaffAttendance) error
+
+	// Cuti/izin
+	CreateLeave(leave *domain.StaffLeave) error
+	FindLeaveByID(id string) (*domain.StaffLeave, error)
+	FindLeaves(from, to time.Time, employeeID string) ([]domain.StaffLeave, error)
+	DeleteLeave(id string) error
+	// HasLeaveOverlap mengecek cuti lain milik pegawai yang rentang tanggalnya beririsan
+	HasLeaveOverlap(employeeID string, from, to time.Time) (bool, error)
+}
+
+type staffAttendanceRepository struct {
+	db *gorm.DB
+}
+
+func NewStaffAttendanceRepository(db *gorm.DB) StaffAttendanceRepository {
+	return &staffAttendanceRepository{db: db}
+}
+
+// CreateShift menyimpan shift baru; jika dijadikan default, shift default lain dilepas
+func (r *staffAttendanceRepository) CreateShift(shift *domain.WorkShift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(shift).Error; err != nil {
+			return err
+		}
+		return releaseOtherDefaultShifts(tx, shift)
+	})
+}
+
+func (r *staffAttendanceRepository) FindShiftByID(id string) (*domain.WorkShift, error) {
+	var shift domain.WorkShift
+	err := r.db.First(&shift, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &shift, err
+}
+
+func (r *staffAttendanceRepository) FindShiftByName(name string) (*domain.WorkShift, error) {
+	var shift domain.WorkShift
+	err := r.db.First(&shift, "name = ?", name).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &shift, err
+}
+
+func (r *staffAttendanceRepository) FindShifts() ([]domain.WorkShift, error) {
+	var shifts []domain.WorkShift
+	err := r.db.Order("is_default DESC, name ASC").Find(&shifts).Error
+	return shifts, err
+}
+
+func (r *staffAttendanceRepository) FindDefaultShift() (*domain.WorkShift, error) {
+	var shift domain.WorkShift
+	err := r.db.First(&shift, "is_default = ?", true).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &shift, err
+}
+
+// UpdateShift menyimpan shift; jika dijadikan default, shift default lama dilepas
+func (r *staffAttendanceRepository) UpdateShift(shift *domain.WorkShift) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(shift).Error; err != nil {
+			return err
+		}
+		return releaseOtherDefaultShifts(tx, shift)
+	})
+}
+
+// releaseOtherDefaultShifts menjaga hanya ada satu shift default. Dijalankan setelah shift disimpan
+// di transaksi yang sama, sehingga dua penyimpanan default yang bersamaan saling menunggu kunci baris
+// dan tidak meninggalkan dua shift default.
+func releaseOtherDefaultShifts(tx *gorm.DB, shift *domain.WorkShift) error {
+	if !shift.IsDefault {
+		return nil
+	}
+	return tx.Model(&domain.WorkShift{}).Where("is_default = ? AND id <> ?", true, shift.ID).Update("is_default", false).Error
+}
+
+func (r *staffAttendanceRepository) DeleteShift(id string) error {
+	return r.db.Delete(&domain.WorkShift{}, "id = ?", id).Error
+}
+
+func (r *staffAttendanceRepository) CountEmployeesByShift(shiftID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Employee{}).Where("work_shift_id = ?", shiftID).Count(&count).Error
+	return count, err
+}
+
+func (r *staffAttendanceRepository) FindByEmployeeDate(employeeID string, date time.Time) (*domain.StaffAttendance, error) {
+	var attendance domain.StaffAttendance
+	err := r.db.Where("employee_id = ? AND date = ?", employeeID, date.Format("2006-01-02")).First(&attendance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &attendance, err
+}
+
+func (r *staffAttendanceRepository) FindByRange(from, to time.Time, employeeID string) ([]domain.StaffAttendance, error) {
+	var attendances []domain.StaffAttendance
+	query := r.db.Where("date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if employeeID != "" {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	err := query.Order("date ASC").Find(&attendances).Error
+	return attendances, err
+}
+
+func (r *staffAttendanceRepository) Save(attendance *domain.StaffAttendance) error {
+	return r.db.Omit(clause.Associations).Save(attendance).Error
+}
+
+func (r *staffAttendanceRepository) SaveAll(attendances []domain.StaffAttendance) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range attendances {
+			if err := tx.Omit(clause.Associations).Save(&attendances[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *staffAttendanceRepository) CreateLeave(leave *domain.StaffLeave) error {
+	return r.db.Omit("Employee").Create(leave).Error
+}
+
+func (r *staffAttendanceRepository) FindLeaveByID(id string) (*domain.StaffLeave, error) {
+	var leave domain.StaffLeave
+	err := r.db.Preload("Employee").First(&leave, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &leave, err
+}
+
+// FindLeaves mengambil cuti yang beririsan dengan rentang tanggal
+func (r *staffAttendanceRepository) FindLeaves(from, to time.Time, employeeID string) ([]domain.StaffLeave, error) {
+	var leaves []domain.StaffLeave
+	query := r.db.Preload("Employee").
+		Where("start_date <= ? AND end_date >= ?", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	if employeeID != "" {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+	err := query.Order("start_date ASC").Find(&leaves).Error
+	return leaves, err
+}
+
+func (r *staffAttendanceRepository) DeleteLeave(id string) error {
+	return r.db.Delete(&domain.StaffLeave{}, "id = ?", id).Error
+}
+
+func (r *staffAttendanceRepository) HasLeaveOverlap(employeeID string, from, to time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.StaffLeave{}).
+		Where("employee_id = ? AND start_date <= ? AND end_date >= ?", employeeID, to.Format("2006-01-02"), from.Format("2006-01-02")).
+		Count(&count).Error
+	return count > 0, err
+}
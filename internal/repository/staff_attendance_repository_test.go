@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"testing"
+
+	"smart_school_be/internal/model/domain"
+)
+
+func TestShiftDefaultIsExclusive(t *testing.T) {
+	db := newTestDB(t, `CREATE TABLE work_shifts (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL UNIQUE,
+	start_time TEXT NOT NULL,
+	end_time TEXT NOT NULL,
+	late_tolerance_minutes INTEGER NOT NULL DEFAULT 0,
+	early_leave_tolerance_minutes INTEGER NOT NULL DEFAULT 0,
+	work_days TEXT NOT NULL DEFAULT '1,2,3,4,5,6',
+	is_default NUMERIC NOT NULL DEFAULT 0,
+	created_at DATETIME,
+	updated_at DATETIME
+)`)
+	repo := NewStaffAttendanceRepository(db)
+
+	pagi := &domain.WorkShift{Name: "Pagi", StartTime: "07:00", EndTime: "14:00", IsDefault: true}
+	siang := &domain.WorkShift{Name: "Siang", StartTime: "12:00", EndTime: "18:00", IsDefault: true}
+	for _, shift := range []*domain.WorkShift{pagi, siang} {
+		if err := repo.CreateShift(shift); err != nil {
+			t.Fatalf("create %s: %v", shift.Name, err)
+		}
+	}
+	assertDefaultShift(t, repo, siang.ID)
+
+	pagi.IsDefault = true
+	if err := repo.UpdateShift(pagi); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	assertDefaultShift(t, repo, pagi.ID)
+}
+
+func assertDefaultShift(t *testing.T, repo StaffAttendanceRepository, wantID string) {
+	t.Helper()
+	shifts, err := repo.FindShifts()
+	if err != nil {
+		t.Fatalf("find shifts: %v", err)
+	}
+	var defaults []string
+	for _, shift := range shifts {
+		if shift.IsDefault {
+			defaults = append(defaults, shift.ID)
+		}
+	}
+	if len(defaults) != 1 || defaults[0] != wantID {
+		t.Errorf("default shifts = %v, want only %s", defaults, wantID)
+	}
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StudentMutationChange adalah perubahan yang diterapkan bersama satu mutasi
+type StudentMutationChange struct {
+	Status          string
+	EntryYear       *string
+	ExitYear        *string
+	PlacementStatus string // Status baru untuk penempatan kelas yang masih ACTIVE (kosong = tidak diubah)
+	ClassroomID     string // Kelas penempatan baru (kosong = tidak ada)
+}
+
+type StudentMutationRepository interface {
+	Apply(mutation *domain.StudentMutation, change StudentMutationChange) error
+	FindByID(id string) (*domain.StudentMutation, error)
+	FindByStudent(studentID string) ([]domain.StudentMutation, error)
+	FindByPeriod(from, to time.Time) ([]domain.StudentMutation, error)
+	CountByStudent(studentID string) (int64, error)
+}
+
+type studentMutationRepository struct {
+	db *gorm.DB
+}
+
+func NewStudentMutationRepository(db *gorm.DB) StudentMutationRepository {
+	return &studentMutationRepository{db: db}
+}
+
+// Apply menyimpan mutasi dan menyesuaikan status siswa, tahun masuk/keluar
+// serta penempatan kelas dalam satu transaksi.
+func (r *studentMutationRepository) Apply(mutation *domain.StudentMutation, change StudentMutationChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Dokumen sudah ada, cukup isi tabel pivot
+		if err := tx.Omit("Student", "Classroom", "Documents.*").Create(mutation).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&domain.Student{}).Where("id = ?", mutation.StudentID).
+			Select("status", "entry_year", "exit_year").
+			Updates(map[string]interface{}{
+				"status":     change.Status,
+				"entry_year": change.EntryYear,
+				"exit_year":  change.ExitYear,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if change.PlacementStatus != "" {
+			err := tx.Model(&domain.StudentClassroom{}).
+				Where("student_id = ? AND status = ?", mutation.StudentID, "ACTIVE").
+				Update("status", change.PlacementStatus).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if change.ClassroomID != "" {
+			// Satu siswa hanya boleh sekali di kelas yang sama, jadi aktifkan kembali jika sudah pernah
+			var placement domain.StudentClassroom
+			err := tx.Where("classroom_id = ? AND student_id = ?", change.ClassroomID, mutation.StudentID).First(&placement).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return tx.Create(&domain.StudentClassroom{
+					ClassroomID: change.ClassroomID,
+					StudentID:   mutation.StudentID,
+					Status:      "ACTIVE",
+				}).Error
+			}
+			if err != nil {
+				return err
+			}
+			return tx.Model(&placement).Update("status", "ACTIVE").Error
+		}
+		return nil
+	})
+}
+
+func (r *studentMutationRepository) FindByID(id string) (*domain.StudentMutation, error) {
+	var mutation domain.StudentMutation
+	err := r.db.Preload("Classroom").Preload("Documents").First(&mutation, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &mutation, err
+}
+
+func (r *studentMutationRepository) FindByStudent(studentID string) ([]domain.StudentMutation, error) {
+	var mutations []domain.StudentMutation
+	err := r.db.Preload("Classroom").Preload("Documents").
+		Where("student_id = ?", studentID).
+		Order("effective_date DESC, created_at DESC").
+		Find(&mutations).Error
+	return mutations, err
+}
+
+// FindByPeriod mengambil mutasi dengan tanggal efektif di [from, to), termasuk siswa yang ada di trash
+func (r *studentMutationRepository) FindByPeriod(from, to time.Time) ([]domain.StudentMutation, error) {
+	var mutations []domain.StudentMutation
+	err := r.db.
+		Preload("Student", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Preload("Classroom").
+		Where("effective_date >= ? AND effective_date < ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("effective_date ASC, created_at ASC").
+		Find(&mutations).Error
+	return mutations, err
+}
+
+func (r *studentMutationRepository) CountByStudent(studentID string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.StudentMutation{}).Where("student_id = ?", studentID).Count(&count).Error
+	return count, err
+}
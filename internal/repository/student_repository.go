@@ -5,23 +5,122 @@ import (
 	"smart_school_be/internal/model/domain"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// StudentImportRecord adalah satu baris import: siswa baru beserta orang tua dan kelasnya.
+// ID siswa dan orang tua baru sudah di-set oleh service agar relasi bisa disusun sebelum insert.
+type StudentImportRecord struct {
+	Student     *domain.Student
+	NewParents  []*domain.Parent
+	Parents     []domain.StudentParent
+	ClassroomID string
+}
+
+// FamilyImportRecord adalah satu baris import orang tua/wali. NewParent/NewGuardian hanya diisi
+// pada baris pertama yang memuat orang tersebut; baris berikutnya cukup membawa relasinya.
+type FamilyImportRecord struct {
+	NewParent    *domain.Parent
+	NewGuardian  *domain.Guardian
+	Link         *domain.StudentParent
+	StudentID    string
+	GuardianType string // Kosong = penanda wali siswa tidak diubah
+	GuardianID   string
+}
+
+// StudentFilter adalah filter daftar siswa yang dipakai bersama oleh list dan export
+type StudentFilter struct {
+	Search          string
+	ClassroomID     string
+	Status          string
+	Gender          string
+	EntryYear       string
+	ExitYear        string
+	City            string // Cocok sebagian, mis. "Bogor" cocok dengan "Kab. Bogor" dan "Kota Bogor"
+	Province        string
+	AcademicYearID  string // Siswa yang punya penempatan kelas di tahun ajaran ini
+	HasGuardian     *bool
+	HasUser         *bool
+	MissingDocument string // Kode jenis dokumen, atau "required" untuk siswa yang belum lengkap dokumen wajibnya
+	Sort            string // Key di studentSortColumns (key tidak dikenal = full_name)
+	SortDesc        bool
+}
+
+// MissingRequiredDocuments adalah nilai MissingDocument untuk semua jenis dokumen wajib
+const MissingRequiredDocuments = "required"
+
+// studentSortColumns adalah daftar kolom yang boleh dipakai untuk mengurutkan daftar siswa
+var studentSortColumns = map[string]string{
+	"full_name":     "students.full_name",
+	"nisn":          "students.nisn",
+	"nim":           "students.nim",
+	"gender":        "students.gender",
+	"date_of_birth": "students.date_of_birth",
+	"city":          "students.city",
+	"status":        "students.status",
+	"entry_year":    "students.entry_year",
+	"exit_year":     "students.exit_year",
+	"created_at":    "students.created_at",
+	"updated_at":    "students.updated_at",
+}
+
+// StudentMergeResult berisi jumlah baris yang dipindahkan ke siswa yang dipertahankan
+// dan jumlah baris ganda yang dibuang karena siswa tersebut sudah punya data yang sama.
+type StudentMergeResult struct {
+	Moved   map[string]int64
+	Dropped map[string]int64
+}
+
+// GuardianRefIssue adalah satu siswa dengan pointer wali (guardian_type + guardian_id) yang rusak.
+// FallbackParentID berisi orang tua aktif dari student_parent yang bisa dijadikan wali pengganti.
+type GuardianRefIssue struct {
+	StudentID        string
+	FullName         string
+	GuardianType     *string
+	GuardianID       *string
+	Reason           string // missing_id, missing_type, unknown_type, parent_not_found, parent_deleted, guardian_not_found, guardian_deleted
+	FallbackParentID *string
+	StudentDeleted   bool
+}
+
 type StudentRepository interface {
 	Create(student *domain.Student) error
 	FindByID(id string) (*domain.Student, error)
 	FindByNISN(nisn string) (*domain.Student, error)
 	FindByNIM(nim string) (*domain.Student, error)
-	FindAll(search string, classroomID string, limit, offset int) ([]domain.Student, int64, error)
+	FindAll(filter StudentFilter, limit, offset int) ([]domain.Student, int64, error)
+	FindAllInBatches(filter StudentFilter, batchSize int, fn func(students []domain.Student) error) error
+	FindGuardianNames(students []domain.Student) (map[string]string, error)
 	Update(student *domain.Student) error
 	Delete(id string) error
 	FindByIDWithParents(id string) (*domain.Student, error)
 	SyncParents(studentID string, parents []domain.StudentParent) error
 	SetGuardian(studentID string, guardianID *string, guardianType *string) error
+	// FindByGuardian mengambil siswa (non-trash) yang walinya menunjuk ke parent/guardian tertentu
+	FindByGuardian(guardianType, guardianID string) ([]domain.Student, error)
+	// CountByGuardian menghitung semua siswa (termasuk trash) yang walinya menunjuk ke parent/guardian tertentu
+	CountByGuardian(guardianType, guardianID string) (int64, error)
+	FindGuardianRefIssues() ([]GuardianRefIssue, error)
+	// RepairGuardianRef mengganti pointer wali satu siswa, termasuk siswa di trash
+	RepairGuardianRef(studentID string, guardianType, guardianID *string) error
 	SetUserID(studentID string, userID *string) error
 	FindByNIKHash(hash string) (*domain.Student, error)
 	FindByUserID(userID string) (*domain.Student, error)
+	// FindIDsByFamilyUserID mengambil ID siswa yang tertaut ke akun user sebagai orang tua atau wali
+	FindIDsByFamilyUserID(userID string) ([]string, error)
 	FindByClassroomID(classroomID string) ([]domain.Student, error)
+	ImportStudents(records []StudentImportRecord) error
+	FindParentLinks(studentID string) ([]domain.StudentParent, error)
+	// FindByIDsWithParents mengambil siswa beserta relasi orang tuanya, untuk routing kontak
+	FindByIDsWithParents(ids []string) ([]domain.Student, error)
+	// FindActiveIDs mengambil ID siswa aktif, dibatasi ke penempatan aktif di kelas tsb jika classroomID diisi
+	FindActiveIDs(classroomID string) ([]string, error)
+	SetPrimaryContact(studentID string, contactID, contactType *string) error
+	ImportFamilies(records []FamilyImportRecord) error
+	FindDuplicateCandidates() ([]domain.Student, error)
+	SetPhoto(studentID string, photo *string) error
+	FindByIDWithActiveClassroom(id string) (*domain.Student, error)
+	Merge(survivor *domain.Student, duplicateID string) (*StudentMergeResult, error)
 }
 
 type studentRepository struct {
@@ -33,7 +132,12 @@ func NewStudentRepository(db *gorm.DB) StudentRepository {
 }
 
 func (r *studentRepository) Create(student *domain.Student) error {
-	return r.db.Create(student).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignHousehold(tx, student); err != nil {
+			return err
+		}
+		return tx.Create(student).Error
+	})
 }
 
 func (r *studentRepository) FindByID(id string) (*domain.Student, error) {
@@ -51,7 +155,8 @@ func (r *studentRepository) FindByID(id string) (*domain.Student, error) {
 
 func (r *studentRepository) FindByNISN(nisn string) (*domain.Student, error) {
 	var student domain.Student
-	err := r.db.First(&student, "nisn = ?", nisn).Error
+	// Unscoped: data di trash tetap memegang unique index, jadi ikut dicek
+	err := r.db.Unscoped().First(&student, "nisn = ?", nisn).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -63,7 +168,7 @@ func (r *studentRepository) FindByNISN(nisn string) (*domain.Student, error) {
 
 func (r *studentRepository) FindByNIM(nim string) (*domain.Student, error) {
 	var student domain.Student
-	err := r.db.First(&student, "nim = ?", nim).Error
+	err := r.db.Unscoped().First(&student, "nim = ?", nim).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -73,38 +178,186 @@ func (r *studentRepository) FindByNIM(nim string) (*domain.Student, error) {
 	return &student, nil
 }
 
-func (r *studentRepository) FindAll(search string, classroomID string, limit, offset int) ([]domain.Student, int64, error) {
-	var students []domain.Student
-	var total int64
-	query := r.db.Model(&domain.Student{})
-
-	if classroomID != "" {
+// applyFilter menerapkan StudentFilter ke query students
+func (r *studentRepository) applyFilter(query *gorm.DB, filter StudentFilter) *gorm.DB {
+	if filter.ClassroomID != "" {
 		// Filter by classroom using JOIN
 		query = query.Joins("JOIN student_classrooms sc ON sc.student_id = students.id").
-			Where("sc.classroom_id = ? AND sc.status = ?", classroomID, "ACTIVE")
+			Where("sc.classroom_id = ? AND sc.status = ?", filter.ClassroomID, "ACTIVE")
+	}
+
+	if filter.AcademicYearID != "" {
+		query = query.Where("students.id IN (SELECT sc2.student_id FROM student_classrooms sc2 JOIN classrooms c2 ON c2.id = sc2.classroom_id WHERE c2.academic_year_id = ?)", filter.AcademicYearID)
 	}
 
-	if search != "" {
-		searchPattern := "%" + search + "%"
+	if filter.Status != "" {
+		query = query.Where("students.status = ?", filter.Status)
+	}
+
+	if filter.Gender != "" {
+		query = query.Where("students.gender = ?", filter.Gender)
+	}
+
+	if filter.EntryYear != "" {
+		query = query.Where("students.entry_year = ?", filter.EntryYear)
+	}
+
+	if filter.ExitYear != "" {
+		query = query.Where("students.exit_year = ?", filter.ExitYear)
+	}
+
+	if filter.City != "" {
+		query = query.Where("students.city LIKE ?", "%"+filter.City+"%")
+	}
+
+	if filter.Province != "" {
+		query = query.Where("students.province LIKE ?", "%"+filter.Province+"%")
+	}
+
+	if filter.HasGuardian != nil {
+		if *filter.HasGuardian {
+			query = query.Where("students.guardian_id IS NOT NULL")
+		} else {
+			query = query.Where("students.guardian_id IS NULL")
+		}
+	}
+
+	if filter.HasUser != nil {
+		if *filter.HasUser {
+			query = query.Where("students.user_id IS NOT NULL")
+		} else {
+			query = query.Where("students.user_id IS NULL")
+		}
+	}
+
+	if filter.MissingDocument != "" {
+		// Dokumen dianggap ada jika versi terbarunya tidak ditolak (sama dengan laporan kelengkapan)
+		const hasDocument = "SELECT 1 FROM documents d WHERE d.document_type_id = dt.id AND d.owner_type = 'student' " +
+			"AND d.owner_id = students.id AND d.is_current = TRUE AND d.status <> 'rejected' AND d.deleted_at IS NULL"
+		if filter.MissingDocument == MissingRequiredDocuments {
+			query = query.Where("EXISTS (SELECT 1 FROM document_types dt WHERE dt.owner_type = 'student' AND dt.is_required = TRUE " +
+				"AND dt.deleted_at IS NULL AND NOT EXISTS (" + hasDocument + "))")
+		} else {
+			query = query.Where("EXISTS (SELECT 1 FROM document_types dt WHERE dt.owner_type = 'student' AND dt.code = ? "+
+				"AND dt.deleted_at IS NULL AND NOT EXISTS ("+hasDocument+"))", filter.MissingDocument)
+		}
+	}
+
+	if filter.Search != "" {
+		searchPattern := "%" + filter.Search + "%"
 		query = query.Where("students.full_name LIKE ? OR students.nisn LIKE ? OR students.nim LIKE ? OR students.city LIKE ?", searchPattern, searchPattern, searchPattern, searchPattern)
 	}
+	return query
+}
+
+// applyStudentSort mengurutkan sesuai filter (default nama), selalu diakhiri id agar urutan stabil antar halaman/batch
+func applyStudentSort(query *gorm.DB, filter StudentFilter) *gorm.DB {
+	column, ok := studentSortColumns[filter.Sort]
+	if !ok {
+		column = studentSortColumns["full_name"]
+	}
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	return query.Order(column + " " + direction).Order("students.id ASC")
+}
+
+func (r *studentRepository) FindAll(filter StudentFilter, limit, offset int) ([]domain.Student, int64, error) {
+	var students []domain.Student
+	var total int64
+	query := r.applyFilter(r.db.Model(&domain.Student{}), filter)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := query.
+	err := applyStudentSort(query, filter).
 		Preload("User").
 		Preload("StudentClassrooms", "status = ?", "ACTIVE").
 		Preload("StudentClassrooms.Classroom").
-		Order("students.full_name ASC").
 		Limit(limit).Offset(offset).
 		Find(&students).Error
 	return students, total, err
 }
 
+// FindAllInBatches membaca semua siswa yang cocok dengan filter per batch (untuk export),
+// lengkap dengan kelas aktif dan orang tua, tanpa memuat semuanya sekaligus ke memori.
+func (r *studentRepository) FindAllInBatches(filter StudentFilter, batchSize int, fn func(students []domain.Student) error) error {
+	for offset := 0; ; offset += batchSize {
+		var students []domain.Student
+		err := applyStudentSort(r.applyFilter(r.db.Model(&domain.Student{}), filter), filter).
+			Preload("StudentClassrooms", "status = ?", "ACTIVE").
+			Preload("StudentClassrooms.Classroom").
+			Preload("Parents").
+			Preload("Parents.Parent").
+			Limit(batchSize).Offset(offset).
+			Find(&students).Error
+		if err != nil {
+			return err
+		}
+		if len(students) == 0 {
+			return nil
+		}
+		if err := fn(students); err != nil {
+			return err
+		}
+		if len(students) < batchSize {
+			return nil
+		}
+	}
+}
+
+// FindGuardianNames mengembalikan peta guardian_id -> nama wali (parent atau guardian)
+func (r *studentRepository) FindGuardianNames(students []domain.Student) (map[string]string, error) {
+	var parentIDs, guardianIDs []string
+	for _, student := range students {
+		if student.GuardianID == nil || student.GuardianType == nil {
+			continue
+		}
+		if *student.GuardianType == "parent" {
+			parentIDs = append(parentIDs, *student.GuardianID)
+		} else {
+			guardianIDs = append(guardianIDs, *student.GuardianID)
+		}
+	}
+
+	names := make(map[string]string)
+	var rows []struct {
+		ID       string
+		FullName string
+	}
+	if len(parentIDs) > 0 {
+		if err := r.db.Table("parents").Select("id, full_name").Where("id IN ?", parentIDs).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			names[row.ID] = row.FullName
+		}
+	}
+	if len(guardianIDs) > 0 {
+		rows = nil
+		if err := r.db.Table("guardians").Select("id, full_name").Where("id IN ?", guardianIDs).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			names[row.ID] = row.FullName
+		}
+	}
+	return names, nil
+}
+
+// Update menyimpan siswa dan menyesuaikan household jika No KK berubah
 func (r *studentRepository) Update(student *domain.Student) error {
-	return r.db.Save(student).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := assignHousehold(tx, student); err != nil {
+			return err
+		}
+		if err := tx.Save(student).Error; err != nil {
+			return err
+		}
+		return removeEmptyHouseholds(tx)
+	})
 }
 
 func (r *studentRepository) Delete(id string) error {
@@ -169,14 +422,97 @@ func (r *studentRepository) SetGuardian(studentID string, guardianID *string, gu
 }
 
 // SetUserID meng-update kolom user_id untuk student
+func (r *studentRepository) FindByGuardian(guardianType, guardianID string) ([]domain.Student, error) {
+	var students []domain.Student
+	err := r.db.Where("guardian_type = ? AND guardian_id = ?", guardianType, guardianID).
+		Order("full_name ASC").
+		Find(&students).Error
+	return students, err
+}
+
+func (r *studentRepository) CountByGuardian(guardianType, guardianID string) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&domain.Student{}).
+		Where("guardian_type = ? AND guardian_id = ?", guardianType, guardianID).
+		Count(&count).Error
+	return count, err
+}
+
+// reassignGuardianPointer memindahkan pointer wali semua siswa (termasuk trash) dari satu parent/guardian
+// ke target baru; target nil berarti dikosongkan. Dipakai saat parent/guardian dihapus.
+func reassignGuardianPointer(tx *gorm.DB, fromType, fromID string, toType, toID *string) error {
+	return tx.Unscoped().Model(&domain.Student{}).
+		Where("guardian_type = ? AND guardian_id = ?", fromType, fromID).
+		Updates(map[string]interface{}{
+			"guardian_type": toType,
+			"guardian_id":   toID,
+		}).Error
+}
+
+func (r *studentRepository) FindGuardianRefIssues() ([]GuardianRefIssue, error) {
+	var issues []GuardianRefIssue
+	err := r.db.Raw(`SELECT s.id AS student_id, s.full_name, s.guardian_type, s.guardian_id,
+			CASE
+				WHEN s.guardian_id IS NULL THEN 'missing_id'
+				WHEN s.guardian_type IS NULL THEN 'missing_type'
+				WHEN s.guardian_type NOT IN ('parent', 'guardian') THEN 'unknown_type'
+				WHEN s.guardian_type = 'parent' AND p.id IS NULL THEN 'parent_not_found'
+				WHEN s.guardian_type = 'parent' THEN 'parent_deleted'
+				WHEN g.id IS NULL THEN 'guardian_not_found'
+				ELSE 'guardian_deleted'
+			END AS reason,
+			(SELECT sp.parent_id FROM student_parent sp
+				JOIN parents fp ON fp.id = sp.parent_id AND fp.deleted_at IS NULL
+				WHERE sp.student_id = s.id
+				ORDER BY sp.created_at ASC LIMIT 1) AS fallback_parent_id,
+			s.deleted_at IS NOT NULL AS student_deleted
+		FROM students s
+		LEFT JOIN parents p ON s.guardian_type = 'parent' AND p.id = s.guardian_id
+		LEFT JOIN guardians g ON s.guardian_type = 'guardian' AND g.id = s.guardian_id
+		WHERE (s.guardian_id IS NOT NULL OR s.guardian_type IS NOT NULL)
+			AND NOT (s.guardian_type = 'parent' AND p.id IS NOT NULL AND p.deleted_at IS NULL)
+			AND NOT (s.guardian_type = 'guardian' AND g.id IS NOT NULL AND g.deleted_at IS NULL)
+		ORDER BY s.full_name ASC`).
+		Scan(&issues).Error
+	return issues, err
+}
+
+func (r *studentRepository) RepairGuardianRef(studentID string, guardianType, guardianID *string) error {
+	return r.db.Unscoped().Model(&domain.Student{}).Where("id = ?", studentID).Updates(map[string]interface{}{
+		"guardian_type": guardianType,
+		"guardian_id":   guardianID,
+	}).Error
+}
+
 func (r *studentRepository) SetUserID(studentID string, userID *string) error {
 	// GORM akan otomatis meng-set ke NULL jika userID adalah nil
 	return r.db.Model(&domain.Student{}).Where("id = ?", studentID).Update("user_id", userID).Error
 }
 
+// SetPhoto meng-update kolom photo (nil untuk menghapus foto)
+func (r *studentRepository) SetPhoto(studentID string, photo *string) error {
+	return r.db.Model(&domain.Student{}).Where("id = ?", studentID).Update("photo", photo).Error
+}
+
+// FindByIDWithActiveClassroom mengambil Student beserta penempatan kelas yang masih ACTIVE
+func (r *studentRepository) FindByIDWithActiveClassroom(id string) (*domain.Student, error) {
+	var student domain.Student
+	err := r.db.
+		Preload("StudentClassrooms", "status = ?", "ACTIVE").
+		Preload("StudentClassrooms.Classroom").
+		First(&student, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &student, nil
+}
+
 func (r *studentRepository) FindByNIKHash(hash string) (*domain.Student, error) {
 	var student domain.Student
-	err := r.db.First(&student, "nik_hash = ?", hash).Error
+	err := r.db.Unscoped().First(&student, "nik_hash = ?", hash).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil // Not found
 	}
@@ -198,6 +534,25 @@ func (r *studentRepository) FindByUserID(userID string) (*domain.Student, error)
 	return &student, nil
 }
 
+func (r *studentRepository) FindIDsByFamilyUserID(userID string) ([]string, error) {
+	var ids []string
+	// Orang tua/wali yang ada di trash tetap menyimpan user_id dan tautan, jadi harus dikecualikan
+	err := r.db.Raw(`SELECT sp.student_id FROM student_parent sp
+			JOIN parents p ON p.id = sp.parent_id AND p.deleted_at IS NULL
+			JOIN students s ON s.id = sp.student_id AND s.deleted_at IS NULL
+			WHERE p.user_id = ?
+		UNION
+		SELECT s.id FROM students s
+			JOIN parents p ON p.id = s.guardian_id AND p.deleted_at IS NULL
+			WHERE s.guardian_type = 'parent' AND p.user_id = ? AND s.deleted_at IS NULL
+		UNION
+		SELECT s.id FROM students s
+			JOIN guardians g ON g.id = s.guardian_id AND g.deleted_at IS NULL
+			WHERE s.guardian_type = 'guardian' AND g.user_id = ? AND s.deleted_at IS NULL`, userID, userID, userID).
+		Scan(&ids).Error
+	return ids, err
+}
+
 func (r *studentRepository) FindByClassroomID(classroomID string) ([]domain.Student, error) {
 	var students []domain.Student
 	// Join with student_classrooms table
@@ -210,3 +565,232 @@ func (r *studentRepository) FindByClassroomID(classroomID string) ([]domain.Stud
 	}
 	return students, nil
 }
+
+// ImportStudents menyimpan semua hasil import dalam satu transaksi
+func (r *studentRepository) ImportStudents(records []StudentImportRecord) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			for _, parent := range record.NewParents {
+				if err := tx.Create(parent).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := assignHousehold(tx, record.Student); err != nil {
+				return err
+			}
+			if err := tx.Omit(clause.Associations).Create(record.Student).Error; err != nil {
+				return err
+			}
+
+			if len(record.Parents) > 0 {
+				if err := tx.Create(&record.Parents).Error; err != nil {
+					return err
+				}
+			}
+
+			if record.ClassroomID != "" {
+				placement := domain.StudentClassroom{
+					ClassroomID: record.ClassroomID,
+					StudentID:   record.Student.ID,
+					Status:      "ACTIVE",
+				}
+				if err := tx.Create(&placement).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// FindParentLinks mengambil relasi student_parent milik seorang siswa (tanpa preload)
+func (r *studentRepository) FindParentLinks(studentID string) ([]domain.StudentParent, error) {
+	var links []domain.StudentParent
+	err := r.db.Where("student_id = ?", studentID).Find(&links).Error
+	return links, err
+}
+
+func (r *studentRepository) FindByIDsWithParents(ids []string) ([]domain.Student, error) {
+	var students []domain.Student
+	if len(ids) == 0 {
+		return students, nil
+	}
+	err := r.db.Preload("Parents.Parent").Where("id IN ?", ids).Order("full_name ASC").Find(&students).Error
+	return students, err
+}
+
+func (r *studentRepository) FindActiveIDs(classroomID string) ([]string, error) {
+	var ids []string
+	query := r.db.Model(&domain.Student{}).Where("students.status = ?", "ACTIVE")
+	if classroomID != "" {
+		query = query.Joins("JOIN student_classrooms sc ON sc.student_id = students.id AND sc.status = ?", "ACTIVE").
+			Where("sc.classroom_id = ?", classroomID)
+	}
+	err := query.Distinct().Pluck("students.id", &ids).Error
+	return ids, err
+}
+
+// SetPrimaryContact meng-update penanda kontak utama (polymorphic) pada tabel student
+func (r *studentRepository) SetPrimaryContact(studentID string, contactID, contactType *string) error {
+	return r.db.Model(&domain.Student{}).Where("id = ?", studentID).Updates(map[string]interface{}{
+		"primary_contact_id":   contactID,
+		"primary_contact_type": contactType,
+	}).Error
+}
+
+// ImportFamilies menyimpan hasil import orang tua/wali dalam satu transaksi
+func (r *studentRepository) ImportFamilies(records []FamilyImportRecord) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, record := range records {
+			if record.NewParent != nil {
+				if err := tx.Omit(clause.Associations).Create(record.NewParent).Error; err != nil {
+					return err
+				}
+			}
+			if record.NewGuardian != nil {
+				if err := tx.Omit(clause.Associations).Create(record.NewGuardian).Error; err != nil {
+					return err
+				}
+			}
+
+			if record.Link != nil {
+				if err := tx.Omit(clause.Associations).Create(record.Link).Error; err != nil {
+					return err
+				}
+			}
+
+			if record.GuardianType != "" {
+				err := tx.Model(&domain.Student{}).Where("id = ?", record.StudentID).Updates(map[string]interface{}{
+					"guardian_id":   record.GuardianID,
+					"guardian_type": record.GuardianType,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// FindDuplicateCandidates mengambil kolom yang dipakai untuk mencari data ganda (tanpa relasi)
+func (r *studentRepository) FindDuplicateCandidates() ([]domain.Student, error) {
+	var students []domain.Student
+	err := r.db.
+		Select("id", "full_name", "no_kk", "nik_hash", "nisn", "nim", "gender", "place_of_birth", "date_of_birth", "status").
+		Order("full_name ASC").
+		Find(&students).Error
+	return students, err
+}
+
+// studentMergeTables adalah tabel turunan yang dipindahkan saat merge. ConflictColumn diisi jika
+// satu siswa hanya boleh punya satu baris per nilai kolom tersebut (baris ganda milik duplikat dibuang).
+// OnePerStudent dipakai untuk tabel yang unik per siswa: baris duplikat dibuang jika survivor sudah punya.
+var studentMergeTables = []struct {
+	Table          string
+	ConflictColumn string
+	OnePerStudent  bool
+}{
+	{Table: "student_classrooms", ConflictColumn: "classroom_id"},
+	{Table: "attendance_details", ConflictColumn: "attendance_session_id"},
+	{Table: "student_scores", ConflictColumn: "assessment_id"},
+	{Table: "student_violations"},
+	{Table: "student_mutations"},
+	{Table: "applicants"},
+	{Table: "student_health_profiles", OnePerStudent: true},
+	{Table: "clinic_visits"},
+	{Table: "dorm_assignments"},
+	{Table: "tahfidz_sessions"},
+	{Table: "tahfidz_targets", ConflictColumn: "academic_year_id"},
+	{Table: "leave_permits"},
+}
+
+// Merge memindahkan semua relasi siswa duplikat ke survivor, menyimpan data survivor
+// yang sudah dilengkapi, lalu memindahkan duplikat ke trash. Semua dalam satu transaksi.
+func (r *studentRepository) Merge(survivor *domain.Student, duplicateID string) (*StudentMergeResult, error) {
+	result := &StudentMergeResult{Moved: map[string]int64{}, Dropped: map[string]int64{}}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// 1. Kosongkan kolom unik duplikat agar nilainya bisa dipindahkan ke survivor
+		err := tx.Model(&domain.Student{}).Where("id = ?", duplicateID).
+			Updates(map[string]interface{}{"nisn": nil, "nim": nil, "nik_hash": nil, "user_id": nil}).Error
+		if err != nil {
+			return err
+		}
+		if err := assignHousehold(tx, survivor); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Save(survivor).Error; err != nil {
+			return err
+		}
+
+		// 2. Orang tua (primary key komposit student_id + parent_id)
+		res := tx.Exec("INSERT IGNORE INTO student_parent (student_id, parent_id, relationship_type, created_at) "+
+			"SELECT ?, parent_id, relationship_type, created_at FROM student_parent WHERE student_id = ?", survivor.ID, duplicateID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Moved["student_parent"] = res.RowsAffected
+		res = tx.Exec("DELETE FROM student_parent WHERE student_id = ?", duplicateID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Dropped["student_parent"] = res.RowsAffected - result.Moved["student_parent"]
+
+		// 3. Santri hanya boleh punya satu penempatan kamar ACTIVE per tahun ajaran; penempatan aktif
+		// duplikat diakhiri (tetap sebagai riwayat) jika survivor sudah menempati kamar di tahun yang sama
+		err = tx.Exec("UPDATE dorm_assignments d JOIN dorm_assignments keep ON keep.student_id = ? "+
+			"AND keep.academic_year_id = d.academic_year_id AND keep.status = ? "+
+			"SET d.status = ?, d.end_date = CURDATE() WHERE d.student_id = ? AND d.status = ?",
+			survivor.ID, domain.DormAssignmentActive, domain.DormAssignmentEnded, duplicateID, domain.DormAssignmentActive).Error
+		if err != nil {
+			return err
+		}
+
+		// 4. Tabel dengan kolom student_id
+		for _, t := range studentMergeTables {
+			if t.OnePerStudent {
+				res := tx.Exec("DELETE d FROM "+t.Table+" d JOIN "+t.Table+" keep ON keep.student_id = ? WHERE d.student_id = ?",
+					survivor.ID, duplicateID)
+				if res.Error != nil {
+					return res.Error
+				}
+				result.Dropped[t.Table] = res.RowsAffected
+			}
+			if t.ConflictColumn != "" {
+				res := tx.Exec("DELETE d FROM "+t.Table+" d JOIN "+t.Table+" keep ON keep."+t.ConflictColumn+" = d."+t.ConflictColumn+
+					" AND keep.student_id = ? WHERE d.student_id = ?", survivor.ID, duplicateID)
+				if res.Error != nil {
+					return res.Error
+				}
+				result.Dropped[t.Table] = res.RowsAffected
+			}
+			res := tx.Exec("UPDATE "+t.Table+" SET student_id = ? WHERE student_id = ?", survivor.ID, duplicateID)
+			if res.Error != nil {
+				return res.Error
+			}
+			result.Moved[t.Table] = res.RowsAffected
+		}
+
+		// 5. Dokumen: versi terbaru milik survivor tetap menjadi versi terbaru
+		err = tx.Exec("UPDATE documents d JOIN documents keep ON keep.owner_type = d.owner_type AND keep.owner_id = ? "+
+			"AND keep.document_type_id = d.document_type_id AND keep.is_current = TRUE "+
+			"SET d.is_current = FALSE WHERE d.owner_type = ? AND d.owner_id = ?", survivor.ID, domain.DocumentOwnerStudent, duplicateID).Error
+		if err != nil {
+			return err
+		}
+		res = tx.Exec("UPDATE documents SET owner_id = ? WHERE owner_type = ? AND owner_id = ?", survivor.ID, domain.DocumentOwnerStudent, duplicateID)
+		if res.Error != nil {
+			return res.Error
+		}
+		result.Moved["documents"] = res.RowsAffected
+
+		// 6. Duplikat masuk trash (bisa dilihat kembali sebelum di-purge)
+		return tx.Delete(&domain.Student{}, "id = ?", duplicateID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"smart_school_be/internal/model/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Jenis kejadian di timeline siswa
+const (
+	TimelineAttendance = "attendance" // Absensi selain PRESENT
+	TimelineScore      = "score"
+	TimelineViolation  = "violation"
+	TimelineClassroom  = "classroom" // Penempatan / perubahan kelas
+	TimelineStatus     = "status"    // Mutasi status siswa
+)
+
+// TimelineEventTypes adalah semua jenis kejadian, urutan dipakai saat tidak ada filter
+var TimelineEventTypes = []string{TimelineAttendance, TimelineScore, TimelineViolation, TimelineClassroom, TimelineStatus}
+
+// StudentTimelineFilter membatasi kejadian yang diambil. AcademicYear nil berarti semua tahun ajaran.
+type StudentTimelineFilter struct {
+	Types        []string
+	AcademicYear *domain.AcademicYear
+}
+
+// StudentTimelineEvent adalah satu baris hasil gabungan (UNION) semua sumber kejadian
+type StudentTimelineEvent struct {
+	EventType     string
+	EventID       string
+	OccurredAt    time.Time
+	Title         string
+	Subtitle      *string
+	Status        *string
+	Score         *float64
+	MaxScore      *int
+	Points        *int
+	ClassroomName *string
+}
+
+type StudentTimelineRepository interface {
+	FindEvents(studentID string, filter StudentTimelineFilter, limit, offset int) ([]StudentTimelineEvent, int64, error)
+}
+
+type studentTimelineRepository struct {
+	db *gorm.DB
+}
+
+func NewStudentTimelineRepository(db *gorm.DB) StudentTimelineRepository {
+	return &studentTimelineRepository{db: db}
+}
+
+// timelineSources berisi SELECT per jenis kejadian dengan kolom yang seragam.
+// classroomYear dipakai untuk filter tahun ajaran lewat kelas, dateColumn untuk filter lewat rentang tanggal.
+var timelineSources = map[string]struct {
+	Query         string
+	ClassroomYear string
+	DateColumn    string
+}{
+	TimelineAttendance: {
+		Query: `SELECT 'attendance' AS event_type, ad.id AS event_id, TIMESTAMP(s.date, sch.start_time) AS occurred_at,
+			subj.name AS title, NULLIF(ad.notes, '') AS subtitle, ad.status AS status,
+			NULL AS score, NULL AS max_score, NULL AS points, c.name AS classroom_name
+			FROM attendance_details ad
+			JOIN attendance_sessions s ON s.id = ad.attendance_session_id
+			JOIN schedules sch ON sch.id = s.schedule_id
+			JOIN teaching_assignments ta ON ta.id = sch.teaching_assignment_id
+			JOIN subjects subj ON subj.id = ta.subject_id
+			JOIN classrooms c ON c.id = ta.classroom_id
+			WHERE ad.student_id = ? AND ad.status <> 'PRESENT'`,
+		ClassroomYear: "c.academic_year_id",
+	},
+	TimelineScore: {
+		Query: `SELECT 'score' AS event_type, ss.id AS event_id, TIMESTAMP(a.date) AS occurred_at,
+			a.title AS title, subj.name AS subtitle, a.type AS status,
+			ss.score AS score, a.max_score AS max_score, NULL AS points, c.name AS classroom_name
+			FROM student_scores ss
+			JOIN assessments a ON a.id = ss.assessment_id
+			JOIN teaching_assignments ta ON ta.id = a.teaching_assignment_id
+			JOIN subjects subj ON subj.id = ta.subject_id
+			JOIN classrooms c ON c.id = ta.classroom_id
+			WHERE ss.student_id = ?`,
+		ClassroomYear: "c.academic_year_id",
+	},
+	TimelineViolation: {
+		Query: `SELECT 'violation' AS event_type, v.id AS event_id, v.violation_date AS occurred_at,
+			vt.name AS title, NULLIF(v.action_taken, '') AS subtitle, NULL AS status,
+			NULL AS score, NULL AS max_score, v.points AS points, NULL AS classroom_name
+			FROM student_violations v
+			JOIN violation_types vt ON vt.id = v.violation_type_id
+			WHERE v.student_id = ? AND v.deleted_at IS NULL`,
+		DateColumn: "v.violation_date",
+	},
+	TimelineClassroom: {
+		Query: `SELECT 'classroom' AS event_type, sc.id AS event_id, sc.created_at AS occurred_at,
+			c.name AS title, ay.name AS subtitle, sc.status AS status,
+			NULL AS score, NULL AS max_score, NULL AS points, c.name AS classroom_name
+			FROM student_classrooms sc
+			JOIN classrooms c ON c.id = sc.classroom_id
+			JOIN academic_years ay ON ay.id = c.academic_year_id
+			WHERE sc.student_id = ?`,
+		ClassroomYear: "c.academic_year_id",
+	},
+	TimelineStatus: {
+		Query: `SELECT 'status' AS event_type, m.id AS event_id, TIMESTAMP(m.effective_date) AS occurred_at,
+			m.type AS title, m.reason AS subtitle, m.new_status AS status,
+			NULL AS score, NULL AS max_score, NULL AS points, c.name AS classroom_name
+			FROM student_mutations m
+			LEFT JOIN classrooms c ON c.id = m.classroom_id
+			WHERE m.student_id = ?`,
+		DateColumn: "m.effective_date",
+	},
+}
+
+// FindEvents menggabungkan semua sumber kejadian dengan UNION ALL lalu mengurutkan
+// dan mem-paginasi di database, terbaru lebih dulu.
+func (r *studentTimelineRepository) FindEvents(studentID string, filter StudentTimelineFilter, limit, offset int) ([]StudentTimelineEvent, int64, error) {
+	types := filter.Types
+	if len(types) == 0 {
+		types = TimelineEventTypes
+	}
+
+	var parts []string
+	var args []interface{}
+	for _, t := range types {
+		source, ok := timelineSources[t]
+		if !ok {
+			continue
+		}
+		query := source.Query
+		args = append(args, studentID)
+
+		if ay := filter.AcademicYear; ay != nil {
+			if source.ClassroomYear != "" {
+				query += " AND " + source.ClassroomYear + " = ?"
+				args = append(args, ay.ID)
+			} else {
+				// Kejadian tanpa kelas difilter berdasarkan rentang tanggal tahun ajaran (tanggal akhir inklusif)
+				query += " AND " + source.DateColumn + " >= ? AND " + source.DateColumn + " < ?"
+				args = append(args, ay.StartDate.Format("2006-01-02"), ay.EndDate.ToTime().AddDate(0, 0, 1).Format("2006-01-02"))
+			}
+		}
+		parts = append(parts, query)
+	}
+	if len(parts) == 0 {
+		return []StudentTimelineEvent{}, 0, nil
+	}
+	union := strings.Join(parts, " UNION ALL ")
+
+	var total int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM ("+union+") t", args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	events := []StudentTimelineEvent{}
+	pageArgs := append(append([]interface{}{}, args...), limit, offset)
+	err := r.db.Raw("SELECT * FROM ("+union+") t ORDER BY occurred_at DESC, event_type ASC, event_id ASC LIMIT ? OFFSET ?", pageArgs...).
+		Scan(&events).Error
+	return events, total, err
+}
@@ -14,6 +14,8 @@ type SubjectRepository interface {
 	FindByCode(code string) (*domain.Subject, error)
 	Update(subject *domain.Subject) error
 	Delete(id string) error
+	// CountTeachingAssignments menghitung teaching assignment yang memakai mapel ini
+	CountTeachingAssignments(id string) (int64, error)
 }
 
 type subjectRepository struct {
@@ -52,7 +54,8 @@ func (r *subjectRepository) FindByID(id string) (*domain.Subject, error) {
 
 func (r *subjectRepository) FindByCode(code string) (*domain.Subject, error) {
 	var subject domain.Subject
-	err := r.db.First(&subject, "code = ?", code).Error
+	// Unscoped: data di trash tetap memegang unique index, jadi ikut dicek
+	err := r.db.Unscoped().First(&subject, "code = ?", code).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -66,3 +69,9 @@ func (r *subjectRepository) Update(subject *domain.Subject) error {
 func (r *subjectRepository) Delete(id string) error {
 	return r.db.Delete(&domain.Subject{}, "id = ?", id).Error
 }
+
+func (r *subjectRepository) CountTeachingAssignments(id string) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.TeachingAssignment{}).Where("subject_id = ?", id).Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"smart_school_be/internal/model/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TahfidzSessionFilter adalah filter daftar sesi tahfidz
+type TahfidzSessionFilter struct {
+	StudentID      string
+	AcademicYearID string
+	Type           string
+	ExaminerID     string
+}
+
+type TahfidzRepository interface {
+	CreateSession(session *domain.TahfidzSession) error
+	FindSessionByID(id string) (*domain.TahfidzSession, error)
+	FindSessions(filter TahfidzSessionFilter, limit, offset int) ([]domain.TahfidzSession, int64, error)
+	// FindSessionsByStudents mengambil semua sesi (lintas tahun ajaran) untuk menghitung hafalan kumulatif
+	FindSessionsByStudents(studentIDs []string) ([]domain.TahfidzSession, error)
+	DeleteSession(id string) error
+
+	FindTargets(studentIDs []string, academicYearID string) ([]domain.TahfidzTarget, error)
+	UpsertTargets(targets []domain.TahfidzTarget) error
+	// FindStudentIDsByYear mengambil siswa yang punya target atau sesi di tahun ajaran tsb
+	FindStudentIDsByYear(academicYearID string) ([]string, error)
+}
+
+type tahfidzRepository struct {
+	db *gorm.DB
+}
+
+func NewTahfidzRepository(db *gorm.DB) TahfidzRepository {
+	return &tahfidzRepository{db: db}
+}
+
+func (r *tahfidzRepository) CreateSession(session *domain.TahfidzSession) error {
+	return r.db.Omit(clause.Associations).Create(session).Error
+}
+
+func (r *tahfidzRepository) FindSessionByID(id string) (*domain.TahfidzSession, error) {
+	var session domain.TahfidzSession
+	err := r.db.Preload("Student").Preload("Examiner").First(&session, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return &session, err
+}
+
+func (r *tahfidzRepository) FindSessions(filter TahfidzSessionFilter, limit, offset int) ([]domain.TahfidzSession, int64, error) {
+	var sessions []domain.TahfidzSession
+	var total int64
+
+	query := r.db.Model(&domain.TahfidzSession{})
+	if filter.StudentID != "" {
+		query = query.Where("student_id = ?", filter.StudentID)
+	}
+	if filter.AcademicYearID != "" {
+		query = query.Where("academic_year_id = ?", filter.AcademicYearID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.ExaminerID != "" {
+		query = query.Where("examiner_id = ?", filter.ExaminerID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Student").Preload("Examiner").
+		Order("session_date DESC, created_at DESC").
+		Limit(limit).Offset(offset).
+		Find(&sessions).Error
+	return sessions, total, err
+}
+
+func (r *tahfidzRepository) FindSessionsByStudents(studentIDs []string) ([]domain.TahfidzSession, error) {
+	var sessions []domain.TahfidzSession
+	if len(studentIDs) == 0 {
+		return sessions, nil
+	}
+	err := r.db.Preload("Examiner").
+		Where("student_id IN ?", studentIDs).
+		Order("session_date ASC, created_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *tahfidzRepository) DeleteSession(id string) error {
+	return r.db.Delete(&domain.TahfidzSession{}, "id = ?", id).Error
+}
+
+func (r *tahfidzRepository) FindTargets(studentIDs []string, academicYearID string) ([]domain.TahfidzTarget, error) {
+	var targets []domain.TahfidzTarget
+	if len(studentIDs) == 0 {
+		return targets, nil
+	}
+	err := r.db.Where("student_id IN ? AND academic_year_id = ?", studentIDs, academicYearID).Find(&targets).Error
+	return targets, err
+}
+
+// UpsertTargets membuat atau memperbarui target (unik per siswa per tahun ajaran)
+func (r *tahfidzRepository) UpsertTargets(targets []domain.TahfidzTarget) error {
+	if len(targets) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "student_id"}, {Name: "academic_year_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_juz", "notes", "updated_at"}),
+	}).Create(&targets).Error
+}
+
+func (r *tahfidzRepository) FindStudentIDsByYear(academicYearID string) ([]string, error) {
+	var ids []string
+	err := r.db.Raw(`SELECT student_id FROM tahfidz_targets WHERE academic_year_id = ?
+		UNION SELECT student_id FROM tahfidz_sessions WHERE academic_year_id = ?`, academicYearID, academicYearID).
+		Scan(&ids).Error
+	return ids, err
+}
@@ -11,6 +11,8 @@ type TeachingAssignmentRepository interface {
 	FindByID(id string) (*domain.TeachingAssignment, error)
 	FindByClassroomID(classroomID string) ([]domain.TeachingAssignment, error)
 	FindByTeacherID(teacherID string) ([]domain.TeachingAssignment, error)
+	// FindByAcademicYear mengambil penugasan mengajar di kelas-kelas tahun ajaran tsb, opsional untuk satu guru
+	FindByAcademicYear(academicYearID, teacherID string) ([]domain.TeachingAssignment, error)
 	FindOne(classroomID, subjectID string) (*domain.TeachingAssignment, error)
 	Delete(id string) error
 }
@@ -53,6 +55,18 @@ func (r *teachingAssignmentRepository) FindByTeacherID(teacherID string) ([]doma
 	return assignments, err
 }
 
+func (r *teachingAssignmentRepository) FindByAcademicYear(academicYearID, teacherID string) ([]domain.TeachingAssignment, error) {
+	var assignments []domain.TeachingAssignment
+	query := r.db.Preload("Classroom").Preload("Subject").Preload("Teacher").
+		Joins("JOIN classrooms c ON c.id = teaching_assignments.classroom_id").
+		Where("c.academic_year_id = ?", academicYearID)
+	if teacherID != "" {
+		query = query.Where("teaching_assignments.teacher_id = ?", teacherID)
+	}
+	err := query.Find(&assignments).Error
+	return assignments, err
+}
+
 func (r *teachingAssignmentRepository) FindOne(classroomID, subjectID string) (*domain.TeachingAssignment, error) {
 	var assignment domain.TeachingAssignment
 	err := r.db.Where("classroom_id = ? AND subject_id = ?", classroomID, subjectID).First(&assignment).Error
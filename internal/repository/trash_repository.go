@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TrashEntity mendeskripsikan satu tabel yang mendukung soft delete
+type TrashEntity struct {
+	Table     string // Nama tabel di database
+	LabelExpr string // Ekspresi SQL untuk label yang ditampilkan di daftar trash
+	// KeepIf adalah kondisi SQL untuk baris yang tidak boleh di-purge karena ON DELETE CASCADE
+	// akan ikut menghapus riwayat milik data yang tidak dihapus (mis. absensi dan nilai siswa)
+	KeepIf string
+}
+
+// TrashEntities adalah daftar tabel yang bisa dilihat, dipulihkan, dan di-purge.
+// Urutan penting untuk purge: tabel anak dibersihkan sebelum tabel induk.
+var TrashEntities = map[string]TrashEntity{
+	"violations": {Table: "student_violations", LabelExpr: "(SELECT s.full_name FROM students s WHERE s.id = student_violations.student_id)"},
+	"students":   {Table: "students", LabelExpr: "full_name"},
+	"parents":    {Table: "parents", LabelExpr: "full_name"},
+	"guardians":  {Table: "guardians", LabelExpr: "full_name"},
+	"classrooms": {
+		Table:     "classrooms",
+		LabelExpr: "name",
+		KeepIf: "EXISTS (SELECT 1 FROM teaching_assignments ta WHERE ta.classroom_id = classrooms.id) " +
+			"OR EXISTS (SELECT 1 FROM student_classrooms sc WHERE sc.classroom_id = classrooms.id)",
+	},
+	"subjects": {
+		Table:     "subjects",
+		LabelExpr: "name",
+		KeepIf:    "EXISTS (SELECT 1 FROM teaching_assignments ta WHERE ta.subject_id = subjects.id)",
+	},
+	"employees": {Table: "employees", LabelExpr: "full_name"},
+	"documents": {Table: "documents", LabelExpr: "COALESCE(original_name, file_path)"},
+}
+
+// TrashPurgeOrder adalah urutan purge agar tidak melanggar foreign key
+var TrashPurgeOrder = []string{"documents", "violations", "students", "parents", "guardians", "classrooms", "subjects", "employees"}
+
+// TrashItem adalah satu baris data yang berada di trash
+type TrashItem struct {
+	ID        string
+	Label     string
+	DeletedAt time.Time
+}
+
+type TrashRepository interface {
+	FindDeleted(entity TrashEntity, limit, offset int) ([]TrashItem, int64, error)
+	Restore(entity TrashEntity, id string) (bool, error)
+	// PurgeBefore mengembalikan jumlah baris yang dihapus dan yang ditahan karena KeepIf
+	PurgeBefore(entity TrashEntity, cutoff time.Time) (int64, int64, error)
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// FindDeleted mengambil baris yang sudah di-soft delete, terbaru lebih dulu.
+// Memakai .Table() sehingga scope soft delete GORM tidak berlaku.
+func (r *trashRepository) FindDeleted(entity TrashEntity, limit, offset int) ([]TrashItem, int64, error) {
+	var items []TrashItem
+	var total int64
+
+	query := r.db.Table(entity.Table).Where("deleted_at IS NOT NULL")
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Select("id, " + entity.LabelExpr + " AS label, deleted_at").
+		Order("deleted_at DESC").
+		Limit(limit).Offset(offset).
+		Scan(&items).Error
+	return items, total, err
+}
+
+// Restore mengosongkan deleted_at. Mengembalikan false jika baris tidak ada di trash.
+func (r *trashRepository) Restore(entity TrashEntity, id string) (bool, error) {
+	result := r.db.Table(entity.Table).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// PurgeBefore menghapus permanen baris yang di-soft delete sebelum cutoff.
+// Relasi turunan (student_parent, student_classrooms, dll.) ikut terhapus lewat ON DELETE CASCADE,
+// kecuali baris yang memenuhi KeepIf: baris tsb tetap di trash sampai data turunannya dibereskan.
+func (r *trashRepository) PurgeBefore(entity TrashEntity, cutoff time.Time) (int64, int64, error) {
+	condition := "deleted_at IS NOT NULL AND deleted_at < ?"
+	var kept int64
+	if entity.KeepIf != "" {
+		err := r.db.Table(entity.Table).Where(condition, cutoff).Where(entity.KeepIf).Count(&kept).Error
+		if err != nil {
+			return 0, 0, err
+		}
+		condition += " AND NOT (" + entity.KeepIf + ")"
+	}
+	result := r.db.Exec("DELETE FROM "+entity.Table+" WHERE "+condition, cutoff)
+	return result.RowsAffected, kept, result.Error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPurgeBeforeKeepsClassroomsWithHistory(t *testing.T) {
+	db := newTestDB(t,
+		`CREATE TABLE classrooms (id TEXT PRIMARY KEY, name TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE teaching_assignments (id TEXT PRIMARY KEY, classroom_id TEXT, subject_id TEXT)`,
+		`CREATE TABLE student_classrooms (id TEXT PRIMARY KEY, classroom_id TEXT, student_id TEXT)`,
+	)
+	trashedAt := time.Now().AddDate(0, 0, -60)
+	err := db.Exec(`INSERT INTO classrooms (id, name, deleted_at) VALUES ('c-assigned', 'X-A', ?), ('c-history', 'X-B', ?), ('c-empty', 'X-C', ?)`,
+		trashedAt, trashedAt, trashedAt).Error
+	if err != nil {
+		t.Fatalf("seed classrooms: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO teaching_assignments (id, classroom_id, subject_id) VALUES ('ta1', 'c-assigned', 'math')`).Error; err != nil {
+		t.Fatalf("seed teaching assignment: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO student_classrooms (id, classroom_id, student_id) VALUES ('sc1', 'c-history', 's1')`).Error; err != nil {
+		t.Fatalf("seed student classroom: %v", err)
+	}
+
+	purged, kept, err := NewTrashRepository(db).PurgeBefore(TrashEntities["classrooms"], time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != 1 || kept != 2 {
+		t.Errorf("purged = %d, kept = %d; want 1 and 2", purged, kept)
+	}
+
+	var remaining []string
+	db.Table("classrooms").Order("id").Pluck("id", &remaining)
+	if len(remaining) != 2 || remaining[0] != "c-assigned" || remaining[1] != "c-history" {
+		t.Errorf("remaining classrooms = %v", remaining)
+	}
+}
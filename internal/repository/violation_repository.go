@@ -154,7 +154,9 @@ func (r *violationRepository) DeleteViolation(id string) error {
 func (r *violationRepository) FindAllViolations(filter string, limit, offset int) ([]domain.StudentViolation, int64, error) {
 	var violations []domain.StudentViolation
 	var total int64
-	query := r.db.Model(&domain.StudentViolation{})
+	// Pelanggaran milik siswa yang ada di trash tidak ikut ditampilkan
+	query := r.db.Model(&domain.StudentViolation{}).
+		Where("student_violations.student_id IN (SELECT id FROM students WHERE deleted_at IS NULL)")
 
 	if filter != "" {
 		searchPattern := "%" + filter + "%"
@@ -0,0 +1,853 @@
+package service
+
+import (
+	"fmt"
+	"mime/multipart"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
+	"sort"
+	"strings"
+	"time"
+)
+
+// applicantTransitions adalah status tujuan yang diizinkan dari setiap status pendaftar.
+// Status accepted dan rejected adalah status akhir.
+var applicantTransitions = map[string][]string{
+	domain.ApplicantStatusSubmitted: {domain.ApplicantStatusVerified, domain.ApplicantStatusRejected},
+	domain.ApplicantStatusVerified:  {domain.ApplicantStatusTested, domain.ApplicantStatusRejected},
+	domain.ApplicantStatusTested:    {domain.ApplicantStatusAccepted, domain.ApplicantStatusRejected},
+}
+
+type AdmissionService interface {
+	// Admission Waves
+	CreateWave(req request.AdmissionWaveRequest) (*response.AdmissionWaveResponse, error)
+	GetWaves(academicYearID string) ([]response.AdmissionWaveResponse, error)
+	GetOpenWaves() ([]response.AdmissionWaveResponse, error)
+	UpdateWave(id string, req request.AdmissionWaveRequest) (*response.AdmissionWaveResponse, error)
+	DeleteWave(id string) error
+
+	// Applicants
+	Apply(req request.ApplicantCreateRequest, files map[string]*multipart.FileHeader) (*response.ApplicantStatusCheckResponse, error)
+	CheckStatus(req request.ApplicantStatusCheckRequest) (*response.ApplicantStatusCheckResponse, error)
+	GetApplicants(filter request.ApplicantFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetApplicantByID(id string) (*response.ApplicantDetailResponse, error)
+	UpdateStatus(id string, req request.ApplicantStatusRequest, userID string) (*response.ApplicantDetailResponse, error)
+	ConvertToStudent(id string, req request.ApplicantConvertRequest) (*response.ApplicantConvertResponse, error)
+}
+
+type admissionService struct {
+	admissionRepo    repository.AdmissionRepository
+	academicYearRepo repository.AcademicYearRepository
+	classroomRepo    repository.ClassroomRepository
+	studentRepo      repository.StudentRepository
+	parentRepo       repository.ParentRepository
+	documentRepo     repository.DocumentRepository
+	encryptionUtil   utils.EncryptionUtil
+	identity         *validation.IdentityValidator
+	baseURL          string
+}
+
+func NewAdmissionService(
+	admissionRepo repository.AdmissionRepository,
+	academicYearRepo repository.AcademicYearRepository,
+	classroomRepo repository.ClassroomRepository,
+	studentRepo repository.StudentRepository,
+	parentRepo repository.ParentRepository,
+	documentRepo repository.DocumentRepository,
+	encryptionUtil utils.EncryptionUtil,
+	identity *validation.IdentityValidator,
+	baseURL string,
+) AdmissionService {
+	return &admissionService{
+		admissionRepo:    admissionRepo,
+		academicYearRepo: academicYearRepo,
+		classroomRepo:    classroomRepo,
+		studentRepo:      studentRepo,
+		parentRepo:       parentRepo,
+		documentRepo:     documentRepo,
+		encryptionUtil:   encryptionUtil,
+		identity:         identity,
+		baseURL:          baseURL,
+	}
+}
+
+// optionalString mengubah string kosong menjadi nil
+func optionalString(v string) *string {
+	if v = strings.TrimSpace(v); v == "" {
+		return nil
+	}
+	return &v
+}
+
+// --- Admission Waves ---
+
+func (s *admissionService) validateWave(req request.AdmissionWaveRequest) error {
+	year, err := s.academicYearRepo.FindByID(req.AcademicYearID)
+	if err != nil {
+		return err
+	}
+	if year == nil {
+		return apperrors.NewNotFoundError("Academic year not found")
+	}
+	if req.EndDate.ToTime().Before(req.StartDate.ToTime()) {
+		return apperrors.NewBadRequestError("end_date must be on or after start_date")
+	}
+	return nil
+}
+
+func (s *admissionService) waveResponse(wave *domain.AdmissionWave) (*response.AdmissionWaveResponse, error) {
+	total, err := s.admissionRepo.CountApplicants(wave.ID)
+	if err != nil {
+		return nil, err
+	}
+	accepted, err := s.admissionRepo.CountApplicants(wave.ID, domain.ApplicantStatusAccepted)
+	if err != nil {
+		return nil, err
+	}
+	res := response.FromDomainAdmissionWave(wave)
+	res.TotalApplicants = &total
+	res.TotalAccepted = &accepted
+	return &res, nil
+}
+
+func (s *admissionService) CreateWave(req request.AdmissionWaveRequest) (*response.AdmissionWaveResponse, error) {
+	if err := s.validateWave(req); err != nil {
+		return nil, err
+	}
+
+	wave := &domain.AdmissionWave{
+		AcademicYearID: req.AcademicYearID,
+		Name:           strings.TrimSpace(req.Name),
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		Quota:          req.Quota,
+		IsOpen:         req.IsOpen == nil || *req.IsOpen,
+		Description:    req.Description,
+	}
+	if err := s.admissionRepo.CreateWave(wave); err != nil {
+		return nil, err
+	}
+
+	created, err := s.admissionRepo.FindWaveByID(wave.ID)
+	if err != nil {
+		return nil, err
+	}
+	return s.waveResponse(created)
+}
+
+func (s *admissionService) GetWaves(academicYearID string) ([]response.AdmissionWaveResponse, error) {
+	waves, err := s.admissionRepo.FindWaves(academicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.AdmissionWaveResponse, 0, len(waves))
+	for i := range waves {
+		item, err := s.waveResponse(&waves[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *item)
+	}
+	return res, nil
+}
+
+// GetOpenWaves menampilkan gelombang yang sedang dibuka untuk formulir pendaftaran publik (tanpa statistik)
+func (s *admissionService) GetOpenWaves() ([]response.AdmissionWaveResponse, error) {
+	waves, err := s.admissionRepo.FindOpenWaves(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.AdmissionWaveResponse, 0, len(waves))
+	for i := range waves {
+		res = append(res, response.FromDomainAdmissionWave(&waves[i]))
+	}
+	return res, nil
+}
+
+func (s *admissionService) UpdateWave(id string, req request.AdmissionWaveRequest) (*response.AdmissionWaveResponse, error) {
+	wave, err := s.admissionRepo.FindWaveByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if wave == nil {
+		return nil, apperrors.NewNotFoundError("Admission wave not found")
+	}
+	if err := s.validateWave(req); err != nil {
+		return nil, err
+	}
+
+	if req.AcademicYearID != wave.AcademicYearID {
+		// Pendaftar dikonversi ke kelas di tahun ajaran gelombang, jadi tahun ajaran dikunci setelah ada pendaftar
+		count, err := s.admissionRepo.CountApplicants(id)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, apperrors.NewBadRequestError("Cannot change the academic year of a wave that already has applicants")
+		}
+	}
+	if req.Quota > 0 {
+		accepted, err := s.admissionRepo.CountApplicants(id, domain.ApplicantStatusAccepted)
+		if err != nil {
+			return nil, err
+		}
+		if int64(req.Quota) < accepted {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Quota cannot be lower than the %d applicants already accepted", accepted))
+		}
+	}
+
+	wave.AcademicYearID = req.AcademicYearID
+	wave.Name = strings.TrimSpace(req.Name)
+	wave.StartDate = req.StartDate
+	wave.EndDate = req.EndDate
+	wave.Quota = req.Quota
+	if req.IsOpen != nil {
+		wave.IsOpen = *req.IsOpen
+	}
+	wave.Description = req.Description
+	wave.AcademicYear = nil
+
+	if err := s.admissionRepo.UpdateWave(wave); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.admissionRepo.FindWaveByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.waveResponse(updated)
+}
+
+// DeleteWave hanya untuk gelombang yang belum punya pendaftar; gelombang lama cukup ditutup (is_open = false)
+func (s *admissionService) DeleteWave(id string) error {
+	wave, err := s.admissionRepo.FindWaveByID(id)
+	if err != nil {
+		return err
+	}
+	if wave == nil {
+		return apperrors.NewNotFoundError("Admission wave not found")
+	}
+	count, err := s.admissionRepo.CountApplicants(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.NewBadRequestError("Admission wave already has applicants, close it instead")
+	}
+	return s.admissionRepo.DeleteWave(id)
+}
+
+// --- Applicants ---
+
+// registrationPrefix adalah prefix nomor pendaftaran PPDB-<tahun mulai tahun ajaran>-.
+// Nomor urut 4 digit dialokasikan repository saat pendaftar disimpan.
+func registrationPrefix(wave *domain.AdmissionWave) string {
+	year := time.Now().Year()
+	if wave.AcademicYear != nil {
+		year = wave.AcademicYear.StartDate.ToTime().Year()
+	}
+	return fmt.Sprintf("PPDB-%d-", year)
+}
+
+// buildApplicantParent menyusun data orang tua pendaftar dengan NIK terenkripsi
+func (s *admissionService) buildApplicantParent(req *request.ApplicantParentRequest, relationship, gender string) (*domain.ApplicantParent, error) {
+	parent := &domain.ApplicantParent{
+		RelationshipType: relationship,
+		FullName:         strings.TrimSpace(req.FullName),
+		Gender:           utils.StringPtr(gender),
+		PlaceOfBirth:     req.PlaceOfBirth,
+		DateOfBirth:      req.DateOfBirth,
+		LifeStatus:       req.LifeStatus,
+		PhoneNumber:      req.PhoneNumber,
+		Email:            req.Email,
+		EducationLevel:   req.EducationLevel,
+		Occupation:       req.Occupation,
+		IncomeRange:      req.IncomeRange,
+	}
+
+	if nik := strings.TrimSpace(utils.SafeString(req.NIK)); nik != "" {
+		if _, err := s.identity.Validate(strings.ToLower(relationship), validation.Person{
+			NIK:         nik,
+			Gender:      gender,
+			DateOfBirth: dateToTime(req.DateOfBirth),
+		}); err != nil {
+			return nil, err
+		}
+		hash, err := s.encryptionUtil.Hash(nik)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to hash NIK")
+		}
+		encrypted, err := s.encryptionUtil.Encrypt(nik)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to encrypt NIK")
+		}
+		parent.NIK = &encrypted
+		parent.NIKHash = &hash
+	}
+	return parent, nil
+}
+
+// Apply menerima formulir pendaftaran publik beserta dokumen (key = kode jenis dokumen siswa)
+func (s *admissionService) Apply(req request.ApplicantCreateRequest, files map[string]*multipart.FileHeader) (*response.ApplicantStatusCheckResponse, error) {
+	wave, err := s.admissionRepo.FindWaveByID(req.AdmissionWaveID)
+	if err != nil {
+		return nil, err
+	}
+	if wave == nil {
+		return nil, apperrors.NewNotFoundError("Admission wave not found")
+	}
+	if !wave.AcceptsApplications(time.Now()) {
+		return nil, apperrors.NewBadRequestError("Admission wave is not open for applications")
+	}
+
+	// 1. Validasi identitas
+	dob := req.DateOfBirth.ToTime()
+	if dob.IsZero() || dob.After(time.Now()) {
+		return nil, apperrors.NewBadRequestError("Invalid date_of_birth")
+	}
+	if _, err := s.identity.Validate("applicant", validation.Person{
+		NIK:         req.NIK,
+		NoKK:        req.NoKK,
+		NISN:        req.NISN,
+		Gender:      req.Gender,
+		DateOfBirth: &dob,
+	}); err != nil {
+		return nil, err
+	}
+
+	applicant := &domain.Applicant{
+		AdmissionWaveID: wave.ID,
+		Status:          domain.ApplicantStatusSubmitted,
+		FullName:        strings.TrimSpace(req.FullName),
+		NISN:            optionalString(req.NISN),
+		Gender:          req.Gender,
+		PlaceOfBirth:    optionalString(req.PlaceOfBirth),
+		DateOfBirth:     req.DateOfBirth,
+		Address:         optionalString(req.Address),
+		RT:              optionalString(req.RT),
+		RW:              optionalString(req.RW),
+		SubDistrict:     optionalString(req.SubDistrict),
+		District:        optionalString(req.District),
+		City:            optionalString(req.City),
+		Province:        optionalString(req.Province),
+		PostalCode:      optionalString(req.PostalCode),
+		PreviousSchool:  optionalString(req.PreviousSchool),
+		PhoneNumber:     optionalString(req.PhoneNumber),
+		Email:           optionalString(req.Email),
+	}
+
+	// 2. Enkripsi NIK & No KK, tolak pendaftaran ganda
+	if nik := strings.TrimSpace(req.NIK); nik != "" {
+		hash, err := s.encryptionUtil.Hash(nik)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to hash NIK")
+		}
+		if existing, err := s.admissionRepo.FindPendingApplicantByNIKHash(wave.ID, hash); err != nil {
+			return nil, err
+		} else if existing != nil {
+			return nil, apperrors.NewConflictError(fmt.Sprintf("This NIK is already registered in this wave (%s)", existing.RegistrationNumber))
+		}
+		if student, err := s.studentRepo.FindByNIKHash(hash); err != nil {
+			return nil, err
+		} else if student != nil {
+			return nil, apperrors.NewConflictError("This NIK is already registered as a student")
+		}
+
+		encrypted, err := s.encryptionUtil.Encrypt(nik)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to encrypt NIK")
+		}
+		applicant.NIK = &encrypted
+		applicant.NIKHash = &hash
+	}
+	if noKK := strings.TrimSpace(req.NoKK); noKK != "" {
+		encrypted, err := s.encryptionUtil.Encrypt(noKK)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to encrypt NoKK")
+		}
+		applicant.NoKK = encrypted
+	}
+
+	// 3. Orang tua
+	parents := []struct {
+		data                 *request.ApplicantParentRequest
+		relationship, gender string
+	}{
+		{req.Father, "FATHER", "male"},
+		{req.Mother, "MOTHER", "female"},
+	}
+	for _, p := range parents {
+		if p.data == nil || strings.TrimSpace(p.data.FullName) == "" {
+			continue
+		}
+		parent, err := s.buildApplicantParent(p.data, p.relationship, p.gender)
+		if err != nil {
+			return nil, err
+		}
+		applicant.Parents = append(applicant.Parents, *parent)
+	}
+
+	// 4. Validasi & simpan file dokumen sebelum data disimpan
+	var documents []*domain.Document
+	removeFiles := func() {
+		for _, doc := range documents {
+			utils.RemoveFile(doc.FilePath)
+		}
+	}
+	codes := make([]string, 0, len(files))
+	for code := range files {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		docType, err := s.documentRepo.FindTypeByCode(domain.DocumentOwnerStudent, code)
+		if err != nil {
+			removeFiles()
+			return nil, err
+		}
+		if docType == nil || docType.DeletedAt.Valid {
+			removeFiles()
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Unknown document type %q", code))
+		}
+		file := files[code]
+		path, mimeType, err := storeDocumentFile(docType, domain.DocumentOwnerApplicant, file)
+		if err != nil {
+			removeFiles()
+			return nil, err
+		}
+		documents = append(documents, &domain.Document{
+			DocumentTypeID: docType.ID,
+			OwnerType:      domain.DocumentOwnerApplicant,
+			FilePath:       path,
+			OriginalName:   utils.StringPtr(file.Filename),
+			MimeType:       utils.StringPtr(mimeType),
+			SizeBytes:      file.Size,
+			Status:         domain.DocumentStatusPending,
+		})
+	}
+
+	// 5. Simpan pendaftar dengan nomor pendaftaran baru beserta dokumennya dalam satu transaksi
+	if err := s.admissionRepo.CreateApplicant(applicant, registrationPrefix(wave), documents); err != nil {
+		removeFiles()
+		return nil, err
+	}
+
+	return &response.ApplicantStatusCheckResponse{
+		RegistrationNumber: applicant.RegistrationNumber,
+		FullName:           applicant.FullName,
+		AdmissionWaveName:  wave.Name,
+		Status:             applicant.Status,
+		UpdatedAt:          applicant.UpdatedAt,
+	}, nil
+}
+
+// CheckStatus dipakai pendaftar untuk melihat status tanpa login (nomor pendaftaran + tanggal lahir)
+func (s *admissionService) CheckStatus(req request.ApplicantStatusCheckRequest) (*response.ApplicantStatusCheckResponse, error) {
+	applicant, err := s.admissionRepo.FindApplicantByRegistrationNumber(strings.TrimSpace(req.RegistrationNumber))
+	if err != nil {
+		return nil, err
+	}
+	if applicant == nil || applicant.DateOfBirth.ToTime().Format("2006-01-02") != req.DateOfBirth {
+		return nil, apperrors.NewNotFoundError("Application not found")
+	}
+
+	res := &response.ApplicantStatusCheckResponse{
+		RegistrationNumber: applicant.RegistrationNumber,
+		FullName:           applicant.FullName,
+		Status:             applicant.Status,
+		UpdatedAt:          applicant.UpdatedAt,
+	}
+	if applicant.AdmissionWave != nil {
+		res.AdmissionWaveName = applicant.AdmissionWave.Name
+	}
+	return res, nil
+}
+
+func (s *admissionService) GetApplicants(filter request.ApplicantFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	limit := pagination.GetLimit()
+	offset := pagination.GetOffset()
+
+	applicants, total, err := s.admissionRepo.FindApplicants(repository.ApplicantFilter{
+		AdmissionWaveID: filter.AdmissionWaveID,
+		Status:          filter.Status,
+		Search:          strings.TrimSpace(filter.Search),
+	}, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.ApplicantListResponse, 0, len(applicants))
+	for i := range applicants {
+		items = append(items, response.FromDomainApplicantList(&applicants[i]))
+	}
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+// applicantDocuments mengambil dokumen pendaftar, atau dokumen siswa jika sudah dikonversi
+func (s *admissionService) applicantDocuments(applicant *domain.Applicant) ([]domain.Document, error) {
+	if applicant.StudentID != nil {
+		return s.documentRepo.FindCurrentByOwner(domain.DocumentOwnerStudent, *applicant.StudentID)
+	}
+	return s.documentRepo.FindCurrentByOwner(domain.DocumentOwnerApplicant, applicant.ID)
+}
+
+func (s *admissionService) toDetailResponse(applicant *domain.Applicant) (*response.ApplicantDetailResponse, error) {
+	decrypted := map[string]string{}
+	if applicant.NIK != nil && *applicant.NIK != "" {
+		if nik, err := s.encryptionUtil.Decrypt(*applicant.NIK); err == nil {
+			decrypted["nik"] = nik
+		}
+	}
+	if applicant.NoKK != "" {
+		if noKK, err := s.encryptionUtil.Decrypt(applicant.NoKK); err == nil {
+			decrypted["no_kk"] = noKK
+		}
+	}
+	for _, p := range applicant.Parents {
+		if p.NIK != nil && *p.NIK != "" {
+			if nik, err := s.encryptionUtil.Decrypt(*p.NIK); err == nil {
+				decrypted["parent:"+p.ID] = nik
+			}
+		}
+	}
+
+	documents, err := s.applicantDocuments(applicant)
+	if err != nil {
+		return nil, err
+	}
+	// Nama jenis dokumen untuk response
+	types, err := s.documentRepo.FindTypes(domain.DocumentOwnerStudent)
+	if err != nil {
+		return nil, err
+	}
+	typeByID := make(map[string]*domain.DocumentType, len(types))
+	for i := range types {
+		typeByID[types[i].ID] = &types[i]
+	}
+	for i := range documents {
+		documents[i].DocumentType = typeByID[documents[i].DocumentTypeID]
+	}
+
+	res := response.FromDomainApplicantDetail(applicant, decrypted, documents, s.baseURL)
+	return &res, nil
+}
+
+func (s *admissionService) GetApplicantByID(id string) (*response.ApplicantDetailResponse, error) {
+	applicant, err := s.admissionRepo.FindApplicantByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if applicant == nil {
+		return nil, apperrors.NewNotFoundError("Applicant not found")
+	}
+	return s.toDetailResponse(applicant)
+}
+
+// missingRequiredDocuments mengembalikan nama dokumen wajib yang belum diupload atau ditolak
+func (s *admissionService) missingRequiredDocuments(applicantID string) ([]string, error) {
+	types, err := s.documentRepo.FindTypes(domain.DocumentOwnerStudent)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.documentRepo.FindCurrentByOwner(domain.DocumentOwnerApplicant, applicantID)
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]string, len(documents))
+	for _, doc := range documents {
+		status[doc.DocumentTypeID] = doc.Status
+	}
+
+	var missing []string
+	for _, t := range types {
+		if !t.IsRequired {
+			continue
+		}
+		if st, ok := status[t.ID]; !ok || st == domain.DocumentStatusRejected {
+			missing = append(missing, t.Name)
+		}
+	}
+	return missing, nil
+}
+
+// UpdateStatus memindahkan pendaftar ke tahap berikutnya sesuai applicantTransitions
+func (s *admissionService) UpdateStatus(id string, req request.ApplicantStatusRequest, userID string) (*response.ApplicantDetailResponse, error) {
+	applicant, err := s.admissionRepo.FindApplicantByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if applicant == nil {
+		return nil, apperrors.NewNotFoundError("Applicant not found")
+	}
+
+	// 1. Validasi urutan status
+	allowed := false
+	for _, next := range applicantTransitions[applicant.Status] {
+		if next == req.Status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Cannot change applicant status from %s to %s", applicant.Status, req.Status))
+	}
+
+	// 2. Syarat per tahap
+	notes := strings.TrimSpace(req.Notes)
+	switch req.Status {
+	case domain.ApplicantStatusVerified:
+		missing, err := s.missingRequiredDocuments(applicant.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			return nil, apperrors.NewBadRequestError("Required documents are missing or rejected: " + strings.Join(missing, ", "))
+		}
+	case domain.ApplicantStatusTested:
+		if req.TestScore == nil {
+			return nil, apperrors.NewBadRequestError("test_score is required")
+		}
+		applicant.TestScore = req.TestScore
+	case domain.ApplicantStatusRejected:
+		if notes == "" {
+			return nil, apperrors.NewBadRequestError("Notes are required when rejecting an applicant")
+		}
+	}
+
+	// 3. Simpan status beserta riwayatnya
+	log := &domain.ApplicantStatusLog{
+		ApplicantID: applicant.ID,
+		FromStatus:  utils.StringPtr(applicant.Status),
+		ToStatus:    req.Status,
+		Notes:       optionalString(notes),
+	}
+	if userID != "" {
+		log.CreatedBy = utils.StringPtr(userID)
+	}
+	applicant.Status = req.Status
+	if notes != "" {
+		applicant.Notes = &notes
+	}
+	// Kuota dicek ulang di repository dengan baris gelombang terkunci
+	saved, err := s.admissionRepo.UpdateStatus(applicant, log)
+	if err != nil {
+		return nil, err
+	}
+	if !saved {
+		return nil, apperrors.NewConflictError(fmt.Sprintf("Admission wave quota of %d is already full", applicant.AdmissionWave.Quota))
+	}
+
+	return s.GetApplicantByID(id)
+}
+
+// resolveApplicantParent menautkan orang tua ke data parents yang sudah ada (NIK lalu No HP)
+// atau menyiapkan data parents baru dari formulir pendaftaran.
+func (s *admissionService) resolveApplicantParent(p *domain.ApplicantParent, address *domain.Applicant) (string, *domain.Parent, error) {
+	if p.NIKHash != nil {
+		existing, err := s.parentRepo.FindByNIKHash(*p.NIKHash)
+		if err != nil {
+			return "", nil, err
+		}
+		if existing != nil {
+			if existing.DeletedAt.Valid {
+				return "", nil, apperrors.NewConflictError(fmt.Sprintf("%s: parent with this NIK is in the trash, restore it first", p.RelationshipType))
+			}
+			return existing.ID, nil, nil
+		}
+	}
+	if p.PhoneNumber != nil && *p.PhoneNumber != "" {
+		existing, err := s.parentRepo.FindByPhone(*p.PhoneNumber)
+		if err != nil {
+			return "", nil, err
+		}
+		if existing != nil {
+			if existing.DeletedAt.Valid {
+				return "", nil, apperrors.NewConflictError(fmt.Sprintf("%s: parent with this phone number is in the trash, restore it first", p.RelationshipType))
+			}
+			return existing.ID, nil, nil
+		}
+	}
+	if p.Email != nil && *p.Email != "" {
+		if existing, err := s.parentRepo.FindByEmail(*p.Email); err != nil {
+			return "", nil, err
+		} else if existing != nil {
+			return "", nil, apperrors.NewConflictError(fmt.Sprintf("%s: email %s is already used by parent %s", p.RelationshipType, *p.Email, existing.FullName))
+		}
+	}
+
+	parent := &domain.Parent{
+		ID:             utils.GenerateUUID(),
+		FullName:       p.FullName,
+		NIK:            p.NIK,
+		NIKHash:        p.NIKHash,
+		Gender:         p.Gender,
+		PlaceOfBirth:   p.PlaceOfBirth,
+		DateOfBirth:    p.DateOfBirth,
+		LifeStatus:     p.LifeStatus,
+		PhoneNumber:    p.PhoneNumber,
+		Email:          p.Email,
+		EducationLevel: p.EducationLevel,
+		Occupation:     p.Occupation,
+		IncomeRange:    p.IncomeRange,
+		// Alamat orang tua diasumsikan sama dengan alamat pendaftar
+		Address:     address.Address,
+		RT:          address.RT,
+		RW:          address.RW,
+		SubDistrict: address.SubDistrict,
+		District:    address.District,
+		City:        address.City,
+		Province:    address.Province,
+		PostalCode:  address.PostalCode,
+	}
+	return parent.ID, parent, nil
+}
+
+// ConvertToStudent membuat data siswa, orang tua dan penempatan kelas dari pendaftar yang diterima
+func (s *admissionService) ConvertToStudent(id string, req request.ApplicantConvertRequest) (*response.ApplicantConvertResponse, error) {
+	applicant, err := s.admissionRepo.FindApplicantByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if applicant == nil {
+		return nil, apperrors.NewNotFoundError("Applicant not found")
+	}
+	if applicant.Status != domain.ApplicantStatusAccepted {
+		return nil, apperrors.NewBadRequestError("Only accepted applicants can be converted to students")
+	}
+	if applicant.StudentID != nil {
+		return nil, apperrors.NewConflictError("Applicant has already been converted to a student")
+	}
+	wave := applicant.AdmissionWave
+
+	// 1. Validasi keunikan data siswa
+	if applicant.NIKHash != nil {
+		if existing, err := s.studentRepo.FindByNIKHash(*applicant.NIKHash); err != nil {
+			return nil, err
+		} else if existing != nil {
+			return nil, apperrors.NewConflictError("NIK already registered to another student")
+		}
+	}
+	if applicant.NISN != nil {
+		if existing, err := s.studentRepo.FindByNISN(*applicant.NISN); err != nil {
+			return nil, err
+		} else if existing != nil {
+			return nil, apperrors.NewConflictError("NISN already exists")
+		}
+	}
+	nim := optionalString(req.NIM)
+	if nim != nil {
+		if existing, err := s.studentRepo.FindByNIM(*nim); err != nil {
+			return nil, err
+		} else if existing != nil {
+			return nil, apperrors.NewConflictError("NIM already exists")
+		}
+	}
+
+	// 2. Validasi kelas awal (harus di tahun ajaran gelombang)
+	if req.ClassroomID != "" {
+		classroom, err := s.classroomRepo.FindByID(req.ClassroomID)
+		if err != nil {
+			return nil, err
+		}
+		if classroom == nil {
+			return nil, apperrors.NewNotFoundError("Classroom not found")
+		}
+		if classroom.AcademicYearID != wave.AcademicYearID {
+			return nil, apperrors.NewBadRequestError("Classroom must belong to the academic year of the admission wave")
+		}
+	}
+
+	// 3. Susun data siswa (NIK/No KK sudah terenkripsi dengan kunci yang sama)
+	dob := applicant.DateOfBirth
+	entryYear := fmt.Sprintf("%d", time.Now().Year())
+	if wave.AcademicYear != nil {
+		entryYear = fmt.Sprintf("%d", wave.AcademicYear.StartDate.ToTime().Year())
+	}
+	student := &domain.Student{
+		ID:           utils.GenerateUUID(),
+		FullName:     applicant.FullName,
+		NoKK:         applicant.NoKK,
+		NIK:          applicant.NIK,
+		NIKHash:      applicant.NIKHash,
+		NISN:         applicant.NISN,
+		NIM:          nim,
+		Gender:       applicant.Gender,
+		PlaceOfBirth: applicant.PlaceOfBirth,
+		DateOfBirth:  &dob,
+		Address:      applicant.Address,
+		RT:           applicant.RT,
+		RW:           applicant.RW,
+		SubDistrict:  applicant.SubDistrict,
+		District:     applicant.District,
+		City:         applicant.City,
+		Province:     applicant.Province,
+		PostalCode:   applicant.PostalCode,
+		Status:       domain.StudentStatusActive,
+		EntryYear:    &entryYear,
+	}
+
+	if applicant.NoKK != "" {
+		noKK, err := s.encryptionUtil.Decrypt(applicant.NoKK)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to decrypt NoKK")
+		}
+		hash, err := s.encryptionUtil.Hash(noKK)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to hash NoKK")
+		}
+		student.NoKKHash = &hash
+	}
+
+	conversion := repository.ApplicantConversion{
+		Record:      repository.StudentImportRecord{Student: student, ClassroomID: req.ClassroomID},
+		ParentLinks: map[string]string{},
+	}
+	res := &response.ApplicantConvertResponse{
+		ApplicantID:   applicant.ID,
+		StudentID:     student.ID,
+		ClassroomID:   optionalString(req.ClassroomID),
+		ParentIDs:     []string{},
+		LinkedParents: []string{},
+	}
+
+	// 4. Orang tua: tautkan ke data yang sudah ada atau buat baru
+	for i := range applicant.Parents {
+		p := &applicant.Parents[i]
+		parentID, newParent, err := s.resolveApplicantParent(p, applicant)
+		if err != nil {
+			return nil, err
+		}
+		if newParent != nil {
+			conversion.Record.NewParents = append(conversion.Record.NewParents, newParent)
+		} else {
+			res.LinkedParents = append(res.LinkedParents, parentID)
+		}
+		conversion.Record.Parents = append(conversion.Record.Parents, domain.StudentParent{
+			StudentID:        student.ID,
+			ParentID:         parentID,
+			RelationshipType: p.RelationshipType,
+		})
+		conversion.ParentLinks[p.ID] = parentID
+		res.ParentIDs = append(res.ParentIDs, parentID)
+	}
+
+	documents, err := s.documentRepo.FindHistory(domain.DocumentOwnerApplicant, applicant.ID, "")
+	if err != nil {
+		return nil, err
+	}
+	res.MovedDocuments = len(documents)
+
+	// 5. Simpan semuanya dalam satu transaksi
+	if err := s.admissionRepo.Convert(applicant, conversion); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
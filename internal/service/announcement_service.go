@@ -0,0 +1,203 @@
+package service
+
+import (
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/contact"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"strings"
+	"time"
+)
+
+type AnnouncementService interface {
+	CreateAnnouncement(req request.AnnouncementRequest, createdBy string) (*response.AnnouncementResponse, error)
+	GetAnnouncements(classroomID string, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetAnnouncementByID(id string) (*response.AnnouncementResponse, error)
+	UpdateAnnouncement(id string, req request.AnnouncementRequest) (*response.AnnouncementResponse, error)
+	DeleteAnnouncement(id string) error
+	GetRecipients(id string) (*response.AnnouncementRecipientsResponse, error)
+}
+
+type announcementService struct {
+	announcementRepo repository.AnnouncementRepository
+	classroomRepo    repository.ClassroomRepository
+	studentRepo      repository.StudentRepository
+	contactRouting   ContactRoutingService
+}
+
+func NewAnnouncementService(
+	announcementRepo repository.AnnouncementRepository,
+	classroomRepo repository.ClassroomRepository,
+	studentRepo repository.StudentRepository,
+	contactRouting ContactRoutingService,
+) AnnouncementService {
+	return &announcementService{
+		announcementRepo: announcementRepo,
+		classroomRepo:    classroomRepo,
+		studentRepo:      studentRepo,
+		contactRouting:   contactRouting,
+	}
+}
+
+func (s *announcementService) CreateAnnouncement(req request.AnnouncementRequest, createdBy string) (*response.AnnouncementResponse, error) {
+	announcement := &domain.Announcement{CreatedBy: &createdBy}
+	if err := s.apply(announcement, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.announcementRepo.Create(announcement); err != nil {
+		return nil, err
+	}
+
+	return s.GetAnnouncementByID(announcement.ID)
+}
+
+func (s *announcementService) GetAnnouncements(classroomID string, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	limit := pagination.GetLimit()
+	announcements, total, err := s.announcementRepo.FindAll(classroomID, limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.AnnouncementResponse, 0, len(announcements))
+	for i := range announcements {
+		items = append(items, toAnnouncementResponse(&announcements[i]))
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+func (s *announcementService) GetAnnouncementByID(id string) (*response.AnnouncementResponse, error) {
+	announcement, err := s.announcementRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil {
+		return nil, apperrors.NewNotFoundError("Announcement not found")
+	}
+
+	res := toAnnouncementResponse(announcement)
+	return &res, nil
+}
+
+func (s *announcementService) UpdateAnnouncement(id string, req request.AnnouncementRequest) (*response.AnnouncementResponse, error) {
+	announcement, err := s.announcementRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil {
+		return nil, apperrors.NewNotFoundError("Announcement not found")
+	}
+
+	if err := s.apply(announcement, req); err != nil {
+		return nil, err
+	}
+	announcement.Classroom = nil
+	if err := s.announcementRepo.Update(announcement); err != nil {
+		return nil, err
+	}
+
+	return s.GetAnnouncementByID(announcement.ID)
+}
+
+func (s *announcementService) DeleteAnnouncement(id string) error {
+	announcement, err := s.announcementRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if announcement == nil {
+		return apperrors.NewNotFoundError("Announcement not found")
+	}
+
+	return s.announcementRepo.Delete(id)
+}
+
+// apply memvalidasi request lalu menyalin isinya ke announcement
+// GetRecipients menentukan kontak keluarga yang menerima pengumuman: siswa aktif di kelas
+// tujuan (atau seluruh sekolah), dirutekan dengan kategori ANNOUNCEMENT pada waktu tayang.
+func (s *announcementService) GetRecipients(id string) (*response.AnnouncementRecipientsResponse, error) {
+	announcement, err := s.announcementRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if announcement == nil {
+		return nil, apperrors.NewNotFoundError("Announcement not found")
+	}
+
+	studentIDs, err := s.studentRepo.FindActiveIDs(utils.SafeString(announcement.ClassroomID))
+	if err != nil {
+		return nil, err
+	}
+
+	sendAt := time.Now()
+	if announcement.PublishedAt.After(sendAt) {
+		sendAt = announcement.PublishedAt
+	}
+	routes, err := s.contactRouting.ResolveStudents(studentIDs, contact.CategoryAnnouncement, sendAt)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.AnnouncementRecipientsResponse{
+		AnnouncementID: announcement.ID,
+		Title:          announcement.Title,
+		SendAt:         sendAt,
+		TotalStudents:  len(routes),
+		Students:       routes,
+	}
+	for _, route := range routes {
+		if len(route.Recipients) == 0 {
+			res.Unreachable++
+		}
+	}
+	return res, nil
+}
+
+func (s *announcementService) apply(announcement *domain.Announcement, req request.AnnouncementRequest) error {
+	publishedAt := time.Now()
+	if req.PublishedAt != nil {
+		publishedAt = req.PublishedAt.In(time.Local)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(publishedAt) {
+		return apperrors.NewBadRequestError("expires_at must be after published_at")
+	}
+
+	var classroomID *string
+	if req.ClassroomID != nil && *req.ClassroomID != "" {
+		classroom, err := s.classroomRepo.FindByID(*req.ClassroomID)
+		if err != nil {
+			return err
+		}
+		if classroom == nil {
+			return apperrors.NewNotFoundError("Classroom not found")
+		}
+		classroomID = &classroom.ID
+	}
+
+	announcement.Title = strings.TrimSpace(req.Title)
+	announcement.Body = req.Body
+	announcement.ClassroomID = classroomID
+	announcement.PublishedAt = publishedAt
+	announcement.ExpiresAt = req.ExpiresAt
+	return nil
+}
+
+func toAnnouncementResponse(announcement *domain.Announcement) response.AnnouncementResponse {
+	res := response.AnnouncementResponse{
+		ID:          announcement.ID,
+		Title:       announcement.Title,
+		Body:        announcement.Body,
+		ClassroomID: announcement.ClassroomID,
+		PublishedAt: announcement.PublishedAt,
+		ExpiresAt:   announcement.ExpiresAt,
+		CreatedAt:   announcement.CreatedAt,
+	}
+	if announcement.Classroom != nil {
+		res.ClassroomName = &announcement.Classroom.Name
+	}
+	return res
+}
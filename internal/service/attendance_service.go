@@ -2,6 +2,7 @@ package service
 
 import (
 	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/contact"
 	"smart_school_be/internal/model/domain"
 	"smart_school_be/internal/model/request"
 	"smart_school_be/internal/model/response"
@@ -18,23 +19,27 @@ type AttendanceService interface {
 	GetSessionByScheduleDate(scheduleID, dateStr string) (*response.AttendanceSessionDetailResponse, error)
 	GetSessionOrClassList(scheduleID, dateStr string) (*response.AttendanceSessionDetailResponse, error)
 	DeleteSession(id string) error
+	GetAbsenceAlerts(dateStr string) (*response.AbsenceAlertResponse, error)
 }
 
 type attendanceService struct {
-	repo         repository.AttendanceRepository
-	scheduleRepo repository.ScheduleRepository
-	studentRepo  repository.StudentRepository
+	repo           repository.AttendanceRepository
+	scheduleRepo   repository.ScheduleRepository
+	studentRepo    repository.StudentRepository
+	contactRouting ContactRoutingService
 }
 
 func NewAttendanceService(
 	repo repository.AttendanceRepository,
 	schedRepo repository.ScheduleRepository,
 	studRepo repository.StudentRepository,
+	contactRouting ContactRoutingService,
 ) AttendanceService {
 	return &attendanceService{
-		repo:         repo,
-		scheduleRepo: schedRepo,
-		studentRepo:  studRepo,
+		repo:           repo,
+		scheduleRepo:   schedRepo,
+		studentRepo:    studRepo,
+		contactRouting: contactRouting,
 	}
 }
 
@@ -271,13 +276,34 @@ func (s *attendanceService) GetSessionOrClassList(scheduleID, dateStr string) (*
 		Summary: make(map[string]int),
 	}
 
+	// Siswa yang sudah dipulangkan UKS sebelum pelajaran selesai otomatis terisi SICK,
+	// santri yang sedang izin pulang asrama terisi PERMISSION
+	studentIDs := make([]string, 0, len(students))
 	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+	sentHome, err := s.repo.FindClinicSentHome(date, schedule.EndTime, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+	onLeave, err := s.repo.FindOnLeave(date, schedule.StartTime, schedule.EndTime, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, student := range students {
+		status, notes := "", "" // Kosong atau default "PRESENT"
+		if sentHome[student.ID] {
+			status, notes = "SICK", "Dipulangkan dari UKS"
+		} else if onLeave[student.ID] {
+			status, notes = "PERMISSION", "Izin pulang asrama"
+		}
 		res.Details = append(res.Details, response.AttendanceDetailResponse{
 			StudentID:   student.ID,
 			StudentName: student.FullName,
 			NISN:        utils.SafeString(student.NISN),
-			Status:      "", // Kosong atau default "PRESENT"
-			Notes:       "",
+			Status:      status,
+			Notes:       notes,
 		})
 	}
 
@@ -296,3 +322,46 @@ func (s *attendanceService) DeleteSession(id string) error {
 
 	return s.repo.DeleteSession(id)
 }
+
+// GetAbsenceAlerts menyiapkan notifikasi ketidakhadiran: siswa berstatus ABSENT pada tanggal tsb
+// beserta kontak keluarga yang dihubungi (kategori ATTENDANCE)
+func (s *attendanceService) GetAbsenceAlerts(dateStr string) (*response.AbsenceAlertResponse, error) {
+	date := time.Now()
+	if dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("Invalid date format, use YYYY-MM-DD")
+		}
+		date = parsed
+	}
+
+	absent, err := s.repo.FindAbsentStudents(date)
+	if err != nil {
+		return nil, err
+	}
+	studentIDs := make([]string, 0, len(absent))
+	for id := range absent {
+		studentIDs = append(studentIDs, id)
+	}
+
+	routes, err := s.contactRouting.ResolveStudents(studentIDs, contact.CategoryAttendance, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.AbsenceAlertResponse{
+		Date:     date.Format("2006-01-02"),
+		Total:    len(routes),
+		Students: make([]response.AbsenceAlertItem, 0, len(routes)),
+	}
+	for _, route := range routes {
+		if len(route.Recipients) == 0 {
+			res.Unreachable++
+		}
+		res.Students = append(res.Students, response.AbsenceAlertItem{
+			ContactRoutingResponse: route,
+			AbsentSessions:         absent[route.StudentID],
+		})
+	}
+	return res, nil
+}
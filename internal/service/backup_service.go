@@ -0,0 +1,481 @@
+package service
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+)
+
+// BackupFormatVersion naik setiap kali struktur arsip (bukan skema database) berubah
+const BackupFormatVersion = 1
+
+const (
+	backupManifestPath = "manifest.json"
+	backupDataDir      = "data/"
+	backupFilesDir     = "files/"
+	backupBatchSize    = 500
+	backupTimeLayout   = "2006-01-02 15:04:05.999999"
+)
+
+type BackupImportOptions struct {
+	// RemapIDs memberi UUID baru pada baris yang ID-nya sudah dipakai, dan menggabungkan
+	// baris dengan natural key yang sama (roles, permissions, users, subjects)
+	RemapIDs bool
+}
+
+type BackupService interface {
+	Export(w io.Writer) (*response.BackupManifest, error)
+	Import(r io.ReaderAt, size int64, opts BackupImportOptions) (*response.BackupImportResponse, error)
+}
+
+type backupService struct {
+	backupRepo repository.BackupRepository
+	uploadDir  string
+}
+
+func NewBackupService(backupRepo repository.BackupRepository, uploadDir string) BackupService {
+	return &backupService{backupRepo: backupRepo, uploadDir: uploadDir}
+}
+
+// hashingWriter menghitung sha256 dan ukuran data yang ditulis
+type hashingWriter struct {
+	w    io.Writer
+	hash hash.Hash
+	size int64
+}
+
+func newHashingWriter(w io.Writer) *hashingWriter {
+	return &hashingWriter{w: w, hash: sha256.New()}
+}
+
+func (hw *hashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.hash.Write(p[:n])
+	hw.size += int64(n)
+	return n, err
+}
+
+func (hw *hashingWriter) entry(archivePath string) response.BackupManifestEntry {
+	return response.BackupManifestEntry{Path: archivePath, Size: hw.size, SHA256: hex.EncodeToString(hw.hash.Sum(nil))}
+}
+
+// Export menulis arsip zip berisi data semua tabel (NDJSON), file upload, dan manifest.json
+func (s *backupService) Export(w io.Writer) (*response.BackupManifest, error) {
+	version, dirty, err := s.backupRepo.SchemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if dirty {
+		return nil, apperrors.NewConflictError("Database schema is dirty, fix migrations before exporting")
+	}
+
+	manifest := &response.BackupManifest{
+		FormatVersion: BackupFormatVersion,
+		SchemaVersion: version,
+		CreatedAt:     time.Now(),
+	}
+	zw := zip.NewWriter(w)
+
+	err = s.backupRepo.Snapshot(func(repo repository.BackupRepository) error {
+		for _, table := range repository.BackupTables {
+			entry, err := s.exportTable(zw, repo, table.Name)
+			if err != nil {
+				return fmt.Errorf("failed to export %s: %w", table.Name, err)
+			}
+			manifest.Entities = append(manifest.Entities, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest.Files, err = s.exportFiles(zw); err != nil {
+		return nil, fmt.Errorf("failed to export uploaded files: %w", err)
+	}
+
+	mw, err := zw.Create(backupManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(mw)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (s *backupService) exportTable(zw *zip.Writer, repo repository.BackupRepository, table string) (response.BackupManifestEntry, error) {
+	archivePath := backupDataDir + table + ".ndjson"
+	fw, err := zw.Create(archivePath)
+	if err != nil {
+		return response.BackupManifestEntry{}, err
+	}
+
+	hw := newHashingWriter(fw)
+	encoder := json.NewEncoder(hw)
+	rows := 0
+	err = repo.StreamRows(table, func(row map[string]interface{}) error {
+		for column, value := range row {
+			switch v := value.(type) {
+			case time.Time:
+				// Disimpan tanpa zona waktu, sama seperti DATETIME di MySQL (loc=Local)
+				row[column] = v.Format(backupTimeLayout)
+			case []byte:
+				row[column] = string(v)
+			}
+		}
+		rows++
+		return encoder.Encode(row)
+	})
+	if err != nil {
+		return response.BackupManifestEntry{}, err
+	}
+
+	entry := hw.entry(archivePath)
+	entry.Table = table
+	entry.Rows = rows
+	return entry, nil
+}
+
+func (s *backupService) exportFiles(zw *zip.Writer) ([]response.BackupManifestEntry, error) {
+	var entries []response.BackupManifestEntry
+
+	err := filepath.WalkDir(s.uploadDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && filePath == s.uploadDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.uploadDir, filePath)
+		if err != nil {
+			return err
+		}
+		archivePath := backupFilesDir + filepath.ToSlash(rel)
+
+		src, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		fw, err := zw.Create(archivePath)
+		if err != nil {
+			return err
+		}
+		hw := newHashingWriter(fw)
+		if _, err := io.Copy(hw, src); err != nil {
+			return err
+		}
+		entries = append(entries, hw.entry(archivePath))
+		return nil
+	})
+	return entries, err
+}
+
+// Import memvalidasi arsip lalu memulihkan semua tabel dalam satu transaksi.
+// File upload baru ditulis setelah transaksi berhasil; file yang sudah ada tidak ditimpa.
+func (s *backupService) Import(r io.ReaderAt, size int64, opts BackupImportOptions) (*response.BackupImportResponse, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid backup archive")
+	}
+	archiveFiles := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		archiveFiles[f.Name] = f
+	}
+
+	manifest, err := readBackupManifest(archiveFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	version, dirty, err := s.backupRepo.SchemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if dirty {
+		return nil, apperrors.NewConflictError("Database schema is dirty, fix migrations before importing")
+	}
+	if manifest.SchemaVersion != version {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf(
+			"Backup schema version %d does not match database schema version %d, migrate the database to the same version first",
+			manifest.SchemaVersion, version))
+	}
+
+	if err := verifyBackupEntries(archiveFiles, manifest); err != nil {
+		return nil, err
+	}
+
+	entitiesByTable := make(map[string]response.BackupManifestEntry, len(manifest.Entities))
+	knownTables := make(map[string]bool, len(repository.BackupTables))
+	for _, table := range repository.BackupTables {
+		knownTables[table.Name] = true
+	}
+	for _, entry := range manifest.Entities {
+		if !knownTables[entry.Table] {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Backup contains unknown table: %s", entry.Table))
+		}
+		entitiesByTable[entry.Table] = entry
+	}
+
+	result := &response.BackupImportResponse{
+		SchemaVersion: version,
+		Tables:        make(map[string]int),
+	}
+	idMap := make(map[string]string)
+
+	err = s.backupRepo.Transaction(func(repo repository.BackupRepository) error {
+		for _, table := range repository.BackupTables {
+			entry, ok := entitiesByTable[table.Name]
+			if !ok {
+				continue
+			}
+			count, err := s.importTable(repo, archiveFiles[entry.Path], table, idMap, opts, result)
+			if err != nil {
+				var appErr *apperrors.AppError
+				if errors.As(err, &appErr) {
+					return err
+				}
+				return fmt.Errorf("failed to restore %s: %w", table.Name, err)
+			}
+			result.Tables[table.Name] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range manifest.Files {
+		restored, err := s.restoreFile(archiveFiles[entry.Path], entry.Path)
+		if err != nil {
+			return result, fmt.Errorf("database restored but failed to write %s: %w", entry.Path, err)
+		}
+		if restored {
+			result.FilesRestored++
+		} else {
+			result.FilesSkipped++
+		}
+	}
+
+	return result, nil
+}
+
+func readBackupManifest(archiveFiles map[string]*zip.File) (*response.BackupManifest, error) {
+	f, ok := archiveFiles[backupManifestPath]
+	if !ok {
+		return nil, apperrors.NewBadRequestError("Backup archive has no manifest.json")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Failed to read manifest.json")
+	}
+	defer rc.Close()
+
+	var manifest response.BackupManifest
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid manifest.json: " + err.Error())
+	}
+	if manifest.FormatVersion != BackupFormatVersion {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Unsupported backup format version %d (expected %d)", manifest.FormatVersion, BackupFormatVersion))
+	}
+	return &manifest, nil
+}
+
+// verifyBackupEntries memastikan setiap file yang disebut manifest ada, aman, dan checksum-nya cocok
+func verifyBackupEntries(archiveFiles map[string]*zip.File, manifest *response.BackupManifest) error {
+	entries := append(append([]response.BackupManifestEntry{}, manifest.Entities...), manifest.Files...)
+	for _, entry := range entries {
+		clean := path.Clean(entry.Path)
+		if clean != entry.Path || strings.HasPrefix(clean, "../") || path.IsAbs(clean) {
+			return apperrors.NewBadRequestError("Invalid path in manifest: " + entry.Path)
+		}
+
+		f, ok := archiveFiles[entry.Path]
+		if !ok {
+			return apperrors.NewBadRequestError("Backup archive is missing " + entry.Path)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return apperrors.NewBadRequestError("Failed to read " + entry.Path)
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, rc)
+		rc.Close()
+		if err != nil {
+			return apperrors.NewBadRequestError("Failed to read " + entry.Path)
+		}
+		if hex.EncodeToString(h.Sum(nil)) != entry.SHA256 {
+			return apperrors.NewBadRequestError("Checksum mismatch for " + entry.Path)
+		}
+	}
+
+	for _, entry := range manifest.Files {
+		if !strings.HasPrefix(entry.Path, backupFilesDir) {
+			return apperrors.NewBadRequestError("Invalid file path in manifest: " + entry.Path)
+		}
+	}
+	return nil
+}
+
+func (s *backupService) importTable(repo repository.BackupRepository, f *zip.File, table repository.BackupTable, idMap map[string]string, opts BackupImportOptions, result *response.BackupImportResponse) (int, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	decoder := json.NewDecoder(rc)
+	decoder.UseNumber()
+
+	count := 0
+	batch := make([]map[string]interface{}, 0, backupBatchSize)
+	flush := func() error {
+		inserted, err := s.importBatch(repo, table, batch, idMap, opts, result)
+		count += inserted
+		batch = batch[:0]
+		return err
+	}
+
+	for {
+		var row map[string]interface{}
+		if err := decoder.Decode(&row); err == io.EOF {
+			break
+		} else if err != nil {
+			return count, apperrors.NewBadRequestError(fmt.Sprintf("Invalid row in %s: %v", f.Name, err))
+		}
+		batch = append(batch, row)
+		if len(batch) == backupBatchSize {
+			if err := flush(); err != nil {
+				return count, err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := flush(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+func (s *backupService) importBatch(repo repository.BackupRepository, table repository.BackupTable, rows []map[string]interface{}, idMap map[string]string, opts BackupImportOptions, result *response.BackupImportResponse) (int, error) {
+	for _, row := range rows {
+		for _, column := range table.RefColumns {
+			if oldID, ok := row[column].(string); ok {
+				if newID, ok := idMap[oldID]; ok {
+					row[column] = newID
+				}
+			}
+		}
+	}
+
+	// Tabel pivot tidak punya kolom id sendiri
+	if len(table.KeyColumns) > 0 {
+		return len(rows), repo.InsertRows(table, rows)
+	}
+
+	pending := rows
+	if opts.RemapIDs && table.NaturalKey != "" {
+		values := make([]interface{}, 0, len(rows))
+		for _, row := range rows {
+			values = append(values, row[table.NaturalKey])
+		}
+		existing, err := repo.FindIDsByNaturalKey(table.Name, table.NaturalKey, values)
+		if err != nil {
+			return 0, err
+		}
+
+		pending = make([]map[string]interface{}, 0, len(rows))
+		for _, row := range rows {
+			if existingID, ok := existing[fmt.Sprint(row[table.NaturalKey])]; ok {
+				idMap[fmt.Sprint(row["id"])] = existingID
+				result.MergedRows++
+				continue
+			}
+			pending = append(pending, row)
+		}
+	}
+
+	ids := make([]string, 0, len(pending))
+	for _, row := range pending {
+		ids = append(ids, fmt.Sprint(row["id"]))
+	}
+	existing, err := repo.ExistingIDs(table.Name, ids)
+	if err != nil {
+		return 0, err
+	}
+	if len(existing) > 0 {
+		if !opts.RemapIDs {
+			return 0, apperrors.NewConflictError(fmt.Sprintf(
+				"%d rows in %s already exist in the database, retry with ID remapping enabled", len(existing), table.Name))
+		}
+		for _, row := range pending {
+			oldID := fmt.Sprint(row["id"])
+			if existing[oldID] {
+				newID := utils.GenerateUUID()
+				idMap[oldID] = newID
+				row["id"] = newID
+				result.RemappedIDs++
+			}
+		}
+	}
+
+	return len(pending), repo.InsertRows(table, pending)
+}
+
+// restoreFile menulis satu file upload dari arsip. Mengembalikan false jika file sudah ada.
+func (s *backupService) restoreFile(f *zip.File, archivePath string) (bool, error) {
+	rel := strings.TrimPrefix(archivePath, backupFilesDir)
+	target := filepath.Join(s.uploadDir, filepath.FromSlash(rel))
+	if _, err := os.Stat(target); err == nil {
+		return false, nil
+	}
+
+	if err := utils.EnsureDir(filepath.Dir(target)); err != nil {
+		return false, err
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	dst, err := os.Create(target)
+	if err != nil {
+		return false, err
+	}
+	if _, err := io.Copy(dst, rc); err != nil {
+		dst.Close()
+		os.Remove(target)
+		return false, err
+	}
+	return true, dst.Close()
+}
@@ -212,7 +212,16 @@ func (s *classroomService) Update(id string, req request.ClassroomUpdateRequest)
 	return s.toResponse(updated), nil
 }
 
+// Delete memindahkan kelas ke trash. Kelas yang masih punya teaching assignment atau siswa ditolak,
+// karena purge akan ikut menghapus jadwal, absensi dan nilai siswa lewat ON DELETE CASCADE.
 func (s *classroomService) Delete(id string) error {
+	assignments, students, err := s.repo.CountDependents(id)
+	if err != nil {
+		return err
+	}
+	if assignments > 0 || students > 0 {
+		return apperrors.NewConflictError("Classroom still has teaching assignments or student history, remove them first")
+	}
 	return s.repo.Delete(id)
 }
 
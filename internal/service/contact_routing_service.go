@@ -0,0 +1,457 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/contact"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+)
+
+// ContactRoutingService mengelola preferensi komunikasi keluarga dan menentukan siapa yang
+// dihubungi tentang seorang siswa. Semua fitur yang mengirim pesan ke keluarga memakai
+// ResolveStudents agar kebijakannya sama (lihat package contact).
+type ContactRoutingService interface {
+	GetPreference(contactType, contactID string) (*response.ContactPreferenceResponse, error)
+	UpdatePreference(contactType, contactID string, req request.ContactPreferenceRequest) (*response.ContactPreferenceResponse, error)
+	GetMyPreference(userID string) (*response.ContactPreferenceResponse, error)
+	UpdateMyPreference(userID string, req request.ContactPreferenceRequest) (*response.ContactPreferenceResponse, error)
+	SetPrimaryContact(studentID string, req request.StudentPrimaryContactRequest) error
+	ClearPrimaryContact(studentID string) error
+	ResolveStudent(studentID, category string) (*response.ContactRoutingResponse, error)
+	ResolveStudents(studentIDs []string, category string, now time.Time) ([]response.ContactRoutingResponse, error)
+}
+
+type contactRoutingService struct {
+	preferenceRepo repository.ContactPreferenceRepository
+	studentRepo    repository.StudentRepository
+	parentRepo     repository.ParentRepository
+	guardianRepo   repository.GuardianRepository
+}
+
+func NewContactRoutingService(
+	preferenceRepo repository.ContactPreferenceRepository,
+	studentRepo repository.StudentRepository,
+	parentRepo repository.ParentRepository,
+	guardianRepo repository.GuardianRepository,
+) ContactRoutingService {
+	return &contactRoutingService{
+		preferenceRepo: preferenceRepo,
+		studentRepo:    studentRepo,
+		parentRepo:     parentRepo,
+		guardianRepo:   guardianRepo,
+	}
+}
+
+func (s *contactRoutingService) GetPreference(contactType, contactID string) (*response.ContactPreferenceResponse, error) {
+	name, err := s.contactName(contactType, contactID)
+	if err != nil {
+		return nil, err
+	}
+	preference, err := s.preferenceRepo.FindByContact(contactType, contactID)
+	if err != nil {
+		return nil, err
+	}
+	res := toContactPreferenceResponse(contactType, contactID, name, preference)
+	return &res, nil
+}
+
+func (s *contactRoutingService) UpdatePreference(contactType, contactID string, req request.ContactPreferenceRequest) (*response.ContactPreferenceResponse, error) {
+	name, err := s.contactName(contactType, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	optOut, err := normalizeOptOut(req.OptOutCategories)
+	if err != nil {
+		return nil, err
+	}
+	if (req.QuietHoursStart == nil) != (req.QuietHoursEnd == nil) {
+		return nil, apperrors.NewBadRequestError("quiet_hours_start and quiet_hours_end must be set together")
+	}
+	for _, value := range []*string{req.QuietHoursStart, req.QuietHoursEnd} {
+		if value == nil {
+			continue
+		}
+		if _, err := contact.ParseClock(*value); err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
+	}
+
+	preference, err := s.preferenceRepo.FindByContact(contactType, contactID)
+	if err != nil {
+		return nil, err
+	}
+	if preference == nil {
+		preference = &domain.ContactPreference{ContactType: contactType, ContactID: contactID}
+	}
+	preference.PreferredChannel = req.PreferredChannel
+	preference.Language = req.Language
+	if preference.Language == "" {
+		preference.Language = contact.DefaultLanguage
+	}
+	preference.QuietHoursStart = req.QuietHoursStart
+	preference.QuietHoursEnd = req.QuietHoursEnd
+	preference.OptOutCategories = strings.Join(optOut, ",")
+
+	if err := s.preferenceRepo.Save(preference); err != nil {
+		return nil, err
+	}
+	res := toContactPreferenceResponse(contactType, contactID, name, preference)
+	return &res, nil
+}
+
+// GetMyPreference mengambil preferensi milik akun orang tua/wali yang sedang login
+func (s *contactRoutingService) GetMyPreference(userID string) (*response.ContactPreferenceResponse, error) {
+	contactType, contactID, err := s.contactForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPreference(contactType, contactID)
+}
+
+func (s *contactRoutingService) UpdateMyPreference(userID string, req request.ContactPreferenceRequest) (*response.ContactPreferenceResponse, error) {
+	contactType, contactID, err := s.contactForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.UpdatePreference(contactType, contactID, req)
+}
+
+// SetPrimaryContact menetapkan kontak utama siswa. Kontak harus orang tua yang tertaut
+// ke siswa, atau wali siswa tsb.
+func (s *contactRoutingService) SetPrimaryContact(studentID string, req request.StudentPrimaryContactRequest) error {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return apperrors.NewNotFoundError("Student not found")
+	}
+	if _, err := s.contactName(req.ContactType, req.ContactID); err != nil {
+		return err
+	}
+
+	linked := student.GuardianType != nil && student.GuardianID != nil &&
+		*student.GuardianType == req.ContactType && *student.GuardianID == req.ContactID
+	if !linked && req.ContactType == "parent" {
+		links, err := s.studentRepo.FindParentLinks(studentID)
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			if link.ParentID == req.ContactID {
+				linked = true
+				break
+			}
+		}
+	}
+	if !linked {
+		return apperrors.NewBadRequestError("Contact is not a parent or guardian of this student")
+	}
+
+	return s.studentRepo.SetPrimaryContact(studentID, &req.ContactID, &req.ContactType)
+}
+
+func (s *contactRoutingService) ClearPrimaryContact(studentID string) error {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return apperrors.NewNotFoundError("Student not found")
+	}
+	return s.studentRepo.SetPrimaryContact(studentID, nil, nil)
+}
+
+func (s *contactRoutingService) ResolveStudent(studentID, category string) (*response.ContactRoutingResponse, error) {
+	routes, err := s.ResolveStudents([]string{studentID}, category, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+	return &routes[0], nil
+}
+
+// ResolveStudents menentukan penerima untuk banyak siswa sekaligus dengan jumlah query tetap
+func (s *contactRoutingService) ResolveStudents(studentIDs []string, category string, now time.Time) ([]response.ContactRoutingResponse, error) {
+	category = strings.ToUpper(category)
+	if !contact.IsCategory(category) {
+		return nil, apperrors.NewBadRequestError("Invalid category, use one of: " + strings.Join(contact.Categories, ", "))
+	}
+
+	students, err := s.studentRepo.FindByIDsWithParents(studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orang tua/wali yang ditunjuk lewat penanda wali atau kontak utama, di luar relasi student_parent
+	parents := map[string]domain.Parent{}
+	var extraParentIDs, guardianIDs []string
+	for _, student := range students {
+		for _, link := range student.Parents {
+			if link.Parent.ID != "" {
+				parents[link.Parent.ID] = link.Parent
+			}
+		}
+	}
+	for _, student := range students {
+		for _, ref := range studentContactRefs(student) {
+			if ref.Type == "parent" {
+				if _, ok := parents[ref.ID]; !ok {
+					extraParentIDs = append(extraParentIDs, ref.ID)
+				}
+			} else if ref.Type == "guardian" {
+				guardianIDs = append(guardianIDs, ref.ID)
+			}
+		}
+	}
+
+	extraParents, err := s.parentRepo.FindByIDs(extraParentIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, parent := range extraParents {
+		parents[parent.ID] = parent
+	}
+	guardianList, err := s.guardianRepo.FindByIDs(guardianIDs)
+	if err != nil {
+		return nil, err
+	}
+	guardians := make(map[string]domain.Guardian, len(guardianList))
+	for _, guardian := range guardianList {
+		guardians[guardian.ID] = guardian
+	}
+
+	parentIDs := make([]string, 0, len(parents))
+	for id := range parents {
+		parentIDs = append(parentIDs, id)
+	}
+	preferences, err := s.preferenceRepo.FindByContacts(parentIDs, guardianIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.ContactRoutingResponse, 0, len(students))
+	for _, student := range students {
+		candidates := buildContactCandidates(student, parents, guardians, preferences)
+		recipients, skipped := contact.Resolve(candidates, category, now)
+		if recipients == nil {
+			recipients = []contact.Route{}
+		}
+		if skipped == nil {
+			skipped = []contact.Route{}
+		}
+		result = append(result, response.ContactRoutingResponse{
+			StudentID:   student.ID,
+			StudentName: student.FullName,
+			Category:    category,
+			Recipients:  recipients,
+			Skipped:     skipped,
+		})
+	}
+	return result, nil
+}
+
+type contactRef struct {
+	Type, ID string
+}
+
+// studentContactRefs mengembalikan penanda wali dan kontak utama siswa yang terisi
+func studentContactRefs(student domain.Student) []contactRef {
+	var refs []contactRef
+	if student.GuardianType != nil && student.GuardianID != nil {
+		refs = append(refs, contactRef{*student.GuardianType, *student.GuardianID})
+	}
+	if student.PrimaryContactType != nil && student.PrimaryContactID != nil {
+		refs = append(refs, contactRef{*student.PrimaryContactType, *student.PrimaryContactID})
+	}
+	return refs
+}
+
+// buildContactCandidates menyusun kandidat kontak siswa: orang tua tertaut, wali, dan kontak utama.
+// Kontak yang sudah dihapus (trash) tidak ikut dimuat sehingga otomatis terlewati.
+func buildContactCandidates(student domain.Student, parents map[string]domain.Parent, guardians map[string]domain.Guardian, preferences map[string]domain.ContactPreference) []contact.Candidate {
+	var candidates []contact.Candidate
+	index := map[string]int{}
+
+	addParent := func(id, relationship string) int {
+		key := "parent:" + id
+		if i, ok := index[key]; ok {
+			return i
+		}
+		parent, ok := parents[id]
+		if !ok {
+			return -1
+		}
+		candidates = append(candidates, contact.Candidate{
+			Type:         "parent",
+			ID:           parent.ID,
+			Name:         parent.FullName,
+			Relationship: relationship,
+			Phone:        utils.SafeString(parent.PhoneNumber),
+			Email:        utils.SafeString(parent.Email),
+			Deceased:     utils.SafeString(parent.LifeStatus) == "deceased",
+			Preference:   toContactPolicyPreference(preferences, key),
+		})
+		index[key] = len(candidates) - 1
+		return index[key]
+	}
+	addGuardian := func(id string) int {
+		key := "guardian:" + id
+		if i, ok := index[key]; ok {
+			return i
+		}
+		guardian, ok := guardians[id]
+		if !ok {
+			return -1
+		}
+		candidates = append(candidates, contact.Candidate{
+			Type:         "guardian",
+			ID:           guardian.ID,
+			Name:         guardian.FullName,
+			Relationship: utils.SafeString(guardian.RelationshipToStudent),
+			Phone:        utils.SafeString(guardian.PhoneNumber),
+			Email:        utils.SafeString(guardian.Email),
+			Preference:   toContactPolicyPreference(preferences, key),
+		})
+		index[key] = len(candidates) - 1
+		return index[key]
+	}
+	add := func(ref contactRef) int {
+		if ref.Type == "parent" {
+			return addParent(ref.ID, "")
+		}
+		if ref.Type == "guardian" {
+			return addGuardian(ref.ID)
+		}
+		return -1
+	}
+
+	for _, link := range student.Parents {
+		if link.Parent.ID != "" {
+			addParent(link.ParentID, link.RelationshipType)
+		}
+	}
+	if student.GuardianType != nil && student.GuardianID != nil {
+		if i := add(contactRef{*student.GuardianType, *student.GuardianID}); i >= 0 {
+			candidates[i].Guardian = true
+		}
+	}
+	if student.PrimaryContactType != nil && student.PrimaryContactID != nil {
+		if i := add(contactRef{*student.PrimaryContactType, *student.PrimaryContactID}); i >= 0 {
+			candidates[i].Primary = true
+		}
+	}
+	return candidates
+}
+
+func toContactPolicyPreference(preferences map[string]domain.ContactPreference, key string) *contact.Preference {
+	preference, ok := preferences[key]
+	if !ok {
+		return nil
+	}
+	return &contact.Preference{
+		Channel:    preference.PreferredChannel,
+		Language:   preference.Language,
+		QuietStart: utils.SafeString(preference.QuietHoursStart),
+		QuietEnd:   utils.SafeString(preference.QuietHoursEnd),
+		OptOut:     preference.OptOutList(),
+	}
+}
+
+// contactName memastikan orang tua/wali ada dan mengembalikan namanya
+func (s *contactRoutingService) contactName(contactType, contactID string) (string, error) {
+	switch contactType {
+	case "parent":
+		parent, err := s.parentRepo.FindByID(contactID)
+		if err != nil {
+			return "", err
+		}
+		if parent == nil {
+			return "", apperrors.NewNotFoundError("Parent not found")
+		}
+		return parent.FullName, nil
+	case "guardian":
+		guardian, err := s.guardianRepo.FindByID(contactID)
+		if err != nil {
+			return "", err
+		}
+		if guardian == nil {
+			return "", apperrors.NewNotFoundError("Guardian not found")
+		}
+		return guardian.FullName, nil
+	}
+	return "", apperrors.NewBadRequestError("Invalid contact type, use parent or guardian")
+}
+
+// contactForUser mencari data orang tua/wali yang tertaut ke akun user
+func (s *contactRoutingService) contactForUser(userID string) (string, string, error) {
+	parent, err := s.parentRepo.FindByUserID(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if parent != nil {
+		return "parent", parent.ID, nil
+	}
+	guardian, err := s.guardianRepo.FindByUserID(userID)
+	if err != nil {
+		return "", "", err
+	}
+	if guardian != nil {
+		return "guardian", guardian.ID, nil
+	}
+	return "", "", apperrors.NewForbiddenError("Your account is not linked to a parent or guardian")
+}
+
+// normalizeOptOut memvalidasi kategori opt-out. EMERGENCY tidak bisa di-opt-out.
+func normalizeOptOut(categories []string) ([]string, error) {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, category := range categories {
+		category = strings.ToUpper(strings.TrimSpace(category))
+		if category == contact.CategoryEmergency {
+			return nil, apperrors.NewBadRequestError("EMERGENCY messages cannot be opted out")
+		}
+		if !contact.IsCategory(category) {
+			return nil, apperrors.NewBadRequestError("Invalid opt-out category: " + category)
+		}
+		if !seen[category] {
+			seen[category] = true
+			result = append(result, category)
+		}
+	}
+	return result, nil
+}
+
+func toContactPreferenceResponse(contactType, contactID, name string, preference *domain.ContactPreference) response.ContactPreferenceResponse {
+	if preference == nil {
+		return response.ContactPreferenceResponse{
+			ContactType:      contactType,
+			ContactID:        contactID,
+			ContactName:      name,
+			PreferredChannel: contact.DefaultChannel,
+			Language:         contact.DefaultLanguage,
+			OptOutCategories: []string{},
+			IsDefault:        true,
+		}
+	}
+	return response.ContactPreferenceResponse{
+		ContactType:      contactType,
+		ContactID:        contactID,
+		ContactName:      name,
+		PreferredChannel: preference.PreferredChannel,
+		Language:         preference.Language,
+		QuietHoursStart:  preference.QuietHoursStart,
+		QuietHoursEnd:    preference.QuietHoursEnd,
+		OptOutCategories: preference.OptOutList(),
+		UpdatedAt:        &preference.UpdatedAt,
+	}
+}
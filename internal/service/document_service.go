@@ -0,0 +1,541 @@
+package service
+
+import (
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Folder penyimpanan file dokumen di bawah utils.UploadDir
+const documentUploadFolder = "documents"
+
+var defaultDocumentMimeTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+type DocumentService interface {
+	// Document Types
+	CreateType(req request.DocumentTypeCreateRequest) (*response.DocumentTypeResponse, error)
+	GetTypes(ownerType string) ([]response.DocumentTypeResponse, error)
+	UpdateType(id string, req request.DocumentTypeUpdateRequest) (*response.DocumentTypeResponse, error)
+	DeleteType(id string) error
+
+	// Documents
+	UploadDocument(ownerType, ownerID string, req request.DocumentUploadRequest, file *multipart.FileHeader, uploadedBy string) (*response.DocumentResponse, error)
+	GetOwnerDocuments(ownerType, ownerID string) (*response.OwnerDocumentsResponse, error)
+	GetDocumentHistory(ownerType, ownerID, documentTypeID string) ([]response.DocumentResponse, error)
+	VerifyDocument(id string, req request.DocumentVerifyRequest, verifiedBy string) (*response.DocumentResponse, error)
+	DeleteDocument(id string) error
+	GetClassroomCompleteness(classroomID string) (*response.DocumentCompletenessResponse, error)
+}
+
+type documentService struct {
+	documentRepo  repository.DocumentRepository
+	studentRepo   repository.StudentRepository
+	employeeRepo  repository.EmployeeRepository
+	classroomRepo repository.ClassroomRepository
+	baseURL       string
+}
+
+func NewDocumentService(
+	documentRepo repository.DocumentRepository,
+	studentRepo repository.StudentRepository,
+	employeeRepo repository.EmployeeRepository,
+	classroomRepo repository.ClassroomRepository,
+	baseURL string,
+) DocumentService {
+	return &documentService{
+		documentRepo:  documentRepo,
+		studentRepo:   studentRepo,
+		employeeRepo:  employeeRepo,
+		classroomRepo: classroomRepo,
+		baseURL:       baseURL,
+	}
+}
+
+// normalizeMimeTypes membersihkan daftar MIME type dan menggabungkannya untuk disimpan
+func normalizeMimeTypes(mimeTypes []string) (string, error) {
+	var cleaned []string
+	for _, m := range mimeTypes {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if m == "" {
+			continue
+		}
+		if !strings.Contains(m, "/") {
+			return "", apperrors.NewBadRequestError(fmt.Sprintf("Invalid MIME type: %s", m))
+		}
+		cleaned = append(cleaned, m)
+	}
+	if len(cleaned) == 0 {
+		cleaned = defaultDocumentMimeTypes
+	}
+	return strings.Join(cleaned, ","), nil
+}
+
+// --- Document Types ---
+
+func (s *documentService) CreateType(req request.DocumentTypeCreateRequest) (*response.DocumentTypeResponse, error) {
+	code := strings.ToLower(strings.TrimSpace(req.Code))
+	existing, err := s.documentRepo.FindTypeByCode(req.OwnerType, code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && !existing.DeletedAt.Valid {
+		return nil, apperrors.NewConflictError("Document type code already exists")
+	}
+
+	mimeTypes, err := normalizeMimeTypes(req.AllowedMimeTypes)
+	if err != nil {
+		return nil, err
+	}
+	maxSize := req.MaxSizeKB
+	if maxSize == 0 {
+		maxSize = 2048
+	}
+
+	docType := &domain.DocumentType{
+		Code:             code,
+		Name:             req.Name,
+		OwnerType:        req.OwnerType,
+		IsRequired:       req.IsRequired,
+		AllowedMimeTypes: mimeTypes,
+		MaxSizeKB:        maxSize,
+		Description:      req.Description,
+	}
+
+	// Kode unik per owner_type termasuk baris di trash: jenis yang pernah dihapus dipulihkan
+	// dengan data baru, sehingga dokumen lama yang memakai jenis ini ikut tertaut kembali
+	if existing != nil {
+		docType.ID = existing.ID
+		docType.CreatedAt = existing.CreatedAt
+		if err := s.documentRepo.RestoreType(docType); err != nil {
+			return nil, err
+		}
+	} else if err := s.documentRepo.CreateType(docType); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, apperrors.NewConflictError("Document type code already exists")
+		}
+		return nil, err
+	}
+
+	res := response.FromDomainDocumentType(docType)
+	return &res, nil
+}
+
+func (s *documentService) GetTypes(ownerType string) ([]response.DocumentTypeResponse, error) {
+	types, err := s.documentRepo.FindTypes(ownerType)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.DocumentTypeResponse, 0, len(types))
+	for i := range types {
+		res = append(res, response.FromDomainDocumentType(&types[i]))
+	}
+	return res, nil
+}
+
+func (s *documentService) UpdateType(id string, req request.DocumentTypeUpdateRequest) (*response.DocumentTypeResponse, error) {
+	docType, err := s.documentRepo.FindTypeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if docType == nil {
+		return nil, apperrors.NewNotFoundError("Document type not found")
+	}
+
+	if req.Name != nil {
+		docType.Name = *req.Name
+	}
+	if req.IsRequired != nil {
+		docType.IsRequired = *req.IsRequired
+	}
+	if req.AllowedMimeTypes != nil {
+		mimeTypes, err := normalizeMimeTypes(req.AllowedMimeTypes)
+		if err != nil {
+			return nil, err
+		}
+		docType.AllowedMimeTypes = mimeTypes
+	}
+	if req.MaxSizeKB != nil {
+		docType.MaxSizeKB = *req.MaxSizeKB
+	}
+	if req.Description != nil {
+		docType.Description = req.Description
+	}
+
+	if err := s.documentRepo.UpdateType(docType); err != nil {
+		return nil, err
+	}
+
+	res := response.FromDomainDocumentType(docType)
+	return &res, nil
+}
+
+// DeleteType menghapus (soft delete) jenis dokumen; dokumen yang sudah diupload tetap tersimpan
+func (s *documentService) DeleteType(id string) error {
+	docType, err := s.documentRepo.FindTypeByID(id)
+	if err != nil {
+		return err
+	}
+	if docType == nil {
+		return apperrors.NewNotFoundError("Document type not found")
+	}
+	return s.documentRepo.DeleteType(id)
+}
+
+// --- Documents ---
+
+// ensureOwnerExists memastikan siswa/pegawai pemilik dokumen ada
+func (s *documentService) ensureOwnerExists(ownerType, ownerID string) error {
+	switch ownerType {
+	case domain.DocumentOwnerStudent:
+		student, err := s.studentRepo.FindByID(ownerID)
+		if err != nil {
+			return err
+		}
+		if student == nil {
+			return apperrors.NewNotFoundError("Student not found")
+		}
+	case domain.DocumentOwnerEmployee:
+		employee, err := s.employeeRepo.FindByID(ownerID)
+		if err != nil {
+			return err
+		}
+		if employee == nil {
+			return apperrors.NewNotFoundError("Employee not found")
+		}
+	default:
+		return apperrors.NewBadRequestError("Invalid document owner type")
+	}
+	return nil
+}
+
+// detectMimeType membaca isi file untuk menentukan MIME type, bukan hanya percaya header dari klien.
+// Format yang tidak dikenali oleh sniffing (mis. docx/xlsx terbaca sebagai zip) memakai ekstensi file.
+func detectMimeType(file multipart.File, filename string) (string, error) {
+	head := make([]byte, 512)
+	n, err := file.Read(head)
+	if err != nil && n == 0 {
+		return "", err
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		return "", err
+	}
+
+	detected := http.DetectContentType(head[:n])
+	if i := strings.Index(detected, ";"); i >= 0 {
+		detected = detected[:i]
+	}
+	if detected == "application/octet-stream" || detected == "application/zip" {
+		if byExt := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename))); byExt != "" {
+			if i := strings.Index(byExt, ";"); i >= 0 {
+				byExt = byExt[:i]
+			}
+			return byExt, nil
+		}
+	}
+	return detected, nil
+}
+
+// storeDocumentFile memvalidasi ukuran dan MIME type (dari isi file) sesuai jenis dokumen
+// lalu menyimpan file. Mengembalikan path relatif dan MIME type yang terdeteksi.
+func storeDocumentFile(docType *domain.DocumentType, ownerType string, file *multipart.FileHeader) (string, string, error) {
+	// 1. Validasi ukuran
+	if file.Size > int64(docType.MaxSizeKB)*1024 {
+		return "", "", apperrors.NewBadRequestError(fmt.Sprintf("File size exceeds %d KB limit", docType.MaxSizeKB))
+	}
+
+	// 2. Validasi MIME type dari isi file
+	src, err := file.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer src.Close()
+
+	mimeType, err := detectMimeType(src, file.Filename)
+	if err != nil {
+		return "", "", err
+	}
+	allowed := false
+	for _, m := range docType.MimeTypes() {
+		if m == mimeType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return "", "", apperrors.NewBadRequestError(fmt.Sprintf("File type %s is not allowed for %s (allowed: %s)", mimeType, docType.Name, docType.AllowedMimeTypes))
+	}
+
+	// 3. Simpan file
+	path, err := utils.StoreFile(src, documentUploadFolder, fmt.Sprintf("%s_%s", ownerType, docType.Code), filepath.Ext(file.Filename))
+	if err != nil {
+		return "", "", err
+	}
+	return path, mimeType, nil
+}
+
+func (s *documentService) UploadDocument(ownerType, ownerID string, req request.DocumentUploadRequest, file *multipart.FileHeader, uploadedBy string) (*response.DocumentResponse, error) {
+	if err := s.ensureOwnerExists(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	docType, err := s.documentRepo.FindTypeByID(req.DocumentTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if docType == nil {
+		return nil, apperrors.NewNotFoundError("Document type not found")
+	}
+	if docType.OwnerType != ownerType {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Document type %s is not for %s", docType.Name, ownerType))
+	}
+
+	path, mimeType, err := storeDocumentFile(docType, ownerType, file)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &domain.Document{
+		DocumentTypeID: docType.ID,
+		OwnerType:      ownerType,
+		OwnerID:        ownerID,
+		FilePath:       path,
+		OriginalName:   utils.StringPtr(file.Filename),
+		MimeType:       utils.StringPtr(mimeType),
+		SizeBytes:      file.Size,
+		Status:         domain.DocumentStatusPending,
+	}
+	if req.Notes != "" {
+		doc.Notes = utils.StringPtr(req.Notes)
+	}
+	if uploadedBy != "" {
+		doc.UploadedBy = utils.StringPtr(uploadedBy)
+	}
+
+	// Simpan sebagai versi terbaru (versi lama tetap ada sebagai riwayat)
+	if err := s.documentRepo.CreateVersion(doc); err != nil {
+		utils.RemoveFile(path)
+		return nil, err
+	}
+	doc.DocumentType = docType
+
+	res := response.FromDomainDocument(doc, s.baseURL)
+	return &res, nil
+}
+
+// documentState menentukan status checklist dari dokumen terbaru (nil berarti belum diupload)
+func documentState(doc *domain.Document) string {
+	if doc == nil {
+		return "missing"
+	}
+	return doc.Status
+}
+
+func (s *documentService) GetOwnerDocuments(ownerType, ownerID string) (*response.OwnerDocumentsResponse, error) {
+	if err := s.ensureOwnerExists(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	types, err := s.documentRepo.FindTypes(ownerType)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := s.documentRepo.FindCurrentByOwner(ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentByType := make(map[string]*domain.Document)
+	for i := range docs {
+		currentByType[docs[i].DocumentTypeID] = &docs[i]
+	}
+
+	res := &response.OwnerDocumentsResponse{
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		Items:     []response.DocumentChecklistItem{},
+	}
+	for i := range types {
+		docType := &types[i]
+		doc := currentByType[docType.ID]
+		item := response.DocumentChecklistItem{
+			DocumentType: response.FromDomainDocumentType(docType),
+			State:        documentState(doc),
+		}
+		if doc != nil {
+			doc.DocumentType = docType
+			docRes := response.FromDomainDocument(doc, s.baseURL)
+			item.Document = &docRes
+		}
+		if docType.IsRequired && (item.State == "missing" || item.State == domain.DocumentStatusRejected) {
+			res.MissingRequired++
+		}
+		res.Items = append(res.Items, item)
+	}
+	return res, nil
+}
+
+func (s *documentService) GetDocumentHistory(ownerType, ownerID, documentTypeID string) ([]response.DocumentResponse, error) {
+	if err := s.ensureOwnerExists(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	docs, err := s.documentRepo.FindHistory(ownerType, ownerID, documentTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.DocumentResponse, 0, len(docs))
+	for i := range docs {
+		res = append(res, response.FromDomainDocument(&docs[i], s.baseURL))
+	}
+	return res, nil
+}
+
+func (s *documentService) VerifyDocument(id string, req request.DocumentVerifyRequest, verifiedBy string) (*response.DocumentResponse, error) {
+	doc, err := s.documentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, apperrors.NewNotFoundError("Document not found")
+	}
+	if !doc.IsCurrent {
+		return nil, apperrors.NewBadRequestError("Only the latest version of a document can be verified")
+	}
+	if req.Status == domain.DocumentStatusRejected && strings.TrimSpace(req.Notes) == "" {
+		return nil, apperrors.NewBadRequestError("Notes are required when rejecting a document")
+	}
+
+	now := time.Now()
+	doc.Status = req.Status
+	doc.VerifiedAt = &now
+	doc.VerifiedBy = nil
+	if verifiedBy != "" {
+		doc.VerifiedBy = utils.StringPtr(verifiedBy)
+	}
+	if req.Notes != "" {
+		doc.Notes = utils.StringPtr(req.Notes)
+	}
+
+	if err := s.documentRepo.Update(doc); err != nil {
+		return nil, err
+	}
+
+	res := response.FromDomainDocument(doc, s.baseURL)
+	return &res, nil
+}
+
+// DeleteDocument menghapus satu versi dokumen; file fisik tetap disimpan untuk jejak audit
+func (s *documentService) DeleteDocument(id string) error {
+	doc, err := s.documentRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		return apperrors.NewNotFoundError("Document not found")
+	}
+	return s.documentRepo.Delete(doc)
+}
+
+// GetClassroomCompleteness menampilkan dokumen wajib yang belum lengkap untuk setiap siswa aktif di kelas
+func (s *documentService) GetClassroomCompleteness(classroomID string) (*response.DocumentCompletenessResponse, error) {
+	classroom, err := s.classroomRepo.FindByID(classroomID)
+	if err != nil {
+		return nil, err
+	}
+	if classroom == nil {
+		return nil, apperrors.NewNotFoundError("Classroom not found")
+	}
+
+	types, err := s.documentRepo.FindTypes(domain.DocumentOwnerStudent)
+	if err != nil {
+		return nil, err
+	}
+	var required []domain.DocumentType
+	for _, t := range types {
+		if t.IsRequired {
+			required = append(required, t)
+		}
+	}
+
+	var students []domain.Student
+	for _, sc := range classroom.StudentClassrooms {
+		if sc.Status == "ACTIVE" {
+			students = append(students, sc.Student)
+		}
+	}
+	sort.Slice(students, func(i, j int) bool { return students[i].FullName < students[j].FullName })
+	studentIDs := make([]string, 0, len(students))
+	for _, st := range students {
+		studentIDs = append(studentIDs, st.ID)
+	}
+
+	docs, err := s.documentRepo.FindCurrentByOwners(domain.DocumentOwnerStudent, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+	// current[studentID][documentTypeID]
+	current := make(map[string]map[string]*domain.Document)
+	for i := range docs {
+		d := &docs[i]
+		if current[d.OwnerID] == nil {
+			current[d.OwnerID] = make(map[string]*domain.Document)
+		}
+		current[d.OwnerID][d.DocumentTypeID] = d
+	}
+
+	res := &response.DocumentCompletenessResponse{
+		ClassroomID:   classroom.ID,
+		ClassroomName: classroom.Name,
+		TotalStudents: len(students),
+		Types:         make([]response.DocumentTypeCompleteness, len(required)),
+		Students:      make([]response.StudentDocumentCompleteness, 0, len(students)),
+	}
+	for i, t := range required {
+		res.Types[i] = response.DocumentTypeCompleteness{DocumentTypeID: t.ID, Name: t.Name}
+	}
+
+	for _, st := range students {
+		row := response.StudentDocumentCompleteness{
+			StudentID: st.ID,
+			FullName:  st.FullName,
+			NISN:      st.NISN,
+			Missing:   []string{},
+			Rejected:  []string{},
+			Pending:   []string{},
+		}
+		for i, t := range required {
+			switch documentState(current[st.ID][t.ID]) {
+			case "missing":
+				row.Missing = append(row.Missing, t.Name)
+				res.Types[i].Missing++
+			case domain.DocumentStatusRejected:
+				row.Rejected = append(row.Rejected, t.Name)
+				res.Types[i].Rejected++
+			case domain.DocumentStatusPending:
+				row.Pending = append(row.Pending, t.Name)
+				res.Types[i].Pending++
+			default:
+				res.Types[i].Verified++
+			}
+		}
+		row.Complete = len(row.Missing) == 0 && len(row.Rejected) == 0
+		if row.Complete {
+			res.CompleteStudents++
+		}
+		res.Students = append(res.Students, row)
+	}
+	return res, nil
+}
@@ -0,0 +1,750 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"strings"
+	"time"
+)
+
+type DormitoryService interface {
+	CreateDormitory(req request.DormitoryRequest) (*response.DormitoryResponse, error)
+	GetDormitories() ([]response.DormitoryResponse, error)
+	GetDormitoryByID(id string) (*response.DormitoryResponse, error)
+	UpdateDormitory(id string, req request.DormitoryRequest) (*response.DormitoryResponse, error)
+	DeleteDormitory(id string) error
+
+	CreateRoom(dormitoryID string, req request.DormRoomRequest) (*response.DormRoomResponse, error)
+	GetRooms(dormitoryID, academicYearID string, viewer *domain.User) ([]response.DormRoomResponse, error)
+	UpdateRoom(id string, req request.DormRoomRequest) (*response.DormRoomResponse, error)
+	DeleteRoom(id string) error
+
+	GetRoomOccupants(roomID, academicYearID string, viewer *domain.User) (*response.DormRoomOccupantsResponse, error)
+	AssignStudents(roomID string, req request.DormAssignRequest, createdBy string) ([]response.DormAssignmentResponse, error)
+	MoveStudent(req request.DormMoveRequest, createdBy string) (*response.DormAssignmentResponse, error)
+	Checkout(assignmentID string, req request.DormCheckoutRequest) (*response.DormAssignmentResponse, error)
+	GetStudentHistory(studentID string) ([]response.DormAssignmentResponse, error)
+	GetOccupancyReport(academicYearID string) (*response.DormOccupancyReport, error)
+}
+
+type dormitoryService struct {
+	dormitoryRepo    repository.DormitoryRepository
+	studentRepo      repository.StudentRepository
+	employeeRepo     repository.EmployeeRepository
+	academicYearRepo repository.AcademicYearRepository
+}
+
+func NewDormitoryService(
+	dormitoryRepo repository.DormitoryRepository,
+	studentRepo repository.StudentRepository,
+	employeeRepo repository.EmployeeRepository,
+	academicYearRepo repository.AcademicYearRepository,
+) DormitoryService {
+	return &dormitoryService{
+		dormitoryRepo:    dormitoryRepo,
+		studentRepo:      studentRepo,
+		employeeRepo:     employeeRepo,
+		academicYearRepo: academicYearRepo,
+	}
+}
+
+// --- Dormitories ---
+
+// CreateDormitory membuat asrama baru. Asrama dihapus permanen (tanpa trash), jadi nama asrama yang
+// sudah dihapus bisa dipakai lagi; pengecekan nama yang kalah balapan dengan request lain tetap 409.
+func (s *dormitoryService) CreateDormitory(req request.DormitoryRequest) (*response.DormitoryResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	existing, err := s.dormitoryRepo.FindDormitoryByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.NewConflictError("Dormitory name already exists")
+	}
+
+	dormitory := &domain.Dormitory{Name: name, Gender: req.Gender, Description: req.Description}
+	if err := s.dormitoryRepo.CreateDormitory(dormitory); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, apperrors.NewConflictError("Dormitory name already exists")
+		}
+		return nil, err
+	}
+
+	return toDormitoryResponse(dormitory), nil
+}
+
+func (s *dormitoryService) GetDormitories() ([]response.DormitoryResponse, error) {
+	dormitories, err := s.dormitoryRepo.FindDormitories()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DormitoryResponse, 0, len(dormitories))
+	for i := range dormitories {
+		result = append(result, *toDormitoryResponse(&dormitories[i]))
+	}
+	return result, nil
+}
+
+func (s *dormitoryService) GetDormitoryByID(id string) (*response.DormitoryResponse, error) {
+	dormitory, err := s.dormitoryRepo.FindDormitoryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if dormitory == nil {
+		return nil, apperrors.NewNotFoundError("Dormitory not found")
+	}
+
+	return toDormitoryResponse(dormitory), nil
+}
+
+func (s *dormitoryService) UpdateDormitory(id string, req request.DormitoryRequest) (*response.DormitoryResponse, error) {
+	dormitory, err := s.dormitoryRepo.FindDormitoryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if dormitory == nil {
+		return nil, apperrors.NewNotFoundError("Dormitory not found")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name != dormitory.Name {
+		existing, err := s.dormitoryRepo.FindDormitoryByName(name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, apperrors.NewConflictError("Dormitory name already exists")
+		}
+	}
+
+	// Asrama putra/putri tidak boleh diubah selama masih ada penghuni
+	if req.Gender != dormitory.Gender {
+		occupied, err := s.dormitoryRepo.CountActiveAssignmentsInDormitory(dormitory.ID)
+		if err != nil {
+			return nil, err
+		}
+		if occupied > 0 {
+			return nil, apperrors.NewConflictError("Cannot change gender of a dormitory that still has occupants")
+		}
+	}
+
+	dormitory.Name = name
+	dormitory.Gender = req.Gender
+	dormitory.Description = req.Description
+	if err := s.dormitoryRepo.UpdateDormitory(dormitory); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, apperrors.NewConflictError("Dormitory name already exists")
+		}
+		return nil, err
+	}
+
+	return toDormitoryResponse(dormitory), nil
+}
+
+func (s *dormitoryService) DeleteDormitory(id string) error {
+	dormitory, err := s.dormitoryRepo.FindDormitoryByID(id)
+	if err != nil {
+		return err
+	}
+	if dormitory == nil {
+		return apperrors.NewNotFoundError("Dormitory not found")
+	}
+
+	occupied, err := s.dormitoryRepo.CountActiveAssignmentsInDormitory(dormitory.ID)
+	if err != nil {
+		return err
+	}
+	if occupied > 0 {
+		return apperrors.NewConflictError(fmt.Sprintf("Dormitory still has %d occupants, move or check them out first", occupied))
+	}
+
+	return s.dormitoryRepo.DeleteDormitory(dormitory.ID)
+}
+
+// --- Rooms ---
+
+func (s *dormitoryService) CreateRoom(dormitoryID string, req request.DormRoomRequest) (*response.DormRoomResponse, error) {
+	dormitory, err := s.dormitoryRepo.FindDormitoryByID(dormitoryID)
+	if err != nil {
+		return nil, err
+	}
+	if dormitory == nil {
+		return nil, apperrors.NewNotFoundError("Dormitory not found")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	existing, err := s.dormitoryRepo.FindRoomByName(dormitory.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperrors.NewConflictError("Room name already exists in this dormitory")
+	}
+
+	musyrifID, err := s.resolveMusyrif(req.MusyrifID)
+	if err != nil {
+		return nil, err
+	}
+
+	room := &domain.DormRoom{
+		DormitoryID: dormitory.ID,
+		Name:        name,
+		Floor:       req.Floor,
+		Capacity:    req.Capacity,
+		MusyrifID:   musyrifID,
+	}
+	if err := s.dormitoryRepo.CreateRoom(room); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, apperrors.NewConflictError("Room name already exists in this dormitory")
+		}
+		return nil, err
+	}
+
+	created, err := s.dormitoryRepo.FindRoomByID(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toDormRoomResponse(created), nil
+}
+
+// GetRooms menampilkan kamar beserta jumlah penghuni. Musyrif (dormitories.read_own) hanya melihat kamar binaannya.
+func (s *dormitoryService) GetRooms(dormitoryID, academicYearID string, viewer *domain.User) ([]response.DormRoomResponse, error) {
+	year, err := s.resolveAcademicYear(academicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.DormRoomFilter{DormitoryID: dormitoryID, AcademicYearID: year.ID}
+	if !viewer.HasPermission("dormitories.read") {
+		musyrif, err := s.findMusyrif(viewer)
+		if err != nil {
+			return nil, err
+		}
+		filter.MusyrifID = musyrif.ID
+	}
+
+	rooms, err := s.dormitoryRepo.FindRooms(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DormRoomResponse, 0, len(rooms))
+	for i := range rooms {
+		result = append(result, *toDormRoomResponse(&rooms[i]))
+	}
+	return result, nil
+}
+
+func (s *dormitoryService) UpdateRoom(id string, req request.DormRoomRequest) (*response.DormRoomResponse, error) {
+	room, err := s.dormitoryRepo.FindRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, apperrors.NewNotFoundError("Dorm room not found")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name != room.Name {
+		existing, err := s.dormitoryRepo.FindRoomByName(room.DormitoryID, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, apperrors.NewConflictError("Room name already exists in this dormitory")
+		}
+	}
+
+	// Kapasitas tidak boleh di bawah jumlah penghuni tahun ajaran aktif
+	if year, err := s.academicYearRepo.FindActive(); err != nil {
+		return nil, err
+	} else if year != nil {
+		occupied, err := s.dormitoryRepo.CountActiveAssignments(room.ID, year.ID)
+		if err != nil {
+			return nil, err
+		}
+		if int64(req.Capacity) < occupied {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Capacity cannot be less than current occupants (%d)", occupied))
+		}
+	}
+
+	musyrifID, err := s.resolveMusyrif(req.MusyrifID)
+	if err != nil {
+		return nil, err
+	}
+
+	room.Name = name
+	room.Floor = req.Floor
+	room.Capacity = req.Capacity
+	room.MusyrifID = musyrifID
+	room.Musyrif = nil
+	if err := s.dormitoryRepo.UpdateRoom(room); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return nil, apperrors.NewConflictError("Room name already exists in this dormitory")
+		}
+		return nil, err
+	}
+
+	updated, err := s.dormitoryRepo.FindRoomByID(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toDormRoomResponse(updated), nil
+}
+
+func (s *dormitoryService) DeleteRoom(id string) error {
+	room, err := s.dormitoryRepo.FindRoomByID(id)
+	if err != nil {
+		return err
+	}
+	if room == nil {
+		return apperrors.NewNotFoundError("Dorm room not found")
+	}
+
+	occupants, err := s.dormitoryRepo.CountActiveAssignmentsInRoom(room.ID)
+	if err != nil {
+		return err
+	}
+	if occupants > 0 {
+		return apperrors.NewConflictError(fmt.Sprintf("Room still has %d occupants, move or check them out first", occupants))
+	}
+
+	return s.dormitoryRepo.DeleteRoom(room.ID)
+}
+
+// --- Assignments ---
+
+func (s *dormitoryService) GetRoomOccupants(roomID, academicYearID string, viewer *domain.User) (*response.DormRoomOccupantsResponse, error) {
+	room, err := s.dormitoryRepo.FindRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, apperrors.NewNotFoundError("Dorm room not found")
+	}
+
+	if !viewer.HasPermission("dormitories.read") {
+		musyrif, err := s.findMusyrif(viewer)
+		if err != nil {
+			return nil, err
+		}
+		if room.MusyrifID == nil || *room.MusyrifID != musyrif.ID {
+			return nil, apperrors.NewForbiddenError("You can only view occupants of rooms you supervise")
+		}
+	}
+
+	year, err := s.resolveAcademicYear(academicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.dormitoryRepo.FindOccupants(room.ID, year.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	room.Occupied = int64(len(assignments))
+	result := &response.DormRoomOccupantsResponse{
+		Room:           *toDormRoomResponse(room),
+		AcademicYearID: year.ID,
+		Occupants:      make([]response.DormOccupantResponse, 0, len(assignments)),
+	}
+	for _, assignment := range assignments {
+		occupant := response.DormOccupantResponse{
+			AssignmentID: assignment.ID,
+			StudentID:    assignment.StudentID,
+			StartDate:    assignment.StartDate,
+			Notes:        assignment.Notes,
+		}
+		if assignment.Student != nil {
+			occupant.FullName = assignment.Student.FullName
+			occupant.NISN = assignment.Student.NISN
+			occupant.Gender = assignment.Student.Gender
+		}
+		result.Occupants = append(result.Occupants, occupant)
+	}
+
+	return result, nil
+}
+
+// AssignStudents menempatkan santri ke kamar. Santri yang sudah punya kamar di tahun ajaran yang sama
+// harus dipindah lewat MoveStudent agar riwayatnya tercatat.
+func (s *dormitoryService) AssignStudents(roomID string, req request.DormAssignRequest, createdBy string) ([]response.DormAssignmentResponse, error) {
+	room, err := s.dormitoryRepo.FindRoomByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room == nil {
+		return nil, apperrors.NewNotFoundError("Dorm room not found")
+	}
+
+	year, err := s.resolveAcademicYear(req.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := utils.Date(time.Now())
+	if req.StartDate != nil && !req.StartDate.IsZero() {
+		startDate = *req.StartDate
+	}
+
+	seen := map[string]bool{}
+	var assignments []domain.DormAssignment
+	for _, studentID := range req.StudentIDs {
+		if seen[studentID] {
+			continue
+		}
+		seen[studentID] = true
+
+		student, err := s.studentRepo.FindByID(studentID)
+		if err != nil {
+			return nil, err
+		}
+		if student == nil {
+			return nil, apperrors.NewNotFoundError(fmt.Sprintf("Student %s not found", studentID))
+		}
+		if err := checkDormGender(room, student); err != nil {
+			return nil, err
+		}
+
+		current, err := s.dormitoryRepo.FindActiveAssignment(student.ID, year.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil {
+			return nil, apperrors.NewConflictError(fmt.Sprintf("%s already lives in room %s, use move instead", student.FullName, current.DormRoom.Name))
+		}
+
+		assignments = append(assignments, domain.DormAssignment{
+			DormRoomID:     room.ID,
+			StudentID:      student.ID,
+			AcademicYearID: year.ID,
+			Status:         domain.DormAssignmentActive,
+			StartDate:      startDate,
+			Notes:          req.Notes,
+			CreatedBy:      optionalString(createdBy),
+		})
+	}
+
+	if err := s.checkCapacity(room, year.ID, len(assignments)); err != nil {
+		return nil, err
+	}
+
+	if err := s.dormitoryRepo.CreateAssignments(assignments); err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DormAssignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		assignments[i].DormRoom = room
+		result = append(result, toDormAssignmentResponse(&assignments[i]))
+	}
+	return result, nil
+}
+
+func (s *dormitoryService) MoveStudent(req request.DormMoveRequest, createdBy string) (*response.DormAssignmentResponse, error) {
+	year, err := s.resolveAcademicYear(req.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := s.studentRepo.FindByID(req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	current, err := s.dormitoryRepo.FindActiveAssignment(student.ID, year.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, apperrors.NewBadRequestError("Student has no room in this academic year, assign instead")
+	}
+	if current.DormRoomID == req.ToRoomID {
+		return nil, apperrors.NewBadRequestError("Student already lives in this room")
+	}
+
+	target, err := s.dormitoryRepo.FindRoomByID(req.ToRoomID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, apperrors.NewNotFoundError("Target dorm room not found")
+	}
+	if err := checkDormGender(target, student); err != nil {
+		return nil, err
+	}
+	if err := s.checkCapacity(target, year.ID, 1); err != nil {
+		return nil, err
+	}
+
+	today := utils.Date(time.Now())
+	current.Status = domain.DormAssignmentMoved
+	current.EndDate = &today
+
+	next := &domain.DormAssignment{
+		DormRoomID:     target.ID,
+		StudentID:      student.ID,
+		AcademicYearID: year.ID,
+		Status:         domain.DormAssignmentActive,
+		StartDate:      today,
+		Notes:          req.Notes,
+		CreatedBy:      optionalString(createdBy),
+	}
+	if err := s.dormitoryRepo.MoveAssignment(current, next); err != nil {
+		return nil, err
+	}
+
+	next.DormRoom = target
+	result := toDormAssignmentResponse(next)
+	return &result, nil
+}
+
+// Checkout mengakhiri penempatan (mis. santri keluar asrama); baris tetap disimpan sebagai riwayat
+func (s *dormitoryService) Checkout(assignmentID string, req request.DormCheckoutRequest) (*response.DormAssignmentResponse, error) {
+	assignment, err := s.dormitoryRepo.FindAssignmentByID(assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, apperrors.NewNotFoundError("Dorm assignment not found")
+	}
+	if assignment.Status != domain.DormAssignmentActive {
+		return nil, apperrors.NewBadRequestError("Dorm assignment is no longer active")
+	}
+
+	today := utils.Date(time.Now())
+	assignment.Status = domain.DormAssignmentEnded
+	assignment.EndDate = &today
+	if req.Notes != nil {
+		assignment.Notes = req.Notes
+	}
+	if err := s.dormitoryRepo.EndAssignment(assignment); err != nil {
+		return nil, err
+	}
+
+	result := toDormAssignmentResponse(assignment)
+	return &result, nil
+}
+
+func (s *dormitoryService) GetStudentHistory(studentID string) ([]response.DormAssignmentResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	assignments, err := s.dormitoryRepo.FindStudentAssignments(student.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]response.DormAssignmentResponse, 0, len(assignments))
+	for i := range assignments {
+		result = append(result, toDormAssignmentResponse(&assignments[i]))
+	}
+	return result, nil
+}
+
+// GetOccupancyReport merekap kapasitas dan keterisian per asrama dan per kamar
+func (s *dormitoryService) GetOccupancyReport(academicYearID string) (*response.DormOccupancyReport, error) {
+	year, err := s.resolveAcademicYear(academicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	dormitories, err := s.dormitoryRepo.FindDormitories()
+	if err != nil {
+		return nil, err
+	}
+	rooms, err := s.dormitoryRepo.FindRooms(repository.DormRoomFilter{AcademicYearID: year.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &response.DormOccupancyReport{
+		AcademicYearID: year.ID,
+		Dormitories:    make([]response.DormOccupancyDormitory, 0, len(dormitories)),
+	}
+	index := map[string]int{}
+	for _, dormitory := range dormitories {
+		index[dormitory.ID] = len(report.Dormitories)
+		report.Dormitories = append(report.Dormitories, response.DormOccupancyDormitory{
+			ID:     dormitory.ID,
+			Name:   dormitory.Name,
+			Gender: dormitory.Gender,
+			Rooms:  []response.DormRoomResponse{},
+		})
+	}
+
+	for i := range rooms {
+		pos, ok := index[rooms[i].DormitoryID]
+		if !ok {
+			continue
+		}
+		item := &report.Dormitories[pos]
+		item.Rooms = append(item.Rooms, *toDormRoomResponse(&rooms[i]))
+		item.Capacity += rooms[i].Capacity
+		item.Occupied += rooms[i].Occupied
+	}
+
+	for i := range report.Dormitories {
+		item := &report.Dormitories[i]
+		item.Available = max(int64(item.Capacity)-item.Occupied, 0)
+		item.OccupancyRate = occupancyRate(item.Occupied, item.Capacity)
+		report.Capacity += item.Capacity
+		report.Occupied += item.Occupied
+	}
+	report.Available = max(int64(report.Capacity)-report.Occupied, 0)
+	report.OccupancyRate = occupancyRate(report.Occupied, report.Capacity)
+
+	return report, nil
+}
+
+// --- Helpers ---
+
+func (s *dormitoryService) resolveAcademicYear(id string) (*domain.AcademicYear, error) {
+	if id == "" {
+		year, err := s.academicYearRepo.FindActive()
+		if err != nil {
+			return nil, err
+		}
+		if year == nil {
+			return nil, apperrors.NewBadRequestError("No active academic year, please specify academic_year_id")
+		}
+		return year, nil
+	}
+
+	year, err := s.academicYearRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if year == nil {
+		return nil, apperrors.NewNotFoundError("Academic year not found")
+	}
+	return year, nil
+}
+
+func (s *dormitoryService) resolveMusyrif(id *string) (*string, error) {
+	if id == nil || *id == "" {
+		return nil, nil
+	}
+	employee, err := s.employeeRepo.FindByID(*id)
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, apperrors.NewNotFoundError("Musyrif (employee) not found")
+	}
+	return &employee.ID, nil
+}
+
+// findMusyrif mengambil data employee milik user yang hanya punya akses dormitories.read_own
+func (s *dormitoryService) findMusyrif(viewer *domain.User) (*domain.Employee, error) {
+	if !viewer.HasPermission("dormitories.read_own") {
+		return nil, apperrors.NewForbiddenError("You don't have permission to access this resource")
+	}
+	employee, err := s.employeeRepo.FindByUserID(viewer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, apperrors.NewForbiddenError("Your account is not linked to an employee profile")
+	}
+	return employee, nil
+}
+
+func (s *dormitoryService) checkCapacity(room *domain.DormRoom, academicYearID string, incoming int) error {
+	occupied, err := s.dormitoryRepo.CountActiveAssignments(room.ID, academicYearID)
+	if err != nil {
+		return err
+	}
+	if occupied+int64(incoming) > int64(room.Capacity) {
+		return apperrors.NewConflictError(fmt.Sprintf("Room %s is full (%d/%d)", room.Name, occupied, room.Capacity))
+	}
+	return nil
+}
+
+func checkDormGender(room *domain.DormRoom, student *domain.Student) error {
+	if room.Dormitory != nil && student.Gender != "" && room.Dormitory.Gender != student.Gender {
+		return apperrors.NewBadRequestError(fmt.Sprintf("%s cannot be placed in a %s dormitory", student.FullName, room.Dormitory.Gender))
+	}
+	return nil
+}
+
+func occupancyRate(occupied int64, capacity int) float64 {
+	if capacity == 0 {
+		return 0
+	}
+	return math.Round(float64(occupied)/float64(capacity)*10000) / 100
+}
+
+func toDormitoryResponse(dormitory *domain.Dormitory) *response.DormitoryResponse {
+	result := &response.DormitoryResponse{
+		ID:          dormitory.ID,
+		Name:        dormitory.Name,
+		Gender:      dormitory.Gender,
+		Description: dormitory.Description,
+	}
+	for i := range dormitory.Rooms {
+		dormitory.Rooms[i].Dormitory = dormitory
+		result.Rooms = append(result.Rooms, *toDormRoomResponse(&dormitory.Rooms[i]))
+	}
+	return result
+}
+
+func toDormRoomResponse(room *domain.DormRoom) *response.DormRoomResponse {
+	result := &response.DormRoomResponse{
+		ID:          room.ID,
+		DormitoryID: room.DormitoryID,
+		Name:        room.Name,
+		Floor:       room.Floor,
+		Capacity:    room.Capacity,
+		Occupied:    room.Occupied,
+		Available:   max(int64(room.Capacity)-room.Occupied, 0),
+		MusyrifID:   room.MusyrifID,
+	}
+	if room.Dormitory != nil {
+		result.DormitoryName = room.Dormitory.Name
+	}
+	if room.Musyrif != nil {
+		result.MusyrifName = &room.Musyrif.FullName
+	}
+	return result
+}
+
+func toDormAssignmentResponse(assignment *domain.DormAssignment) response.DormAssignmentResponse {
+	result := response.DormAssignmentResponse{
+		ID:             assignment.ID,
+		StudentID:      assignment.StudentID,
+		DormRoomID:     assignment.DormRoomID,
+		AcademicYearID: assignment.AcademicYearID,
+		Status:         assignment.Status,
+		StartDate:      assignment.StartDate,
+		EndDate:        assignment.EndDate,
+		Notes:          assignment.Notes,
+	}
+	if assignment.DormRoom != nil {
+		result.DormRoomName = assignment.DormRoom.Name
+		result.DormitoryID = assignment.DormRoom.DormitoryID
+		if assignment.DormRoom.Dormitory != nil {
+			result.DormitoryName = assignment.DormRoom.Dormitory.Name
+		}
+	}
+	if assignment.AcademicYear != nil {
+		result.AcademicYearName = assignment.AcademicYear.Name
+	}
+	return result
+}
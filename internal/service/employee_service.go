@@ -9,6 +9,7 @@ import (
 	"smart_school_be/internal/model/response"
 	"smart_school_be/internal/repository"
 	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
 )
 
 type EmployeeService interface {
@@ -28,6 +29,7 @@ type employeeService struct {
 	userRepo     repository.UserRepository // Dependensi untuk validasi user
 	encryptUtil  utils.EncryptionUtil
 	converter    converter.EmployeeConverterInterface
+	identity     *validation.IdentityValidator
 }
 
 func NewEmployeeService(
@@ -35,17 +37,28 @@ func NewEmployeeService(
 	userRepo repository.UserRepository, // Tambahkan parameter
 	encryptUtil utils.EncryptionUtil,
 	converter converter.EmployeeConverterInterface,
+	identity *validation.IdentityValidator,
 ) EmployeeService {
 	return &employeeService{
 		employeeRepo: employeeRepo,
 		userRepo:     userRepo, // Inject dependensi
 		encryptUtil:  encryptUtil,
 		converter:    converter,
+		identity:     identity,
 	}
 }
 
 // CreateEmployee menangani pembuatan pegawai baru
 func (s *employeeService) CreateEmployee(req request.EmployeeCreateRequest) (*response.EmployeeDetailResponse, error) {
+	// Validasi struktur NIK dan kecocokannya dengan tanggal lahir & jenis kelamin
+	if _, err := s.identity.Validate("employee", validation.Person{
+		NIK:         req.NIK,
+		Gender:      utils.SafeString(req.Gender),
+		DateOfBirth: dateToTime(req.DateOfBirth),
+	}); err != nil {
+		return nil, err
+	}
+
 	// 1. Validasi Duplikat (NIP & Phone)
 	if req.NIP != nil && *req.NIP != "" {
 		if existing, _ := s.employeeRepo.FindByNIP(*req.NIP); existing != nil {
@@ -181,6 +194,15 @@ func (s *employeeService) UpdateEmployee(id string, req request.EmployeeUpdateRe
 		return nil, apperrors.NewNotFoundError("Employee not found")
 	}
 
+	// Validasi NIK baru (jika dikirim) terhadap tanggal lahir & jenis kelamin
+	if _, err := s.identity.Validate("employee", validation.Person{
+		NIK:         req.NIK,
+		Gender:      utils.SafeString(req.Gender),
+		DateOfBirth: dateToTime(req.DateOfBirth),
+	}); err != nil {
+		return nil, err
+	}
+
 	// Update fields jika disediakan
 	if req.FullName != "" {
 		employee.FullName = req.FullName
@@ -0,0 +1,665 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const familyImportSheet = "Data Orang Tua Wali"
+
+// familyImportColumns adalah kolom template import orang tua/wali, urutan = urutan kolom di Excel
+var familyImportColumns = []studentImportColumn{
+	{Key: "type", Label: "Jenis (ORTU/WALI)", Required: true},
+	{Key: "full_name", Label: "Nama Lengkap", Required: true},
+	{Key: "student_nisn", Label: "NISN Siswa"},
+	{Key: "student_nim", Label: "NIM Siswa"},
+	{Key: "relationship", Label: "Hubungan"},
+	{Key: "set_guardian", Label: "Jadikan Wali (Y/T)"},
+	{Key: "gender", Label: "Jenis Kelamin (L/P)"},
+	{Key: "nik", Label: "NIK"},
+	{Key: "phone_number", Label: "No HP"},
+	{Key: "email", Label: "Email"},
+	{Key: "occupation", Label: "Pekerjaan"},
+	{Key: "address", Label: "Alamat"},
+	{Key: "city", Label: "Kota/Kabupaten"},
+	{Key: "province", Label: "Provinsi"},
+}
+
+// familyParentRelationships adalah nilai relationship_type yang diterima untuk orang tua.
+// FATHER dan MOTHER hanya boleh satu per siswa.
+var familyParentRelationships = map[string]string{
+	"father": "FATHER", "ayah": "FATHER", "bapak": "FATHER",
+	"mother": "MOTHER", "ibu": "MOTHER",
+	"step_father": "STEP_FATHER", "ayah tiri": "STEP_FATHER",
+	"step_mother": "STEP_MOTHER", "ibu tiri": "STEP_MOTHER",
+}
+
+type FamilyImportService interface {
+	GetImportTemplate() (*bytes.Buffer, error)
+	ImportFamilies(file io.Reader, req request.FamilyImportRequest) (*response.FamilyImportResponse, error)
+}
+
+type familyImportService struct {
+	studentRepo    repository.StudentRepository
+	parentRepo     repository.ParentRepository
+	guardianRepo   repository.GuardianRepository
+	encryptionUtil utils.EncryptionUtil
+	identity       *validation.IdentityValidator
+}
+
+func NewFamilyImportService(
+	studentRepo repository.StudentRepository,
+	parentRepo repository.ParentRepository,
+	guardianRepo repository.GuardianRepository,
+	encryptionUtil utils.EncryptionUtil,
+	identity *validation.IdentityValidator,
+) FamilyImportService {
+	return &familyImportService{
+		studentRepo:    studentRepo,
+		parentRepo:     parentRepo,
+		guardianRepo:   guardianRepo,
+		encryptionUtil: encryptionUtil,
+		identity:       identity,
+	}
+}
+
+// familyImportPerson adalah data orang tua/wali yang sudah ada, diringkas untuk pencocokan
+type familyImportPerson struct {
+	ID       string
+	FullName string
+	NIKHash  *string
+	Trashed  bool
+}
+
+// familyImportPending adalah orang tua/wali baru yang dibuat oleh baris sebelumnya di file yang sama
+type familyImportPending struct {
+	ID  string
+	Row int
+}
+
+// familyImportState menyimpan hasil baris sebelumnya agar baris berikutnya bisa dicek terhadapnya
+type familyImportState struct {
+	pending      map[string]familyImportPending // key: "<type>|nik:<hash>" atau "<type>|phone:<nomor>" atau "<type>|email:<email>"
+	links        map[string]int                 // student_id|parent_id -> baris
+	relations    map[string]int                 // student_id|FATHER/MOTHER -> baris
+	guardianRows map[string]int                 // student_id -> baris yang menetapkan wali
+	studentLinks map[string][]domain.StudentParent
+}
+
+// GetImportTemplate membuat template Excel kosong untuk import orang tua/wali
+func (s *familyImportService) GetImportTemplate() (*bytes.Buffer, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName("Sheet1", familyImportSheet)
+
+	lastCol, _ := excelize.ColumnNumberToName(len(familyImportColumns))
+
+	// Semua kolom bertipe teks agar nol di depan NISN/NIK/No HP tidak hilang
+	textStyle, _ := f.NewStyle(&excelize.Style{NumFmt: 49})
+	f.SetColStyle(familyImportSheet, "A:"+lastCol, textStyle)
+	f.SetColWidth(familyImportSheet, "A", lastCol, 20)
+
+	for i, col := range familyImportColumns {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		label := col.Label
+		if col.Required {
+			label += "*"
+		}
+		f.SetCellValue(familyImportSheet, cell, label)
+	}
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true},
+		Fill:   excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+		NumFmt: 49,
+	})
+	f.SetCellStyle(familyImportSheet, "A1", lastCol+"1", headerStyle)
+	f.SetPanes(familyImportSheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	guide := "Petunjuk"
+	f.NewSheet(guide)
+	lines := []string{
+		"Petunjuk pengisian template import orang tua/wali",
+		"",
+		"1. Isi data mulai baris ke-2 pada sheet \"" + familyImportSheet + "\". Jangan ubah judul kolom.",
+		"2. Satu baris = satu orang tua/wali untuk satu siswa. Untuk kakak-adik, ulangi orang yang sama di baris berbeda dengan NIK/No HP yang sama.",
+		"3. Jenis: ORTU (orang tua) atau WALI.",
+		"4. Siswa dirujuk dengan NISN Siswa atau NIM Siswa (salah satu wajib diisi). Siswa harus sudah terdaftar.",
+		"5. Hubungan untuk ORTU: AYAH, IBU, AYAH TIRI atau IBU TIRI (kosong = ditentukan dari jenis kelamin). Untuk WALI: bebas, mis. Paman.",
+		"6. Jadikan Wali: Y untuk menetapkan orang tua sebagai wali siswa. Baris WALI selalu ditetapkan sebagai wali siswa.",
+		"7. No HP wajib untuk WALI. NIK: 16 digit angka dengan kode wilayah yang valid.",
+		"8. Orang tua/wali dengan NIK atau No HP yang sudah terdaftar akan ditautkan, bukan dibuat ulang.",
+		"9. Upload dengan mode dry_run terlebih dahulu untuk melihat laporan konflik per baris.",
+	}
+	for i, line := range lines {
+		f.SetCellValue(guide, fmt.Sprintf("A%d", i+1), line)
+	}
+	f.SetColWidth(guide, "A", "A", 120)
+
+	f.SetActiveSheet(0)
+	return f.WriteToBuffer()
+}
+
+// ImportFamilies memvalidasi file Excel per baris. Pada mode commit, semua data disimpan
+// dalam satu transaksi, dan hanya jika tidak ada baris yang invalid.
+func (s *familyImportService) ImportFamilies(file io.Reader, req request.FamilyImportRequest) (*response.FamilyImportResponse, error) {
+	f, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid Excel file")
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(familyImportSheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Sheet %q not found, please use the import template", familyImportSheet))
+	}
+	if len(rows) < 2 {
+		return nil, apperrors.NewBadRequestError("Import file has no data rows")
+	}
+
+	columnIndex, err := parseImportHeader(rows[0], familyImportColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &familyImportState{
+		pending:      make(map[string]familyImportPending),
+		links:        make(map[string]int),
+		relations:    make(map[string]int),
+		guardianRows: make(map[string]int),
+		studentLinks: make(map[string][]domain.StudentParent),
+	}
+
+	result := &response.FamilyImportResponse{
+		DryRun: req.Mode != "commit",
+		Rows:   []response.FamilyImportRowResult{},
+	}
+	var records []repository.FamilyImportRecord
+	var recordRows []int
+
+	for i, cells := range rows[1:] {
+		values := make(map[string]string, len(columnIndex))
+		empty := true
+		for key, idx := range columnIndex {
+			if idx < len(cells) {
+				values[key] = strings.TrimSpace(cells[idx])
+				if values[key] != "" {
+					empty = false
+				}
+			}
+		}
+		if empty {
+			continue
+		}
+
+		record, rowResult, err := s.validateRow(i+2, values, req, state)
+		if err != nil {
+			return nil, err
+		}
+		result.TotalRows++
+		if len(rowResult.Errors) > 0 {
+			rowResult.Status = "invalid"
+			result.InvalidRows++
+		} else {
+			rowResult.Status = "valid"
+			result.ValidRows++
+			records = append(records, *record)
+			recordRows = append(recordRows, len(result.Rows))
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	if result.TotalRows == 0 {
+		return nil, apperrors.NewBadRequestError("Import file has no data rows")
+	}
+	if result.DryRun || result.InvalidRows > 0 {
+		return result, nil
+	}
+
+	if err := s.studentRepo.ImportFamilies(records); err != nil {
+		return nil, fmt.Errorf("failed to import parents and guardians: %w", err)
+	}
+	for i, record := range records {
+		result.Rows[recordRows[i]].Status = "imported"
+		if record.NewParent != nil {
+			result.CreatedParents++
+		}
+		if record.NewGuardian != nil {
+			result.CreatedGuardians++
+		}
+		if record.Link != nil {
+			result.LinkedStudents++
+		}
+		if record.GuardianType != "" {
+			result.AssignedGuardians++
+		}
+	}
+	return result, nil
+}
+
+// validateRow memvalidasi satu baris dan menyiapkan record untuk disimpan.
+// Error yang dikembalikan hanya error sistem (DB/enkripsi), bukan error validasi.
+func (s *familyImportService) validateRow(rowNum int, values map[string]string, req request.FamilyImportRequest, state *familyImportState) (*repository.FamilyImportRecord, response.FamilyImportRowResult, error) {
+	result := response.FamilyImportRowResult{Row: rowNum, FullName: values["full_name"]}
+	addError := func(format string, args ...interface{}) {
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+	}
+	addWarning := func(format string, args ...interface{}) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(format, args...))
+	}
+	record := &repository.FamilyImportRecord{}
+
+	personType, ok := normalizeFamilyImportType(values["type"])
+	if !ok {
+		addError("Invalid Jenis %q (use ORTU or WALI)", values["type"])
+		return record, result, nil
+	}
+	result.Type = personType
+
+	if result.FullName == "" {
+		addError("Nama Lengkap is required")
+	}
+
+	var gender string
+	if values["gender"] != "" {
+		if g, ok := normalizeImportGender(values["gender"]); ok {
+			gender = g
+		} else {
+			addError("Invalid Jenis Kelamin %q (use L or P)", values["gender"])
+		}
+	}
+
+	phone, email := values["phone_number"], values["email"]
+	if personType == "guardian" && phone == "" {
+		addError("No HP is required for WALI")
+	}
+
+	student, err := s.findImportStudent(values["student_nisn"], values["student_nim"], addError)
+	if err != nil {
+		return nil, result, err
+	}
+	if student != nil {
+		result.StudentName = student.FullName
+		record.StudentID = student.ID
+		if student.Status != "ACTIVE" {
+			addWarning("Student status is %s", student.Status)
+		}
+	}
+
+	// Hubungan & penetapan wali
+	relationship := strings.TrimSpace(values["relationship"])
+	if personType == "parent" {
+		if relationship == "" {
+			switch gender {
+			case "male":
+				relationship = "FATHER"
+			case "female":
+				relationship = "MOTHER"
+			default:
+				addError("Hubungan is required when Jenis Kelamin is empty")
+			}
+		} else if rel, ok := familyParentRelationships[strings.ToLower(relationship)]; ok {
+			relationship = rel
+		} else {
+			addError("Invalid Hubungan %q (use AYAH, IBU, AYAH TIRI or IBU TIRI)", values["relationship"])
+		}
+		result.SetGuardian = isImportYes(values["set_guardian"])
+	} else {
+		result.SetGuardian = true
+	}
+	result.Relationship = relationship
+
+	// Validasi struktur NIK dan kecocokannya dengan jenis kelamin
+	nik := values["nik"]
+	var nikHash string
+	if nik != "" {
+		for _, issue := range validation.Check(validation.Person{NIK: nik, Gender: gender}) {
+			if s.identity.Strict() {
+				addError("%s", issue.String())
+			} else {
+				addWarning("%s", issue.String())
+			}
+		}
+		if nikHash, err = s.encryptionUtil.Hash(nik); err != nil {
+			return nil, result, fmt.Errorf("failed to hash NIK: %w", err)
+		}
+	}
+	if len(result.Errors) > 0 {
+		return record, result, nil
+	}
+
+	// Cari orang yang sama: baris sebelumnya di file ini, lalu database (NIK, lalu No HP)
+	keys := familyImportKeys(personType, nikHash, phone, email)
+	personID := ""
+	for _, key := range keys {
+		if p, ok := state.pending[key]; ok {
+			personID = p.ID
+			result.Action = "use_row"
+			addWarning("Same person as row %d", p.Row)
+			for _, other := range keys {
+				if _, ok := state.pending[other]; !ok {
+					state.pending[other] = p
+				}
+			}
+			break
+		}
+	}
+	if personID == "" {
+		existing, errMsg, err := s.matchExistingPerson(personType, nikHash, phone, email)
+		if err != nil {
+			return nil, result, err
+		}
+		if errMsg != "" {
+			addError("%s", errMsg)
+			return record, result, nil
+		}
+		if existing != nil {
+			personID = existing.ID
+			result.Action = "use_existing"
+			if !strings.EqualFold(existing.FullName, result.FullName) {
+				addWarning("Matched existing %s %q, name in file is ignored", personType, existing.FullName)
+			}
+		}
+	}
+	if personID == "" {
+		personID = utils.GenerateUUID()
+		result.Action = "create"
+		if err := s.buildNewPerson(record, personID, personType, values, gender, relationship, nik, nikHash); err != nil {
+			return nil, result, err
+		}
+		for _, key := range keys {
+			state.pending[key] = familyImportPending{ID: personID, Row: rowNum}
+		}
+	}
+	result.PersonID = personID
+
+	if student == nil {
+		return record, result, nil
+	}
+
+	if personType == "parent" {
+		if err := s.planParentLink(record, result.PersonID, relationship, rowNum, state, addError, addWarning); err != nil {
+			return nil, result, err
+		}
+	}
+
+	if result.SetGuardian {
+		if row, ok := state.guardianRows[student.ID]; ok {
+			addError("Guardian for this student is already set in row %d", row)
+		} else if student.GuardianID != nil && *student.GuardianID == personID {
+			addWarning("Already the student's guardian")
+		} else {
+			if student.GuardianID != nil && !req.OverwriteGuardian {
+				addError("Student already has a guardian, enable overwrite_guardian to replace it")
+			}
+			state.guardianRows[student.ID] = rowNum
+			record.GuardianType = personType
+			record.GuardianID = personID
+		}
+	}
+
+	return record, result, nil
+}
+
+// findImportStudent mencari siswa berdasarkan NISN/NIM. Jika keduanya diisi, harus menunjuk siswa yang sama.
+func (s *familyImportService) findImportStudent(nisn, nim string, addError func(string, ...interface{})) (*domain.Student, error) {
+	if nisn == "" && nim == "" {
+		addError("NISN Siswa or NIM Siswa is required")
+		return nil, nil
+	}
+
+	var student *domain.Student
+	if nisn != "" {
+		found, err := s.studentRepo.FindByNISN(nisn)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			addError("Student with NISN %s not found", nisn)
+			return nil, nil
+		}
+		student = found
+	}
+	if nim != "" {
+		found, err := s.studentRepo.FindByNIM(nim)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			addError("Student with NIM %s not found", nim)
+			return nil, nil
+		}
+		if student != nil && student.ID != found.ID {
+			addError("NISN %s and NIM %s belong to different students", nisn, nim)
+			return nil, nil
+		}
+		student = found
+	}
+
+	if student.DeletedAt.Valid {
+		addError("Student %s is in the trash", student.FullName)
+		return nil, nil
+	}
+	return student, nil
+}
+
+// matchExistingPerson mencari orang tua/wali yang sudah terdaftar berdasarkan NIK lalu No HP.
+// Konflik dikembalikan sebagai pesan: data di trash, atau NIK dan No HP/email menunjuk orang berbeda.
+func (s *familyImportService) matchExistingPerson(personType, nikHash, phone, email string) (*familyImportPerson, string, error) {
+	var byNIK, byPhone, byEmail *familyImportPerson
+	var err error
+	if nikHash != "" {
+		if byNIK, err = s.findPerson(personType, "nik", nikHash); err != nil {
+			return nil, "", err
+		}
+	}
+	if phone != "" {
+		if byPhone, err = s.findPerson(personType, "phone", phone); err != nil {
+			return nil, "", err
+		}
+	}
+	if email != "" {
+		if byEmail, err = s.findPerson(personType, "email", email); err != nil {
+			return nil, "", err
+		}
+	}
+
+	match := byNIK
+	if match == nil {
+		match = byPhone
+	}
+	if match != nil && byPhone != nil && byPhone.ID != match.ID {
+		return nil, fmt.Sprintf("No HP %s is already used by %s %q", phone, personType, byPhone.FullName), nil
+	}
+	if match != nil && nikHash != "" && match.NIKHash != nil && *match.NIKHash != nikHash {
+		return nil, fmt.Sprintf("No HP %s is registered to %s %q with a different NIK", phone, personType, match.FullName), nil
+	}
+	if byEmail != nil && (match == nil || byEmail.ID != match.ID) {
+		return nil, fmt.Sprintf("Email %s is already used by %s %q", email, personType, byEmail.FullName), nil
+	}
+	if match != nil && match.Trashed {
+		return nil, fmt.Sprintf("Matching %s %q is in the trash, restore it first", personType, match.FullName), nil
+	}
+	return match, "", nil
+}
+
+// findPerson membungkus lookup repository orang tua/wali menjadi familyImportPerson
+func (s *familyImportService) findPerson(personType, field, value string) (*familyImportPerson, error) {
+	if personType == "parent" {
+		var parent *domain.Parent
+		var err error
+		switch field {
+		case "nik":
+			parent, err = s.parentRepo.FindByNIKHash(value)
+		case "phone":
+			parent, err = s.parentRepo.FindByPhone(value)
+		default:
+			parent, err = s.parentRepo.FindByEmail(value)
+		}
+		if err != nil || parent == nil {
+			return nil, err
+		}
+		return &familyImportPerson{ID: parent.ID, FullName: parent.FullName, NIKHash: parent.NIKHash, Trashed: parent.DeletedAt.Valid}, nil
+	}
+
+	var guardian *domain.Guardian
+	var err error
+	switch field {
+	case "nik":
+		guardian, err = s.guardianRepo.FindByNIKHash(value)
+	case "phone":
+		guardian, err = s.guardianRepo.FindByPhone(value)
+	default:
+		guardian, err = s.guardianRepo.FindByEmail(value)
+	}
+	if err != nil || guardian == nil {
+		return nil, err
+	}
+	return &familyImportPerson{ID: guardian.ID, FullName: guardian.FullName, NIKHash: guardian.NIKHash, Trashed: guardian.DeletedAt.Valid}, nil
+}
+
+// buildNewPerson menyiapkan data orang tua/wali baru pada record
+func (s *familyImportService) buildNewPerson(record *repository.FamilyImportRecord, id, personType string, values map[string]string, gender, relationship, nik, nikHash string) error {
+	toPtr := func(v string) *string {
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+
+	var encryptedNIK, hashPtr *string
+	if nik != "" {
+		encrypted, err := s.encryptionUtil.Encrypt(nik)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt NIK: %w", err)
+		}
+		encryptedNIK = &encrypted
+		hashPtr = &nikHash
+	}
+
+	if personType == "parent" {
+		record.NewParent = &domain.Parent{
+			ID:          id,
+			FullName:    values["full_name"],
+			NIK:         encryptedNIK,
+			NIKHash:     hashPtr,
+			Gender:      toPtr(gender),
+			PhoneNumber: toPtr(values["phone_number"]),
+			Email:       toPtr(values["email"]),
+			Occupation:  toPtr(values["occupation"]),
+			Address:     toPtr(values["address"]),
+			City:        toPtr(values["city"]),
+			Province:    toPtr(values["province"]),
+		}
+		return nil
+	}
+
+	record.NewGuardian = &domain.Guardian{
+		ID:                    id,
+		FullName:              values["full_name"],
+		NIK:                   encryptedNIK,
+		NIKHash:               hashPtr,
+		Gender:                toPtr(gender),
+		PhoneNumber:           toPtr(values["phone_number"]),
+		Email:                 toPtr(values["email"]),
+		Address:               toPtr(values["address"]),
+		City:                  toPtr(values["city"]),
+		Province:              toPtr(values["province"]),
+		RelationshipToStudent: toPtr(relationship),
+	}
+	return nil
+}
+
+// planParentLink menyiapkan relasi student_parent, dan menolak jika siswa sudah punya
+// ayah/ibu lain (di database maupun di baris sebelumnya)
+func (s *familyImportService) planParentLink(record *repository.FamilyImportRecord, parentID, relationship string, rowNum int, state *familyImportState, addError, addWarning func(string, ...interface{})) error {
+	studentID := record.StudentID
+	existing, ok := state.studentLinks[studentID]
+	if !ok {
+		links, err := s.studentRepo.FindParentLinks(studentID)
+		if err != nil {
+			return err
+		}
+		existing = links
+		state.studentLinks[studentID] = links
+	}
+
+	for _, link := range existing {
+		if link.ParentID == parentID {
+			addWarning("Already linked to the student as %s", link.RelationshipType)
+			return nil
+		}
+	}
+	if row, ok := state.links[studentID+"|"+parentID]; ok {
+		addError("Same parent and student already in row %d", row)
+		return nil
+	}
+
+	if relationship == "FATHER" || relationship == "MOTHER" {
+		for _, link := range existing {
+			if link.RelationshipType == relationship {
+				addError("Student already has another %s", relationship)
+				return nil
+			}
+		}
+		if row, ok := state.relations[studentID+"|"+relationship]; ok {
+			addError("%s for this student is already in row %d", relationship, row)
+			return nil
+		}
+		state.relations[studentID+"|"+relationship] = rowNum
+	}
+
+	state.links[studentID+"|"+parentID] = rowNum
+	record.Link = &domain.StudentParent{
+		StudentID:        studentID,
+		ParentID:         parentID,
+		RelationshipType: relationship,
+	}
+	return nil
+}
+
+// familyImportKeys adalah key pencocokan orang yang sama antar baris di satu file
+func familyImportKeys(personType, nikHash, phone, email string) []string {
+	var keys []string
+	if nikHash != "" {
+		keys = append(keys, personType+"|nik:"+nikHash)
+	}
+	if phone != "" {
+		keys = append(keys, personType+"|phone:"+phone)
+	}
+	if email != "" {
+		keys = append(keys, personType+"|email:"+strings.ToLower(email))
+	}
+	return keys
+}
+
+func normalizeFamilyImportType(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "ortu", "orang tua", "parent":
+		return "parent", true
+	case "wali", "guardian":
+		return "guardian", true
+	}
+	return "", false
+}
+
+func isImportYes(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "ya", "yes", "1", "true":
+		return true
+	}
+	return false
+}
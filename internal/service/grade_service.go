@@ -6,6 +6,7 @@ import (
 	"smart_school_be/internal/model/request"
 	"smart_school_be/internal/model/response"
 	"smart_school_be/internal/repository"
+	"time"
 )
 
 type GradeService interface {
@@ -15,6 +16,7 @@ type GradeService interface {
 	GetAssessmentDetail(id string) (*domain.Assessment, error)
 	SubmitScores(req request.BulkScoreRequest) error
 	DeleteAssessment(id string) error
+	SetAssessmentPublished(id string, published bool) error
 }
 
 type gradeService struct {
@@ -116,3 +118,21 @@ func (s *gradeService) DeleteAssessment(id string) error {
 
 	return s.gradeRepo.DeleteAssessment(id)
 }
+
+// SetAssessmentPublished menampilkan/menyembunyikan nilai assessment di portal orang tua
+func (s *gradeService) SetAssessmentPublished(id string, published bool) error {
+	var publishedAt *time.Time
+	if published {
+		now := time.Now()
+		publishedAt = &now
+	}
+
+	found, err := s.gradeRepo.SetAssessmentPublished(id, publishedAt)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return apperrors.NewNotFoundError("assessment not found")
+	}
+	return nil
+}
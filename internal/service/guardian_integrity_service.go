@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+)
+
+// GuardianIntegrityService memeriksa pointer wali polimorfik (students.guardian_type + guardian_id)
+// yang menunjuk ke parent/guardian yang tidak ada atau sudah dihapus.
+type GuardianIntegrityService interface {
+	// CheckGuardianRefs melaporkan pointer rusak; jika fix, pointer dipindah ke orang tua aktif
+	// dari student_parent bila ada, selain itu dikosongkan.
+	CheckGuardianRefs(fix bool) (*response.GuardianIntegrityReport, error)
+}
+
+type guardianIntegrityService struct {
+	studentRepo repository.StudentRepository
+}
+
+func NewGuardianIntegrityService(studentRepo repository.StudentRepository) GuardianIntegrityService {
+	return &guardianIntegrityService{studentRepo: studentRepo}
+}
+
+func (s *guardianIntegrityService) CheckGuardianRefs(fix bool) (*response.GuardianIntegrityReport, error) {
+	issues, err := s.studentRepo.FindGuardianRefIssues()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &response.GuardianIntegrityReport{
+		Fixed:  fix,
+		Issues: make([]response.GuardianIntegrityIssue, 0, len(issues)),
+	}
+	for _, issue := range issues {
+		item := response.GuardianIntegrityIssue{
+			StudentID:      issue.StudentID,
+			FullName:       issue.FullName,
+			StudentDeleted: issue.StudentDeleted,
+			GuardianType:   issue.GuardianType,
+			GuardianID:     issue.GuardianID,
+			Reason:         issue.Reason,
+			Action:         "nullify",
+		}
+
+		var toType, toID *string
+		if issue.FallbackParentID != nil {
+			item.Action = "reassign_parent"
+			item.ReassignedToID = issue.FallbackParentID
+			parentType := "parent"
+			toType, toID = &parentType, issue.FallbackParentID
+		}
+
+		if fix {
+			if err := s.studentRepo.RepairGuardianRef(issue.StudentID, toType, toID); err != nil {
+				return report, fmt.Errorf("failed to repair guardian of student %s: %w", issue.StudentID, err)
+			}
+		}
+		report.Issues = append(report.Issues, item)
+	}
+	return report, nil
+}
+
+// resolveWardsTarget menentukan target pointer wali saat parent/guardian (selfType, selfID) dihapus.
+// Mengembalikan target nil untuk nullify.
+func resolveWardsTarget(
+	req request.GuardianDeleteRequest,
+	selfType, selfID string,
+	wards int64,
+	parentRepo repository.ParentRepository,
+	guardianRepo repository.GuardianRepository,
+) (*string, *string, error) {
+	switch req.OnWards {
+	case "nullify":
+		return nil, nil, nil
+	case "reassign":
+		if req.ReassignToType == "" || req.ReassignToID == "" {
+			return nil, nil, apperrors.NewBadRequestError("reassign_to_type and reassign_to_id are required when on_wards=reassign")
+		}
+		if req.ReassignToType == selfType && req.ReassignToID == selfID {
+			return nil, nil, apperrors.NewBadRequestError("Cannot reassign students to the record being deleted")
+		}
+
+		switch req.ReassignToType {
+		case "parent":
+			parent, err := parentRepo.FindByID(req.ReassignToID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if parent == nil {
+				return nil, nil, apperrors.NewNotFoundError("Reassign target parent not found")
+			}
+		case "guardian":
+			guardian, err := guardianRepo.FindByID(req.ReassignToID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if guardian == nil {
+				return nil, nil, apperrors.NewNotFoundError("Reassign target guardian not found")
+			}
+		}
+		return &req.ReassignToType, &req.ReassignToID, nil
+	default:
+		return nil, nil, apperrors.NewConflictError(fmt.Sprintf(
+			"Still the guardian of %d students; delete with on_wards=nullify or on_wards=reassign", wards))
+	}
+}
+
+func toGuardianWardResponses(students []domain.Student) []response.GuardianWardResponse {
+	wards := make([]response.GuardianWardResponse, 0, len(students))
+	for _, student := range students {
+		wards = append(wards, response.GuardianWardResponse{
+			ID:       student.ID,
+			FullName: student.FullName,
+			NISN:     student.NISN,
+			NIM:      student.NIM,
+			Gender:   student.Gender,
+			Status:   student.Status,
+		})
+	}
+	return wards
+}
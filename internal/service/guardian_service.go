@@ -11,6 +11,7 @@ import (
 	"smart_school_be/internal/model/response"
 	"smart_school_be/internal/repository"
 	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
 )
 
 type GuardianService interface {
@@ -18,34 +19,52 @@ type GuardianService interface {
 	GetGuardianByID(id string) (*response.GuardianDetailResponse, error)
 	GetAllGuardians(search string) ([]response.GuardianListResponse, error)
 	UpdateGuardian(id string, req request.GuardianUpdateRequest) (*response.GuardianDetailResponse, error)
-	DeleteGuardian(id string) error
+	DeleteGuardian(id string, req request.GuardianDeleteRequest) error
+	GetWards(guardianID string) ([]response.GuardianWardResponse, error)
 	LinkUser(guardianID string, userID string) error
 	UnlinkUser(guardianID string) error
 }
 
 type guardianService struct {
 	guardianRepo   repository.GuardianRepository
+	parentRepo     repository.ParentRepository
+	studentRepo    repository.StudentRepository
 	userRepo       repository.UserRepository
 	encryptionUtil utils.EncryptionUtil
 	converter      converter.GuardianConverterInterface
+	identity       *validation.IdentityValidator
 }
 
 func NewGuardianService(
 	guardianRepo repository.GuardianRepository,
+	parentRepo repository.ParentRepository,
+	studentRepo repository.StudentRepository,
 	userRepo repository.UserRepository,
 	encryptionUtil utils.EncryptionUtil,
 	converter converter.GuardianConverterInterface,
+	identity *validation.IdentityValidator,
 ) GuardianService {
 	return &guardianService{
 		guardianRepo:   guardianRepo,
+		parentRepo:     parentRepo,
+		studentRepo:    studentRepo,
 		userRepo:       userRepo,
 		encryptionUtil: encryptionUtil,
 		converter:      converter,
+		identity:       identity,
 	}
 }
 
 // CreateGuardian menangani pembuatan data wali baru
 func (s *guardianService) CreateGuardian(req request.GuardianCreateRequest) (*response.GuardianDetailResponse, error) {
+	// Validasi struktur NIK dan kecocokannya dengan jenis kelamin
+	if _, err := s.identity.Validate("guardian", validation.Person{
+		NIK:    utils.SafeString(req.NIK),
+		Gender: utils.SafeString(req.Gender),
+	}); err != nil {
+		return nil, err
+	}
+
 	// 1. Validasi Duplikat (Phone & Email)
 	if req.PhoneNumber != nil && *req.PhoneNumber != "" { // Phone number optional
 		if existing, _ := s.guardianRepo.FindByPhone(*req.PhoneNumber); existing != nil {
@@ -184,6 +203,14 @@ func (s *guardianService) UpdateGuardian(id string, req request.GuardianUpdateRe
 		return nil, apperrors.NewNotFoundError("Guardian not found")
 	}
 
+	// Validasi NIK baru (jika dikirim) terhadap jenis kelamin
+	if _, err := s.identity.Validate("guardian", validation.Person{
+		NIK:    utils.SafeString(req.NIK),
+		Gender: utils.SafeString(req.Gender),
+	}); err != nil {
+		return nil, err
+	}
+
 	// Update fields jika disediakan
 	if req.FullName != "" {
 		guardian.FullName = req.FullName
@@ -289,8 +316,9 @@ func (s *guardianService) UpdateGuardian(id string, req request.GuardianUpdateRe
 	return resp, nil
 }
 
-// DeleteGuardian menghapus data wali
-func (s *guardianService) DeleteGuardian(id string) error {
+// DeleteGuardian menghapus data wali. Jika masih menjadi wali siswa, req.OnWards menentukan
+// apakah penghapusan ditolak (block), pointer wali dikosongkan (nullify), atau dipindah (reassign).
+func (s *guardianService) DeleteGuardian(id string, req request.GuardianDeleteRequest) error {
 	guardian, err := s.guardianRepo.FindByID(id)
 	if err != nil {
 		return err
@@ -299,7 +327,36 @@ func (s *guardianService) DeleteGuardian(id string) error {
 		return apperrors.NewNotFoundError("Guardian not found")
 	}
 
-	return s.guardianRepo.Delete(id)
+	wards, err := s.studentRepo.CountByGuardian("guardian", id)
+	if err != nil {
+		return err
+	}
+	if wards == 0 {
+		return s.guardianRepo.Delete(id)
+	}
+
+	toType, toID, err := resolveWardsTarget(req, "guardian", id, wards, s.parentRepo, s.guardianRepo)
+	if err != nil {
+		return err
+	}
+	return s.guardianRepo.DeleteReassigningWards(id, toType, toID)
+}
+
+// GetWards menampilkan siswa yang walinya menunjuk ke wali ini
+func (s *guardianService) GetWards(guardianID string) ([]response.GuardianWardResponse, error) {
+	guardian, err := s.guardianRepo.FindByID(guardianID)
+	if err != nil {
+		return nil, err
+	}
+	if guardian == nil {
+		return nil, apperrors.NewNotFoundError("Guardian not found")
+	}
+
+	students, err := s.studentRepo.FindByGuardian("guardian", guardian.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toGuardianWardResponses(students), nil
 }
 
 // LinkUser menautkan profil Guardian ke akun User
@@ -0,0 +1,358 @@
+package service
+
+import (
+	"fmt"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"strings"
+	"time"
+)
+
+type HealthService interface {
+	GetProfile(studentID string, viewer *domain.User) (*response.HealthProfileResponse, error)
+	UpdateProfile(studentID string, req request.HealthProfileRequest, updatedBy string) (*response.HealthProfileResponse, error)
+	CreateVisit(req request.ClinicVisitCreateRequest, handledBy string) (*response.ClinicVisitResponse, error)
+	GetVisitByID(id string, viewer *domain.User) (*response.ClinicVisitResponse, error)
+	GetStudentVisits(studentID string, viewer *domain.User, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetVisits(filter request.ClinicVisitFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error)
+}
+
+type healthService struct {
+	healthRepo     repository.HealthRepository
+	studentRepo    repository.StudentRepository
+	employeeRepo   repository.EmployeeRepository
+	encryptionUtil utils.EncryptionUtil
+}
+
+func NewHealthService(
+	healthRepo repository.HealthRepository,
+	studentRepo repository.StudentRepository,
+	employeeRepo repository.EmployeeRepository,
+	encryptionUtil utils.EncryptionUtil,
+) HealthService {
+	return &healthService{
+		healthRepo:     healthRepo,
+		studentRepo:    studentRepo,
+		employeeRepo:   employeeRepo,
+		encryptionUtil: encryptionUtil,
+	}
+}
+
+// authorizeRead: petugas kesehatan (health.read) bisa membaca semua siswa,
+// wali kelas (health.read_homeroom) hanya siswa di kelas aktif yang ia pegang.
+func (s *healthService) authorizeRead(student *domain.Student, viewer *domain.User) error {
+	if viewer.HasPermission("health.read") {
+		return nil
+	}
+	if viewer.HasPermission("health.read_homeroom") {
+		employee, err := s.employeeRepo.FindByUserID(viewer.ID)
+		if err != nil {
+			return err
+		}
+		if employee != nil {
+			for _, placement := range student.StudentClassrooms {
+				if placement.Classroom.HomeroomTeacherID != nil && *placement.Classroom.HomeroomTeacherID == employee.ID {
+					return nil
+				}
+			}
+		}
+	}
+	return apperrors.NewForbiddenError("You don't have permission to access this student's health records")
+}
+
+func (s *healthService) findStudent(studentID string) (*domain.Student, error) {
+	student, err := s.studentRepo.FindByIDWithActiveClassroom(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+	return student, nil
+}
+
+func (s *healthService) GetProfile(studentID string, viewer *domain.User) (*response.HealthProfileResponse, error) {
+	student, err := s.findStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeRead(student, viewer); err != nil {
+		return nil, err
+	}
+
+	profile, err := s.healthRepo.FindProfileByStudentID(student.ID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		// Profil belum diisi: kembalikan kerangka kosong
+		return &response.HealthProfileResponse{StudentID: student.ID, StudentName: student.FullName}, nil
+	}
+
+	return s.toProfileResponse(profile, student)
+}
+
+func (s *healthService) UpdateProfile(studentID string, req request.HealthProfileRequest, updatedBy string) (*response.HealthProfileResponse, error) {
+	student, err := s.findStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.healthRepo.FindProfileByStudentID(student.ID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = &domain.StudentHealthProfile{StudentID: student.ID}
+	}
+
+	fields := []struct {
+		value  *string
+		target **string
+	}{
+		{req.BloodType, &profile.BloodType},
+		{req.Allergies, &profile.Allergies},
+		{req.ChronicConditions, &profile.ChronicConditions},
+		{req.RegularMedications, &profile.RegularMedications},
+		{req.Notes, &profile.Notes},
+	}
+	for _, field := range fields {
+		encrypted, err := s.encryptOptional(field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = encrypted
+	}
+	profile.UpdatedBy = optionalString(updatedBy)
+
+	if err := s.healthRepo.SaveProfile(profile); err != nil {
+		return nil, err
+	}
+
+	return s.toProfileResponse(profile, student)
+}
+
+func (s *healthService) CreateVisit(req request.ClinicVisitCreateRequest, handledBy string) (*response.ClinicVisitResponse, error) {
+	student, err := s.findStudent(req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Outcome == domain.ClinicOutcomeReferred && strings.TrimSpace(utils.SafeString(req.ReferredTo)) == "" {
+		return nil, apperrors.NewBadRequestError("referred_to is required when outcome is referred")
+	}
+	if req.MarkAttendanceSick && req.Outcome != domain.ClinicOutcomeSentHome {
+		return nil, apperrors.NewBadRequestError("mark_attendance_sick is only allowed when outcome is sent_home")
+	}
+
+	visitedAt := time.Now()
+	if req.VisitedAt != nil {
+		visitedAt = req.VisitedAt.In(time.Local)
+		if visitedAt.After(time.Now()) {
+			return nil, apperrors.NewBadRequestError("visited_at cannot be in the future")
+		}
+	}
+
+	complaint, err := s.encryptionUtil.Encrypt(req.Complaint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt complaint: %w", err)
+	}
+	treatment, err := s.encryptOptional(req.Treatment)
+	if err != nil {
+		return nil, err
+	}
+	medication, err := s.encryptOptional(req.MedicationGiven)
+	if err != nil {
+		return nil, err
+	}
+
+	visit := &domain.ClinicVisit{
+		StudentID:          student.ID,
+		VisitedAt:          visitedAt,
+		Complaint:          complaint,
+		Treatment:          treatment,
+		MedicationGiven:    medication,
+		Outcome:            req.Outcome,
+		MarkAttendanceSick: req.MarkAttendanceSick,
+		HandledBy:          optionalString(handledBy),
+	}
+	if req.Outcome == domain.ClinicOutcomeReferred {
+		visit.ReferredTo = req.ReferredTo
+	}
+
+	// Absensi ditandai di kelas aktif siswa; siswa tanpa kelas aktif tidak punya sesi untuk ditandai
+	classroomID := ""
+	if len(student.StudentClassrooms) > 0 {
+		classroomID = student.StudentClassrooms[0].ClassroomID
+	}
+	if err := s.healthRepo.CreateVisit(visit, classroomID); err != nil {
+		return nil, err
+	}
+
+	visit.Student = student
+	return s.toVisitResponse(visit)
+}
+
+func (s *healthService) GetVisitByID(id string, viewer *domain.User) (*response.ClinicVisitResponse, error) {
+	visit, err := s.healthRepo.FindVisitByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if visit == nil {
+		return nil, apperrors.NewNotFoundError("Clinic visit not found")
+	}
+
+	student, err := s.findStudent(visit.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeRead(student, viewer); err != nil {
+		return nil, err
+	}
+
+	return s.toVisitResponse(visit)
+}
+
+func (s *healthService) GetStudentVisits(studentID string, viewer *domain.User, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	student, err := s.findStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeRead(student, viewer); err != nil {
+		return nil, err
+	}
+
+	return s.findVisits(repository.ClinicVisitFilter{StudentID: student.ID}, pagination)
+}
+
+func (s *healthService) GetVisits(req request.ClinicVisitFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	filter := repository.ClinicVisitFilter{StudentID: req.StudentID, Outcome: req.Outcome}
+	for _, item := range []struct {
+		raw    string
+		name   string
+		target **time.Time
+	}{
+		{req.DateFrom, "date_from", &filter.DateFrom},
+		{req.DateTo, "date_to", &filter.DateTo},
+	} {
+		if item.raw == "" {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", item.raw, time.Local)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid %s, expected YYYY-MM-DD", item.name))
+		}
+		*item.target = &date
+	}
+
+	return s.findVisits(filter, pagination)
+}
+
+func (s *healthService) findVisits(filter repository.ClinicVisitFilter, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	visits, total, err := s.healthRepo.FindVisits(filter, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.ClinicVisitResponse, 0, len(visits))
+	for i := range visits {
+		item, err := s.toVisitResponse(&visits[i])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), pagination.GetLimit())
+	return &paginated, nil
+}
+
+// encryptOptional mengenkripsi nilai opsional; string kosong disimpan sebagai NULL
+func (s *healthService) encryptOptional(value *string) (*string, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+	encrypted, err := s.encryptionUtil.Encrypt(strings.TrimSpace(*value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt health data: %w", err)
+	}
+	return &encrypted, nil
+}
+
+func (s *healthService) decryptOptional(value *string) (*string, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	decrypted, err := s.encryptionUtil.Decrypt(*value)
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to decrypt health data")
+	}
+	return &decrypted, nil
+}
+
+func (s *healthService) toProfileResponse(profile *domain.StudentHealthProfile, student *domain.Student) (*response.HealthProfileResponse, error) {
+	result := &response.HealthProfileResponse{
+		StudentID:   student.ID,
+		StudentName: student.FullName,
+		UpdatedBy:   profile.UpdatedBy,
+		UpdatedAt:   &profile.UpdatedAt,
+	}
+
+	fields := []struct {
+		value  *string
+		target **string
+	}{
+		{profile.BloodType, &result.BloodType},
+		{profile.Allergies, &result.Allergies},
+		{profile.ChronicConditions, &result.ChronicConditions},
+		{profile.RegularMedications, &result.RegularMedications},
+		{profile.Notes, &result.Notes},
+	}
+	for _, field := range fields {
+		decrypted, err := s.decryptOptional(field.value)
+		if err != nil {
+			return nil, err
+		}
+		*field.target = decrypted
+	}
+
+	return result, nil
+}
+
+func (s *healthService) toVisitResponse(visit *domain.ClinicVisit) (*response.ClinicVisitResponse, error) {
+	complaint, err := s.decryptOptional(&visit.Complaint)
+	if err != nil {
+		return nil, err
+	}
+	treatment, err := s.decryptOptional(visit.Treatment)
+	if err != nil {
+		return nil, err
+	}
+	medication, err := s.decryptOptional(visit.MedicationGiven)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.ClinicVisitResponse{
+		ID:                 visit.ID,
+		StudentID:          visit.StudentID,
+		VisitedAt:          visit.VisitedAt,
+		Complaint:          utils.SafeString(complaint),
+		Treatment:          treatment,
+		MedicationGiven:    medication,
+		Outcome:            visit.Outcome,
+		ReferredTo:         visit.ReferredTo,
+		MarkAttendanceSick: visit.MarkAttendanceSick,
+		AttendanceMarked:   visit.AttendanceMarked,
+		HandledBy:          visit.HandledBy,
+		CreatedAt:          visit.CreatedAt,
+	}
+	if visit.Student != nil {
+		result.StudentName = visit.Student.FullName
+	}
+	return result, nil
+}
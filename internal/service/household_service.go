@@ -0,0 +1,208 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"sort"
+)
+
+// Jumlah siswa per batch saat backfill household
+const householdBackfillBatch = 200
+
+type HouseholdService interface {
+	GetStudentSiblings(studentID string) (*response.StudentSiblingsResponse, error)
+	GetHouseholdByID(id string) (*response.HouseholdResponse, error)
+	GetHouseholds(minChildren int, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	Backfill() (int, error)
+}
+
+type householdService struct {
+	householdRepo  repository.HouseholdRepository
+	studentRepo    repository.StudentRepository
+	encryptionUtil utils.EncryptionUtil
+}
+
+func NewHouseholdService(householdRepo repository.HouseholdRepository, studentRepo repository.StudentRepository, encryptionUtil utils.EncryptionUtil) HouseholdService {
+	return &householdService{
+		householdRepo:  householdRepo,
+		studentRepo:    studentRepo,
+		encryptionUtil: encryptionUtil,
+	}
+}
+
+// GetStudentSiblings mengembalikan saudara kandung siswa (siswa lain dengan No KK yang sama)
+func (s *householdService) GetStudentSiblings(studentID string) (*response.StudentSiblingsResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	siblings, err := s.householdRepo.FindSiblings(student)
+	if err != nil {
+		return nil, err
+	}
+
+	// Urutan lahir dihitung bersama siswa itu sendiri, dari yang tertua
+	family := append([]domain.Student{*student}, siblings...)
+	sortByBirth(family)
+
+	result := &response.StudentSiblingsResponse{
+		StudentID:    student.ID,
+		HouseholdID:  student.HouseholdID,
+		SiblingCount: len(siblings),
+		Siblings:     make([]response.HouseholdStudent, 0, len(siblings)),
+	}
+	for i, member := range family {
+		if member.ID == student.ID {
+			result.BirthOrder = i + 1
+			continue
+		}
+		item := toHouseholdStudent(member)
+		item.BirthOrder = i + 1
+		result.Siblings = append(result.Siblings, item)
+	}
+
+	return result, nil
+}
+
+func (s *householdService) GetHouseholdByID(id string) (*response.HouseholdResponse, error) {
+	household, err := s.householdRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if household == nil {
+		return nil, apperrors.NewNotFoundError("Household not found")
+	}
+
+	return s.toHouseholdResponse(household), nil
+}
+
+// GetHouseholds menampilkan keluarga beserta anak-anaknya. minChildren=2 hanya menampilkan keluarga yang punya saudara kandung.
+func (s *householdService) GetHouseholds(minChildren int, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	households, total, err := s.householdRepo.FindAll(minChildren, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.HouseholdResponse, 0, len(households))
+	for i := range households {
+		items = append(items, *s.toHouseholdResponse(&households[i]))
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), pagination.GetLimit())
+	return &paginated, nil
+}
+
+// Backfill mengisi no_kk_hash dan household_id untuk siswa lama yang dibuat sebelum fitur household ada.
+// Baris yang gagal didekripsi dilewati dan dicatat di log.
+func (s *householdService) Backfill() (int, error) {
+	assigned := 0
+	afterID := ""
+	for {
+		students, err := s.householdRepo.FindStudentsWithoutHousehold(afterID, householdBackfillBatch)
+		if err != nil {
+			return assigned, err
+		}
+		if len(students) == 0 {
+			return assigned, nil
+		}
+
+		for i := range students {
+			student := &students[i]
+			afterID = student.ID
+
+			noKK, err := s.encryptionUtil.Decrypt(student.NoKK)
+			if err != nil {
+				log.Printf("household backfill: skip student %s: failed to decrypt NoKK: %v", student.ID, err)
+				continue
+			}
+			hash, err := s.encryptionUtil.Hash(noKK)
+			if err != nil {
+				return assigned, fmt.Errorf("failed to hash NoKK: %w", err)
+			}
+			student.NoKKHash = &hash
+
+			if err := s.householdRepo.SetStudentHousehold(student); err != nil {
+				return assigned, err
+			}
+			assigned++
+		}
+	}
+}
+
+func (s *householdService) toHouseholdResponse(household *domain.Household) *response.HouseholdResponse {
+	noKK := ""
+	if household.NoKK != "" {
+		if decrypted, err := s.encryptionUtil.Decrypt(household.NoKK); err == nil {
+			noKK = decrypted
+		}
+	}
+
+	result := &response.HouseholdResponse{
+		ID:           household.ID,
+		NoKK:         noKK,
+		StudentCount: len(household.Students),
+		Students:     make([]response.HouseholdStudent, 0, len(household.Students)),
+		Parents:      []response.HouseholdParent{},
+	}
+
+	sortByBirth(household.Students)
+	seenParents := map[string]bool{}
+	for i, student := range household.Students {
+		item := toHouseholdStudent(student)
+		item.BirthOrder = i + 1
+		result.Students = append(result.Students, item)
+
+		for _, link := range student.Parents {
+			if seenParents[link.ParentID] {
+				continue
+			}
+			seenParents[link.ParentID] = true
+			result.Parents = append(result.Parents, response.HouseholdParent{
+				ID:               link.ParentID,
+				FullName:         link.Parent.FullName,
+				RelationshipType: link.RelationshipType,
+				PhoneNumber:      link.Parent.PhoneNumber,
+			})
+		}
+	}
+
+	return result
+}
+
+func toHouseholdStudent(student domain.Student) response.HouseholdStudent {
+	item := response.HouseholdStudent{
+		ID:          student.ID,
+		FullName:    student.FullName,
+		NISN:        student.NISN,
+		Gender:      student.Gender,
+		DateOfBirth: student.DateOfBirth,
+		Status:      student.Status,
+	}
+	if len(student.StudentClassrooms) > 0 {
+		classroom := student.StudentClassrooms[0].Classroom
+		item.ClassroomID = &classroom.ID
+		item.ClassroomName = &classroom.Name
+	}
+	return item
+}
+
+// sortByBirth mengurutkan dari yang tertua; siswa tanpa tanggal lahir ditaruh paling akhir
+func sortByBirth(students []domain.Student) {
+	sort.SliceStable(students, func(i, j int) bool {
+		a, b := students[i].DateOfBirth, students[j].DateOfBirth
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.ToTime().Before(b.ToTime())
+	})
+}
@@ -0,0 +1,477 @@
+package service
+
+import (
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"strings"
+	"time"
+)
+
+type LeavePermitService interface {
+	CreatePermit(req request.LeavePermitCreateRequest, viewer *domain.User) (*response.LeavePermitResponse, error)
+	GetPermits(filter request.LeavePermitFilterRequest, pagination request.PaginationRequest, viewer *domain.User) (*response.PaginatedData, error)
+	GetOverduePermits(pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetPermitByID(id string, viewer *domain.User) (*response.LeavePermitResponse, error)
+	ConfirmGuardian(id string, req request.LeaveGuardianConfirmRequest, viewer *domain.User) (*response.LeavePermitResponse, error)
+	ApprovePermit(id string, req request.LeavePermitApproveRequest, viewer *domain.User) (*response.LeavePermitResponse, error)
+	RejectPermit(id string, req request.LeavePermitRejectRequest, viewer *domain.User) (*response.LeavePermitResponse, error)
+	CancelPermit(id string, viewer *domain.User) (*response.LeavePermitResponse, error)
+	CheckOut(id string, req request.LeavePermitCheckOutRequest, userID string) (*response.LeavePermitResponse, error)
+	CheckIn(id string, userID string) (*response.LeavePermitResponse, error)
+}
+
+type leavePermitService struct {
+	leavePermitRepo  repository.LeavePermitRepository
+	studentRepo      repository.StudentRepository
+	dormitoryRepo    repository.DormitoryRepository
+	employeeRepo     repository.EmployeeRepository
+	academicYearRepo repository.AcademicYearRepository
+}
+
+func NewLeavePermitService(
+	leavePermitRepo repository.LeavePermitRepository,
+	studentRepo repository.StudentRepository,
+	dormitoryRepo repository.DormitoryRepository,
+	employeeRepo repository.EmployeeRepository,
+	academicYearRepo repository.AcademicYearRepository,
+) LeavePermitService {
+	return &leavePermitService{
+		leavePermitRepo:  leavePermitRepo,
+		studentRepo:      studentRepo,
+		dormitoryRepo:    dormitoryRepo,
+		employeeRepo:     employeeRepo,
+		academicYearRepo: academicYearRepo,
+	}
+}
+
+// CreatePermit mencatat pengajuan izin. Petugas (leave_permits.create) bisa mengajukan untuk santri mana pun,
+// akun orang tua/wali hanya untuk anaknya sendiri dan pengajuannya otomatis terhitung terkonfirmasi wali.
+func (s *leavePermitService) CreatePermit(req request.LeavePermitCreateRequest, viewer *domain.User) (*response.LeavePermitResponse, error) {
+	departure := req.DepartureAt.In(time.Local)
+	expectedReturn := req.ExpectedReturnAt.In(time.Local)
+	if !expectedReturn.After(departure) {
+		return nil, apperrors.NewBadRequestError("expected_return_at must be after departure_at")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, apperrors.NewBadRequestError("reason is required")
+	}
+
+	student, err := s.studentRepo.FindByID(req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+	if student.Status != "ACTIVE" {
+		return nil, apperrors.NewBadRequestError("Leave permits can only be requested for active students")
+	}
+
+	requesterType := domain.LeaveRequesterStaff
+	if !viewer.HasPermission("leave_permits.create") {
+		linked, err := s.isFamilyOf(student.ID, viewer)
+		if err != nil {
+			return nil, err
+		}
+		if !linked {
+			return nil, apperrors.NewForbiddenError("You can only request leave permits for your own children")
+		}
+		requesterType = domain.LeaveRequesterParent
+	}
+
+	year, err := s.academicYearRepo.FindActive()
+	if err != nil {
+		return nil, err
+	}
+	if year == nil {
+		return nil, apperrors.NewBadRequestError("No active academic year")
+	}
+	assignment, err := s.dormitoryRepo.FindActiveAssignment(student.ID, year.ID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, apperrors.NewBadRequestError("Student is not a boarding student (no active dorm room this academic year)")
+	}
+
+	overlap, err := s.leavePermitRepo.HasOverlap(student.ID, departure, expectedReturn, "")
+	if err != nil {
+		return nil, err
+	}
+	if overlap {
+		return nil, apperrors.NewConflictError("Student already has an active leave permit in this period")
+	}
+
+	permit := &domain.LeavePermit{
+		StudentID:        student.ID,
+		AcademicYearID:   year.ID,
+		Reason:           reason,
+		Destination:      req.Destination,
+		DepartureAt:      departure,
+		ExpectedReturnAt: expectedReturn,
+		Status:           domain.LeavePermitPending,
+		RequesterType:    requesterType,
+		RequestedBy:      &viewer.ID,
+	}
+	if requesterType == domain.LeaveRequesterParent {
+		now := time.Now()
+		permit.GuardianConfirmedAt = &now
+		permit.GuardianConfirmedBy = &viewer.ID
+	}
+
+	if err := s.leavePermitRepo.Create(permit); err != nil {
+		return nil, err
+	}
+	permit.Student = student
+
+	return toLeavePermitResponse(permit, time.Now()), nil
+}
+
+// GetPermits menampilkan izin sesuai cakupan: semua (leave_permits.read), santri kamar binaan
+// (leave_permits.approve_own), atau anak sendiri untuk akun orang tua/wali.
+func (s *leavePermitService) GetPermits(filter request.LeavePermitFilterRequest, pagination request.PaginationRequest, viewer *domain.User) (*response.PaginatedData, error) {
+	repoFilter := repository.LeavePermitFilter{
+		StudentID: filter.StudentID,
+		Status:    filter.Status,
+	}
+	if filter.Date != "" {
+		date, err := time.ParseInLocation("2006-01-02", filter.Date, time.Local)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("invalid date format, use YYYY-MM-DD")
+		}
+		repoFilter.Date = &date
+	}
+
+	switch {
+	case viewer.HasPermission("leave_permits.read"):
+	case viewer.HasPermission("leave_permits.approve_own"):
+		employee, err := s.employeeRepo.FindByUserID(viewer.ID)
+		if err != nil {
+			return nil, err
+		}
+		if employee == nil {
+			return nil, apperrors.NewForbiddenError("Your account is not linked to an employee profile")
+		}
+		repoFilter.MusyrifID = employee.ID
+	default:
+		studentIDs, err := s.studentRepo.FindIDsByFamilyUserID(viewer.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(studentIDs) == 0 {
+			return nil, apperrors.NewForbiddenError("You don't have permission to view leave permits")
+		}
+		repoFilter.StudentIDs = studentIDs
+	}
+
+	return s.findPermits(repoFilter, pagination)
+}
+
+// GetOverduePermits menampilkan santri yang sudah keluar dan belum kembali melewati batas waktu
+func (s *leavePermitService) GetOverduePermits(pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	return s.findPermits(repository.LeavePermitFilter{Overdue: true}, pagination)
+}
+
+func (s *leavePermitService) findPermits(filter repository.LeavePermitFilter, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	now := time.Now()
+	filter.Now = now
+
+	limit := pagination.GetLimit()
+	permits, total, err := s.leavePermitRepo.FindAll(filter, limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.LeavePermitResponse, 0, len(permits))
+	for i := range permits {
+		items = append(items, *toLeavePermitResponse(&permits[i], now))
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+func (s *leavePermitService) GetPermitByID(id string, viewer *domain.User) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := viewer.HasPermission("leave_permits.read")
+	if !allowed && viewer.HasPermission("leave_permits.approve_own") {
+		if allowed, err = s.isMusyrifOf(permit, viewer); err != nil {
+			return nil, err
+		}
+	}
+	if !allowed {
+		if allowed, err = s.isFamilyOf(permit.StudentID, viewer); err != nil {
+			return nil, err
+		}
+	}
+	if !allowed {
+		return nil, apperrors.NewForbiddenError("You don't have permission to view this leave permit")
+	}
+
+	return toLeavePermitResponse(permit, time.Now()), nil
+}
+
+// ConfirmGuardian mencatat persetujuan wali. Bisa dilakukan akun orang tua/wali santri,
+// atau petugas atas nama wali (mis. konfirmasi lewat telepon).
+func (s *leavePermitService) ConfirmGuardian(id string, req request.LeaveGuardianConfirmRequest, viewer *domain.User) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+	if permit.Status != domain.LeavePermitPending {
+		return nil, apperrors.NewConflictError("Only pending leave permits can be confirmed")
+	}
+	if permit.GuardianConfirmedAt != nil {
+		return nil, apperrors.NewConflictError("Leave permit is already confirmed by guardian")
+	}
+
+	note := req.Note
+	if viewer.HasPermission("leave_permits.create") {
+		if note == nil || strings.TrimSpace(*note) == "" {
+			return nil, apperrors.NewBadRequestError("note is required when staff confirms on behalf of the guardian")
+		}
+	} else {
+		linked, err := s.isFamilyOf(permit.StudentID, viewer)
+		if err != nil {
+			return nil, err
+		}
+		if !linked {
+			return nil, apperrors.NewForbiddenError("Only the student's parent or guardian can confirm this leave permit")
+		}
+	}
+
+	now := time.Now()
+	permit.GuardianConfirmedAt = &now
+	permit.GuardianConfirmedBy = &viewer.ID
+	permit.GuardianConfirmationNote = note
+	if err := s.leavePermitRepo.Update(permit); err != nil {
+		return nil, err
+	}
+
+	return toLeavePermitResponse(permit, now), nil
+}
+
+// ApprovePermit menyetujui izin; wajib sudah dikonfirmasi wali
+func (s *leavePermitService) ApprovePermit(id string, req request.LeavePermitApproveRequest, viewer *domain.User) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeReview(permit, viewer); err != nil {
+		return nil, err
+	}
+	if permit.Status != domain.LeavePermitPending {
+		return nil, apperrors.NewConflictError("Only pending leave permits can be approved")
+	}
+	if permit.GuardianConfirmedAt == nil {
+		return nil, apperrors.NewBadRequestError("Leave permit has not been confirmed by the guardian yet")
+	}
+
+	now := time.Now()
+	permit.Status = domain.LeavePermitApproved
+	permit.ReviewedBy = &viewer.ID
+	permit.ReviewedAt = &now
+	permit.ReviewNotes = req.Notes
+	if err := s.leavePermitRepo.Update(permit); err != nil {
+		return nil, err
+	}
+
+	return toLeavePermitResponse(permit, now), nil
+}
+
+// RejectPermit menolak izin yang belum keluar gerbang
+func (s *leavePermitService) RejectPermit(id string, req request.LeavePermitRejectRequest, viewer *domain.User) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeReview(permit, viewer); err != nil {
+		return nil, err
+	}
+	if permit.Status != domain.LeavePermitPending && permit.Status != domain.LeavePermitApproved {
+		return nil, apperrors.NewConflictError("Only pending or approved leave permits can be rejected")
+	}
+
+	now := time.Now()
+	notes := strings.TrimSpace(req.Notes)
+	permit.Status = domain.LeavePermitRejected
+	permit.ReviewedBy = &viewer.ID
+	permit.ReviewedAt = &now
+	permit.ReviewNotes = &notes
+	if err := s.leavePermitRepo.Update(permit); err != nil {
+		return nil, err
+	}
+
+	return toLeavePermitResponse(permit, now), nil
+}
+
+// CancelPermit membatalkan izin oleh pengaju, petugas, atau orang tua/wali santri
+func (s *leavePermitService) CancelPermit(id string, viewer *domain.User) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+	if permit.Status != domain.LeavePermitPending && permit.Status != domain.LeavePermitApproved {
+		return nil, apperrors.NewConflictError("Only pending or approved leave permits can be cancelled")
+	}
+
+	allowed := viewer.HasPermission("leave_permits.create") ||
+		(permit.RequestedBy != nil && *permit.RequestedBy == viewer.ID)
+	if !allowed {
+		if allowed, err = s.isFamilyOf(permit.StudentID, viewer); err != nil {
+			return nil, err
+		}
+	}
+	if !allowed {
+		return nil, apperrors.NewForbiddenError("You don't have permission to cancel this leave permit")
+	}
+
+	permit.Status = domain.LeavePermitCancelled
+	if err := s.leavePermitRepo.Update(permit); err != nil {
+		return nil, err
+	}
+
+	return toLeavePermitResponse(permit, time.Now()), nil
+}
+
+// CheckOut mencatat santri keluar gerbang
+func (s *leavePermitService) CheckOut(id string, req request.LeavePermitCheckOutRequest, userID string) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+	if permit.Status != domain.LeavePermitApproved {
+		return nil, apperrors.NewConflictError("Only approved leave permits can be checked out")
+	}
+
+	now := time.Now()
+	if !now.Before(permit.ExpectedReturnAt) {
+		return nil, apperrors.NewBadRequestError("Leave permit has already expired")
+	}
+
+	permit.Status = domain.LeavePermitCheckedOut
+	permit.CheckedOutAt = &now
+	permit.CheckedOutBy = &userID
+	permit.PickedUpBy = req.PickedUpBy
+	if err := s.leavePermitRepo.Update(permit); err != nil {
+		return nil, err
+	}
+
+	return toLeavePermitResponse(permit, now), nil
+}
+
+// CheckIn mencatat santri kembali ke asrama; keterlambatan terlihat di late_minutes
+func (s *leavePermitService) CheckIn(id string, userID string) (*response.LeavePermitResponse, error) {
+	permit, err := s.findPermit(id)
+	if err != nil {
+		return nil, err
+	}
+	if permit.Status != domain.LeavePermitCheckedOut {
+		return nil, apperrors.NewConflictError("Only checked-out leave permits can be checked in")
+	}
+
+	now := time.Now()
+	permit.Status = domain.LeavePermitReturned
+	permit.CheckedInAt = &now
+	permit.CheckedInBy = &userID
+	if err := s.leavePermitRepo.Update(permit); err != nil {
+		return nil, err
+	}
+
+	return toLeavePermitResponse(permit, now), nil
+}
+
+// --- Helpers ---
+
+func (s *leavePermitService) findPermit(id string) (*domain.LeavePermit, error) {
+	permit, err := s.leavePermitRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if permit == nil {
+		return nil, apperrors.NewNotFoundError("Leave permit not found")
+	}
+	return permit, nil
+}
+
+// authorizeReview: leave_permits.approve untuk semua santri, leave_permits.approve_own hanya musyrif kamar santri
+func (s *leavePermitService) authorizeReview(permit *domain.LeavePermit, viewer *domain.User) error {
+	if viewer.HasPermission("leave_permits.approve") {
+		return nil
+	}
+	if viewer.HasPermission("leave_permits.approve_own") {
+		isMusyrif, err := s.isMusyrifOf(permit, viewer)
+		if err != nil {
+			return err
+		}
+		if isMusyrif {
+			return nil
+		}
+	}
+	return apperrors.NewForbiddenError("Only the student's musyrif can review this leave permit")
+}
+
+func (s *leavePermitService) isMusyrifOf(permit *domain.LeavePermit, viewer *domain.User) (bool, error) {
+	employee, err := s.employeeRepo.FindByUserID(viewer.ID)
+	if err != nil || employee == nil {
+		return false, err
+	}
+	assignment, err := s.dormitoryRepo.FindActiveAssignment(permit.StudentID, permit.AcademicYearID)
+	if err != nil || assignment == nil || assignment.DormRoom == nil {
+		return false, err
+	}
+	return assignment.DormRoom.MusyrifID != nil && *assignment.DormRoom.MusyrifID == employee.ID, nil
+}
+
+func (s *leavePermitService) isFamilyOf(studentID string, viewer *domain.User) (bool, error) {
+	studentIDs, err := s.studentRepo.FindIDsByFamilyUserID(viewer.ID)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range studentIDs {
+		if id == studentID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func toLeavePermitResponse(permit *domain.LeavePermit, now time.Time) *response.LeavePermitResponse {
+	res := &response.LeavePermitResponse{
+		ID:                       permit.ID,
+		StudentID:                permit.StudentID,
+		AcademicYearID:           permit.AcademicYearID,
+		Reason:                   permit.Reason,
+		Destination:              permit.Destination,
+		DepartureAt:              permit.DepartureAt,
+		ExpectedReturnAt:         permit.ExpectedReturnAt,
+		Status:                   permit.Status,
+		RequesterType:            permit.RequesterType,
+		RequestedBy:              permit.RequestedBy,
+		GuardianConfirmed:        permit.GuardianConfirmedAt != nil,
+		GuardianConfirmedAt:      permit.GuardianConfirmedAt,
+		GuardianConfirmationNote: permit.GuardianConfirmationNote,
+		ReviewedBy:               permit.ReviewedBy,
+		ReviewedAt:               permit.ReviewedAt,
+		ReviewNotes:              permit.ReviewNotes,
+		CheckedOutAt:             permit.CheckedOutAt,
+		PickedUpBy:               permit.PickedUpBy,
+		CheckedInAt:              permit.CheckedInAt,
+		IsOverdue:                permit.IsOverdue(now),
+		LateMinutes:              permit.LateMinutes(now),
+		CreatedAt:                permit.CreatedAt,
+	}
+	if permit.Student != nil {
+		res.StudentName = permit.Student.FullName
+		res.NISN = permit.Student.NISN
+	}
+	return res
+}
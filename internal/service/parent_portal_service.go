@@ -0,0 +1,287 @@
+package service
+
+import (
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"time"
+)
+
+// ParentPortalService melayani akun orang tua/wali. Semua data dibatasi ke anak yang tertaut
+// lewat student_parent atau Student.GuardianID, tanpa memerlukan permission staf.
+type ParentPortalService interface {
+	GetChildren(userID string) ([]response.PortalChildResponse, error)
+	GetSchedule(userID, studentID string) ([]response.ScheduleResponse, error)
+	GetAttendance(userID, studentID string, filter request.PortalAttendanceFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetScores(userID, studentID string, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetViolations(userID, studentID string, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	GetAnnouncements(userID, studentID string, pagination request.PaginationRequest) (*response.PaginatedData, error)
+}
+
+type parentPortalService struct {
+	studentRepo      repository.StudentRepository
+	scheduleRepo     repository.ScheduleRepository
+	attendanceRepo   repository.AttendanceRepository
+	gradeRepo        repository.GradeRepository
+	violationRepo    repository.ViolationRepository
+	announcementRepo repository.AnnouncementRepository
+}
+
+func NewParentPortalService(
+	studentRepo repository.StudentRepository,
+	scheduleRepo repository.ScheduleRepository,
+	attendanceRepo repository.AttendanceRepository,
+	gradeRepo repository.GradeRepository,
+	violationRepo repository.ViolationRepository,
+	announcementRepo repository.AnnouncementRepository,
+) ParentPortalService {
+	return &parentPortalService{
+		studentRepo:      studentRepo,
+		scheduleRepo:     scheduleRepo,
+		attendanceRepo:   attendanceRepo,
+		gradeRepo:        gradeRepo,
+		violationRepo:    violationRepo,
+		announcementRepo: announcementRepo,
+	}
+}
+
+func (s *parentPortalService) GetChildren(userID string) ([]response.PortalChildResponse, error) {
+	studentIDs, err := s.studentRepo.FindIDsByFamilyUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make([]response.PortalChildResponse, 0, len(studentIDs))
+	for _, id := range studentIDs {
+		student, err := s.studentRepo.FindByIDWithActiveClassroom(id)
+		if err != nil {
+			return nil, err
+		}
+		if student == nil {
+			continue
+		}
+
+		child := response.PortalChildResponse{
+			ID:       student.ID,
+			FullName: student.FullName,
+			NISN:     student.NISN,
+			NIM:      student.NIM,
+			Gender:   student.Gender,
+			Status:   student.Status,
+		}
+		if len(student.StudentClassrooms) > 0 {
+			classroom := student.StudentClassrooms[0].Classroom
+			child.ClassroomID = &classroom.ID
+			child.ClassroomName = &classroom.Name
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+func (s *parentPortalService) GetSchedule(userID, studentID string) ([]response.ScheduleResponse, error) {
+	student, err := s.findChild(userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []response.ScheduleResponse{}
+	for _, placement := range student.StudentClassrooms {
+		schedules, err := s.scheduleRepo.FindByClassroomID(placement.ClassroomID)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range schedules {
+			result = append(result, response.ScheduleResponse{
+				ID:            d.ID,
+				DayOfWeek:     d.DayOfWeek,
+				DayName:       getDayName(d.DayOfWeek),
+				StartTime:     d.StartTime,
+				EndTime:       d.EndTime,
+				SubjectName:   d.TeachingAssignment.Subject.Name,
+				TeacherName:   d.TeachingAssignment.Teacher.FullName,
+				ClassroomName: d.TeachingAssignment.Classroom.Name,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (s *parentPortalService) GetAttendance(userID, studentID string, filter request.PortalAttendanceFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	student, err := s.findChild(userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	dateFrom, err := parseOptionalDate(filter.DateFrom, "date_from")
+	if err != nil {
+		return nil, err
+	}
+	dateTo, err := parseOptionalDate(filter.DateTo, "date_to")
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pagination.GetLimit()
+	sessions, total, err := s.attendanceRepo.FindStudentSessions(student.ID, dateFrom, dateTo, limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.PortalAttendanceResponse, 0, len(sessions))
+	for _, session := range sessions {
+		item := response.PortalAttendanceResponse{
+			SessionID:   session.ID,
+			Date:        session.Date,
+			StartTime:   session.Schedule.StartTime,
+			EndTime:     session.Schedule.EndTime,
+			SubjectName: session.Schedule.TeachingAssignment.Subject.Name,
+			Topic:       session.Topic,
+		}
+		if len(session.Details) > 0 {
+			item.Status = session.Details[0].Status
+			item.Notes = session.Details[0].Notes
+		}
+		items = append(items, item)
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+// GetScores hanya menampilkan nilai dari assessment yang sudah dipublikasikan guru
+func (s *parentPortalService) GetScores(userID, studentID string, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	student, err := s.findChild(userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pagination.GetLimit()
+	scores, total, err := s.gradeRepo.FindPublishedScoresByStudent(student.ID, limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.PortalScoreResponse, 0, len(scores))
+	for _, score := range scores {
+		items = append(items, response.PortalScoreResponse{
+			AssessmentID: score.AssessmentID,
+			Title:        score.Assessment.Title,
+			Type:         score.Assessment.Type,
+			SubjectName:  score.Assessment.TeachingAssignment.Subject.Name,
+			Date:         score.Assessment.Date,
+			MaxScore:     score.Assessment.MaxScore,
+			Score:        score.Score,
+			Feedback:     score.Feedback,
+			PublishedAt:  score.Assessment.PublishedAt,
+		})
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+func (s *parentPortalService) GetViolations(userID, studentID string, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	student, err := s.findChild(userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pagination.GetLimit()
+	violations, total, err := s.violationRepo.FindStudentViolations(student.ID, limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.StudentViolationListResponse, 0, len(violations))
+	for _, v := range violations {
+		item := response.StudentViolationListResponse{
+			ID:            v.ID,
+			StudentID:     v.StudentID,
+			StudentName:   student.FullName,
+			ViolationDate: v.ViolationDate,
+			Points:        v.Points,
+			CreatedAt:     v.CreatedAt,
+			UpdatedAt:     v.UpdatedAt,
+		}
+		if v.ViolationType != nil {
+			item.ViolationName = v.ViolationType.Name
+			if v.ViolationType.Category != nil {
+				item.ViolationCategory = v.ViolationType.Category.Name
+			}
+		}
+		items = append(items, item)
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+// GetAnnouncements menampilkan pengumuman sekolah dan pengumuman kelas anak yang sedang tayang
+func (s *parentPortalService) GetAnnouncements(userID, studentID string, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	student, err := s.findChild(userID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	classroomIDs := make([]string, 0, len(student.StudentClassrooms))
+	for _, placement := range student.StudentClassrooms {
+		classroomIDs = append(classroomIDs, placement.ClassroomID)
+	}
+
+	limit := pagination.GetLimit()
+	announcements, total, err := s.announcementRepo.FindVisible(classroomIDs, time.Now(), limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.AnnouncementResponse, 0, len(announcements))
+	for i := range announcements {
+		items = append(items, toAnnouncementResponse(&announcements[i]))
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
+
+// findChild memastikan siswa tertaut ke akun pemanggil. Siswa lain diperlakukan sama dengan
+// yang tidak ada agar ID siswa tidak bisa ditebak-tebak.
+func (s *parentPortalService) findChild(userID, studentID string) (*domain.Student, error) {
+	studentIDs, err := s.studentRepo.FindIDsByFamilyUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked := false
+	for _, id := range studentIDs {
+		if id == studentID {
+			linked = true
+			break
+		}
+	}
+	if !linked {
+		return nil, apperrors.NewForbiddenError("You don't have access to this student")
+	}
+
+	student, err := s.studentRepo.FindByIDWithActiveClassroom(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewForbiddenError("You don't have access to this student")
+	}
+	return student, nil
+}
+
+func parseOptionalDate(value, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid " + field + " format, use YYYY-MM-DD")
+	}
+	return &date, nil
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/repository"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newFamilyTestDB membuat skema minimal untuk menelusuri tautan orang tua/wali ke siswa
+func newFamilyTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql db: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	for _, stmt := range []string{
+		`CREATE TABLE students (id TEXT PRIMARY KEY, full_name TEXT, guardian_id TEXT, guardian_type TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE parents (id TEXT PRIMARY KEY, full_name TEXT, user_id TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE guardians (id TEXT PRIMARY KEY, full_name TEXT, user_id TEXT, deleted_at DATETIME)`,
+		`CREATE TABLE student_parent (student_id TEXT, parent_id TEXT, relationship_type TEXT)`,
+		`CREATE TABLE student_classrooms (id TEXT PRIMARY KEY, student_id TEXT, classroom_id TEXT, status TEXT)`,
+		`INSERT INTO students (id, full_name, guardian_id, guardian_type) VALUES
+			('s1', 'Anak Satu', 'p1', 'parent'),
+			('s2', 'Anak Dua', 'g1', 'guardian')`,
+		`INSERT INTO parents (id, full_name, user_id) VALUES ('p1', 'Ibu', 'u-parent')`,
+		`INSERT INTO guardians (id, full_name, user_id) VALUES ('g1', 'Paman', 'u-guardian')`,
+		`INSERT INTO student_parent (student_id, parent_id, relationship_type) VALUES ('s1', 'p1', 'MOTHER')`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("create schema: %v", err)
+		}
+	}
+	return db
+}
+
+func assertForbidden(t *testing.T, err error) {
+	t.Helper()
+	var appErr *apperrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %v", err)
+	}
+}
+
+func TestFindChildDeniesTrashedParent(t *testing.T) {
+	db := newFamilyTestDB(t)
+	svc := &parentPortalService{studentRepo: repository.NewStudentRepository(db)}
+
+	if _, err := svc.findChild("u-parent", "s1"); err != nil {
+		t.Fatalf("active parent should reach the child: %v", err)
+	}
+
+	if err := db.Exec(`UPDATE parents SET deleted_at = CURRENT_TIMESTAMP WHERE id = 'p1'`).Error; err != nil {
+		t.Fatalf("trash parent: %v", err)
+	}
+	_, err := svc.findChild("u-parent", "s1")
+	assertForbidden(t, err)
+}
+
+func TestFindChildDeniesTrashedGuardian(t *testing.T) {
+	db := newFamilyTestDB(t)
+	svc := &parentPortalService{studentRepo: repository.NewStudentRepository(db)}
+
+	if _, err := svc.findChild("u-guardian", "s2"); err != nil {
+		t.Fatalf("active guardian should reach the child: %v", err)
+	}
+
+	if err := db.Exec(`UPDATE guardians SET deleted_at = CURRENT_TIMESTAMP WHERE id = 'g1'`).Error; err != nil {
+		t.Fatalf("trash guardian: %v", err)
+	}
+	_, err := svc.findChild("u-guardian", "s2")
+	assertForbidden(t, err)
+}
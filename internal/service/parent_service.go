@@ -9,6 +9,7 @@ import (
 	"smart_school_be/internal/model/response"
 	"smart_school_be/internal/repository"
 	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
 	"strings"
 )
 
@@ -17,34 +18,53 @@ type ParentService interface {
 	GetParentByID(id string) (*response.ParentDetailResponse, error)
 	GetAllParents(search string, pagination request.PaginationRequest) (*response.PaginatedData, error)
 	UpdateParent(id string, req request.ParentUpdateRequest) (*response.ParentDetailResponse, error)
-	DeleteParent(id string) error
+	DeleteParent(id string, req request.GuardianDeleteRequest) error
+	GetWards(parentID string) ([]response.GuardianWardResponse, error)
 	LinkUser(parentID string, userID string) error
 	UnlinkUser(parentID string) error
 }
 
 type parentService struct {
 	parentRepo     repository.ParentRepository
+	guardianRepo   repository.GuardianRepository
+	studentRepo    repository.StudentRepository
 	userRepo       repository.UserRepository
 	encryptionUtil utils.EncryptionUtil
 	converter      converter.ParentConverterInterface
+	identity       *validation.IdentityValidator
 }
 
 func NewParentService(
 	parentRepo repository.ParentRepository,
+	guardianRepo repository.GuardianRepository,
+	studentRepo repository.StudentRepository,
 	userRepo repository.UserRepository,
 	encryptionUtil utils.EncryptionUtil,
 	converter converter.ParentConverterInterface,
+	identity *validation.IdentityValidator,
 ) ParentService {
 	return &parentService{
 		parentRepo:     parentRepo,
+		guardianRepo:   guardianRepo,
+		studentRepo:    studentRepo,
 		userRepo:       userRepo,
 		encryptionUtil: encryptionUtil,
 		converter:      converter,
+		identity:       identity,
 	}
 }
 
 // CreateParent menangani pembuatan data orang tua baru
 func (s *parentService) CreateParent(req request.ParentCreateRequest) (*response.ParentDetailResponse, error) {
+	// Validasi struktur NIK dan kecocokannya dengan tanggal lahir & jenis kelamin
+	if _, err := s.identity.Validate("parent", validation.Person{
+		NIK:         utils.SafeString(req.NIK),
+		Gender:      utils.SafeString(req.Gender),
+		DateOfBirth: dateToTime(req.DateOfBirth),
+	}); err != nil {
+		return nil, err
+	}
+
 	// 1. Validasi Duplikat (Phone & Email) - jika ada
 	if req.PhoneNumber != nil && *req.PhoneNumber != "" {
 		if existing, _ := s.parentRepo.FindByPhone(*req.PhoneNumber); existing != nil {
@@ -203,6 +223,15 @@ func (s *parentService) UpdateParent(id string, req request.ParentUpdateRequest)
 		return nil, apperrors.NewNotFoundError("Parent not found")
 	}
 
+	// Validasi NIK baru (jika dikirim) terhadap tanggal lahir & jenis kelamin
+	if _, err := s.identity.Validate("parent", validation.Person{
+		NIK:         utils.SafeString(req.NIK),
+		Gender:      utils.SafeString(req.Gender),
+		DateOfBirth: dateToTime(req.DateOfBirth),
+	}); err != nil {
+		return nil, err
+	}
+
 	// Update fields jika disediakan
 	if req.FullName != "" {
 		parent.FullName = req.FullName
@@ -319,8 +348,9 @@ func (s *parentService) UpdateParent(id string, req request.ParentUpdateRequest)
 	return resp, nil
 }
 
-// DeleteParent menghapus data orang tua
-func (s *parentService) DeleteParent(id string) error {
+// DeleteParent menghapus data orang tua. Jika masih menjadi wali siswa, req.OnWards menentukan
+// apakah penghapusan ditolak (block), pointer wali dikosongkan (nullify), atau dipindah (reassign).
+func (s *parentService) DeleteParent(id string, req request.GuardianDeleteRequest) error {
 	parent, err := s.parentRepo.FindByID(id)
 	if err != nil {
 		return err
@@ -329,7 +359,36 @@ func (s *parentService) DeleteParent(id string) error {
 		return apperrors.NewNotFoundError("Parent not found")
 	}
 
-	return s.parentRepo.Delete(id)
+	wards, err := s.studentRepo.CountByGuardian("parent", id)
+	if err != nil {
+		return err
+	}
+	if wards == 0 {
+		return s.parentRepo.Delete(id)
+	}
+
+	toType, toID, err := resolveWardsTarget(req, "parent", id, wards, s.parentRepo, s.guardianRepo)
+	if err != nil {
+		return err
+	}
+	return s.parentRepo.DeleteReassigningWards(id, toType, toID)
+}
+
+// GetWards menampilkan siswa yang walinya menunjuk ke orang tua ini
+func (s *parentService) GetWards(parentID string) ([]response.GuardianWardResponse, error) {
+	parent, err := s.parentRepo.FindByID(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, apperrors.NewNotFoundError("Parent not found")
+	}
+
+	students, err := s.studentRepo.FindByGuardian("parent", parent.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toGuardianWardResponses(students), nil
 }
 
 // LinkUser menautkan profil Parent ke akun User
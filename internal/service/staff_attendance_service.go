@@ -0,0 +1,1068 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/attlog"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type StaffAttendanceService interface {
+	// Shift kerja
+	CreateShift(req request.WorkShiftRequest) (*response.WorkShiftResponse, error)
+	GetShifts() ([]response.WorkShiftResponse, error)
+	UpdateShift(id string, req request.WorkShiftRequest) (*response.WorkShiftResponse, error)
+	DeleteShift(id string) error
+	UpdateEmployeeSettings(employeeID string, req request.EmployeeAttendanceSettingsRequest) (*response.EmployeeAttendanceSettingsResponse, error)
+
+	// Kehadiran
+	ClockIn(userID string) (*response.StaffAttendanceResponse, error)
+	ClockOut(userID string) (*response.StaffAttendanceResponse, error)
+	RecordManual(req request.StaffAttendanceManualRequest, recordedBy string) (*response.StaffAttendanceResponse, error)
+	GetDaily(dateStr string) (*response.StaffDailyResponse, error)
+	ImportDeviceLog(file io.Reader, req request.StaffAttendanceImportRequest, recordedBy string) (*response.StaffAttendanceImportResponse, error)
+
+	// Cuti/izin
+	CreateLeave(req request.StaffLeaveRequest, recordedBy string) (*response.StaffLeaveResponse, error)
+	GetLeaves(filter request.StaffLeaveFilterRequest) ([]response.StaffLeaveResponse, error)
+	DeleteLeave(id string) error
+
+	// Rekap bulanan
+	GetMonthlyRecap(month string) (*response.StaffRecapResponse, error)
+	ExportRecap(w io.Writer, month string) error
+}
+
+type staffAttendanceService struct {
+	staffAttendanceRepo repository.StaffAttendanceRepository
+	employeeRepo        repository.EmployeeRepository
+}
+
+func NewStaffAttendanceService(
+	staffAttendanceRepo repository.StaffAttendanceRepository,
+	employeeRepo repository.EmployeeRepository,
+) StaffAttendanceService {
+	return &staffAttendanceService{
+		staffAttendanceRepo: staffAttendanceRepo,
+		employeeRepo:        employeeRepo,
+	}
+}
+
+var staffLeaveLabels = map[string]string{
+	domain.StaffLeaveSick:       "Sakit",
+	domain.StaffLeaveAnnual:     "Cuti Tahunan",
+	domain.StaffLeavePermission: "Izin",
+	domain.StaffLeaveDuty:       "Dinas Luar",
+	domain.StaffLeaveMaternity:  "Cuti Melahirkan",
+}
+
+var staffStatusLabels = map[string]string{
+	response.StaffStatusPresent:    "Hadir",
+	response.StaffStatusLate:       "Terlambat",
+	response.StaffStatusLeave:      "Cuti/Izin",
+	response.StaffStatusAbsent:     "Tanpa Keterangan",
+	response.StaffStatusNotYet:     "Belum Absen",
+	response.StaffStatusOff:        "Libur",
+	response.StaffStatusNoSchedule: "Tanpa Shift",
+}
+
+// ===== Shift kerja =====
+
+func (s *staffAttendanceService) CreateShift(req request.WorkShiftRequest) (*response.WorkShiftResponse, error) {
+	shift := &domain.WorkShift{}
+	if err := s.applyShiftRequest(shift, req); err != nil {
+		return nil, err
+	}
+	if err := s.staffAttendanceRepo.CreateShift(shift); err != nil {
+		return nil, err
+	}
+	return toWorkShiftResponse(shift), nil
+}
+
+func (s *staffAttendanceService) GetShifts() ([]response.WorkShiftResponse, error) {
+	shifts, err := s.staffAttendanceRepo.FindShifts()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]response.WorkShiftResponse, 0, len(shifts))
+	for i := range shifts {
+		res = append(res, *toWorkShiftResponse(&shifts[i]))
+	}
+	return res, nil
+}
+
+func (s *staffAttendanceService) UpdateShift(id string, req request.WorkShiftRequest) (*response.WorkShiftResponse, error) {
+	shift, err := s.staffAttendanceRepo.FindShiftByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, apperrors.NewNotFoundError("Work shift not found")
+	}
+	if err := s.applyShiftRequest(shift, req); err != nil {
+		return nil, err
+	}
+	if err := s.staffAttendanceRepo.UpdateShift(shift); err != nil {
+		return nil, err
+	}
+	return toWorkShiftResponse(shift), nil
+}
+
+// DeleteShift menolak menghapus shift yang masih dipakai pegawai. Rekaman kehadiran lama tetap
+// menyimpan salinan jam shift sehingga rekap tidak berubah.
+func (s *staffAttendanceService) DeleteShift(id string) error {
+	shift, err := s.staffAttendanceRepo.FindShiftByID(id)
+	if err != nil {
+		return err
+	}
+	if shift == nil {
+		return apperrors.NewNotFoundError("Work shift not found")
+	}
+	count, err := s.staffAttendanceRepo.CountEmployeesByShift(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.NewConflictError(fmt.Sprintf("Work shift is still assigned to %d employee(s)", count))
+	}
+	return s.staffAttendanceRepo.DeleteShift(id)
+}
+
+func (s *staffAttendanceService) applyShiftRequest(shift *domain.WorkShift, req request.WorkShiftRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return apperrors.NewBadRequestError("name is required")
+	}
+	existing, err := s.staffAttendanceRepo.FindShiftByName(name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != shift.ID {
+		return apperrors.NewConflictError("Work shift name already exists")
+	}
+
+	start, err := parseClockMinutes(req.StartTime)
+	if err != nil {
+		return apperrors.NewBadRequestError("start_time: " + err.Error())
+	}
+	end, err := parseClockMinutes(req.EndTime)
+	if err != nil {
+		return apperrors.NewBadRequestError("end_time: " + err.Error())
+	}
+	if end <= start {
+		return apperrors.NewBadRequestError("end_time must be after start_time")
+	}
+	workDays, err := normalizeWorkDays(req.WorkDays)
+	if err != nil {
+		return apperrors.NewBadRequestError(err.Error())
+	}
+
+	shift.Name = name
+	shift.StartTime = formatClockMinutes(start)
+	shift.EndTime = formatClockMinutes(end)
+	shift.LateToleranceMinutes = req.LateToleranceMinutes
+	shift.EarlyLeaveToleranceMinutes = req.EarlyLeaveToleranceMinutes
+	shift.WorkDays = workDays
+	shift.IsDefault = req.IsDefault
+	return nil
+}
+
+// UpdateEmployeeSettings memetakan pegawai ke ID user mesin fingerprint dan shift kerjanya
+func (s *staffAttendanceService) UpdateEmployeeSettings(employeeID string, req request.EmployeeAttendanceSettingsRequest) (*response.EmployeeAttendanceSettingsResponse, error) {
+	employee, err := s.employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, apperrors.NewNotFoundError("Employee not found")
+	}
+
+	var deviceUserID *string
+	if req.DeviceUserID != nil {
+		if id := attlog.NormalizeID(*req.DeviceUserID); id != "" {
+			if len(id) > 20 {
+				return nil, apperrors.NewBadRequestError("device_user_id must be at most 20 characters")
+			}
+			other, err := s.employeeRepo.FindByDeviceUserID(id)
+			if err != nil {
+				return nil, err
+			}
+			if other != nil && other.ID != employee.ID {
+				return nil, apperrors.NewConflictError(fmt.Sprintf("Device user ID %s is already mapped to %s", id, other.FullName))
+			}
+			deviceUserID = &id
+		}
+	}
+
+	var workShiftID *string
+	if req.WorkShiftID != nil && *req.WorkShiftID != "" {
+		shift, err := s.staffAttendanceRepo.FindShiftByID(*req.WorkShiftID)
+		if err != nil {
+			return nil, err
+		}
+		if shift == nil {
+			return nil, apperrors.NewNotFoundError("Work shift not found")
+		}
+		workShiftID = &shift.ID
+	}
+
+	if err := s.employeeRepo.SetAttendanceSettings(employee.ID, deviceUserID, workShiftID); err != nil {
+		return nil, err
+	}
+	return &response.EmployeeAttendanceSettingsResponse{
+		EmployeeID:   employee.ID,
+		FullName:     employee.FullName,
+		DeviceUserID: deviceUserID,
+		WorkShiftID:  workShiftID,
+	}, nil
+}
+
+// ===== Kehadiran =====
+
+// ClockIn mencatat jam masuk pegawai yang sedang login
+func (s *staffAttendanceService) ClockIn(userID string) (*response.StaffAttendanceResponse, error) {
+	employee, err := s.findEmployeeByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	attendance, err := s.staffAttendanceRepo.FindByEmployeeDate(employee.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if attendance != nil && attendance.ClockInAt != nil {
+		return nil, apperrors.NewConflictError("You have already clocked in today")
+	}
+	if attendance == nil {
+		attendance = &domain.StaffAttendance{
+			EmployeeID: employee.ID,
+			Date:       utils.Date(startOfDay(now)),
+			Source:     domain.StaffAttendanceSourceSelf,
+		}
+	}
+	attendance.ClockInAt = &now
+
+	if err := s.evaluate(attendance, employee, now); err != nil {
+		return nil, err
+	}
+	if err := s.staffAttendanceRepo.Save(attendance); err != nil {
+		return nil, err
+	}
+	return toStaffAttendanceResponse(attendance), nil
+}
+
+// ClockOut mencatat jam pulang; boleh diulang, jam pulang terakhir yang dipakai
+func (s *staffAttendanceService) ClockOut(userID string) (*response.StaffAttendanceResponse, error) {
+	employee, err := s.findEmployeeByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	attendance, err := s.staffAttendanceRepo.FindByEmployeeDate(employee.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil || attendance.ClockInAt == nil {
+		return nil, apperrors.NewBadRequestError("You have not clocked in today")
+	}
+	attendance.ClockOutAt = &now
+
+	if err := s.evaluate(attendance, employee, now); err != nil {
+		return nil, err
+	}
+	if err := s.staffAttendanceRepo.Save(attendance); err != nil {
+		return nil, err
+	}
+	return toStaffAttendanceResponse(attendance), nil
+}
+
+// RecordManual membuat atau mengoreksi kehadiran satu pegawai pada satu tanggal
+func (s *staffAttendanceService) RecordManual(req request.StaffAttendanceManualRequest, recordedBy string) (*response.StaffAttendanceResponse, error) {
+	date, err := time.ParseInLocation("2006-01-02", req.Date, time.Local)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid date format, use YYYY-MM-DD")
+	}
+	if date.After(time.Now()) {
+		return nil, apperrors.NewBadRequestError("date cannot be in the future")
+	}
+	if req.ClockIn == nil && req.ClockOut == nil {
+		return nil, apperrors.NewBadRequestError("clock_in or clock_out is required")
+	}
+	employee, err := s.employeeRepo.FindByID(req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, apperrors.NewNotFoundError("Employee not found")
+	}
+
+	attendance, err := s.staffAttendanceRepo.FindByEmployeeDate(employee.ID, date)
+	if err != nil {
+		return nil, err
+	}
+	if attendance == nil {
+		attendance = &domain.StaffAttendance{EmployeeID: employee.ID, Date: utils.Date(date)}
+	}
+	if req.ClockIn != nil {
+		if attendance.ClockInAt, err = clockOnDate(date, *req.ClockIn); err != nil {
+			return nil, apperrors.NewBadRequestError("clock_in: " + err.Error())
+		}
+	}
+	if req.ClockOut != nil {
+		if attendance.ClockOutAt, err = clockOnDate(date, *req.ClockOut); err != nil {
+			return nil, apperrors.NewBadRequestError("clock_out: " + err.Error())
+		}
+	}
+	if attendance.ClockInAt != nil && attendance.ClockOutAt != nil && !attendance.ClockOutAt.After(*attendance.ClockInAt) {
+		return nil, apperrors.NewBadRequestError("clock_out must be after clock_in")
+	}
+	attendance.Source = domain.StaffAttendanceSourceManual
+	attendance.Notes = req.Notes
+	attendance.RecordedBy = &recordedBy
+
+	if err := s.evaluate(attendance, employee, date); err != nil {
+		return nil, err
+	}
+	if err := s.staffAttendanceRepo.Save(attendance); err != nil {
+		return nil, err
+	}
+	return toStaffAttendanceResponse(attendance), nil
+}
+
+// GetDaily menampilkan status semua pegawai pada satu tanggal (default hari ini)
+func (s *staffAttendanceService) GetDaily(dateStr string) (*response.StaffDailyResponse, error) {
+	date := startOfDay(time.Now())
+	if dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, time.Local)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("Invalid date format, use YYYY-MM-DD")
+		}
+		date = parsed
+	}
+
+	data, err := s.loadStaffAttendance(date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.StaffDailyResponse{
+		Date:      date.Format("2006-01-02"),
+		Summary:   map[string]int{},
+		Employees: make([]response.StaffDailyItem, 0, len(data.employees)),
+	}
+	for i := range data.employees {
+		employee := &data.employees[i]
+		day := data.day(employee, date)
+		item := response.StaffDailyItem{
+			EmployeeID: employee.ID,
+			FullName:   employee.FullName,
+			NIP:        employee.NIP,
+			Status:     day.Status,
+			LeaveType:  day.LeaveType,
+		}
+		if day.Attendance != nil {
+			item.Attendance = toStaffAttendanceResponse(day.Attendance)
+		}
+		res.Summary[day.Status]++
+		res.Employees = append(res.Employees, item)
+	}
+	return res, nil
+}
+
+// ImportDeviceLog membaca log mesin fingerprint (attlog.dat atau CSV), memetakan ID user mesin ke
+// pegawai, lalu menggabungkan rekaman per hari: rekaman paling awal jadi jam masuk, paling akhir
+// jadi jam pulang. Rekaman yang sudah ada ikut digabung sehingga import ulang file yang sama aman.
+// Rekaman hasil koreksi manual tidak ditimpa. Mode dry_run (default) hanya menampilkan hasil.
+func (s *staffAttendanceService) ImportDeviceLog(file io.Reader, req request.StaffAttendanceImportRequest, recordedBy string) (*response.StaffAttendanceImportResponse, error) {
+	punches, lineErrors, err := attlog.Parse(file, time.Local)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid attendance log: " + err.Error())
+	}
+
+	res := &response.StaffAttendanceImportResponse{
+		DryRun:       req.Mode != "commit",
+		TotalPunches: len(punches),
+		InvalidLines: lineErrors,
+		Unmapped:     []response.StaffImportUnmapped{},
+		Days:         []response.StaffImportDayResult{},
+	}
+	if res.InvalidLines == nil {
+		res.InvalidLines = []attlog.LineError{}
+	}
+	if len(punches) == 0 {
+		return nil, apperrors.NewBadRequestError("Attendance log has no valid records")
+	}
+
+	mapped, err := s.employeeRepo.FindWithDeviceUserID()
+	if err != nil {
+		return nil, err
+	}
+	employeeByDevice := map[string]*domain.Employee{}
+	for i := range mapped {
+		employeeByDevice[attlog.NormalizeID(*mapped[i].DeviceUserID)] = &mapped[i]
+	}
+
+	days := attlog.Daily(punches)
+	from, to := days[0].First, days[0].First
+	for _, day := range days {
+		if day.First.Before(from) {
+			from = day.First
+		}
+		if day.Last.After(to) {
+			to = day.Last
+		}
+	}
+	existing, err := s.staffAttendanceRepo.FindByRange(from, to, "")
+	if err != nil {
+		return nil, err
+	}
+	existingByKey := map[string]*domain.StaffAttendance{}
+	for i := range existing {
+		existingByKey[staffDayKey(existing[i].EmployeeID, time.Time(existing[i].Date))] = &existing[i]
+	}
+	shifts, err := s.loadShifts()
+	if err != nil {
+		return nil, err
+	}
+
+	unmapped := map[string]*response.StaffImportUnmapped{}
+	var unmappedOrder []string
+	var toSave []domain.StaffAttendance
+	for _, day := range days {
+		employee := employeeByDevice[day.DeviceUserID]
+		if employee == nil {
+			u, ok := unmapped[day.DeviceUserID]
+			if !ok {
+				u = &response.StaffImportUnmapped{DeviceUserID: day.DeviceUserID, FirstSeen: day.Date}
+				unmapped[day.DeviceUserID] = u
+				unmappedOrder = append(unmappedOrder, day.DeviceUserID)
+			}
+			u.Punches += day.Punches
+			u.LastSeen = day.Date
+			continue
+		}
+
+		date := startOfDay(day.First)
+		result := response.StaffImportDayResult{
+			DeviceUserID: day.DeviceUserID,
+			EmployeeID:   employee.ID,
+			FullName:     employee.FullName,
+			Date:         day.Date,
+			Punches:      day.Punches,
+		}
+
+		current := existingByKey[staffDayKey(employee.ID, date)]
+		if current != nil && current.Source == domain.StaffAttendanceSourceManual {
+			result.Action = "skip_manual"
+			result.ClockInAt, result.ClockOutAt = current.ClockInAt, current.ClockOutAt
+			result.LateMinutes, result.EarlyLeaveMinutes = current.LateMinutes, current.EarlyLeaveMinutes
+			res.SkippedManual++
+			res.Days = append(res.Days, result)
+			continue
+		}
+
+		var attendance domain.StaffAttendance
+		times := []time.Time{day.First, day.Last}
+		if current != nil {
+			attendance = *current
+			if current.ClockInAt != nil {
+				times = append(times, *current.ClockInAt)
+			}
+			if current.ClockOutAt != nil {
+				times = append(times, *current.ClockOutAt)
+			}
+		} else {
+			attendance = domain.StaffAttendance{EmployeeID: employee.ID, Date: utils.Date(date)}
+		}
+		clockIn, clockOut := mergePunchTimes(times)
+
+		switch {
+		case current == nil:
+			result.Action = "create"
+			res.Created++
+		case !sameTime(current.ClockInAt, clockIn) || !sameTime(current.ClockOutAt, clockOut):
+			result.Action = "update"
+			res.Updated++
+		default:
+			result.Action = "unchanged"
+			res.Unchanged++
+		}
+
+		attendance.ClockInAt, attendance.ClockOutAt = clockIn, clockOut
+		attendance.Evaluate(shifts.forDate(employee, date))
+		if result.Action != "unchanged" {
+			attendance.Source = domain.StaffAttendanceSourceDevice
+			attendance.RecordedBy = &recordedBy
+			toSave = append(toSave, attendance)
+		}
+
+		result.ClockInAt, result.ClockOutAt = attendance.ClockInAt, attendance.ClockOutAt
+		result.LateMinutes, result.EarlyLeaveMinutes = attendance.LateMinutes, attendance.EarlyLeaveMinutes
+		res.Days = append(res.Days, result)
+	}
+	for _, id := range unmappedOrder {
+		res.Unmapped = append(res.Unmapped, *unmapped[id])
+	}
+
+	if res.DryRun || len(toSave) == 0 {
+		return res, nil
+	}
+	if err := s.staffAttendanceRepo.SaveAll(toSave); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ===== Cuti/izin =====
+
+func (s *staffAttendanceService) CreateLeave(req request.StaffLeaveRequest, recordedBy string) (*response.StaffLeaveResponse, error) {
+	start, err := time.ParseInLocation("2006-01-02", req.StartDate, time.Local)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid start_date format, use YYYY-MM-DD")
+	}
+	end, err := time.ParseInLocation("2006-01-02", req.EndDate, time.Local)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid end_date format, use YYYY-MM-DD")
+	}
+	if end.Before(start) {
+		return nil, apperrors.NewBadRequestError("end_date must not be before start_date")
+	}
+
+	employee, err := s.employeeRepo.FindByID(req.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, apperrors.NewNotFoundError("Employee not found")
+	}
+	overlap, err := s.staffAttendanceRepo.HasLeaveOverlap(employee.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if overlap {
+		return nil, apperrors.NewConflictError("Employee already has leave in this date range")
+	}
+
+	leave := &domain.StaffLeave{
+		EmployeeID: employee.ID,
+		LeaveType:  req.LeaveType,
+		StartDate:  utils.Date(start),
+		EndDate:    utils.Date(end),
+		Reason:     req.Reason,
+		RecordedBy: &recordedBy,
+	}
+	if err := s.staffAttendanceRepo.CreateLeave(leave); err != nil {
+		return nil, err
+	}
+	leave.Employee = employee
+	return toStaffLeaveResponse(leave), nil
+}
+
+func (s *staffAttendanceService) GetLeaves(filter request.StaffLeaveFilterRequest) ([]response.StaffLeaveResponse, error) {
+	from, to, err := parseRecapMonth(filter.Month)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := s.staffAttendanceRepo.FindLeaves(from, to, filter.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]response.StaffLeaveResponse, 0, len(leaves))
+	for i := range leaves {
+		res = append(res, *toStaffLeaveResponse(&leaves[i]))
+	}
+	return res, nil
+}
+
+func (s *staffAttendanceService) DeleteLeave(id string) error {
+	leave, err := s.staffAttendanceRepo.FindLeaveByID(id)
+	if err != nil {
+		return err
+	}
+	if leave == nil {
+		return apperrors.NewNotFoundError("Leave not found")
+	}
+	return s.staffAttendanceRepo.DeleteLeave(id)
+}
+
+// ===== Rekap bulanan =====
+
+// GetMonthlyRecap merekap kehadiran semua pegawai dalam satu bulan (YYYY-MM, default bulan ini).
+// Bulan berjalan dihitung sampai hari ini.
+func (s *staffAttendanceService) GetMonthlyRecap(month string) (*response.StaffRecapResponse, error) {
+	res, _, err := s.buildMonthlyRecap(month)
+	return res, err
+}
+
+func (s *staffAttendanceService) buildMonthlyRecap(month string) (*response.StaffRecapResponse, *staffAttendanceData, error) {
+	from, to, err := parseRecapMonth(month)
+	if err != nil {
+		return nil, nil, err
+	}
+	today := startOfDay(time.Now())
+	if from.After(today) {
+		return nil, nil, apperrors.NewBadRequestError("month must not be in the future")
+	}
+	if to.After(today) {
+		to = today
+	}
+
+	data, err := s.loadStaffAttendance(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := &response.StaffRecapResponse{
+		Month:     from.Format("2006-01"),
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Employees: make([]response.StaffRecapItem, 0, len(data.employees)),
+	}
+	for i := range data.employees {
+		employee := &data.employees[i]
+		item := response.StaffRecapItem{
+			EmployeeID: employee.ID,
+			FullName:   employee.FullName,
+			NIP:        employee.NIP,
+			Leave:      map[string]int{},
+		}
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			day := data.day(employee, date)
+			if day.WorkDay {
+				item.WorkDays++
+			}
+			switch day.Status {
+			case response.StaffStatusPresent, response.StaffStatusLate:
+				item.Present++
+				if day.Attendance.LateMinutes > 0 {
+					item.Late++
+					item.LateMinutes += day.Attendance.LateMinutes
+				}
+				if day.Attendance.EarlyLeaveMinutes > 0 {
+					item.EarlyLeave++
+					item.EarlyLeaveMinutes += day.Attendance.EarlyLeaveMinutes
+				}
+				if day.Attendance.ClockInAt != nil && day.Attendance.ClockOutAt == nil && date.Before(today) {
+					item.MissingClockOut++
+				}
+			case response.StaffStatusLeave:
+				// Cuti dihitung per hari kerja, libur di tengah rentang cuti tidak ikut
+				if day.WorkDay {
+					item.Leave[day.LeaveType]++
+				}
+			case response.StaffStatusAbsent:
+				item.Absent++
+			}
+		}
+		res.Employees = append(res.Employees, item)
+	}
+	return res, data, nil
+}
+
+// ExportRecap menulis rekap bulanan ke Excel: sheet rekap per pegawai dan sheet detail harian
+func (s *staffAttendanceService) ExportRecap(w io.Writer, month string) error {
+	recap, data, err := s.buildMonthlyRecap(month)
+	if err != nil {
+		return err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sheet 1: rekap per pegawai
+	summarySheet := "Rekap Kehadiran"
+	f.SetSheetName("Sheet1", summarySheet)
+	summaryHeaders := []string{"No", "NIP", "Nama Lengkap", "Hari Kerja", "Hadir", "Terlambat (kali)", "Terlambat (menit)",
+		"Pulang Cepat (kali)", "Pulang Cepat (menit)", "Tidak Absen Pulang"}
+	for _, leaveType := range domain.StaffLeaveTypes {
+		summaryHeaders = append(summaryHeaders, staffLeaveLabels[leaveType])
+	}
+	summaryHeaders = append(summaryHeaders, "Tanpa Keterangan")
+	if err := writeExcelHeader(f, summarySheet, summaryHeaders, headerStyle); err != nil {
+		return err
+	}
+
+	for i, item := range recap.Employees {
+		row := []interface{}{
+			i + 1,
+			utils.SafeString(item.NIP),
+			item.FullName,
+			item.WorkDays,
+			item.Present,
+			item.Late,
+			item.LateMinutes,
+			item.EarlyLeave,
+			item.EarlyLeaveMinutes,
+			item.MissingClockOut,
+		}
+		for _, leaveType := range domain.StaffLeaveTypes {
+			row = append(row, item.Leave[leaveType])
+		}
+		row = append(row, item.Absent)
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(summarySheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	// Sheet 2: detail harian
+	detailSheet := "Detail Harian"
+	if _, err := f.NewSheet(detailSheet); err != nil {
+		return err
+	}
+	detailHeaders := []string{"Tanggal", "NIP", "Nama Lengkap", "Status", "Jam Masuk", "Jam Pulang", "Jadwal",
+		"Terlambat (menit)", "Pulang Cepat (menit)", "Sumber", "Catatan"}
+	if err := writeExcelHeader(f, detailSheet, detailHeaders, headerStyle); err != nil {
+		return err
+	}
+
+	from, _ := time.ParseInLocation("2006-01-02", recap.From, time.Local)
+	to, _ := time.ParseInLocation("2006-01-02", recap.To, time.Local)
+	rowNum := 1
+	for i := range data.employees {
+		employee := &data.employees[i]
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			day := data.day(employee, date)
+			status := staffStatusLabels[day.Status]
+			if day.Status == response.StaffStatusLeave {
+				status = staffLeaveLabels[day.LeaveType]
+			}
+			row := []interface{}{date.Format("2006-01-02"), utils.SafeString(employee.NIP), employee.FullName, status}
+			if a := day.Attendance; a != nil {
+				schedule := ""
+				if a.ShiftStart != nil && a.ShiftEnd != nil {
+					schedule = *a.ShiftStart + "-" + *a.ShiftEnd
+				}
+				row = append(row, formatClockTime(a.ClockInAt), formatClockTime(a.ClockOutAt), schedule,
+					a.LateMinutes, a.EarlyLeaveMinutes, a.Source, utils.SafeString(a.Notes))
+			}
+			rowNum++
+			cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+			if err := f.SetSheetRow(detailSheet, cell, &row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+// ===== Helper =====
+
+// staffShifts adalah daftar shift untuk menentukan jadwal pegawai
+type staffShifts struct {
+	byID       map[string]*domain.WorkShift
+	defaultOne *domain.WorkShift
+}
+
+// forEmployee mengembalikan shift pegawai, atau shift default jika belum diatur
+func (s *staffShifts) forEmployee(employee *domain.Employee) *domain.WorkShift {
+	if employee.WorkShiftID != nil {
+		if shift, ok := s.byID[*employee.WorkShiftID]; ok {
+			return shift
+		}
+	}
+	return s.defaultOne
+}
+
+// forDate mengembalikan shift pegawai hanya jika tanggal tsb hari kerjanya
+func (s *staffShifts) forDate(employee *domain.Employee, date time.Time) *domain.WorkShift {
+	shift := s.forEmployee(employee)
+	if shift == nil || !shift.IsWorkDay(date) {
+		return nil
+	}
+	return shift
+}
+
+func (s *staffAttendanceService) loadShifts() (*staffShifts, error) {
+	shifts, err := s.staffAttendanceRepo.FindShifts()
+	if err != nil {
+		return nil, err
+	}
+	res := &staffShifts{byID: map[string]*domain.WorkShift{}}
+	for i := range shifts {
+		res.byID[shifts[i].ID] = &shifts[i]
+		if shifts[i].IsDefault && res.defaultOne == nil {
+			res.defaultOne = &shifts[i]
+		}
+	}
+	return res, nil
+}
+
+// evaluate menghitung terlambat/pulang cepat berdasarkan shift pegawai pada tanggal tsb
+func (s *staffAttendanceService) evaluate(attendance *domain.StaffAttendance, employee *domain.Employee, date time.Time) error {
+	shifts, err := s.loadShifts()
+	if err != nil {
+		return err
+	}
+	attendance.Evaluate(shifts.forDate(employee, date))
+	return nil
+}
+
+func (s *staffAttendanceService) findEmployeeByUser(userID string) (*domain.Employee, error) {
+	employee, err := s.employeeRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if employee == nil {
+		return nil, apperrors.NewForbiddenError("Your account is not linked to an employee")
+	}
+	return employee, nil
+}
+
+// staffAttendanceData adalah data kehadiran pegawai dalam rentang tanggal
+type staffAttendanceData struct {
+	employees []domain.Employee
+	records   map[string]*domain.StaffAttendance // employeeID|tanggal
+	leaves    map[string][]domain.StaffLeave     // employeeID
+	shifts    *staffShifts
+	today     string
+}
+
+// staffDay adalah status turunan satu pegawai pada satu tanggal
+type staffDay struct {
+	Status     string
+	LeaveType  string
+	WorkDay    bool
+	Attendance *domain.StaffAttendance
+}
+
+func (s *staffAttendanceService) loadStaffAttendance(from, to time.Time) (*staffAttendanceData, error) {
+	employees, err := s.employeeRepo.FindAll("")
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(employees, func(i, j int) bool { return employees[i].FullName < employees[j].FullName })
+
+	attendances, err := s.staffAttendanceRepo.FindByRange(from, to, "")
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := s.staffAttendanceRepo.FindLeaves(from, to, "")
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.loadShifts()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &staffAttendanceData{
+		employees: employees,
+		records:   map[string]*domain.StaffAttendance{},
+		leaves:    map[string][]domain.StaffLeave{},
+		shifts:    shifts,
+		today:     time.Now().Format("2006-01-02"),
+	}
+	for i := range attendances {
+		data.records[staffDayKey(attendances[i].EmployeeID, time.Time(attendances[i].Date))] = &attendances[i]
+	}
+	for _, leave := range leaves {
+		data.leaves[leave.EmployeeID] = append(data.leaves[leave.EmployeeID], leave)
+	}
+	return data, nil
+}
+
+// day menurunkan status: rekaman kehadiran > cuti > tanpa shift > libur > belum absen (hari ini) > tanpa keterangan
+func (d *staffAttendanceData) day(employee *domain.Employee, date time.Time) staffDay {
+	key := date.Format("2006-01-02")
+	shift := d.shifts.forEmployee(employee)
+	res := staffDay{
+		WorkDay:    shift != nil && shift.IsWorkDay(date),
+		Attendance: d.records[staffDayKey(employee.ID, date)],
+	}
+	for i := range d.leaves[employee.ID] {
+		if d.leaves[employee.ID][i].Covers(key) {
+			res.LeaveType = d.leaves[employee.ID][i].LeaveType
+			break
+		}
+	}
+
+	switch {
+	case res.Attendance != nil && res.Attendance.LateMinutes > 0:
+		res.Status = response.StaffStatusLate
+	case res.Attendance != nil:
+		res.Status = response.StaffStatusPresent
+	case res.LeaveType != "":
+		res.Status = response.StaffStatusLeave
+	case shift == nil:
+		res.Status = response.StaffStatusNoSchedule
+	case !res.WorkDay:
+		res.Status = response.StaffStatusOff
+	case key >= d.today:
+		res.Status = response.StaffStatusNotYet
+	default:
+		res.Status = response.StaffStatusAbsent
+	}
+	if res.Status != response.StaffStatusLeave {
+		res.LeaveType = ""
+	}
+	return res
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func staffDayKey(employeeID string, date time.Time) string {
+	return employeeID + "|" + date.Format("2006-01-02")
+}
+
+// mergePunchTimes memakai waktu paling awal sebagai jam masuk dan paling akhir sebagai jam pulang.
+// Satu rekaman saja dianggap jam masuk.
+func mergePunchTimes(times []time.Time) (*time.Time, *time.Time) {
+	first, last := times[0], times[0]
+	for _, t := range times[1:] {
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	first, last = first.Truncate(time.Second), last.Truncate(time.Second)
+	if last.Equal(first) {
+		return &first, nil
+	}
+	return &first, &last
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
+// parseRecapMonth mengubah YYYY-MM (default bulan ini) menjadi tanggal awal & akhir bulan
+func parseRecapMonth(month string) (time.Time, time.Time, error) {
+	var from time.Time
+	if month == "" {
+		now := time.Now()
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	} else {
+		parsed, err := time.ParseInLocation("2006-01", month, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, apperrors.NewBadRequestError("Invalid month format, use YYYY-MM")
+		}
+		from = parsed
+	}
+	return from, from.AddDate(0, 1, -1), nil
+}
+
+func parseClockMinutes(value string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClockMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func clockOnDate(date time.Time, value string) (*time.Time, error) {
+	minutes, err := parseClockMinutes(value)
+	if err != nil {
+		return nil, err
+	}
+	t := date.Add(time.Duration(minutes) * time.Minute)
+	return &t, nil
+}
+
+func formatClockTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local).Format("15:04")
+}
+
+// normalizeWorkDays memvalidasi dan mengurutkan hari kerja ISO, mis. "6,1,2" -> "1,2,6"
+func normalizeWorkDays(value string) (string, error) {
+	seen := map[int]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		day, err := strconv.Atoi(part)
+		if err != nil || day < 1 || day > 7 {
+			return "", fmt.Errorf("work_days must be ISO weekdays 1-7 separated by commas")
+		}
+		seen[day] = true
+	}
+	if len(seen) == 0 {
+		return "", fmt.Errorf("work_days is required")
+	}
+	days := make([]string, 0, len(seen))
+	for day := 1; day <= 7; day++ {
+		if seen[day] {
+			days = append(days, strconv.Itoa(day))
+		}
+	}
+	return strings.Join(days, ","), nil
+}
+
+func toWorkShiftResponse(shift *domain.WorkShift) *response.WorkShiftResponse {
+	return &response.WorkShiftResponse{
+		ID:                         shift.ID,
+		Name:                       shift.Name,
+		StartTime:                  shift.StartTime,
+		EndTime:                    shift.EndTime,
+		LateToleranceMinutes:       shift.LateToleranceMinutes,
+		EarlyLeaveToleranceMinutes: shift.EarlyLeaveToleranceMinutes,
+		WorkDays:                   shift.WorkDays,
+		IsDefault:                  shift.IsDefault,
+	}
+}
+
+func toStaffAttendanceResponse(a *domain.StaffAttendance) *response.StaffAttendanceResponse {
+	return &response.StaffAttendanceResponse{
+		ID:                a.ID,
+		EmployeeID:        a.EmployeeID,
+		Date:              time.Time(a.Date).Format("2006-01-02"),
+		ClockInAt:         a.ClockInAt,
+		ClockOutAt:        a.ClockOutAt,
+		ShiftStart:        a.ShiftStart,
+		ShiftEnd:          a.ShiftEnd,
+		LateMinutes:       a.LateMinutes,
+		EarlyLeaveMinutes: a.EarlyLeaveMinutes,
+		Source:            a.Source,
+		Notes:             a.Notes,
+	}
+}
+
+func toStaffLeaveResponse(leave *domain.StaffLeave) *response.StaffLeaveResponse {
+	res := &response.StaffLeaveResponse{
+		ID:         leave.ID,
+		EmployeeID: leave.EmployeeID,
+		LeaveType:  leave.LeaveType,
+		StartDate:  time.Time(leave.StartDate).Format("2006-01-02"),
+		EndDate:    time.Time(leave.EndDate).Format("2006-01-02"),
+		Reason:     leave.Reason,
+		CreatedAt:  leave.CreatedAt,
+	}
+	if leave.Employee != nil {
+		res.EmployeeName = leave.Employee.FullName
+	}
+	return res
+}
@@ -0,0 +1,400 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	// Pas foto 3x4 cm pada 300 dpi
+	studentPhotoWidth   = 354
+	studentPhotoHeight  = 472
+	studentPhotoMinSide = 150
+	studentPhotoMaxSize = 5 * 1024 * 1024
+	studentPhotoFolder  = "students"
+
+	// Ukuran kartu CR80 (ISO/IEC 7810 ID-1) dalam mm
+	cardWidth  = 85.6
+	cardHeight = 53.98
+
+	// CardLayoutCard: satu kartu per halaman seukuran CR80 (untuk printer kartu)
+	// CardLayoutSheet: 10 kartu per halaman A4 dengan garis potong
+	CardLayoutCard  = "card"
+	CardLayoutSheet = "sheet"
+
+	schoolLogoPath = "assets/logo_sekolah.png"
+)
+
+type StudentCardService interface {
+	UploadPhoto(studentID string, file *multipart.FileHeader) (*response.StudentPhotoResponse, error)
+	DeletePhoto(studentID string) error
+	GenerateStudentCard(studentID string, layout string) (*bytes.Buffer, error)
+	GenerateClassroomCards(classroomID string, layout string) (*bytes.Buffer, error)
+	VerifyCard(token string) (*response.StudentCardVerifyResponse, error)
+}
+
+type studentCardService struct {
+	studentRepo   repository.StudentRepository
+	classroomRepo repository.ClassroomRepository
+	schoolName    string
+	cardSecret    string
+	baseURL       string
+}
+
+func NewStudentCardService(
+	studentRepo repository.StudentRepository,
+	classroomRepo repository.ClassroomRepository,
+	schoolName string,
+	cardSecret string,
+	baseURL string,
+) StudentCardService {
+	return &studentCardService{
+		studentRepo:   studentRepo,
+		classroomRepo: classroomRepo,
+		schoolName:    schoolName,
+		cardSecret:    cardSecret,
+		baseURL:       baseURL,
+	}
+}
+
+// UploadPhoto memproses foto (orientasi EXIF, crop 3:4 dari bagian atas, resize, JPEG) lalu menggantikan foto lama
+func (s *studentCardService) UploadPhoto(studentID string, file *multipart.FileHeader) (*response.StudentPhotoResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	if file.Size > studentPhotoMaxSize {
+		return nil, apperrors.NewBadRequestError("Photo size exceeds 5MB limit")
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Failed to open uploaded photo")
+	}
+	defer src.Close()
+
+	img, err := imaging.Decode(src, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Photo must be a valid JPG or PNG image")
+	}
+	if b := img.Bounds(); b.Dx() < studentPhotoMinSide || b.Dy() < studentPhotoMinSide {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Photo is too small, minimum %dx%d pixels", studentPhotoMinSide, studentPhotoMinSide))
+	}
+
+	// Anchor atas agar kepala tidak terpotong pada foto setengah/seluruh badan
+	processed := imaging.Fill(img, studentPhotoWidth, studentPhotoHeight, imaging.Top, imaging.Lanczos)
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, processed, imaging.JPEG, imaging.JPEGQuality(85)); err != nil {
+		return nil, err
+	}
+
+	path, err := utils.StoreFile(&buf, studentPhotoFolder, "photo_"+student.ID, ".jpg")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.studentRepo.SetPhoto(student.ID, &path); err != nil {
+		utils.RemoveFile(path)
+		return nil, err
+	}
+	if student.Photo != nil {
+		utils.RemoveFile(*student.Photo)
+	}
+
+	return &response.StudentPhotoResponse{
+		StudentID: student.ID,
+		PhotoURL:  response.GenerateFileURL(&path, s.baseURL),
+	}, nil
+}
+
+func (s *studentCardService) DeletePhoto(studentID string) error {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return err
+	}
+	if student == nil {
+		return apperrors.NewNotFoundError("Student not found")
+	}
+	if student.Photo == nil {
+		return apperrors.NewNotFoundError("Student has no photo")
+	}
+
+	if err := s.studentRepo.SetPhoto(student.ID, nil); err != nil {
+		return err
+	}
+	utils.RemoveFile(*student.Photo)
+	return nil
+}
+
+// GenerateStudentCard mencetak kartu pelajar satu siswa (default satu halaman CR80)
+func (s *studentCardService) GenerateStudentCard(studentID string, layout string) (*bytes.Buffer, error) {
+	student, err := s.studentRepo.FindByIDWithActiveClassroom(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	className := ""
+	if len(student.StudentClassrooms) > 0 {
+		className = student.StudentClassrooms[0].Classroom.Name
+	}
+
+	if layout == "" {
+		layout = CardLayoutCard
+	}
+	return s.renderCards([]domain.Student{*student}, []string{className}, layout)
+}
+
+// GenerateClassroomCards mencetak kartu pelajar semua siswa aktif di satu kelas (default lembar A4)
+func (s *studentCardService) GenerateClassroomCards(classroomID string, layout string) (*bytes.Buffer, error) {
+	classroom, err := s.classroomRepo.FindByID(classroomID)
+	if err != nil {
+		return nil, err
+	}
+	if classroom == nil {
+		return nil, apperrors.NewNotFoundError("Classroom not found")
+	}
+
+	students, err := s.studentRepo.FindByClassroomID(classroomID)
+	if err != nil {
+		return nil, err
+	}
+	if len(students) == 0 {
+		return nil, apperrors.NewBadRequestError("Classroom has no active students")
+	}
+
+	classNames := make([]string, len(students))
+	for i := range classNames {
+		classNames[i] = classroom.Name
+	}
+
+	if layout == "" {
+		layout = CardLayoutSheet
+	}
+	return s.renderCards(students, classNames, layout)
+}
+
+// VerifyCard memeriksa token dari QR kartu dan mengembalikan identitas ringkas siswa
+func (s *studentCardService) VerifyCard(token string) (*response.StudentCardVerifyResponse, error) {
+	studentID, ok := utils.VerifyStudentCardToken(s.cardSecret, token)
+	if !ok {
+		return nil, apperrors.NewNotFoundError("Invalid student card")
+	}
+
+	student, err := s.studentRepo.FindByIDWithActiveClassroom(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Invalid student card")
+	}
+
+	res := &response.StudentCardVerifyResponse{
+		StudentID: student.ID,
+		FullName:  student.FullName,
+		NISN:      student.NISN,
+		Status:    student.Status,
+		Active:    student.Status == domain.StudentStatusActive,
+	}
+	if len(student.StudentClassrooms) > 0 {
+		res.ClassName = student.StudentClassrooms[0].Classroom.Name
+	}
+	return res, nil
+}
+
+func (s *studentCardService) renderCards(students []domain.Student, classNames []string, layout string) (*bytes.Buffer, error) {
+	var pdf *fpdf.Fpdf
+	switch layout {
+	case CardLayoutCard:
+		pdf = fpdf.NewCustom(&fpdf.InitType{
+			OrientationStr: "P",
+			UnitStr:        "mm",
+			Size:           fpdf.SizeType{Wd: cardWidth, Ht: cardHeight},
+		})
+	case CardLayoutSheet:
+		pdf = fpdf.New("P", "mm", "A4", "")
+	default:
+		return nil, apperrors.NewBadRequestError("layout must be card or sheet")
+	}
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	hasLogo := false
+	if _, err := os.Stat(schoolLogoPath); err == nil {
+		pdf.RegisterImageOptions(schoolLogoPath, fpdf.ImageOptions{ImageType: "PNG"})
+		hasLogo = pdf.Ok()
+		if !hasLogo {
+			// Logo rusak tidak boleh menggagalkan pencetakan kartu
+			pdf.ClearError()
+		}
+	}
+
+	// Lembar A4: 2 kolom x 5 baris, jarak antar kartu 3 mm
+	const perSheet, columns, gap = 10, 2, 3.0
+	sheetLeft := (210 - (columns*cardWidth + gap)) / 2
+	sheetTop := (297 - (5*cardHeight + 4*gap)) / 2
+
+	for i := range students {
+		x, y := 0.0, 0.0
+		if layout == CardLayoutCard {
+			pdf.AddPage()
+		} else {
+			if i%perSheet == 0 {
+				pdf.AddPage()
+			}
+			slot := i % perSheet
+			x = sheetLeft + float64(slot%columns)*(cardWidth+gap)
+			y = sheetTop + float64(slot/columns)*(cardHeight+gap)
+
+			// Garis potong
+			pdf.SetDrawColor(190, 190, 190)
+			pdf.SetLineWidth(0.1)
+			pdf.Rect(x, y, cardWidth, cardHeight, "D")
+		}
+
+		if err := s.drawCard(pdf, tr, x, y, &students[i], classNames[i], hasLogo); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// drawCard menggambar satu kartu dengan pojok kiri atas di (x, y)
+func (s *studentCardService) drawCard(pdf *fpdf.Fpdf, tr func(string) string, x, y float64, student *domain.Student, className string, hasLogo bool) error {
+	// 1. Header
+	pdf.SetFillColor(22, 101, 52)
+	pdf.Rect(x, y, cardWidth, 12, "F")
+	textLeft := x + 3
+	if hasLogo {
+		pdf.ImageOptions(schoolLogoPath, x+2.5, y+1.5, 9, 9, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		textLeft = x + 13.5
+	}
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "B", 8)
+	pdf.SetXY(textLeft, y+2)
+	pdf.CellFormat(cardWidth-(textLeft-x)-2, 4, tr(strings.ToUpper(s.schoolName)), "", 0, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 6.5)
+	pdf.SetXY(textLeft, y+6.5)
+	pdf.CellFormat(cardWidth-(textLeft-x)-2, 3.5, "KARTU PELAJAR", "", 0, "L", false, 0, "")
+
+	// 2. Pas foto 3:4
+	photoX, photoY, photoW, photoH := x+4, y+15, 21.0, 28.0
+	photoDrawn := false
+	if student.Photo != nil {
+		if data, err := os.ReadFile(fmt.Sprintf("%s/%s", utils.UploadDir, *student.Photo)); err == nil {
+			name := "photo_" + student.ID
+			pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "JPG"}, bytes.NewReader(data))
+			if pdf.Ok() {
+				pdf.ImageOptions(name, photoX, photoY, photoW, photoH, false, fpdf.ImageOptions{ImageType: "JPG"}, 0, "")
+				photoDrawn = true
+			} else {
+				pdf.ClearError()
+			}
+		}
+	}
+	if !photoDrawn {
+		pdf.SetFillColor(235, 235, 235)
+		pdf.Rect(photoX, photoY, photoW, photoH, "F")
+		pdf.SetTextColor(150, 150, 150)
+		pdf.SetFont("Arial", "", 6)
+		pdf.SetXY(photoX, photoY+photoH/2-2)
+		pdf.CellFormat(photoW, 4, "FOTO 3x4", "", 0, "C", false, 0, "")
+	}
+
+	// 3. Identitas
+	infoX, infoW := x+27.5, 33.0
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "B", 7.5)
+	lines := pdf.SplitText(tr(strings.ToUpper(student.FullName)), infoW)
+	if len(lines) > 2 {
+		lines = lines[:2]
+	}
+	cursorY := y + 15
+	for _, line := range lines {
+		pdf.SetXY(infoX, cursorY)
+		pdf.CellFormat(infoW, 3.4, line, "", 0, "L", false, 0, "")
+		cursorY += 3.4
+	}
+	cursorY += 1
+
+	dob := "-"
+	if student.DateOfBirth != nil && !student.DateOfBirth.IsZero() {
+		dob = student.DateOfBirth.Format("02-01-2006")
+	}
+	birth := dob
+	if place := utils.SafeString(student.PlaceOfBirth); place != "" {
+		birth = place + ", " + dob
+	}
+	if className == "" {
+		className = "-"
+	}
+	rows := [][2]string{
+		{"NISN", student.NISNValue()},
+		{"NIS", student.NIMValue()},
+		{"TTL", birth},
+		{"Kelas", className},
+	}
+	for _, row := range rows {
+		pdf.SetXY(infoX, cursorY)
+		pdf.SetFont("Arial", "", 5.5)
+		pdf.CellFormat(7, 3, row[0], "", 0, "L", false, 0, "")
+		pdf.CellFormat(1.5, 3, ":", "", 0, "L", false, 0, "")
+		pdf.SetFont("Arial", "B", 5.5)
+		value := tr(row[1])
+		for pdf.GetStringWidth(value) > infoW-8.5 && len(value) > 1 {
+			value = value[:len(value)-1]
+		}
+		pdf.CellFormat(infoW-8.5, 3, value, "", 0, "L", false, 0, "")
+		cursorY += 3.6
+	}
+
+	// 4. QR token verifikasi
+	qr, err := qrcode.New(utils.SignStudentCardToken(s.cardSecret, student.ID), qrcode.Medium)
+	if err != nil {
+		return err
+	}
+	qr.DisableBorder = true
+	png, err := qr.PNG(256)
+	if err != nil {
+		return err
+	}
+	qrName := "qr_" + student.ID
+	pdf.RegisterImageOptionsReader(qrName, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+	pdf.ImageOptions(qrName, x+cardWidth-21.5, y+15, 18, 18, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.SetFont("Arial", "", 4.5)
+	pdf.SetTextColor(90, 90, 90)
+	pdf.SetXY(x+cardWidth-23, y+33.5)
+	pdf.CellFormat(21, 2.5, "Scan untuk verifikasi", "", 0, "C", false, 0, "")
+
+	// 5. Footer
+	pdf.SetFillColor(22, 101, 52)
+	pdf.Rect(x, y+cardHeight-4.5, cardWidth, 4.5, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Arial", "I", 5)
+	pdf.SetXY(x, y+cardHeight-4.5)
+	pdf.CellFormat(cardWidth, 4.5, "Berlaku selama terdaftar sebagai siswa aktif", "", 0, "C", false, 0, "")
+
+	return pdf.Error()
+}
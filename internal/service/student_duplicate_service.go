@@ -0,0 +1,338 @@
+package service
+
+import (
+	"fmt"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Skor minimal default agar pasangan dianggap kandidat duplikat
+const defaultDuplicateMinScore = 60
+
+// Variasi ejaan yang sering muncul di nama siswa, disamakan sebelum dibandingkan
+var nameTokenAliases = map[string]string{
+	"muhammad": "muhammad", "muhamad": "muhammad", "mohammad": "muhammad", "mohamad": "muhammad",
+	"muhammed": "muhammad", "mochammad": "muhammad", "mochamad": "muhammad", "moch": "muhammad",
+	"moh": "muhammad", "muh": "muhammad", "mhd": "muhammad", "m": "muhammad",
+	"abd": "abdul", "abdul": "abdul",
+	"nur": "nur", "noor": "nur", "nuur": "nur",
+	"siti": "siti", "sitti": "siti",
+}
+
+// normalizeStudentName: huruf kecil, tanpa tanda baca, alias ejaan disamakan
+func normalizeStudentName(name string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, name)
+
+	tokens := strings.Fields(cleaned)
+	for i, t := range tokens {
+		if alias, ok := nameTokenAliases[t]; ok {
+			tokens[i] = alias
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// levenshteinRatio mengembalikan kemiripan 0..1 berdasarkan jarak edit
+func levenshteinRatio(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	longest := max(len(ra), len(rb))
+	return 1 - float64(prev[len(rb)])/float64(longest)
+}
+
+// nameSimilarity membandingkan nama apa adanya dan dengan urutan kata diurutkan,
+// sehingga "Siti Aisyah" dan "Aisyah Siti" tetap dianggap mirip.
+func nameSimilarity(a, b string) float64 {
+	direct := levenshteinRatio(a, b)
+
+	ta, tb := strings.Fields(a), strings.Fields(b)
+	sort.Strings(ta)
+	sort.Strings(tb)
+	sorted := levenshteinRatio(strings.Join(ta, " "), strings.Join(tb, " "))
+
+	return max(direct, sorted)
+}
+
+// duplicateCandidate adalah data siswa yang sudah dinormalisasi untuk pencarian duplikat
+type duplicateCandidate struct {
+	student *domain.Student
+	name    string
+	place   string
+	dob     string
+	noKK    string
+}
+
+// scoreDuplicatePair memberi skor 0-100 dan alasannya. Skor 0 berarti pasangan pasti berbeda.
+func scoreDuplicatePair(a, b *duplicateCandidate) (int, []string) {
+	sa, sb := a.student, b.student
+
+	// Bukti kuat bahwa keduanya orang berbeda
+	if sa.Gender != "" && sb.Gender != "" && sa.Gender != sb.Gender {
+		return 0, nil
+	}
+	if sa.NIKHash != nil && sb.NIKHash != nil && *sa.NIKHash != *sb.NIKHash {
+		return 0, nil
+	}
+	if sa.NISN != nil && sb.NISN != nil && *sa.NISN != "" && *sb.NISN != "" && *sa.NISN != *sb.NISN {
+		return 0, nil
+	}
+
+	var reasons []string
+	score := 0.0
+
+	nameSim := nameSimilarity(a.name, b.name)
+	if nameSim < 0.75 {
+		// Nama terlalu berbeda; satu KK dengan nama berbeda adalah saudara kandung
+		return 0, nil
+	}
+	score += nameSim * 50
+	if nameSim == 1 {
+		reasons = append(reasons, "Nama sama")
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Nama mirip (%.0f%%)", nameSim*100))
+	}
+
+	if a.dob != "" && b.dob != "" {
+		if a.dob == b.dob {
+			score += 25
+			reasons = append(reasons, "Tanggal lahir sama")
+		} else {
+			score -= 20
+		}
+	}
+
+	if a.place != "" && b.place != "" && levenshteinRatio(a.place, b.place) >= 0.8 {
+		score += 10
+		reasons = append(reasons, "Tempat lahir sama/mirip")
+	}
+
+	if a.noKK != "" && a.noKK == b.noKK {
+		score += 15
+		reasons = append(reasons, "Nomor KK sama")
+	}
+
+	if score < 0 {
+		score = 0
+	}
+	return int(score + 0.5), reasons
+}
+
+func toDuplicateStudentInfo(s *domain.Student) response.DuplicateStudentInfo {
+	return response.DuplicateStudentInfo{
+		ID:           s.ID,
+		FullName:     s.FullName,
+		NISN:         s.NISN,
+		Gender:       s.Gender,
+		PlaceOfBirth: s.PlaceOfBirth,
+		DateOfBirth:  s.DateOfBirth,
+		Status:       s.Status,
+	}
+}
+
+// FindDuplicateStudents mencari pasangan siswa yang kemungkinan data ganda.
+// Pasangan hanya dibandingkan jika berbagi tanggal lahir, nomor KK, atau kata pertama nama
+// agar tidak perlu membandingkan semua pasangan.
+func (s *studentService) FindDuplicateStudents(minScore int, limit int) ([]response.StudentDuplicateCandidate, error) {
+	if minScore <= 0 {
+		minScore = defaultDuplicateMinScore
+	}
+
+	students, err := s.studentRepo.FindDuplicateCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]duplicateCandidate, len(students))
+	blocks := make(map[string][]int)
+	for i := range students {
+		st := &students[i]
+		c := duplicateCandidate{student: st, name: normalizeStudentName(st.FullName)}
+		if st.PlaceOfBirth != nil {
+			c.place = normalizeStudentName(*st.PlaceOfBirth)
+		}
+		if st.DateOfBirth != nil && !st.DateOfBirth.IsZero() {
+			c.dob = st.DateOfBirth.Format("2006-01-02")
+		}
+		if st.NoKK != "" {
+			if noKK, err := s.encryptionUtil.Decrypt(st.NoKK); err == nil {
+				c.noKK = strings.TrimSpace(noKK)
+			}
+		}
+		candidates[i] = c
+
+		if c.dob != "" {
+			blocks["dob:"+c.dob] = append(blocks["dob:"+c.dob], i)
+		}
+		if c.noKK != "" {
+			blocks["kk:"+c.noKK] = append(blocks["kk:"+c.noKK], i)
+		}
+		for _, token := range strings.Fields(c.name) {
+			// Lewati kata yang sangat umum agar blok tidak terlalu besar
+			if token != "muhammad" && token != "siti" && token != "nur" && token != "abdul" {
+				blocks["name:"+token] = append(blocks["name:"+token], i)
+				break
+			}
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	results := []response.StudentDuplicateCandidate{}
+	for _, members := range blocks {
+		for x := 0; x < len(members); x++ {
+			for y := x + 1; y < len(members); y++ {
+				i, j := members[x], members[y]
+				if i > j {
+					i, j = j, i
+				}
+				if seen[[2]int{i, j}] {
+					continue
+				}
+				seen[[2]int{i, j}] = true
+
+				score, reasons := scoreDuplicatePair(&candidates[i], &candidates[j])
+				if score < minScore {
+					continue
+				}
+				results = append(results, response.StudentDuplicateCandidate{
+					Score:    score,
+					Reasons:  reasons,
+					StudentA: toDuplicateStudentInfo(candidates[i].student),
+					StudentB: toDuplicateStudentInfo(candidates[j].student),
+				})
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].StudentA.FullName < results[j].StudentA.FullName
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// MergeStudents menggabungkan duplikat ke survivor: kolom kosong survivor dilengkapi dari duplikat,
+// semua relasi dipindahkan, lalu duplikat dipindah ke trash.
+func (s *studentService) MergeStudents(req request.StudentMergeRequest) (*response.StudentMergeResponse, error) {
+	if req.SurvivorID == req.DuplicateID {
+		return nil, apperrors.NewBadRequestError("Survivor and duplicate must be different students")
+	}
+
+	survivor, err := s.studentRepo.FindByID(req.SurvivorID)
+	if err != nil {
+		return nil, err
+	}
+	if survivor == nil {
+		return nil, apperrors.NewNotFoundError("Survivor student not found")
+	}
+	duplicate, err := s.studentRepo.FindByID(req.DuplicateID)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate == nil {
+		return nil, apperrors.NewNotFoundError("Duplicate student not found")
+	}
+
+	// Dua akun login berbeda tidak bisa digabung otomatis
+	if survivor.UserID != nil && duplicate.UserID != nil && *survivor.UserID != *duplicate.UserID {
+		return nil, apperrors.NewConflictError("Both students are linked to different user accounts, unlink one first")
+	}
+
+	var filled []string
+	fillString := func(field string, dst **string, src *string) {
+		if (*dst == nil || **dst == "") && src != nil && *src != "" {
+			*dst = src
+			filled = append(filled, field)
+		}
+	}
+	fillString("nisn", &survivor.NISN, duplicate.NISN)
+	fillString("nim", &survivor.NIM, duplicate.NIM)
+	if survivor.NIK == nil && duplicate.NIK != nil {
+		survivor.NIK = duplicate.NIK
+		survivor.NIKHash = duplicate.NIKHash
+		filled = append(filled, "nik")
+	}
+	if survivor.NoKK == "" && duplicate.NoKK != "" {
+		survivor.NoKK = duplicate.NoKK
+		survivor.NoKKHash = duplicate.NoKKHash
+		filled = append(filled, "no_kk")
+	}
+	if survivor.Gender == "" && duplicate.Gender != "" {
+		survivor.Gender = duplicate.Gender
+		filled = append(filled, "gender")
+	}
+	fillString("place_of_birth", &survivor.PlaceOfBirth, duplicate.PlaceOfBirth)
+	if (survivor.DateOfBirth == nil || survivor.DateOfBirth.IsZero()) && duplicate.DateOfBirth != nil && !duplicate.DateOfBirth.IsZero() {
+		survivor.DateOfBirth = duplicate.DateOfBirth
+		filled = append(filled, "date_of_birth")
+	}
+	fillString("address", &survivor.Address, duplicate.Address)
+	fillString("rt", &survivor.RT, duplicate.RT)
+	fillString("rw", &survivor.RW, duplicate.RW)
+	fillString("sub_district", &survivor.SubDistrict, duplicate.SubDistrict)
+	fillString("district", &survivor.District, duplicate.District)
+	fillString("city", &survivor.City, duplicate.City)
+	fillString("province", &survivor.Province, duplicate.Province)
+	fillString("postal_code", &survivor.PostalCode, duplicate.PostalCode)
+	fillString("entry_year", &survivor.EntryYear, duplicate.EntryYear)
+	fillString("photo", &survivor.Photo, duplicate.Photo)
+	if survivor.GuardianID == nil && duplicate.GuardianID != nil {
+		survivor.GuardianID = duplicate.GuardianID
+		survivor.GuardianType = duplicate.GuardianType
+		filled = append(filled, "guardian")
+	}
+	if survivor.UserID == nil && duplicate.UserID != nil {
+		survivor.UserID = duplicate.UserID
+		filled = append(filled, "user_id")
+	}
+
+	result, err := s.studentRepo.Merge(survivor, duplicate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filled == nil {
+		filled = []string{}
+	}
+	return &response.StudentMergeResponse{
+		SurvivorID:   survivor.ID,
+		DuplicateID:  duplicate.ID,
+		FilledFields: filled,
+		Moved:        result.Moved,
+		Dropped:      result.Dropped,
+	}, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// Jumlah siswa yang dibaca dari database per batch saat export
+const studentExportBatchSize = 500
+
+// studentExportRow adalah satu siswa beserta data relasi yang sudah di-resolve untuk export
+type studentExportRow struct {
+	Student      *domain.Student
+	ClassName    string
+	FatherName   string
+	MotherName   string
+	GuardianName string
+}
+
+// studentExportColumn mendefinisikan satu kolom yang bisa dipilih lewat query ?columns=
+type studentExportColumn struct {
+	Key      string
+	Label    string
+	PdfWidth float64 // Lebar relatif kolom di PDF (mm, diskalakan ke lebar halaman)
+	Value    func(row *studentExportRow) string
+}
+
+var studentExportColumns = []studentExportColumn{
+	{Key: "nisn", Label: "NISN", PdfWidth: 25, Value: func(r *studentExportRow) string { return utils.SafeString(r.Student.NISN) }},
+	{Key: "nim", Label: "NIM", PdfWidth: 25, Value: func(r *studentExportRow) string { return utils.SafeString(r.Student.NIM) }},
+	{Key: "full_name", Label: "Nama Lengkap", PdfWidth: 55, Value: func(r *studentExportRow) string { return r.Student.FullName }},
+	{Key: "gender", Label: "Jenis Kelamin", PdfWidth: 15, Value: func(r *studentExportRow) string { return r.Student.Gender }},
+	{Key: "place_of_birth", Label: "Tempat Lahir", PdfWidth: 30, Value: func(r *studentExportRow) string { return utils.SafeString(r.Student.PlaceOfBirth) }},
+	{Key: "date_of_birth", Label: "Tanggal Lahir", PdfWidth: 25, Value: func(r *studentExportRow) string {
+		if r.Student.DateOfBirth == nil || r.Student.DateOfBirth.IsZero() {
+			return ""
+		}
+		return r.Student.DateOfBirth.Format("2006-01-02")
+	}},
+	{Key: "address", Label: "Alamat", PdfWidth: 80, Value: func(r *studentExportRow) string {
+		s := r.Student
+		return utils.JoinAddress(s.Address, s.RT, s.RW, s.SubDistrict, s.District, s.City, s.Province, s.PostalCode)
+	}},
+	{Key: "city", Label: "Kota/Kabupaten", PdfWidth: 35, Value: func(r *studentExportRow) string { return utils.SafeString(r.Student.City) }},
+	{Key: "status", Label: "Status", PdfWidth: 20, Value: func(r *studentExportRow) string { return r.Student.Status }},
+	{Key: "entry_year", Label: "Tahun Masuk", PdfWidth: 18, Value: func(r *studentExportRow) string { return utils.SafeString(r.Student.EntryYear) }},
+	{Key: "exit_year", Label: "Tahun Keluar", PdfWidth: 18, Value: func(r *studentExportRow) string { return utils.SafeString(r.Student.ExitYear) }},
+	{Key: "class", Label: "Kelas", PdfWidth: 25, Value: func(r *studentExportRow) string { return r.ClassName }},
+	{Key: "father_name", Label: "Nama Ayah", PdfWidth: 45, Value: func(r *studentExportRow) string { return r.FatherName }},
+	{Key: "mother_name", Label: "Nama Ibu", PdfWidth: 45, Value: func(r *studentExportRow) string { return r.MotherName }},
+	{Key: "guardian_name", Label: "Nama Wali", PdfWidth: 45, Value: func(r *studentExportRow) string { return r.GuardianName }},
+}
+
+// Kolom default jika ?columns= tidak diisi
+var (
+	defaultStudentExcelColumns = []string{"nisn", "nim", "full_name", "gender", "place_of_birth", "date_of_birth", "address", "status", "entry_year", "exit_year"}
+	defaultStudentPdfColumns   = []string{"nisn", "full_name", "gender", "date_of_birth", "address"}
+)
+
+// toStudentFilter memetakan filter dari request ke filter repository
+func toStudentFilter(req request.StudentFilterRequest) repository.StudentFilter {
+	return repository.StudentFilter{
+		Search:          strings.TrimSpace(req.Search),
+		ClassroomID:     req.ClassroomID,
+		Status:          req.Status,
+		Gender:          req.Gender,
+		EntryYear:       req.EntryYear,
+		ExitYear:        req.ExitYear,
+		City:            strings.TrimSpace(req.City),
+		Province:        strings.TrimSpace(req.Province),
+		AcademicYearID:  req.AcademicYearID,
+		HasGuardian:     req.HasGuardian,
+		HasUser:         req.HasUser,
+		MissingDocument: strings.TrimSpace(req.MissingDocument),
+		Sort:            req.Sort,
+		SortDesc:        req.Order == "desc",
+	}
+}
+
+// resolveStudentExportColumns memvalidasi key kolom yang diminta dan menjaga urutannya
+func resolveStudentExportColumns(keys []string, defaults []string) ([]studentExportColumn, error) {
+	if len(keys) == 0 {
+		keys = defaults
+	}
+
+	byKey := make(map[string]studentExportColumn, len(studentExportColumns))
+	for _, col := range studentExportColumns {
+		byKey[col.Key] = col
+	}
+
+	columns := make([]studentExportColumn, 0, len(keys))
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		col, ok := byKey[key]
+		if !ok {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Unknown export column: %s", key))
+		}
+		seen[key] = true
+		columns = append(columns, col)
+	}
+	if len(columns) == 0 {
+		return nil, apperrors.NewBadRequestError("At least one export column is required")
+	}
+	return columns, nil
+}
+
+// needsStudentGuardian mengecek apakah kolom wali dipilih, agar lookup wali hanya dilakukan jika perlu
+func needsStudentGuardian(columns []studentExportColumn) bool {
+	for _, col := range columns {
+		if col.Key == "guardian_name" {
+			return true
+		}
+	}
+	return false
+}
+
+// eachStudentExportRow membaca siswa per batch dan memanggil fn untuk setiap baris export
+func (s *studentService) eachStudentExportRow(filter request.StudentFilterRequest, withGuardian bool, fn func(row *studentExportRow) error) error {
+	repoFilter := toStudentFilter(filter)
+	return s.studentRepo.FindAllInBatches(repoFilter, studentExportBatchSize, func(students []domain.Student) error {
+		guardianNames := map[string]string{}
+		if withGuardian {
+			names, err := s.studentRepo.FindGuardianNames(students)
+			if err != nil {
+				return err
+			}
+			guardianNames = names
+		}
+
+		for i := range students {
+			student := &students[i]
+			row := &studentExportRow{Student: student}
+
+			for _, sc := range student.StudentClassrooms {
+				if row.ClassName == "" || sc.Classroom.AcademicYearID == repoFilter.AcademicYearID {
+					row.ClassName = sc.Classroom.Name
+				}
+			}
+			for _, sp := range student.Parents {
+				switch sp.RelationshipType {
+				case "FATHER":
+					row.FatherName = sp.Parent.FullName
+				case "MOTHER":
+					row.MotherName = sp.Parent.FullName
+				}
+			}
+			if student.GuardianID != nil {
+				row.GuardianName = guardianNames[*student.GuardianID]
+			}
+
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ExportStudentsToExcel menulis export siswa ke w memakai StreamWriter excelize,
+// sehingga memori tetap datar berapa pun jumlah siswanya.
+func (s *studentService) ExportStudentsToExcel(w io.Writer, filter request.StudentFilterRequest, columnKeys []string) error {
+	columns, err := resolveStudentExportColumns(columnKeys, defaultStudentExcelColumns)
+	if err != nil {
+		return err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Data Siswa"
+	f.SetSheetName("Sheet1", sheetName)
+
+	sw, err := f.NewStreamWriter(sheetName)
+	if err != nil {
+		return err
+	}
+
+	// Style Header (Bold, Kuning)
+	style, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Lebar kolom harus diset sebelum baris pertama ditulis
+	if err := sw.SetColWidth(1, 1, 6); err != nil {
+		return err
+	}
+	for i, col := range columns {
+		if err := sw.SetColWidth(i+2, i+2, col.PdfWidth/2+5); err != nil {
+			return err
+		}
+	}
+
+	header := []interface{}{excelize.Cell{StyleID: style, Value: "No"}}
+	for _, col := range columns {
+		header = append(header, excelize.Cell{StyleID: style, Value: col.Label})
+	}
+	if err := sw.SetRow("A1", header); err != nil {
+		return err
+	}
+
+	rowNum := 1
+	err = s.eachStudentExportRow(filter, needsStudentGuardian(columns), func(row *studentExportRow) error {
+		rowNum++
+		values := make([]interface{}, 0, len(columns)+1)
+		values = append(values, rowNum-1)
+		for _, col := range columns {
+			values = append(values, col.Value(row))
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		return sw.SetRow(cell, values)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+	return f.Write(w)
+}
+
+// ExportStudentsToPdf membuat laporan data siswa (landscape A4) dengan filter dan kolom yang sama seperti Excel
+func (s *studentService) ExportStudentsToPdf(filter request.StudentFilterRequest, columnKeys []string) (*bytes.Buffer, error) {
+	columns, err := resolveStudentExportColumns(columnKeys, defaultStudentPdfColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Init PDF (Landscape, mm, A4)
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.AddPage()
+
+	// 2. Judul
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, "LAPORAN DATA SISWA", "", 1, "C", false, 0, "")
+	pdf.Ln(5)
+
+	// 3. Lebar kolom diskalakan agar pas dengan lebar halaman
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	noWidth := 10.0
+	total := 0.0
+	for _, col := range columns {
+		total += col.PdfWidth
+	}
+	scale := 1.0
+	if available := pageWidth - left - right - noWidth; total > available {
+		scale = available / total
+	}
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	writeHeader := func() {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetFillColor(240, 240, 240) // Abu-abu muda
+		pdf.CellFormat(noWidth, 10, "No", "1", 0, "C", true, 0, "")
+		for _, col := range columns {
+			pdf.CellFormat(col.PdfWidth*scale, 10, col.Label, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Arial", "", 9)
+	}
+	writeHeader()
+
+	// 4. Isi Data
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	no := 0
+	err = s.eachStudentExportRow(filter, needsStudentGuardian(columns), func(row *studentExportRow) error {
+		if pdf.GetY()+8 > pageHeight-bottom {
+			pdf.AddPage()
+			writeHeader()
+		}
+		no++
+		pdf.CellFormat(noWidth, 8, fmt.Sprintf("%d", no), "1", 0, "C", false, 0, "")
+		for _, col := range columns {
+			width := col.PdfWidth * scale
+			text := tr(col.Value(row))
+			// Potong teks yang terlalu panjang agar tidak menabrak kolom sebelah
+			for len(text) > 0 && pdf.GetStringWidth(text) > width-2 {
+				text = text[:len(text)-1]
+			}
+			pdf.CellFormat(width, 8, text, "1", 0, "L", false, 0, "")
+		}
+		pdf.Ln(-1)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. Output ke Buffer
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
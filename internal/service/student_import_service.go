@@ -0,0 +1,589 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const studentImportSheet = "Data Siswa"
+
+type studentImportColumn struct {
+	Key      string
+	Label    string
+	Required bool
+}
+
+// studentImportColumns adalah kolom template import, urutan = urutan kolom di Excel.
+// Saat membaca file, kolom dicocokkan berdasarkan label header sehingga urutannya boleh berubah.
+var studentImportColumns = []studentImportColumn{
+	{Key: "full_name", Label: "Nama Lengkap", Required: true},
+	{Key: "gender", Label: "Jenis Kelamin (L/P)", Required: true},
+	{Key: "nisn", Label: "NISN"},
+	{Key: "nim", Label: "NIM"},
+	{Key: "nik", Label: "NIK"},
+	{Key: "no_kk", Label: "No KK"},
+	{Key: "place_of_birth", Label: "Tempat Lahir"},
+	{Key: "date_of_birth", Label: "Tanggal Lahir (YYYY-MM-DD)"},
+	{Key: "address", Label: "Alamat"},
+	{Key: "rt", Label: "RT"},
+	{Key: "rw", Label: "RW"},
+	{Key: "sub_district", Label: "Kelurahan/Desa"},
+	{Key: "district", Label: "Kecamatan"},
+	{Key: "city", Label: "Kota/Kabupaten"},
+	{Key: "province", Label: "Provinsi"},
+	{Key: "postal_code", Label: "Kode Pos"},
+	{Key: "entry_year", Label: "Tahun Masuk"},
+	{Key: "status", Label: "Status"},
+	{Key: "classroom", Label: "Kelas"},
+	{Key: "father_name", Label: "Nama Ayah"},
+	{Key: "father_nik", Label: "NIK Ayah"},
+	{Key: "father_phone", Label: "No HP Ayah"},
+	{Key: "mother_name", Label: "Nama Ibu"},
+	{Key: "mother_nik", Label: "NIK Ibu"},
+	{Key: "mother_phone", Label: "No HP Ibu"},
+}
+
+var importYearPattern = regexp.MustCompile(`^[0-9]{4}$`)
+
+// studentImportState menyimpan nilai unik yang sudah dipakai baris sebelumnya di file yang sama
+type studentImportState struct {
+	nisnRows    map[string]int
+	nimRows     map[string]int
+	nikRows     map[string]int
+	newParents  map[string]*domain.Parent // key: "nik:<hash>" atau "phone:<nomor>"
+	classrooms  map[string]string         // nama kelas (lowercase) -> id
+	assignClass bool
+}
+
+// GetImportTemplate membuat template Excel kosong untuk import siswa
+func (s *studentService) GetImportTemplate() (*bytes.Buffer, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName("Sheet1", studentImportSheet)
+
+	lastCol, _ := excelize.ColumnNumberToName(len(studentImportColumns))
+
+	// Semua kolom bertipe teks agar nol di depan NISN/NIK/No HP tidak hilang
+	textStyle, _ := f.NewStyle(&excelize.Style{NumFmt: 49})
+	f.SetColStyle(studentImportSheet, "A:"+lastCol, textStyle)
+	f.SetColWidth(studentImportSheet, "A", lastCol, 20)
+
+	for i, col := range studentImportColumns {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		label := col.Label
+		if col.Required {
+			label += "*"
+		}
+		f.SetCellValue(studentImportSheet, cell, label)
+	}
+
+	headerStyle, _ := f.NewStyle(&excelize.Style{
+		Font:   &excelize.Font{Bold: true},
+		Fill:   excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+		NumFmt: 49,
+	})
+	f.SetCellStyle(studentImportSheet, "A1", lastCol+"1", headerStyle)
+	f.SetPanes(studentImportSheet, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"})
+
+	// Sheet petunjuk
+	guide := "Petunjuk"
+	f.NewSheet(guide)
+	lines := []string{
+		"Petunjuk pengisian template import siswa",
+		"",
+		"1. Isi data mulai baris ke-2 pada sheet \"" + studentImportSheet + "\". Jangan ubah judul kolom.",
+		"2. Kolom bertanda * wajib diisi.",
+		"3. Jenis Kelamin: L atau P.",
+		"4. Tanggal Lahir: format YYYY-MM-DD, contoh 2012-08-17.",
+		"5. NIK dan No KK: 16 digit angka dengan kode wilayah yang valid; tanggal lahir dan jenis kelamin harus sesuai NIK. NISN: 10 digit angka.",
+		"6. Status: kosongkan atau isi ACTIVE. Siswa lulus/keluar dicatat lewat mutasi setelah import.",
+		"7. Kelas: nama kelas pada tahun ajaran yang dipilih (mis. 7A). Dipakai jika opsi penempatan kelas aktif.",
+		"8. Data Ayah/Ibu dipakai jika opsi import orang tua aktif. Orang tua dengan NIK atau No HP yang sudah terdaftar akan ditautkan, bukan dibuat ulang.",
+		"9. Upload dengan mode dry_run terlebih dahulu untuk melihat laporan validasi per baris.",
+	}
+	for i, line := range lines {
+		f.SetCellValue(guide, fmt.Sprintf("A%d", i+1), line)
+	}
+	f.SetColWidth(guide, "A", "A", 120)
+
+	f.SetActiveSheet(0)
+	return f.WriteToBuffer()
+}
+
+// ImportStudents memvalidasi file Excel per baris. Pada mode commit, semua siswa
+// disimpan dalam satu transaksi, dan hanya jika tidak ada baris yang invalid.
+func (s *studentService) ImportStudents(file io.Reader, req request.StudentImportRequest) (*response.StudentImportResponse, error) {
+	f, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("Invalid Excel file")
+	}
+	defer f.Close()
+
+	rows, err := f.GetRows(studentImportSheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Sheet %q not found, please use the import template", studentImportSheet))
+	}
+	if len(rows) < 2 {
+		return nil, apperrors.NewBadRequestError("Import file has no data rows")
+	}
+
+	columnIndex, err := parseImportHeader(rows[0], studentImportColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &studentImportState{
+		nisnRows:    make(map[string]int),
+		nimRows:     make(map[string]int),
+		nikRows:     make(map[string]int),
+		newParents:  make(map[string]*domain.Parent),
+		assignClass: req.AssignClassroom,
+	}
+	if req.AssignClassroom {
+		if state.classrooms, err = s.importClassroomMap(req.AcademicYearID); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &response.StudentImportResponse{
+		DryRun: req.Mode != "commit",
+		Rows:   []response.StudentImportRowResult{},
+	}
+	var records []repository.StudentImportRecord
+	var recordRows []int // index di result.Rows untuk setiap record
+
+	for i, cells := range rows[1:] {
+		values := make(map[string]string, len(columnIndex))
+		empty := true
+		for key, idx := range columnIndex {
+			if idx < len(cells) {
+				values[key] = strings.TrimSpace(cells[idx])
+				if values[key] != "" {
+					empty = false
+				}
+			}
+		}
+		if empty {
+			continue
+		}
+
+		record, rowResult, err := s.validateImportRow(i+2, values, req, state)
+		if err != nil {
+			return nil, err
+		}
+		result.TotalRows++
+		if len(rowResult.Errors) > 0 {
+			rowResult.Status = "invalid"
+			result.InvalidRows++
+		} else {
+			rowResult.Status = "valid"
+			result.ValidRows++
+			records = append(records, *record)
+			recordRows = append(recordRows, len(result.Rows))
+		}
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	if result.TotalRows == 0 {
+		return nil, apperrors.NewBadRequestError("Import file has no data rows")
+	}
+	if result.DryRun || result.InvalidRows > 0 {
+		return result, nil
+	}
+
+	if err := s.studentRepo.ImportStudents(records); err != nil {
+		return nil, fmt.Errorf("failed to import students: %w", err)
+	}
+	for i, record := range records {
+		row := &result.Rows[recordRows[i]]
+		row.Status = "created"
+		row.StudentID = record.Student.ID
+	}
+	result.Created = len(records)
+	return result, nil
+}
+
+func normalizeImportHeader(label string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(label), "*")))
+}
+
+// parseImportHeader memetakan key kolom ke index kolom berdasarkan header
+func parseImportHeader(header []string, columns []studentImportColumn) (map[string]int, error) {
+	byLabel := make(map[string]string, len(columns)*2)
+	for _, col := range columns {
+		byLabel[normalizeImportHeader(col.Label)] = col.Key
+		byLabel[col.Key] = col.Key
+	}
+
+	columnIndex := make(map[string]int)
+	for i, label := range header {
+		if key, ok := byLabel[normalizeImportHeader(label)]; ok {
+			columnIndex[key] = i
+		}
+	}
+
+	var missing []string
+	for _, col := range columns {
+		if _, ok := columnIndex[col.Key]; !ok && col.Required {
+			missing = append(missing, col.Label)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, apperrors.NewBadRequestError("Missing required columns: " + strings.Join(missing, ", "))
+	}
+	return columnIndex, nil
+}
+
+// importClassroomMap mengambil daftar kelas pada tahun ajaran tujuan (default: yang aktif)
+func (s *studentService) importClassroomMap(academicYearID string) (map[string]string, error) {
+	if academicYearID == "" {
+		active, err := s.academicYearRepo.FindActive()
+		if err != nil {
+			return nil, err
+		}
+		if active == nil {
+			return nil, apperrors.NewBadRequestError("No active academic year, please set academic_year_id")
+		}
+		academicYearID = active.ID
+	} else {
+		year, err := s.academicYearRepo.FindByID(academicYearID)
+		if err != nil {
+			return nil, err
+		}
+		if year == nil {
+			return nil, apperrors.NewNotFoundError("Academic year not found")
+		}
+	}
+
+	classrooms, err := s.classroomRepo.FindAll(academicYearID)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(classrooms))
+	for _, classroom := range classrooms {
+		result[strings.ToLower(classroom.Name)] = classroom.ID
+	}
+	return result, nil
+}
+
+// validateImportRow memvalidasi satu baris dan menyiapkan record untuk disimpan.
+// Error yang dikembalikan hanya error sistem (DB/enkripsi), bukan error validasi.
+func (s *studentService) validateImportRow(rowNum int, values map[string]string, req request.StudentImportRequest, state *studentImportState) (*repository.StudentImportRecord, response.StudentImportRowResult, error) {
+	result := response.StudentImportRowResult{Row: rowNum, FullName: values["full_name"]}
+	addError := func(format string, args ...interface{}) {
+		result.Errors = append(result.Errors, fmt.Sprintf(format, args...))
+	}
+	toPtr := func(v string) *string {
+		if v == "" {
+			return nil
+		}
+		return &v
+	}
+
+	student := &domain.Student{
+		ID:           utils.GenerateUUID(),
+		FullName:     values["full_name"],
+		PlaceOfBirth: toPtr(values["place_of_birth"]),
+		Address:      toPtr(values["address"]),
+		RT:           toPtr(values["rt"]),
+		RW:           toPtr(values["rw"]),
+		SubDistrict:  toPtr(values["sub_district"]),
+		District:     toPtr(values["district"]),
+		City:         toPtr(values["city"]),
+		Province:     toPtr(values["province"]),
+		PostalCode:   toPtr(values["postal_code"]),
+		EntryYear:    toPtr(values["entry_year"]),
+		Status:       "ACTIVE",
+	}
+
+	if student.FullName == "" {
+		addError("Nama Lengkap is required")
+	}
+
+	if gender, ok := normalizeImportGender(values["gender"]); ok {
+		student.Gender = gender
+	} else if values["gender"] == "" {
+		addError("Jenis Kelamin is required")
+	} else {
+		addError("Invalid Jenis Kelamin %q (use L or P)", values["gender"])
+	}
+
+	// Status selain ACTIVE hanya lewat mutasi agar riwayat, tahun keluar dan penempatan kelas konsisten
+	if status := strings.ToUpper(values["status"]); status != "" && status != "ACTIVE" {
+		addError("Invalid Status %q (only ACTIVE can be imported, record graduation or dropout as a mutation)", values["status"])
+	}
+
+	if nisn := values["nisn"]; nisn != "" {
+		if row, ok := state.nisnRows[nisn]; ok {
+			addError("NISN %s is duplicated in row %d", nisn, row)
+		} else {
+			existing, err := s.studentRepo.FindByNISN(nisn)
+			if err != nil {
+				return nil, result, err
+			}
+			if existing != nil {
+				addError("NISN %s already exists", nisn)
+			}
+			state.nisnRows[nisn] = rowNum
+		}
+		student.NISN = &nisn
+	}
+
+	if nim := values["nim"]; nim != "" {
+		if row, ok := state.nimRows[nim]; ok {
+			addError("NIM %s is duplicated in row %d", nim, row)
+		} else {
+			existing, err := s.studentRepo.FindByNIM(nim)
+			if err != nil {
+				return nil, result, err
+			}
+			if existing != nil {
+				addError("NIM %s already exists", nim)
+			}
+			state.nimRows[nim] = rowNum
+		}
+		student.NIM = &nim
+	}
+
+	if nik := values["nik"]; nik != "" {
+		hash, err := s.encryptionUtil.Hash(nik)
+		if err != nil {
+			return nil, result, fmt.Errorf("failed to hash NIK: %w", err)
+		}
+		if row, ok := state.nikRows[hash]; ok {
+			addError("NIK is duplicated in row %d", row)
+		} else {
+			existing, err := s.studentRepo.FindByNIKHash(hash)
+			if err != nil {
+				return nil, result, err
+			}
+			if existing != nil {
+				addError("NIK already registered to another student")
+			}
+			state.nikRows[hash] = rowNum
+		}
+
+		encrypted, err := s.encryptionUtil.Encrypt(nik)
+		if err != nil {
+			return nil, result, fmt.Errorf("failed to encrypt NIK: %w", err)
+		}
+		student.NIK = &encrypted
+		student.NIKHash = &hash
+	}
+
+	if noKK := values["no_kk"]; noKK != "" {
+		encrypted, err := s.encryptionUtil.Encrypt(noKK)
+		if err != nil {
+			return nil, result, fmt.Errorf("failed to encrypt NoKK: %w", err)
+		}
+		hash, err := s.encryptionUtil.Hash(noKK)
+		if err != nil {
+			return nil, result, fmt.Errorf("failed to hash NoKK: %w", err)
+		}
+		student.NoKK = encrypted
+		student.NoKKHash = &hash
+	}
+
+	if dob := values["date_of_birth"]; dob != "" {
+		parsed, err := parseImportDate(dob)
+		if err != nil {
+			addError("Invalid Tanggal Lahir %q (use YYYY-MM-DD)", dob)
+		} else if parsed.After(time.Now()) {
+			addError("Tanggal Lahir cannot be in the future")
+		} else {
+			date := utils.Date(parsed)
+			student.DateOfBirth = &date
+		}
+	}
+
+	if year := values["entry_year"]; year != "" && !importYearPattern.MatchString(year) {
+		addError("Tahun Masuk must be a 4-digit year")
+	}
+
+	// Struktur NIK/No KK/NISN dan kecocokan NIK dengan tanggal lahir & jenis kelamin.
+	// Mode strict menolak baris, mode warn hanya menambahkan peringatan.
+	for _, issue := range validation.Check(validation.Person{
+		NIK:         values["nik"],
+		NoKK:        values["no_kk"],
+		NISN:        values["nisn"],
+		Gender:      student.Gender,
+		DateOfBirth: dateToTime(student.DateOfBirth),
+	}) {
+		if s.identity.Strict() {
+			addError("%s", issue.String())
+		} else {
+			result.Warnings = append(result.Warnings, issue.String())
+		}
+	}
+
+	record := &repository.StudentImportRecord{Student: student}
+
+	if state.assignClass {
+		if name := values["classroom"]; name == "" {
+			result.Warnings = append(result.Warnings, "Kelas is empty, student will not be placed in a classroom")
+		} else if id, ok := state.classrooms[strings.ToLower(name)]; ok {
+			record.ClassroomID = id
+		} else {
+			addError("Kelas %q not found in the selected academic year", name)
+		}
+	}
+
+	if req.IncludeParents {
+		parents := []struct {
+			prefix, relationship, gender string
+		}{
+			{"father", "FATHER", "male"},
+			{"mother", "MOTHER", "female"},
+		}
+		for _, p := range parents {
+			name := values[p.prefix+"_name"]
+			if name == "" {
+				continue
+			}
+			// Validasi NIK orang tua mengikuti aturan baris siswa: strict menolak, warn jadi peringatan
+			nik := values[p.prefix+"_nik"]
+			if nik != "" {
+				issues := validation.Check(validation.Person{NIK: nik, Gender: p.gender})
+				if len(issues) > 0 && s.identity.Strict() {
+					addError("%s: %s", p.relationship, issues[0].String())
+					continue
+				}
+				for _, issue := range issues {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", p.relationship, issue.String()))
+				}
+			}
+
+			parentID, newParent, warning, errMsg, err := s.resolveImportParent(name, nik, values[p.prefix+"_phone"], p.gender, state)
+			if err != nil {
+				return nil, result, err
+			}
+			if errMsg != "" {
+				addError("%s: %s", p.relationship, errMsg)
+				continue
+			}
+			if warning != "" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", p.relationship, warning))
+			}
+			if newParent != nil {
+				record.NewParents = append(record.NewParents, newParent)
+			}
+			record.Parents = append(record.Parents, domain.StudentParent{
+				StudentID:        student.ID,
+				ParentID:         parentID,
+				RelationshipType: p.relationship,
+			})
+		}
+	}
+
+	return record, result, nil
+}
+
+// resolveImportParent mencari orang tua yang sudah ada (berdasarkan NIK lalu No HP), baik di
+// database maupun di baris sebelumnya (kakak-adik), dan membuat data baru jika belum ada.
+func (s *studentService) resolveImportParent(name, nik, phone, gender string, state *studentImportState) (string, *domain.Parent, string, string, error) {
+	var nikHash string
+	if nik != "" {
+		hash, err := s.encryptionUtil.Hash(nik)
+		if err != nil {
+			return "", nil, "", "", fmt.Errorf("failed to hash NIK: %w", err)
+		}
+		nikHash = hash
+
+		if parent, ok := state.newParents["nik:"+hash]; ok {
+			return parent.ID, nil, "", "", nil
+		}
+		existing, err := s.parentRepo.FindByNIKHash(hash)
+		if err != nil {
+			return "", nil, "", "", err
+		}
+		if existing != nil {
+			if existing.DeletedAt.Valid {
+				return "", nil, "", "parent with this NIK is in the trash, restore it first", nil
+			}
+			return existing.ID, nil, fmt.Sprintf("linked to existing parent %s", existing.FullName), "", nil
+		}
+	}
+
+	// No HP keluarga bisa dipakai bersama, jadi kecocokan No HP tidak boleh menggabungkan dua orang
+	// dengan NIK berbeda
+	if phone != "" {
+		if parent, ok := state.newParents["phone:"+phone]; ok {
+			if nikHash != "" && parent.NIKHash != nil && *parent.NIKHash != nikHash {
+				return "", nil, "", fmt.Sprintf("phone number %s is used by %s in this file with a different NIK", phone, parent.FullName), nil
+			}
+			return parent.ID, nil, "", "", nil
+		}
+		existing, err := s.parentRepo.FindByPhone(phone)
+		if err != nil {
+			return "", nil, "", "", err
+		}
+		if existing != nil {
+			if existing.DeletedAt.Valid {
+				return "", nil, "", "parent with this phone number is in the trash, restore it first", nil
+			}
+			if nikHash != "" && existing.NIKHash != nil && *existing.NIKHash != "" && *existing.NIKHash != nikHash {
+				return "", nil, "", fmt.Sprintf("phone number %s belongs to existing parent %s with a different NIK", phone, existing.FullName), nil
+			}
+			return existing.ID, nil, fmt.Sprintf("linked to existing parent %s (matched by phone number)", existing.FullName), "", nil
+		}
+	}
+
+	parent := &domain.Parent{
+		ID:       utils.GenerateUUID(),
+		FullName: name,
+		Gender:   &gender,
+	}
+	if phone != "" {
+		parent.PhoneNumber = &phone
+		state.newParents["phone:"+phone] = parent
+	}
+	if nikHash != "" {
+		encrypted, err := s.encryptionUtil.Encrypt(nik)
+		if err != nil {
+			return "", nil, "", "", fmt.Errorf("failed to encrypt NIK: %w", err)
+		}
+		parent.NIK = &encrypted
+		parent.NIKHash = &nikHash
+		state.newParents["nik:"+nikHash] = parent
+	}
+	return parent.ID, parent, "", "", nil
+}
+
+func normalizeImportGender(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "l", "laki-laki", "laki laki", "male":
+		return "male", true
+	case "p", "perempuan", "female":
+		return "female", true
+	}
+	return "", false
+}
+
+// parseImportDate menerima YYYY-MM-DD, DD/MM/YYYY, DD-MM-YYYY, atau nomor seri tanggal Excel
+func parseImportDate(value string) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(value, 64); err == nil {
+		return excelize.ExcelDateToTime(serial, false)
+	}
+	for _, layout := range []string{utils.DateLayout, "02/01/2006", "2/1/2006", "02-01-2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s", value)
+}
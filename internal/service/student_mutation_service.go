@@ -0,0 +1,281 @@
+package service
+
+import (
+	"fmt"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"strings"
+	"time"
+)
+
+// studentTransition mendefinisikan aturan satu jenis mutasi
+type studentTransition struct {
+	From            []string // Status siswa yang diizinkan sebelum mutasi (kosong = semua)
+	To              string
+	Incoming        bool   // Mutasi masuk: tahun keluar dikosongkan, boleh menempatkan kelas
+	PlacementStatus string // Status baru untuk penempatan kelas yang masih aktif
+	NeedsSchool     bool
+}
+
+var studentTransitions = map[string]studentTransition{
+	domain.MutationTransferIn: {
+		To: domain.StudentStatusActive, Incoming: true, NeedsSchool: true,
+	},
+	domain.MutationReadmission: {
+		From: []string{domain.StudentStatusTransferred, domain.StudentStatusDropout},
+		To:   domain.StudentStatusActive, Incoming: true,
+	},
+	domain.MutationTransferOut: {
+		From: []string{domain.StudentStatusActive},
+		To:   domain.StudentStatusTransferred, PlacementStatus: "TRANSFERRED", NeedsSchool: true,
+	},
+	domain.MutationDropout: {
+		From: []string{domain.StudentStatusActive},
+		To:   domain.StudentStatusDropout, PlacementStatus: "DROPOUT",
+	},
+	domain.MutationGraduate: {
+		From: []string{domain.StudentStatusActive},
+		To:   domain.StudentStatusGraduated, PlacementStatus: "GRADUATED",
+	},
+}
+
+// Urutan jenis mutasi di laporan bulanan
+var studentMutationReportOrder = []string{
+	domain.MutationTransferIn,
+	domain.MutationReadmission,
+	domain.MutationTransferOut,
+	domain.MutationDropout,
+	domain.MutationGraduate,
+}
+
+type StudentMutationService interface {
+	CreateMutation(studentID string, req request.StudentMutationRequest, userID string) (*response.StudentMutationResponse, error)
+	GetStudentMutations(studentID string) ([]response.StudentMutationResponse, error)
+	GetMonthlyReport(year, month int) (*response.StudentMutationReportResponse, error)
+}
+
+type studentMutationService struct {
+	mutationRepo  repository.StudentMutationRepository
+	studentRepo   repository.StudentRepository
+	classroomRepo repository.ClassroomRepository
+	documentRepo  repository.DocumentRepository
+	baseURL       string
+}
+
+func NewStudentMutationService(
+	mutationRepo repository.StudentMutationRepository,
+	studentRepo repository.StudentRepository,
+	classroomRepo repository.ClassroomRepository,
+	documentRepo repository.DocumentRepository,
+	baseURL string,
+) StudentMutationService {
+	return &studentMutationService{
+		mutationRepo:  mutationRepo,
+		studentRepo:   studentRepo,
+		classroomRepo: classroomRepo,
+		documentRepo:  documentRepo,
+		baseURL:       baseURL,
+	}
+}
+
+func (s *studentMutationService) CreateMutation(studentID string, req request.StudentMutationRequest, userID string) (*response.StudentMutationResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	transition, ok := studentTransitions[req.Type]
+	if !ok {
+		return nil, apperrors.NewBadRequestError("Invalid mutation type")
+	}
+
+	// 1. Validasi status asal
+	if len(transition.From) > 0 {
+		allowed := false
+		for _, from := range transition.From {
+			if student.Status == from {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Cannot apply %s to a student with status %s", req.Type, student.Status))
+		}
+	}
+	if req.Type == domain.MutationTransferIn {
+		// Pindah masuk hanya untuk siswa baru; siswa yang pernah keluar memakai READMISSION
+		count, err := s.mutationRepo.CountByStudent(studentID)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, apperrors.NewBadRequestError("Student already has mutation history, use READMISSION instead")
+		}
+	}
+
+	// 2. Validasi data mutasi
+	effective := req.EffectiveDate.ToTime()
+	if effective.IsZero() {
+		return nil, apperrors.NewBadRequestError("effective_date is required")
+	}
+	if effective.After(time.Now()) {
+		return nil, apperrors.NewBadRequestError("effective_date cannot be in the future")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return nil, apperrors.NewBadRequestError("reason is required")
+	}
+	if transition.NeedsSchool && strings.TrimSpace(req.SchoolName) == "" {
+		return nil, apperrors.NewBadRequestError("school_name is required for transfers")
+	}
+
+	mutation := &domain.StudentMutation{
+		StudentID:      studentID,
+		Type:           req.Type,
+		EffectiveDate:  req.EffectiveDate,
+		Reason:         strings.TrimSpace(req.Reason),
+		PreviousStatus: student.Status,
+		NewStatus:      transition.To,
+	}
+	if req.SchoolName != "" {
+		mutation.SchoolName = utils.StringPtr(strings.TrimSpace(req.SchoolName))
+	}
+	if userID != "" {
+		mutation.CreatedBy = utils.StringPtr(userID)
+	}
+
+	if req.ClassroomID != "" {
+		if !transition.Incoming {
+			return nil, apperrors.NewBadRequestError("classroom_id is only allowed for TRANSFER_IN and READMISSION")
+		}
+		classroom, err := s.classroomRepo.FindByID(req.ClassroomID)
+		if err != nil {
+			return nil, err
+		}
+		if classroom == nil {
+			return nil, apperrors.NewNotFoundError("Classroom not found")
+		}
+		mutation.ClassroomID = utils.StringPtr(classroom.ID)
+		mutation.Classroom = classroom
+	}
+
+	for _, docID := range req.DocumentIDs {
+		doc, err := s.documentRepo.FindByID(docID)
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil || doc.OwnerType != domain.DocumentOwnerStudent || doc.OwnerID != studentID {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Document %s does not belong to this student", docID))
+		}
+		mutation.Documents = append(mutation.Documents, *doc)
+	}
+
+	// 3. Tahun masuk/keluar mengikuti tanggal efektif
+	year := utils.StringPtr(effective.Format("2006"))
+	change := repository.StudentMutationChange{
+		Status:          transition.To,
+		EntryYear:       student.EntryYear,
+		ExitYear:        year,
+		PlacementStatus: transition.PlacementStatus,
+		ClassroomID:     req.ClassroomID,
+	}
+	if transition.Incoming {
+		change.ExitYear = nil
+		if req.Type == domain.MutationTransferIn || student.EntryYear == nil {
+			change.EntryYear = year
+		}
+		// Siswa yang masuk kembali tidak boleh punya penempatan aktif ganda
+		if req.ClassroomID != "" {
+			change.PlacementStatus = "TRANSFERRED"
+		}
+	}
+
+	if err := s.mutationRepo.Apply(mutation, change); err != nil {
+		return nil, err
+	}
+
+	res := response.FromDomainStudentMutation(mutation, s.baseURL)
+	res.StudentName = student.FullName
+	res.Gender = student.Gender
+	return &res, nil
+}
+
+func (s *studentMutationService) GetStudentMutations(studentID string) ([]response.StudentMutationResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	mutations, err := s.mutationRepo.FindByStudent(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]response.StudentMutationResponse, 0, len(mutations))
+	for i := range mutations {
+		res = append(res, response.FromDomainStudentMutation(&mutations[i], s.baseURL))
+	}
+	return res, nil
+}
+
+// GetMonthlyReport menyusun laporan mutasi (jumlah per jenis, L/P, dan daftar siswa) untuk satu bulan
+func (s *studentMutationService) GetMonthlyReport(year, month int) (*response.StudentMutationReportResponse, error) {
+	if month < 1 || month > 12 {
+		return nil, apperrors.NewBadRequestError("month must be between 1 and 12")
+	}
+	if year < 1900 {
+		return nil, apperrors.NewBadRequestError("Invalid year")
+	}
+
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 1, 0)
+	mutations, err := s.mutationRepo.FindByPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summaryByType := make(map[string]*response.StudentMutationSummary)
+	res := &response.StudentMutationReportResponse{
+		Year:      year,
+		Month:     month,
+		Summary:   make([]response.StudentMutationSummary, 0, len(studentMutationReportOrder)),
+		Mutations: make([]response.StudentMutationResponse, 0, len(mutations)),
+	}
+	for _, t := range studentMutationReportOrder {
+		res.Summary = append(res.Summary, response.StudentMutationSummary{Type: t})
+	}
+	for i := range res.Summary {
+		summaryByType[res.Summary[i].Type] = &res.Summary[i]
+	}
+
+	for i := range mutations {
+		m := &mutations[i]
+		summary := summaryByType[m.Type]
+		if m.Student != nil {
+			switch m.Student.Gender {
+			case "male":
+				summary.Male++
+			case "female":
+				summary.Female++
+			}
+		}
+		summary.Total++
+
+		if studentTransitions[m.Type].Incoming {
+			res.Incoming++
+		} else {
+			res.Outgoing++
+		}
+		res.Mutations = append(res.Mutations, response.FromDomainStudentMutation(m, s.baseURL))
+	}
+	return res, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"smart_school_be/internal/apperrors"
 	"smart_school_be/internal/converter"
 	"smart_school_be/internal/model/domain"
@@ -11,48 +12,44 @@ import (
 	"smart_school_be/internal/model/response"
 	"smart_school_be/internal/repository"
 	"smart_school_be/internal/utils"
+	"smart_school_be/internal/validation"
 	"strings"
 	"time"
 
 	"github.com/go-pdf/fpdf"
 	"github.com/phpdave11/gofpdf/contrib/gofpdi"
-	"github.com/xuri/excelize/v2"
 )
 
 type StudentService interface {
-	CreateStudent(req request.StudentCreateRequest, files StudentFiles) (*response.StudentDetailResponse, error)
+	CreateStudent(req request.StudentCreateRequest) (*response.StudentDetailResponse, error)
 	GetStudentByID(id string) (*response.StudentDetailResponse, error)
-	GetAllStudents(search string, classroomID string, pagination request.PaginationRequest) (*response.PaginatedData, error)
-	UpdateStudent(id string, req request.StudentUpdateRequest, files StudentFiles) (*response.StudentDetailResponse, error)
+	GetAllStudents(filter request.StudentFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	UpdateStudent(id string, req request.StudentUpdateRequest) (*response.StudentDetailResponse, error)
 	DeleteStudent(id string) error
 	SyncParents(studentID string, req request.StudentSyncParentsRequest) error
 	SetGuardian(studentID string, req request.StudentSetGuardianRequest) error
 	RemoveGuardian(studentID string) error // Helper untuk menghapus wali
 	LinkUser(studentID string, userID string) error
 	UnlinkUser(studentID string) error
-	ExportStudentsToExcel() (*bytes.Buffer, error)
-	ExportStudentsToPdf() (*bytes.Buffer, error)
+	ExportStudentsToExcel(w io.Writer, filter request.StudentFilterRequest, columns []string) error
+	ExportStudentsToPdf(filter request.StudentFilterRequest, columns []string) (*bytes.Buffer, error)
 	ExportStudentBiodata(id string) (*bytes.Buffer, error)
-}
-
-type StudentFiles struct {
-	BirthCertificateFile        string
-	FamilyCardFile              string
-	ParentStatementFile         string
-	StudentStatementFile        string
-	HealthInsuranceFile         string
-	DiplomaCertificateFile      string
-	GraduationCertificateFile   string
-	FinancialHardshipLetterFile string
+	GetImportTemplate() (*bytes.Buffer, error)
+	ImportStudents(file io.Reader, req request.StudentImportRequest) (*response.StudentImportResponse, error)
+	FindDuplicateStudents(minScore int, limit int) ([]response.StudentDuplicateCandidate, error)
+	MergeStudents(req request.StudentMergeRequest) (*response.StudentMergeResponse, error)
 }
 
 type studentService struct {
-	studentRepo    repository.StudentRepository
-	parentRepo     repository.ParentRepository
-	guardianRepo   repository.GuardianRepository
-	userRepo       repository.UserRepository
-	encryptionUtil utils.EncryptionUtil                // <-- Untuk ENKRIPSI
-	converter      converter.StudentConverterInterface // <-- Untuk DEKRIPSI/Response
+	studentRepo      repository.StudentRepository
+	parentRepo       repository.ParentRepository
+	guardianRepo     repository.GuardianRepository
+	userRepo         repository.UserRepository
+	classroomRepo    repository.ClassroomRepository
+	academicYearRepo repository.AcademicYearRepository
+	encryptionUtil   utils.EncryptionUtil                // <-- Untuk ENKRIPSI
+	converter        converter.StudentConverterInterface // <-- Untuk DEKRIPSI/Response
+	identity         *validation.IdentityValidator       // <-- Validasi NIK/NISN/No KK
 }
 
 func NewStudentService(
@@ -60,21 +57,36 @@ func NewStudentService(
 	parentRepo repository.ParentRepository,
 	guardianRepo repository.GuardianRepository,
 	userRepo repository.UserRepository,
+	classroomRepo repository.ClassroomRepository,
+	academicYearRepo repository.AcademicYearRepository,
 	encryptionUtil utils.EncryptionUtil,
 	converter converter.StudentConverterInterface,
+	identity *validation.IdentityValidator,
 ) StudentService {
 	return &studentService{
-		studentRepo:    studentRepo,
-		parentRepo:     parentRepo,
-		guardianRepo:   guardianRepo,
-		userRepo:       userRepo,
-		encryptionUtil: encryptionUtil,
-		converter:      converter,
+		studentRepo:      studentRepo,
+		parentRepo:       parentRepo,
+		guardianRepo:     guardianRepo,
+		userRepo:         userRepo,
+		classroomRepo:    classroomRepo,
+		academicYearRepo: academicYearRepo,
+		encryptionUtil:   encryptionUtil,
+		converter:        converter,
+		identity:         identity,
+	}
+}
+
+// dateToTime mengubah tanggal opsional dari request untuk validasi identitas
+func dateToTime(d *utils.Date) *time.Time {
+	if d == nil || d.IsZero() {
+		return nil
 	}
+	t := d.ToTime()
+	return &t
 }
 
 // CreateStudent menangani pembuatan siswa baru
-func (s *studentService) CreateStudent(req request.StudentCreateRequest, files StudentFiles) (*response.StudentDetailResponse, error) {
+func (s *studentService) CreateStudent(req request.StudentCreateRequest) (*response.StudentDetailResponse, error) {
 	// Helpers untuk konversi string kosong ke nil pointer
 	toPtr := func(s string) *string {
 		if s == "" {
@@ -89,7 +101,24 @@ func (s *studentService) CreateStudent(req request.StudentCreateRequest, files S
 		return &d
 	}
 
-	// 1. Validasi Duplikat
+	// Siswa baru selalu ACTIVE; lulus/keluar dicatat lewat mutasi agar riwayatnya tercatat
+	if req.Status != "" && req.Status != "ACTIVE" {
+		return nil, apperrors.NewBadRequestError("New students are always ACTIVE, record graduation or dropout as a mutation")
+	}
+
+	// 1. Validasi struktur NIK/NISN/No KK dan kecocokannya dengan tanggal lahir & jenis kelamin
+	identity := validation.Person{
+		NIK:         req.NIK,
+		NoKK:        req.NoKK,
+		NISN:        req.NISN,
+		Gender:      req.Gender,
+		DateOfBirth: dateToTime(toDatePtr(req.DateOfBirth)),
+	}
+	if _, err := s.identity.Validate("student", identity); err != nil {
+		return nil, err
+	}
+
+	// 2. Validasi Duplikat
 	var nisn *string
 	if req.NISN != "" {
 		nisn = &req.NISN
@@ -106,7 +135,7 @@ func (s *studentService) CreateStudent(req request.StudentCreateRequest, files S
 		}
 	}
 
-	// 2. Enkripsi Data Sensitif & Hash NIK
+	// 3. Enkripsi Data Sensitif & Hash NIK
 	var encryptedNIK *string
 	var nikHash *string
 
@@ -135,18 +164,26 @@ func (s *studentService) CreateStudent(req request.StudentCreateRequest, files S
 	}
 
 	encryptedNoKK := ""
+	var noKKHash *string
 	if req.NoKK != "" {
 		var err error
 		encryptedNoKK, err = s.encryptionUtil.Encrypt(req.NoKK)
 		if err != nil {
 			return nil, fmt.Errorf("failed to encrypt NoKK: %w", err)
 		}
+		// Blind index untuk pengelompokan keluarga (household)
+		hash, err := s.encryptionUtil.Hash(req.NoKK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash NoKK: %w", err)
+		}
+		noKKHash = &hash
 	}
 
-	// 3. Buat Domain Object
+	// 4. Buat Domain Object
 	student := &domain.Student{
 		FullName:                    req.FullName,
 		NoKK:                        encryptedNoKK,
+		NoKKHash:                    noKKHash,
 		NIK:                         encryptedNIK,
 		NIKHash:                     nikHash,
 		NISN:                        nisn,
@@ -162,30 +199,18 @@ func (s *studentService) CreateStudent(req request.StudentCreateRequest, files S
 		City:                        toPtr(req.City),
 		Province:                    toPtr(req.Province),
 		PostalCode:                  toPtr(req.PostalCode),
-		Status:                      req.Status,
+		Status:                      "ACTIVE",
 		EntryYear:                   toPtr(req.EntryYear),
 		ExitYear:                    toPtr(req.ExitYear),
-		BirthCertificateFile:        toPtr(files.BirthCertificateFile),
-		FamilyCardFile:              toPtr(files.FamilyCardFile),
-		ParentStatementFile:         toPtr(files.ParentStatementFile),
-		StudentStatementFile:        toPtr(files.StudentStatementFile),
-		HealthInsuranceFile:         toPtr(files.HealthInsuranceFile),
-		DiplomaCertificateFile:      toPtr(files.DiplomaCertificateFile),
-		GraduationCertificateFile:   toPtr(files.GraduationCertificateFile),
-		FinancialHardshipLetterFile: toPtr(files.FinancialHardshipLetterFile),
 	}
 
-	// Set default status if empty
-	if student.Status == "" {
-		student.Status = "ACTIVE"
-	}
 
-	// 4. Panggil Repository
+	// 5. Panggil Repository
 	if err := s.studentRepo.Create(student); err != nil {
 		return nil, err
 	}
 
-	// 5. Ambil data yang baru dibuat
+	// 6. Ambil data yang baru dibuat
 	createdStudent, err := s.studentRepo.FindByID(student.ID)
 	if err != nil {
 		return nil, err
@@ -194,7 +219,7 @@ func (s *studentService) CreateStudent(req request.StudentCreateRequest, files S
 		return nil, apperrors.NewInternalError("Failed to retrieve created student")
 	}
 
-	// 6. Konversi ke Response (menggunakan konverter)
+	// 7. Konversi ke Response (menggunakan konverter)
 	return s.converter.ToStudentDetailResponse(createdStudent), nil
 }
 
@@ -246,11 +271,11 @@ func (s *studentService) GetStudentByID(id string) (*response.StudentDetailRespo
 }
 
 // GetAllStudents mengambil semua siswa dengan pagination
-func (s *studentService) GetAllStudents(search string, classroomID string, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+func (s *studentService) GetAllStudents(filter request.StudentFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error) {
 	limit := pagination.GetLimit()
 	offset := pagination.GetOffset()
 
-	students, total, err := s.studentRepo.FindAll(search, classroomID, limit, offset)
+	students, total, err := s.studentRepo.FindAll(toStudentFilter(filter), limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -261,7 +286,7 @@ func (s *studentService) GetAllStudents(search string, classroomID string, pagin
 }
 
 // UpdateStudent memperbarui data siswa
-func (s *studentService) UpdateStudent(id string, req request.StudentUpdateRequest, files StudentFiles) (*response.StudentDetailResponse, error) {
+func (s *studentService) UpdateStudent(id string, req request.StudentUpdateRequest) (*response.StudentDetailResponse, error) {
 	student, err := s.studentRepo.FindByID(id)
 	if err != nil {
 		return nil, err
@@ -270,6 +295,28 @@ func (s *studentService) UpdateStudent(id string, req request.StudentUpdateReque
 		return nil, apperrors.NewNotFoundError("Student not found")
 	}
 
+	// Validasi identitas dengan nilai setelah update; NIK, tanggal lahir dan jenis kelamin yang
+	// tersimpan dipakai jika tidak dikirim agar kecocokan dengan NIK tetap dicek
+	identity := validation.Person{NoKK: req.NoKK, NISN: req.NISN, Gender: student.Gender, DateOfBirth: dateToTime(student.DateOfBirth)}
+	if req.NIK != nil {
+		identity.NIK = *req.NIK
+	} else if student.NIK != nil {
+		nik, err := s.encryptionUtil.Decrypt(*student.NIK)
+		if err != nil {
+			return nil, apperrors.NewInternalError("Failed to decrypt NIK")
+		}
+		identity.NIK = nik
+	}
+	if req.DateOfBirth != nil {
+		identity.DateOfBirth = dateToTime(req.DateOfBirth)
+	}
+	if req.Gender != nil {
+		identity.Gender = *req.Gender
+	}
+	if _, err := s.identity.Validate("student", identity); err != nil {
+		return nil, err
+	}
+
 	// Update fields jika disediakan (meniru RoleService)
 	if req.FullName != "" {
 		student.FullName = req.FullName
@@ -339,17 +386,25 @@ func (s *studentService) UpdateStudent(id string, req request.StudentUpdateReque
 	// NoKK - bisa di-null dengan mengirim empty string
 	if req.NoKK == "" {
 		student.NoKK = "" // Set ke empty untuk null
+		student.NoKKHash = nil
 	} else {
 		encryptedNoKK, err := s.encryptionUtil.Encrypt(req.NoKK)
 		if err != nil {
 			return nil, fmt.Errorf("Failed to encrypt NoKK: %w", err)
 		}
+		noKKHash, err := s.encryptionUtil.Hash(req.NoKK)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to hash NoKK: %w", err)
+		}
 		student.NoKK = encryptedNoKK
+		student.NoKKHash = &noKKHash
 	}
 
 	// Update field lainnya - direct assign untuk pointer fields
 	student.PlaceOfBirth = req.PlaceOfBirth
-	student.DateOfBirth = req.DateOfBirth
+	if req.DateOfBirth != nil {
+		student.DateOfBirth = req.DateOfBirth
+	}
 	student.Address = req.Address
 	student.RT = req.RT
 	student.RW = req.RW
@@ -365,30 +420,11 @@ func (s *studentService) UpdateStudent(id string, req request.StudentUpdateReque
 	if req.Gender != nil {
 		student.Gender = *req.Gender
 	}
-	if req.Status != nil {
-		student.Status = *req.Status
+	// Status hanya boleh berubah lewat mutasi (/students/:id/mutations) agar riwayatnya tercatat
+	if req.Status != nil && *req.Status != student.Status {
+		return nil, apperrors.NewBadRequestError("Student status can only be changed through a mutation")
 	}
 
-	// Update File Paths
-	// Helper to update file path if provided
-	updateFile := func(currentPath **string, newPath string) {
-		if newPath != "" {
-			if *currentPath != nil {
-				utils.RemoveFile(**currentPath)
-			}
-			*currentPath = &newPath
-		}
-	}
-
-	updateFile(&student.BirthCertificateFile, files.BirthCertificateFile)
-	updateFile(&student.FamilyCardFile, files.FamilyCardFile)
-	updateFile(&student.ParentStatementFile, files.ParentStatementFile)
-	updateFile(&student.StudentStatementFile, files.StudentStatementFile)
-	updateFile(&student.HealthInsuranceFile, files.HealthInsuranceFile)
-	updateFile(&student.DiplomaCertificateFile, files.DiplomaCertificateFile)
-	updateFile(&student.GraduationCertificateFile, files.GraduationCertificateFile)
-	updateFile(&student.FinancialHardshipLetterFile, files.FinancialHardshipLetterFile)
-
 	if err := s.studentRepo.Update(student); err != nil {
 		return nil, err
 	}
@@ -630,145 +666,6 @@ func (s *studentService) UnlinkUser(studentID string) error {
 	return s.studentRepo.SetUserID(studentID, nil)
 }
 
-func (s *studentService) ExportStudentsToExcel() (*bytes.Buffer, error) {
-	// 1. Ambil semua data siswa
-	// 1. Ambil semua data siswa (tanpa limit/offset, atau set limit besar)
-	// Gunakan limit besar untuk export
-	students, _, err := s.studentRepo.FindAll("", "", 10000, 0)
-	if err != nil {
-		return nil, err
-	}
-
-	// 2. Buat File Excel Baru
-	f := excelize.NewFile()
-	sheetName := "Data Siswa"
-	index, _ := f.NewSheet(sheetName)
-	f.SetActiveSheet(index)
-	// Hapus sheet default "Sheet1" jika tidak dipakai
-	f.DeleteSheet("Sheet1")
-
-	// 3. Buat Header
-	headers := []string{"No", "NISN", "NIM", "Nama Lengkap", "Jenis Kelamin", "Tempat Lahir", "Tanggal Lahir", "Alamat", "Status", "Tahun Masuk", "Tahun Keluar"}
-	for i, header := range headers {
-		// Konversi koordinat (0,0 -> A1, 1,0 -> B1)
-		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
-		f.SetCellValue(sheetName, cell, header)
-	}
-
-	// Style Header (Bold, Kuning) - Opsional biar cantik
-	style, _ := f.NewStyle(&excelize.Style{
-		Font: &excelize.Font{Bold: true},
-		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
-	})
-	f.SetCellStyle(sheetName, "A1", "L1", style)
-
-	// 4. Isi Data
-	for i, student := range students {
-		row := i + 2 // Mulai dari baris ke-2
-
-		// Format Tanggal
-		dob := ""
-		if !student.DateOfBirth.IsZero() {
-			dob = student.DateOfBirth.Format("2006-01-02")
-		}
-
-		address := utils.JoinAddress(
-			student.Address,
-			student.RT,
-			student.RW,
-			student.SubDistrict,
-			student.District,
-			student.City,
-			student.Province,
-			student.PostalCode,
-		)
-
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), i+1)
-		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), utils.SafeString(student.NISN))
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), utils.SafeString(student.NIM))
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), student.FullName)
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), student.Gender)
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), utils.SafeString(student.PlaceOfBirth))
-		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), dob)
-		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), address)
-		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), student.Status)
-		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), student.EntryYear)
-		f.SetCellValue(sheetName, fmt.Sprintf("K%d", row), student.ExitYear)
-	}
-
-	// 5. Simpan ke Buffer (Memory)
-	buffer, err := f.WriteToBuffer()
-	if err != nil {
-		return nil, err
-	}
-
-	return buffer, nil
-}
-
-func (s *studentService) ExportStudentsToPdf() (*bytes.Buffer, error) {
-	// Gunakan limit besar untuk export
-	students, _, err := s.studentRepo.FindAll("", "", 10000, 0)
-	if err != nil {
-		return nil, err
-	}
-
-	// 1. Init PDF (Landscape, mm, A4)
-	pdf := fpdf.New("L", "mm", "A4", "")
-	pdf.AddPage()
-
-	// 2. Judul
-	pdf.SetFont("Arial", "B", 16)
-	pdf.CellFormat(0, 10, "LAPORAN DATA SISWA", "", 1, "C", false, 0, "")
-	pdf.Ln(5)
-
-	// 3. Header Tabel
-	pdf.SetFont("Arial", "B", 10)
-	pdf.SetFillColor(240, 240, 240) // Abu-abu muda
-
-	// Lebar Kolom: No, NISN, Nama, Gender, Alamat
-	widths := []float64{10, 30, 60, 20, 40, 80}
-	headers := []string{"No", "NISN", "Nama Lengkap", "JK", "Tgl Lahir", "Alamat"}
-
-	for i, header := range headers {
-		pdf.CellFormat(widths[i], 10, header, "1", 0, "C", true, 0, "")
-	}
-	pdf.Ln(-1) // Pindah baris
-
-	// 4. Isi Data
-	pdf.SetFont("Arial", "", 9)
-	pdf.SetFillColor(255, 255, 255) // Putih
-
-	for i, student := range students {
-		// Format Tanggal
-		dob := "-"
-		if !student.DateOfBirth.IsZero() {
-			dob = student.DateOfBirth.Format("02-01-2006")
-		}
-
-		// Convert Gender
-		gender := "L"
-		if student.Gender == "female" {
-			gender = "P"
-		}
-
-		pdf.CellFormat(widths[0], 8, fmt.Sprintf("%d", i+1), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(widths[1], 8, student.NISNValue(), "1", 0, "C", false, 0, "")
-		pdf.CellFormat(widths[2], 8, student.FullName, "1", 0, "L", false, 0, "")
-		pdf.CellFormat(widths[3], 8, gender, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(widths[4], 8, dob, "1", 0, "C", false, 0, "")
-		pdf.CellFormat(widths[5], 8, utils.SafeString(student.Address), "1", 0, "L", false, 0, "") // Alamat mungkin terpotong jika panjang, nanti bisa pakai MultiCell
-		pdf.Ln(-1)
-	}
-
-	// 5. Output ke Buffer
-	var buf bytes.Buffer
-	if err := pdf.Output(&buf); err != nil {
-		return nil, err
-	}
-
-	return &buf, nil
-}
-
 func (s *studentService) ExportStudentBiodata(id string) (*bytes.Buffer, error) {
 	// 1. Ambil data lengkap (termasuk parents & guardian)
 	student, err := s.studentRepo.FindByIDWithParents(id)
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"strings"
+)
+
+type StudentTimelineService interface {
+	GetTimeline(studentID string, req request.StudentTimelineRequest, pagination request.PaginationRequest) (*response.PaginatedData, error)
+}
+
+type studentTimelineService struct {
+	timelineRepo     repository.StudentTimelineRepository
+	studentRepo      repository.StudentRepository
+	academicYearRepo repository.AcademicYearRepository
+}
+
+func NewStudentTimelineService(
+	timelineRepo repository.StudentTimelineRepository,
+	studentRepo repository.StudentRepository,
+	academicYearRepo repository.AcademicYearRepository,
+) StudentTimelineService {
+	return &studentTimelineService{
+		timelineRepo:     timelineRepo,
+		studentRepo:      studentRepo,
+		academicYearRepo: academicYearRepo,
+	}
+}
+
+// GetTimeline menggabungkan absensi, nilai, pelanggaran, perubahan kelas dan mutasi status
+// menjadi satu daftar kronologis (terbaru lebih dulu) yang dipaginasi.
+func (s *studentTimelineService) GetTimeline(studentID string, req request.StudentTimelineRequest, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	var filter repository.StudentTimelineFilter
+	// Tipe yang diulang (types=attendance,attendance) hanya dipakai sekali agar event tidak ganda
+	seen := map[string]bool{}
+	for _, t := range strings.Split(req.Types, ",") {
+		t = strings.TrimSpace(strings.ToLower(t))
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		valid := false
+		for _, known := range repository.TimelineEventTypes {
+			if t == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("Unknown timeline type: %s (allowed: %s)", t, strings.Join(repository.TimelineEventTypes, ", ")))
+		}
+		filter.Types = append(filter.Types, t)
+	}
+
+	if req.AcademicYearID != "" {
+		academicYear, err := s.academicYearRepo.FindByID(req.AcademicYearID)
+		if err != nil {
+			return nil, err
+		}
+		if academicYear == nil {
+			return nil, apperrors.NewNotFoundError("Academic year not found")
+		}
+		filter.AcademicYear = academicYear
+	}
+
+	limit := pagination.GetLimit()
+	events, total, err := s.timelineRepo.FindEvents(studentID, filter, limit, pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.StudentTimelineEventResponse, 0, len(events))
+	for _, e := range events {
+		items = append(items, response.StudentTimelineEventResponse{
+			Type:          e.EventType,
+			ID:            e.EventID,
+			OccurredAt:    e.OccurredAt,
+			Title:         e.Title,
+			Subtitle:      e.Subtitle,
+			Status:        e.Status,
+			Score:         e.Score,
+			MaxScore:      e.MaxScore,
+			Points:        e.Points,
+			ClassroomName: e.ClassroomName,
+		})
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), limit)
+	return &paginated, nil
+}
@@ -114,7 +114,15 @@ func (s *subjectService) Update(id string, req request.SubjectUpdateRequest) (*r
 	return s.toResponse(sub), nil
 }
 
+// Delete memindahkan mapel ke trash. Mapel yang masih dipakai teaching assignment ditolak, karena
+// purge akan ikut menghapus jadwal, absensi dan nilai siswa lewat ON DELETE CASCADE.
 func (s *subjectService) Delete(id string) error {
-	// Optional: Cek apakah mapel ini sedang dipakai di jadwal/kelas (Future improvement)
+	count, err := s.repo.CountTeachingAssignments(id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.NewConflictError("Subject is still used by teaching assignments, remove them first")
+	}
 	return s.repo.Delete(id)
 }
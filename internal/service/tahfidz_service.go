@@ -0,0 +1,635 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/quran"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Bobot predikat untuk menghitung predikat rata-rata
+var tahfidzGradeScores = map[string]int{
+	domain.TahfidzGradeMumtaz:       4,
+	domain.TahfidzGradeJayyidJiddan: 3,
+	domain.TahfidzGradeJayyid:       2,
+	domain.TahfidzGradeMaqbul:       1,
+	domain.TahfidzGradeRasib:        0,
+}
+
+var tahfidzGradeLabels = map[string]string{
+	domain.TahfidzGradeMumtaz:       "Mumtaz",
+	domain.TahfidzGradeJayyidJiddan: "Jayyid Jiddan",
+	domain.TahfidzGradeJayyid:       "Jayyid",
+	domain.TahfidzGradeMaqbul:       "Maqbul",
+	domain.TahfidzGradeRasib:        "Rasib",
+}
+
+// Status laju hafalan terhadap target
+const (
+	tahfidzPaceNoTarget = "no_target"
+	tahfidzPaceAhead    = "ahead"
+	tahfidzPaceOnTrack  = "on_track"
+	tahfidzPaceBehind   = "behind"
+)
+
+type TahfidzService interface {
+	GetSurahs() []quran.Surah
+	CreateSession(req request.TahfidzSessionCreateRequest, userID string) (*response.TahfidzSessionResponse, error)
+	GetSessions(filter request.TahfidzSessionFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	DeleteSession(id string) error
+	SetTargets(req request.TahfidzTargetRequest) (int, error)
+	GetProgress(studentID, academicYearID string) (*response.TahfidzProgressResponse, error)
+	GetReportCardSection(studentID, academicYearID string) (*response.TahfidzReportCardSection, error)
+	ExportToExcel(w io.Writer, req request.TahfidzExportRequest) error
+}
+
+type tahfidzService struct {
+	tahfidzRepo      repository.TahfidzRepository
+	studentRepo      repository.StudentRepository
+	employeeRepo     repository.EmployeeRepository
+	academicYearRepo repository.AcademicYearRepository
+}
+
+func NewTahfidzService(
+	tahfidzRepo repository.TahfidzRepository,
+	studentRepo repository.StudentRepository,
+	employeeRepo repository.EmployeeRepository,
+	academicYearRepo repository.AcademicYearRepository,
+) TahfidzService {
+	return &tahfidzService{
+		tahfidzRepo:      tahfidzRepo,
+		studentRepo:      studentRepo,
+		employeeRepo:     employeeRepo,
+		academicYearRepo: academicYearRepo,
+	}
+}
+
+func (s *tahfidzService) GetSurahs() []quran.Surah {
+	return quran.Surahs()
+}
+
+func (s *tahfidzService) CreateSession(req request.TahfidzSessionCreateRequest, userID string) (*response.TahfidzSessionResponse, error) {
+	student, err := s.studentRepo.FindByID(req.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	year, err := s.resolveAcademicYear(req.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
+	if req.SessionDate.ToTime().After(time.Now()) {
+		return nil, apperrors.NewBadRequestError("session_date cannot be in the future")
+	}
+
+	from, to, err := quran.Range(req.StartSurah, req.StartAyah, req.EndSurah, req.EndAyah)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(err.Error())
+	}
+
+	// Penguji default: employee milik user yang mencatat
+	var examiner *domain.Employee
+	if req.ExaminerID != "" {
+		examiner, err = s.employeeRepo.FindByID(req.ExaminerID)
+	} else if userID != "" {
+		examiner, err = s.employeeRepo.FindByUserID(userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if examiner == nil {
+		return nil, apperrors.NewBadRequestError("Examiner not found, please provide a valid examiner_id")
+	}
+
+	session := &domain.TahfidzSession{
+		StudentID:      student.ID,
+		AcademicYearID: year.ID,
+		SessionDate:    req.SessionDate,
+		Type:           req.Type,
+		StartSurah:     req.StartSurah,
+		StartAyah:      req.StartAyah,
+		EndSurah:       req.EndSurah,
+		EndAyah:        req.EndAyah,
+		AyahFrom:       from,
+		AyahTo:         to,
+		AyahCount:      to - from + 1,
+		JuzStart:       quran.JuzOf(from),
+		JuzEnd:         quran.JuzOf(to),
+		Grade:          req.Grade,
+		ExaminerID:     &examiner.ID,
+		Notes:          req.Notes,
+		CreatedBy:      optionalString(userID),
+	}
+	if err := s.tahfidzRepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	session.Student = student
+	session.Examiner = examiner
+	result := toTahfidzSessionResponse(session)
+	return &result, nil
+}
+
+func (s *tahfidzService) GetSessions(filter request.TahfidzSessionFilterRequest, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	sessions, total, err := s.tahfidzRepo.FindSessions(repository.TahfidzSessionFilter{
+		StudentID:      filter.StudentID,
+		AcademicYearID: filter.AcademicYearID,
+		Type:           filter.Type,
+		ExaminerID:     filter.ExaminerID,
+	}, pagination.GetLimit(), pagination.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.TahfidzSessionResponse, 0, len(sessions))
+	for i := range sessions {
+		items = append(items, toTahfidzSessionResponse(&sessions[i]))
+	}
+
+	paginated := response.NewPaginatedData(items, total, pagination.GetPage(), pagination.GetLimit())
+	return &paginated, nil
+}
+
+func (s *tahfidzService) DeleteSession(id string) error {
+	session, err := s.tahfidzRepo.FindSessionByID(id)
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		return apperrors.NewNotFoundError("Tahfidz session not found")
+	}
+	return s.tahfidzRepo.DeleteSession(session.ID)
+}
+
+// SetTargets membuat/memperbarui target untuk semua santri di req.StudentIDs, mengembalikan jumlah target tersimpan
+func (s *tahfidzService) SetTargets(req request.TahfidzTargetRequest) (int, error) {
+	year, err := s.resolveAcademicYear(req.AcademicYearID)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := map[string]bool{}
+	var targets []domain.TahfidzTarget
+	for _, studentID := range req.StudentIDs {
+		if seen[studentID] {
+			continue
+		}
+		seen[studentID] = true
+
+		student, err := s.studentRepo.FindByID(studentID)
+		if err != nil {
+			return 0, err
+		}
+		if student == nil {
+			return 0, apperrors.NewNotFoundError(fmt.Sprintf("Student %s not found", studentID))
+		}
+		targets = append(targets, domain.TahfidzTarget{
+			StudentID:      student.ID,
+			AcademicYearID: year.ID,
+			TargetJuz:      req.TargetJuz,
+			Notes:          req.Notes,
+		})
+	}
+
+	if err := s.tahfidzRepo.UpsertTargets(targets); err != nil {
+		return 0, err
+	}
+	return len(targets), nil
+}
+
+func (s *tahfidzService) GetProgress(studentID, academicYearID string) (*response.TahfidzProgressResponse, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		return nil, apperrors.NewNotFoundError("Student not found")
+	}
+
+	year, err := s.resolveAcademicYear(academicYearID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.tahfidzRepo.FindSessionsByStudents([]string{student.ID})
+	if err != nil {
+		return nil, err
+	}
+	targets, err := s.tahfidzRepo.FindTargets([]string{student.ID}, year.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *domain.TahfidzTarget
+	if len(targets) > 0 {
+		target = &targets[0]
+	}
+
+	return buildTahfidzProgress(student, year, sessions, target, time.Now()), nil
+}
+
+// GetReportCardSection meringkas progres tahfidz satu tahun ajaran dalam format bagian rapor
+func (s *tahfidzService) GetReportCardSection(studentID, academicYearID string) (*response.TahfidzReportCardSection, error) {
+	progress, err := s.GetProgress(studentID, academicYearID)
+	if err != nil {
+		return nil, err
+	}
+	return toTahfidzReportCardSection(progress), nil
+}
+
+// ExportToExcel menulis rekap progres per santri (sheet "Rekap Tahfidz") dan log setoran (sheet "Riwayat Setoran")
+func (s *tahfidzService) ExportToExcel(w io.Writer, req request.TahfidzExportRequest) error {
+	year, err := s.resolveAcademicYear(req.AcademicYearID)
+	if err != nil {
+		return err
+	}
+
+	var students []domain.Student
+	if req.ClassroomID != "" {
+		students, err = s.studentRepo.FindByClassroomID(req.ClassroomID)
+		if err != nil {
+			return err
+		}
+	} else {
+		ids, err := s.tahfidzRepo.FindStudentIDsByYear(year.ID)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			student, err := s.studentRepo.FindByID(id)
+			if err != nil {
+				return err
+			}
+			if student != nil {
+				students = append(students, *student)
+			}
+		}
+		sort.Slice(students, func(i, j int) bool { return students[i].FullName < students[j].FullName })
+	}
+
+	studentIDs := make([]string, 0, len(students))
+	for _, student := range students {
+		studentIDs = append(studentIDs, student.ID)
+	}
+	sessions, err := s.tahfidzRepo.FindSessionsByStudents(studentIDs)
+	if err != nil {
+		return err
+	}
+	targets, err := s.tahfidzRepo.FindTargets(studentIDs, year.ID)
+	if err != nil {
+		return err
+	}
+
+	sessionsByStudent := map[string][]domain.TahfidzSession{}
+	for _, session := range sessions {
+		sessionsByStudent[session.StudentID] = append(sessionsByStudent[session.StudentID], session)
+	}
+	targetByStudent := map[string]*domain.TahfidzTarget{}
+	for i := range targets {
+		targetByStudent[targets[i].StudentID] = &targets[i]
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sheet 1: rekap per santri
+	summarySheet := "Rekap Tahfidz"
+	f.SetSheetName("Sheet1", summarySheet)
+	summaryHeaders := []string{"No", "NISN", "Nama Lengkap", "Target (Juz)", "Capaian Tahun Ini (Juz)", "Capaian Target (%)",
+		"Total Hafalan (Juz)", "Juz Selesai", "Setoran", "Murajaah", "Predikat Rata-rata", "Laju", "Setoran Terakhir"}
+	if err := writeExcelHeader(f, summarySheet, summaryHeaders, headerStyle); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range students {
+		student := &students[i]
+		progress := buildTahfidzProgress(student, year, sessionsByStudent[student.ID], targetByStudent[student.ID], now)
+
+		target := ""
+		if progress.TargetJuz != nil {
+			target = fmt.Sprintf("%.2f", *progress.TargetJuz)
+		}
+		lastSession := ""
+		if progress.LastSessionDate != nil {
+			lastSession = progress.LastSessionDate.Format("2006-01-02")
+		}
+
+		row := []interface{}{
+			i + 1,
+			utils.SafeString(student.NISN),
+			student.FullName,
+			target,
+			progress.Year.JuzEquivalent,
+			progress.TargetPercent,
+			progress.JuzEquivalent,
+			joinInts(progress.JuzCompleted),
+			progress.Year.SetoranCount,
+			progress.Year.MurajaahCount,
+			progress.Year.AverageGrade,
+			progress.Pace,
+			lastSession,
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(summarySheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	// Sheet 2: riwayat setoran & murajaah di tahun ajaran ini
+	logSheet := "Riwayat Setoran"
+	if _, err := f.NewSheet(logSheet); err != nil {
+		return err
+	}
+	logHeaders := []string{"Tanggal", "NISN", "Nama Lengkap", "Jenis", "Dari", "Sampai", "Jumlah Ayat", "Juz", "Predikat", "Penguji", "Catatan"}
+	if err := writeExcelHeader(f, logSheet, logHeaders, headerStyle); err != nil {
+		return err
+	}
+
+	studentByID := map[string]*domain.Student{}
+	for i := range students {
+		studentByID[students[i].ID] = &students[i]
+	}
+	rowNum := 1
+	for i := range sessions {
+		session := &sessions[i]
+		if session.AcademicYearID != year.ID {
+			continue
+		}
+		student := studentByID[session.StudentID]
+		session.Student = student
+		item := toTahfidzSessionResponse(session)
+
+		juz := fmt.Sprintf("%d", session.JuzStart)
+		if session.JuzEnd != session.JuzStart {
+			juz = fmt.Sprintf("%d-%d", session.JuzStart, session.JuzEnd)
+		}
+
+		rowNum++
+		row := []interface{}{
+			session.SessionDate.Format("2006-01-02"),
+			utils.SafeString(student.NISN),
+			student.FullName,
+			session.Type,
+			fmt.Sprintf("%s %d", item.StartSurahName, session.StartAyah),
+			fmt.Sprintf("%s %d", item.EndSurahName, session.EndAyah),
+			session.AyahCount,
+			juz,
+			tahfidzGradeLabels[session.Grade],
+			item.ExaminerName,
+			utils.SafeString(session.Notes),
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		if err := f.SetSheetRow(logSheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	return f.Write(w)
+}
+
+func (s *tahfidzService) resolveAcademicYear(id string) (*domain.AcademicYear, error) {
+	if id == "" {
+		year, err := s.academicYearRepo.FindActive()
+		if err != nil {
+			return nil, err
+		}
+		if year == nil {
+			return nil, apperrors.NewBadRequestError("No active academic year, please specify academic_year_id")
+		}
+		return year, nil
+	}
+
+	year, err := s.academicYearRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if year == nil {
+		return nil, apperrors.NewNotFoundError("Academic year not found")
+	}
+	return year, nil
+}
+
+// buildTahfidzProgress menghitung cakupan hafalan kumulatif sampai akhir tahun ajaran, capaian tahun ini,
+// dan laju terhadap target. sessions harus sudah urut berdasarkan tanggal.
+func buildTahfidzProgress(student *domain.Student, year *domain.AcademicYear, sessions []domain.TahfidzSession, target *domain.TahfidzTarget, now time.Time) *response.TahfidzProgressResponse {
+	yearStart := year.StartDate.Format("2006-01-02")
+	yearEnd := year.EndDate.Format("2006-01-02")
+
+	var cumulative, prior quran.Coverage
+	result := &response.TahfidzProgressResponse{
+		StudentID:        student.ID,
+		StudentName:      student.FullName,
+		AcademicYearID:   year.ID,
+		AcademicYearName: year.Name,
+		Juz:              []response.TahfidzJuzProgress{},
+		Pace:             tahfidzPaceNoTarget,
+	}
+
+	gradeTotal, gradeCount := 0, 0
+	for i := range sessions {
+		session := &sessions[i]
+		date := session.SessionDate.Format("2006-01-02")
+		if date > yearEnd {
+			continue
+		}
+
+		if session.Type == domain.TahfidzTypeSetoran && session.Passed() {
+			cumulative.Add(session.AyahFrom, session.AyahTo)
+			if date < yearStart {
+				prior.Add(session.AyahFrom, session.AyahTo)
+			}
+		}
+
+		if session.AcademicYearID != year.ID {
+			continue
+		}
+		switch session.Type {
+		case domain.TahfidzTypeSetoran:
+			result.Year.SetoranCount++
+		case domain.TahfidzTypeMurajaah:
+			result.Year.MurajaahCount++
+		}
+		if !session.Passed() {
+			result.Year.RasibCount++
+		}
+		gradeTotal += tahfidzGradeScores[session.Grade]
+		gradeCount++
+		sessionDate := session.SessionDate
+		result.LastSessionDate = &sessionDate
+	}
+
+	result.MemorizedAyahs = cumulative.Count()
+	result.JuzEquivalent = round2(cumulative.JuzEquivalent())
+	result.JuzCompleted = cumulative.CompletedJuz()
+	for juz := 1; juz <= quran.TotalJuz; juz++ {
+		covered, total := cumulative.JuzAyahs(juz)
+		if covered == 0 {
+			continue
+		}
+		result.Juz = append(result.Juz, response.TahfidzJuzProgress{
+			Juz:            juz,
+			MemorizedAyahs: covered,
+			TotalAyahs:     total,
+			Percent:        round2(float64(covered) / float64(total) * 100),
+		})
+	}
+
+	result.Year.NewAyahs = cumulative.Count() - prior.Count()
+	result.Year.JuzEquivalent = round2(cumulative.JuzEquivalent() - prior.JuzEquivalent())
+	if gradeCount > 0 {
+		result.Year.AverageGrade = averageTahfidzGrade(float64(gradeTotal) / float64(gradeCount))
+	}
+
+	// Porsi tahun ajaran yang sudah berjalan dipakai sebagai capaian yang diharapkan
+	start, end := year.StartDate.ToTime(), year.EndDate.ToTime()
+	elapsed := 1.0
+	if end.After(start) {
+		elapsed = math.Min(math.Max(now.Sub(start).Hours()/end.Sub(start).Hours(), 0), 1)
+	}
+	result.ExpectedPercent = round2(elapsed * 100)
+
+	if target != nil && target.TargetJuz > 0 {
+		targetJuz := target.TargetJuz
+		result.TargetJuz = &targetJuz
+		result.TargetPercent = round2(result.Year.JuzEquivalent / targetJuz * 100)
+
+		switch {
+		case result.TargetPercent >= result.ExpectedPercent*1.1 || result.TargetPercent >= 100:
+			result.Pace = tahfidzPaceAhead
+		case result.TargetPercent >= result.ExpectedPercent*0.9:
+			result.Pace = tahfidzPaceOnTrack
+		default:
+			result.Pace = tahfidzPaceBehind
+		}
+	}
+
+	return result
+}
+
+func toTahfidzReportCardSection(progress *response.TahfidzProgressResponse) *response.TahfidzReportCardSection {
+	section := &response.TahfidzReportCardSection{
+		Title:            "Tahfidz Al-Qur'an",
+		AcademicYearName: progress.AcademicYearName,
+		TargetJuz:        progress.TargetJuz,
+		AchievedJuz:      progress.Year.JuzEquivalent,
+		TotalJuz:         progress.JuzEquivalent,
+		JuzCompleted:     progress.JuzCompleted,
+		AverageGrade:     progress.Year.AverageGrade,
+		SetoranCount:     progress.Year.SetoranCount,
+		MurajaahCount:    progress.Year.MurajaahCount,
+		Pace:             progress.Pace,
+	}
+
+	remarks := fmt.Sprintf("Pada tahun ajaran %s, ananda menambah hafalan %.2f juz", progress.AcademicYearName, progress.Year.JuzEquivalent)
+	if progress.TargetJuz != nil {
+		remarks += fmt.Sprintf(" dari target %.2f juz (%.0f%%)", *progress.TargetJuz, progress.TargetPercent)
+	}
+	remarks += fmt.Sprintf(". Total hafalan %.2f juz", progress.JuzEquivalent)
+	if len(progress.JuzCompleted) > 0 {
+		remarks += fmt.Sprintf(", dengan juz yang telah tuntas: %s", joinInts(progress.JuzCompleted))
+	}
+	remarks += "."
+	if progress.Year.AverageGrade != "" {
+		remarks += fmt.Sprintf(" Predikat rata-rata: %s.", progress.Year.AverageGrade)
+	}
+	section.Remarks = remarks
+
+	return section
+}
+
+func toTahfidzSessionResponse(session *domain.TahfidzSession) response.TahfidzSessionResponse {
+	startSurah, _ := quran.SurahByNumber(session.StartSurah)
+	endSurah, _ := quran.SurahByNumber(session.EndSurah)
+
+	result := response.TahfidzSessionResponse{
+		ID:             session.ID,
+		StudentID:      session.StudentID,
+		AcademicYearID: session.AcademicYearID,
+		SessionDate:    session.SessionDate,
+		Type:           session.Type,
+		StartSurah:     session.StartSurah,
+		StartSurahName: startSurah.Name,
+		StartAyah:      session.StartAyah,
+		EndSurah:       session.EndSurah,
+		EndSurahName:   endSurah.Name,
+		EndAyah:        session.EndAyah,
+		Range:          fmt.Sprintf("%s %d - %s %d", startSurah.Name, session.StartAyah, endSurah.Name, session.EndAyah),
+		AyahCount:      session.AyahCount,
+		JuzStart:       session.JuzStart,
+		JuzEnd:         session.JuzEnd,
+		Grade:          session.Grade,
+		ExaminerID:     session.ExaminerID,
+		Notes:          session.Notes,
+		CreatedAt:      session.CreatedAt,
+	}
+	if session.Student != nil {
+		result.StudentName = session.Student.FullName
+	}
+	if session.Examiner != nil {
+		result.ExaminerName = session.Examiner.FullName
+	}
+	return result
+}
+
+// averageTahfidzGrade membulatkan skor rata-rata ke predikat terdekat
+func averageTahfidzGrade(score float64) string {
+	rounded := int(math.Round(score))
+	for grade, value := range tahfidzGradeScores {
+		if value == rounded {
+			return tahfidzGradeLabels[grade]
+		}
+	}
+	return ""
+}
+
+func writeExcelHeader(f *excelize.File, sheet string, headers []string, style int) error {
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		if err := f.SetCellValue(sheet, cell, header); err != nil {
+			return err
+		}
+		if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+			return err
+		}
+		col, _ := excelize.ColumnNumberToName(i + 1)
+		if err := f.SetColWidth(sheet, col, col, float64(max(len(header), 10)+4)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		parts = append(parts, fmt.Sprintf("%d", v))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
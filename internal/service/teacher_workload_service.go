@@ -0,0 +1,419 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/domain"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+	"smart_school_be/internal/utils"
+	"smart_school_be/internal/workload"
+	"sort"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+type TeacherWorkloadService interface {
+	GetReport(req request.TeacherWorkloadRequest) (*response.TeacherWorkloadResponse, error)
+	ExportToExcel(w io.Writer, req request.TeacherWorkloadRequest) error
+	ExportToPdf(req request.TeacherWorkloadRequest) (*bytes.Buffer, error)
+}
+
+type teacherWorkloadService struct {
+	scheduleRepo           repository.ScheduleRepository
+	teachingAssignmentRepo repository.TeachingAssignmentRepository
+	attendanceRepo         repository.AttendanceRepository
+	academicYearRepo       repository.AcademicYearRepository
+	periodMinutes          int
+	minimumJP              int
+}
+
+func NewTeacherWorkloadService(
+	scheduleRepo repository.ScheduleRepository,
+	teachingAssignmentRepo repository.TeachingAssignmentRepository,
+	attendanceRepo repository.AttendanceRepository,
+	academicYearRepo repository.AcademicYearRepository,
+	periodMinutes int,
+	minimumJP int,
+) TeacherWorkloadService {
+	if periodMinutes <= 0 {
+		periodMinutes = workload.DefaultPeriodMinutes
+	}
+	if minimumJP < 0 {
+		minimumJP = workload.DefaultMinimumJP
+	}
+	return &teacherWorkloadService{
+		scheduleRepo:           scheduleRepo,
+		teachingAssignmentRepo: teachingAssignmentRepo,
+		attendanceRepo:         attendanceRepo,
+		academicYearRepo:       academicYearRepo,
+		periodMinutes:          periodMinutes,
+		minimumJP:              minimumJP,
+	}
+}
+
+var workloadStatusLabels = map[string]string{
+	workload.StatusBelowMinimum: "Kurang",
+	workload.StatusMeetsMinimum: "Memenuhi",
+}
+
+// GetReport menghitung JP mingguan tiap guru dari jadwal tahun ajaran, lalu membandingkan pertemuan
+// terjadwal dengan sesi absensi yang benar-benar diisi per bulan. Guru yang punya penugasan mengajar
+// tetapi belum dijadwalkan tetap muncul dengan 0 JP.
+func (s *teacherWorkloadService) GetReport(req request.TeacherWorkloadRequest) (*response.TeacherWorkloadResponse, error) {
+	year, err := s.resolveAcademicYear(req.AcademicYearID)
+	if err != nil {
+		return nil, err
+	}
+	minimumJP := s.minimumJP
+	if req.MinimumJP != nil {
+		minimumJP = *req.MinimumJP
+	}
+
+	// Rentang realisasi: seluruh tahun ajaran atau satu bulan, dibatasi sampai hari ini
+	start, end := time.Time(year.StartDate), time.Time(year.EndDate)
+	from := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local)
+	to := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.Local)
+	if req.Month != "" {
+		month, err := time.ParseInLocation("2006-01", req.Month, time.Local)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("Invalid month format, use YYYY-MM")
+		}
+		monthEnd := month.AddDate(0, 1, -1)
+		if monthEnd.Before(from) || month.After(to) {
+			return nil, apperrors.NewBadRequestError("month is outside the academic year")
+		}
+		if month.After(from) {
+			from = month
+		}
+		if monthEnd.Before(to) {
+			to = monthEnd
+		}
+	}
+	now := time.Now()
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local); to.After(today) {
+		to = today
+	}
+	var months []workload.Month
+	if !from.After(to) {
+		months = workload.Months(from, to)
+	}
+
+	assignments, err := s.teachingAssignmentRepo.FindByAcademicYear(year.ID, req.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := s.scheduleRepo.FindByAcademicYear(year.ID, req.TeacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sesi absensi per jadwal per bulan
+	realized := map[string]map[string]int{}
+	if len(months) > 0 {
+		scheduleIDs := make([]string, 0, len(schedules))
+		for _, schedule := range schedules {
+			scheduleIDs = append(scheduleIDs, schedule.ID)
+		}
+		sessions, err := s.attendanceRepo.FindSessionDates(scheduleIDs, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range sessions {
+			if realized[session.ScheduleID] == nil {
+				realized[session.ScheduleID] = map[string]int{}
+			}
+			realized[session.ScheduleID][time.Time(session.Date).Format("2006-01")]++
+		}
+	}
+
+	res := &response.TeacherWorkloadResponse{
+		AcademicYearID:   year.ID,
+		AcademicYearName: year.Name,
+		PeriodMinutes:    s.periodMinutes,
+		MinimumJP:        minimumJP,
+		From:             from.Format("2006-01-02"),
+		To:               to.Format("2006-01-02"),
+		Teachers:         []response.TeacherWorkloadItem{},
+	}
+
+	items := map[string]*response.TeacherWorkloadItem{}
+	assignmentIndex := map[string]int{} // teaching_assignment_id -> index di item.Assignments
+	addAssignment := func(ta *domain.TeachingAssignment) *response.TeacherWorkloadItem {
+		item, ok := items[ta.TeacherID]
+		if !ok {
+			item = &response.TeacherWorkloadItem{
+				TeacherID:   ta.TeacherID,
+				FullName:    ta.Teacher.FullName,
+				NIP:         ta.Teacher.NIP,
+				Assignments: []response.TeacherWorkloadAssignment{},
+				Months:      make([]response.TeacherWorkloadMonth, len(months)),
+			}
+			for i, month := range months {
+				item.Months[i].Month = month.Key
+			}
+			items[ta.TeacherID] = item
+		}
+		if _, ok := assignmentIndex[ta.ID]; !ok {
+			assignmentIndex[ta.ID] = len(item.Assignments)
+			item.Assignments = append(item.Assignments, response.TeacherWorkloadAssignment{
+				TeachingAssignmentID: ta.ID,
+				ClassroomName:        ta.Classroom.Name,
+				SubjectName:          ta.Subject.Name,
+			})
+		}
+		return item
+	}
+
+	for i := range assignments {
+		addAssignment(&assignments[i])
+	}
+	for i := range schedules {
+		schedule := &schedules[i]
+		periods, err := workload.Periods(schedule.StartTime, schedule.EndTime, s.periodMinutes)
+		if err != nil {
+			res.Summary.InvalidSchedules++
+			continue
+		}
+		item := addAssignment(&schedule.TeachingAssignment)
+		assignment := &item.Assignments[assignmentIndex[schedule.TeachingAssignmentID]]
+		assignment.WeeklySessions++
+		assignment.WeeklyJP += periods
+		item.WeeklySessions++
+		item.WeeklyJP += periods
+
+		for m, month := range months {
+			scheduled := workload.CountWeekday(schedule.DayOfWeek, month.From, month.To)
+			done := realized[schedule.ID][month.Key]
+			item.Months[m].ScheduledSessions += scheduled
+			item.Months[m].ScheduledJP += scheduled * periods
+			item.Months[m].RealizedSessions += done
+			item.Months[m].RealizedJP += done * periods
+		}
+	}
+
+	for _, item := range items {
+		item.Status = workload.Status(item.WeeklyJP, minimumJP)
+		item.ShortfallJP = workload.Shortfall(item.WeeklyJP, minimumJP)
+		for m := range item.Months {
+			month := &item.Months[m]
+			month.RealizationPercent = workload.Percent(month.RealizedJP, month.ScheduledJP)
+			item.ScheduledJP += month.ScheduledJP
+			item.RealizedJP += month.RealizedJP
+		}
+		item.RealizationPercent = workload.Percent(item.RealizedJP, item.ScheduledJP)
+		sort.Slice(item.Assignments, func(i, j int) bool {
+			if item.Assignments[i].ClassroomName != item.Assignments[j].ClassroomName {
+				return item.Assignments[i].ClassroomName < item.Assignments[j].ClassroomName
+			}
+			return item.Assignments[i].SubjectName < item.Assignments[j].SubjectName
+		})
+
+		res.Teachers = append(res.Teachers, *item)
+		res.Summary.Teachers++
+		res.Summary.TotalWeeklyJP += item.WeeklyJP
+		if item.Status == workload.StatusBelowMinimum {
+			res.Summary.BelowMinimum++
+		}
+	}
+	sort.Slice(res.Teachers, func(i, j int) bool { return res.Teachers[i].FullName < res.Teachers[j].FullName })
+	return res, nil
+}
+
+// ExportToExcel menulis laporan beban mengajar: rekap per guru, rincian per kelas dan realisasi bulanan
+func (s *teacherWorkloadService) ExportToExcel(w io.Writer, req request.TeacherWorkloadRequest) error {
+	report, err := s.GetReport(req)
+	if err != nil {
+		return err
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Color: []string{"#FFFF00"}, Pattern: 1},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Sheet 1: rekap per guru
+	summarySheet := "Beban Mengajar"
+	f.SetSheetName("Sheet1", summarySheet)
+	summaryHeaders := []string{"No", "NIP", "Nama Guru", "Pertemuan/Minggu", "JP/Minggu", "Beban Minimum (JP)", "Kekurangan (JP)",
+		"Status", "JP Terjadwal", "JP Terealisasi", "Realisasi (%)"}
+	if err := writeExcelHeader(f, summarySheet, summaryHeaders, headerStyle); err != nil {
+		return err
+	}
+	for i, item := range report.Teachers {
+		row := []interface{}{
+			i + 1,
+			utils.SafeString(item.NIP),
+			item.FullName,
+			item.WeeklySessions,
+			item.WeeklyJP,
+			report.MinimumJP,
+			item.ShortfallJP,
+			workloadStatusLabels[item.Status],
+			item.ScheduledJP,
+			item.RealizedJP,
+			item.RealizationPercent,
+		}
+		cell, _ := excelize.CoordinatesToCellName(1, i+2)
+		if err := f.SetSheetRow(summarySheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	// Sheet 2: rincian per kelas & mapel
+	assignmentSheet := "Rincian Kelas"
+	if _, err := f.NewSheet(assignmentSheet); err != nil {
+		return err
+	}
+	assignmentHeaders := []string{"NIP", "Nama Guru", "Kelas", "Mata Pelajaran", "Pertemuan/Minggu", "JP/Minggu"}
+	if err := writeExcelHeader(f, assignmentSheet, assignmentHeaders, headerStyle); err != nil {
+		return err
+	}
+	rowNum := 1
+	for _, item := range report.Teachers {
+		for _, assignment := range item.Assignments {
+			rowNum++
+			row := []interface{}{utils.SafeString(item.NIP), item.FullName, assignment.ClassroomName, assignment.SubjectName,
+				assignment.WeeklySessions, assignment.WeeklyJP}
+			cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+			if err := f.SetSheetRow(assignmentSheet, cell, &row); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Sheet 3: realisasi bulanan dari sesi absensi
+	monthSheet := "Realisasi Bulanan"
+	if _, err := f.NewSheet(monthSheet); err != nil {
+		return err
+	}
+	monthHeaders := []string{"NIP", "Nama Guru", "Bulan", "Pertemuan Terjadwal", "JP Terjadwal", "Pertemuan Terealisasi",
+		"JP Terealisasi", "Realisasi (%)"}
+	if err := writeExcelHeader(f, monthSheet, monthHeaders, headerStyle); err != nil {
+		return err
+	}
+	rowNum = 1
+	for _, item := range report.Teachers {
+		for _, month := range item.Months {
+			rowNum++
+			row := []interface{}{utils.SafeString(item.NIP), item.FullName, month.Month, month.ScheduledSessions, month.ScheduledJP,
+				month.RealizedSessions, month.RealizedJP, month.RealizationPercent}
+			cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+			if err := f.SetSheetRow(monthSheet, cell, &row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+// ExportToPdf membuat laporan beban mengajar per guru (landscape A4)
+func (s *teacherWorkloadService) ExportToPdf(req request.TeacherWorkloadRequest) (*bytes.Buffer, error) {
+	report, err := s.GetReport(req)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	// Judul
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, "LAPORAN BEBAN MENGAJAR GURU", "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("Tahun Ajaran %s, realisasi %s s.d. %s", report.AcademicYearName, report.From, report.To)), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("1 JP = %d menit, beban minimum %d JP/minggu", report.PeriodMinutes, report.MinimumJP), "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	headers := []string{"No", "NIP", "Nama Guru", "Pertemuan/Mg", "JP/Mg", "Kurang (JP)", "Status", "JP Terjadwal", "JP Terealisasi", "Realisasi (%)"}
+	widths := []float64{10, 35, 70, 24, 18, 22, 24, 24, 26, 24}
+	aligns := []string{"C", "L", "L", "C", "C", "C", "C", "C", "C", "C"}
+
+	writeHeader := func() {
+		pdf.SetFont("Arial", "B", 9)
+		pdf.SetFillColor(240, 240, 240) // Abu-abu muda
+		for i, header := range headers {
+			pdf.CellFormat(widths[i], 9, header, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Arial", "", 9)
+	}
+	writeHeader()
+
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	for i, item := range report.Teachers {
+		if pdf.GetY()+8 > pageHeight-bottom {
+			pdf.AddPage()
+			writeHeader()
+		}
+		values := []string{
+			fmt.Sprintf("%d", i+1),
+			utils.SafeString(item.NIP),
+			item.FullName,
+			fmt.Sprintf("%d", item.WeeklySessions),
+			fmt.Sprintf("%d", item.WeeklyJP),
+			fmt.Sprintf("%d", item.ShortfallJP),
+			workloadStatusLabels[item.Status],
+			fmt.Sprintf("%d", item.ScheduledJP),
+			fmt.Sprintf("%d", item.RealizedJP),
+			fmt.Sprintf("%.2f", item.RealizationPercent),
+		}
+		for c, value := range values {
+			text := tr(value)
+			// Potong teks yang terlalu panjang agar tidak menabrak kolom sebelah
+			for len(text) > 0 && pdf.GetStringWidth(text) > widths[c]-2 {
+				text = text[:len(text)-1]
+			}
+			pdf.CellFormat(widths[c], 8, text, "1", 0, aligns[c], false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	// Ringkasan
+	pdf.Ln(4)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Jumlah guru: %d, di bawah beban minimum: %d, total %d JP/minggu",
+		report.Summary.Teachers, report.Summary.BelowMinimum, report.Summary.TotalWeeklyJP), "", 1, "L", false, 0, "")
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+func (s *teacherWorkloadService) resolveAcademicYear(id string) (*domain.AcademicYear, error) {
+	if id == "" {
+		year, err := s.academicYearRepo.FindActive()
+		if err != nil {
+			return nil, err
+		}
+		if year == nil {
+			return nil, apperrors.NewBadRequestError("No active academic year, please specify academic_year_id")
+		}
+		return year, nil
+	}
+
+	year, err := s.academicYearRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if year == nil {
+		return nil, apperrors.NewNotFoundError("Academic year not found")
+	}
+	return year, nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"smart_school_be/internal/apperrors"
+	"smart_school_be/internal/model/request"
+	"smart_school_be/internal/model/response"
+	"smart_school_be/internal/repository"
+)
+
+type TrashService interface {
+	GetTrash(entityType string, pagination request.PaginationRequest) (*response.PaginatedData, error)
+	Restore(entityType string, id string) error
+	Purge(retention time.Duration) (*response.TrashPurgeResponse, error)
+}
+
+type trashService struct {
+	trashRepo repository.TrashRepository
+}
+
+func NewTrashService(trashRepo repository.TrashRepository) TrashService {
+	return &trashService{trashRepo: trashRepo}
+}
+
+func (s *trashService) resolveEntity(entityType string) (repository.TrashEntity, error) {
+	entity, ok := repository.TrashEntities[entityType]
+	if !ok {
+		return repository.TrashEntity{}, apperrors.NewBadRequestError(fmt.Sprintf("unsupported trash type: %s", entityType))
+	}
+	return entity, nil
+}
+
+// GetTrash menampilkan data yang sudah di-soft delete untuk satu tipe entitas
+func (s *trashService) GetTrash(entityType string, pagination request.PaginationRequest) (*response.PaginatedData, error) {
+	entity, err := s.resolveEntity(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := pagination.GetLimit()
+	offset := pagination.GetOffset()
+
+	items, total, err := s.trashRepo.FindDeleted(entity, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]response.TrashItemResponse, 0, len(items))
+	for _, item := range items {
+		data = append(data, response.TrashItemResponse{
+			ID:        item.ID,
+			Type:      entityType,
+			Label:     item.Label,
+			DeletedAt: item.DeletedAt,
+		})
+	}
+
+	paginatedData := response.NewPaginatedData(data, total, pagination.GetPage(), limit)
+	return &paginatedData, nil
+}
+
+// Restore mengembalikan data dari trash
+func (s *trashService) Restore(entityType string, id string) error {
+	entity, err := s.resolveEntity(entityType)
+	if err != nil {
+		return err
+	}
+
+	restored, err := s.trashRepo.Restore(entity, id)
+	if err != nil {
+		return err
+	}
+	if !restored {
+		return apperrors.NewNotFoundError("Record not found in trash")
+	}
+	return nil
+}
+
+// Purge menghapus permanen semua data di trash yang lebih lama dari retention.
+// Kegagalan satu tabel (mis. employee yang masih dirujuk donasi) tidak menghentikan tabel lain.
+func (s *trashService) Purge(retention time.Duration) (*response.TrashPurgeResponse, error) {
+	if retention < 0 {
+		return nil, apperrors.NewBadRequestError("retention must not be negative")
+	}
+
+	cutoff := time.Now().Add(-retention)
+	result := &response.TrashPurgeResponse{
+		Cutoff: cutoff,
+		Purged: make(map[string]int64),
+		Kept:   make(map[string]int64),
+	}
+
+	var failed []string
+	for _, entityType := range repository.TrashPurgeOrder {
+		count, kept, err := s.trashRepo.PurgeBefore(repository.TrashEntities[entityType], cutoff)
+		if err != nil {
+			log.Printf("Failed to purge %s: %v", entityType, err)
+			failed = append(failed, entityType)
+			continue
+		}
+		result.Purged[entityType] = count
+		if kept > 0 {
+			result.Kept[entityType] = kept
+		}
+	}
+
+	if len(failed) > 0 {
+		return result, fmt.Errorf("failed to purge: %v", failed)
+	}
+	return result, nil
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
@@ -11,6 +12,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// UploadDir adalah root penyimpanan file upload
+const UploadDir = "./storage/uploads"
+
 // EnsureDir memastikan folder tujuan ada
 func EnsureDir(path string) error {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -33,7 +37,7 @@ func SaveUploadedFile(c *gin.Context, file *multipart.FileHeader, destFolder str
 	}
 
 	// 3. Buat Folder jika belum ada
-	uploadPath := fmt.Sprintf("./storage/uploads/%s", destFolder)
+	uploadPath := fmt.Sprintf("%s/%s", UploadDir, destFolder)
 	if err := EnsureDir(uploadPath); err != nil {
 		return "", err
 	}
@@ -59,6 +63,28 @@ func RemoveFile(filePath string) {
 		return
 	}
 	// Sesuaikan dengan root storage path Anda
-	fullPath := fmt.Sprintf("./storage/uploads/%s", filePath)
+	fullPath := fmt.Sprintf("%s/%s", UploadDir, filePath)
 	os.Remove(fullPath)
 }
+
+// StoreFile menyimpan isi src ke folder upload dengan nama unik tanpa validasi tipe/ukuran
+// (validasi dilakukan pemanggil). Mengembalikan path relatif seperti SaveUploadedFile.
+func StoreFile(src io.Reader, destFolder string, prefix string, ext string) (string, error) {
+	uploadPath := fmt.Sprintf("%s/%s", UploadDir, destFolder)
+	if err := EnsureDir(uploadPath); err != nil {
+		return "", err
+	}
+
+	filename := fmt.Sprintf("%s_%d%s", prefix, time.Now().UnixNano(), strings.ToLower(ext))
+	dst, err := os.Create(filepath.Join(uploadPath, filename))
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		os.Remove(dst.Name())
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", destFolder, filename), nil
+}
@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"strings"
 )
 
 // HashToken creates a hash of the token for storage
@@ -10,3 +13,32 @@ func HashToken(token string) string {
 	hash := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(hash[:])
 }
+
+// studentCardTokenPrefix menandai versi format token kartu pelajar
+const studentCardTokenPrefix = "SC1"
+
+// SignStudentCardToken membuat token kartu pelajar "SC1.<student_id>.<signature>".
+// Token tidak kedaluwarsa; kartu dinyatakan tidak berlaku lewat status siswa.
+func SignStudentCardToken(secret, studentID string) string {
+	return studentCardTokenPrefix + "." + studentID + "." + studentCardSignature(secret, studentID)
+}
+
+// VerifyStudentCardToken memeriksa tanda tangan token dan mengembalikan ID siswa jika valid
+func VerifyStudentCardToken(secret, token string) (string, bool) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 || parts[0] != studentCardTokenPrefix || parts[1] == "" {
+		return "", false
+	}
+	expected := studentCardSignature(secret, parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expected)) {
+		return "", false
+	}
+	return parts[1], true
+}
+
+func studentCardSignature(secret, studentID string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(studentCardTokenPrefix + ":" + studentID))
+	// 16 byte cukup untuk mencegah pemalsuan dan menjaga QR tetap kecil
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,227 @@
+// Package validation memeriksa struktur nomor identitas kependudukan Indonesia (NIK, No KK, NISN)
+// dan kecocokannya dengan data lain (tanggal lahir, jenis kelamin) sebelum dienkripsi dan disimpan.
+package validation
+
+import (
+	"fmt"
+	"log"
+	"smart_school_be/internal/apperrors"
+	"strings"
+	"time"
+)
+
+// Mode validasi identitas
+const (
+	ModeStrict = "strict" // Data yang tidak valid ditolak
+	ModeWarn   = "warn"   // Data tetap disimpan, masalah hanya dicatat sebagai peringatan
+)
+
+// provinceCodes adalah kode wilayah provinsi Kemendagri (2 digit pertama NIK/No KK),
+// termasuk kode provinsi Papua hasil pemekaran.
+var provinceCodes = map[string]string{
+	"11": "Aceh", "12": "Sumatera Utara", "13": "Sumatera Barat", "14": "Riau", "15": "Jambi",
+	"16": "Sumatera Selatan", "17": "Bengkulu", "18": "Lampung", "19": "Kepulauan Bangka Belitung",
+	"21": "Kepulauan Riau", "31": "DKI Jakarta", "32": "Jawa Barat", "33": "Jawa Tengah",
+	"34": "DI Yogyakarta", "35": "Jawa Timur", "36": "Banten", "51": "Bali",
+	"52": "Nusa Tenggara Barat", "53": "Nusa Tenggara Timur", "61": "Kalimantan Barat",
+	"62": "Kalimantan Tengah", "63": "Kalimantan Selatan", "64": "Kalimantan Timur",
+	"65": "Kalimantan Utara", "71": "Sulawesi Utara", "72": "Sulawesi Tengah",
+	"73": "Sulawesi Selatan", "74": "Sulawesi Tenggara", "75": "Gorontalo", "76": "Sulawesi Barat",
+	"81": "Maluku", "82": "Maluku Utara", "91": "Papua", "92": "Papua Barat",
+	"93": "Papua Selatan", "94": "Papua Tengah", "95": "Papua Pegunungan", "96": "Papua Barat Daya",
+}
+
+// Issue adalah satu temuan validasi pada field tertentu
+type Issue struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return i.Field + ": " + i.Message
+}
+
+// NIKInfo adalah isi NIK yang sudah diurai
+type NIKInfo struct {
+	ProvinceCode string
+	RegencyCode  string // 2 digit kabupaten/kota
+	DistrictCode string // 2 digit kecamatan
+	BirthDay     int
+	BirthMonth   int
+	BirthYear2   int  // 2 digit terakhir tahun lahir (abad tidak tersimpan di NIK)
+	Female       bool // Tanggal lahir ditambah 40 untuk perempuan
+	Serial       string
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func atoi2(s string) int {
+	return int(s[0]-'0')*10 + int(s[1]-'0')
+}
+
+// validRegionPrefix memeriksa 6 digit kode wilayah (provinsi, kab/kota, kecamatan)
+func validRegionPrefix(number string) error {
+	if _, ok := provinceCodes[number[0:2]]; !ok {
+		return fmt.Errorf("unknown province code %s", number[0:2])
+	}
+	if number[2:4] == "00" {
+		return fmt.Errorf("invalid regency code %s", number[2:4])
+	}
+	if number[4:6] == "00" {
+		return fmt.Errorf("invalid district code %s", number[4:6])
+	}
+	return nil
+}
+
+// ParseNIK mengurai NIK 16 digit: PPKKCC DDMMYY SSSS.
+// DD ditambah 40 untuk perempuan; SSSS adalah nomor urut dan tidak boleh 0000.
+func ParseNIK(nik string) (*NIKInfo, error) {
+	if len(nik) != 16 || !isDigits(nik) {
+		return nil, fmt.Errorf("must be 16 digits")
+	}
+	if err := validRegionPrefix(nik); err != nil {
+		return nil, err
+	}
+
+	info := &NIKInfo{
+		ProvinceCode: nik[0:2],
+		RegencyCode:  nik[2:4],
+		DistrictCode: nik[4:6],
+		BirthDay:     atoi2(nik[6:8]),
+		BirthMonth:   atoi2(nik[8:10]),
+		BirthYear2:   atoi2(nik[10:12]),
+		Serial:       nik[12:16],
+	}
+	if info.BirthDay > 40 {
+		info.Female = true
+		info.BirthDay -= 40
+	}
+	if info.BirthDay < 1 || info.BirthDay > 31 || info.BirthMonth < 1 || info.BirthMonth > 12 {
+		return nil, fmt.Errorf("invalid encoded birth date %s", nik[6:12])
+	}
+	// Abad tidak diketahui; tahun 20YY dipakai karena kabisatnya sama untuk 00-99
+	date := time.Date(2000+info.BirthYear2, time.Month(info.BirthMonth), info.BirthDay, 0, 0, 0, 0, time.UTC)
+	if date.Day() != info.BirthDay {
+		return nil, fmt.Errorf("invalid encoded birth date %s", nik[6:12])
+	}
+	if info.Serial == "0000" {
+		return nil, fmt.Errorf("invalid serial number 0000")
+	}
+	return info, nil
+}
+
+// ValidateNoKK memeriksa nomor Kartu Keluarga: 16 digit dengan kode wilayah yang valid
+func ValidateNoKK(noKK string) error {
+	if len(noKK) != 16 || !isDigits(noKK) {
+		return fmt.Errorf("must be 16 digits")
+	}
+	return validRegionPrefix(noKK)
+}
+
+// ValidateNISN memeriksa NISN: 10 digit angka
+func ValidateNISN(nisn string) error {
+	if len(nisn) != 10 || !isDigits(nisn) {
+		return fmt.Errorf("must be 10 digits")
+	}
+	return nil
+}
+
+// Person adalah data identitas yang diperiksa. Field kosong/nil dilewati.
+type Person struct {
+	NIK         string
+	NoKK        string
+	NISN        string
+	Gender      string // male / female
+	DateOfBirth *time.Time
+}
+
+// Check mengembalikan semua masalah struktur dan ketidakcocokan antar field
+func Check(p Person) []Issue {
+	var issues []Issue
+
+	if nik := strings.TrimSpace(p.NIK); nik != "" {
+		info, err := ParseNIK(nik)
+		if err != nil {
+			issues = append(issues, Issue{Field: "nik", Message: err.Error()})
+		} else {
+			if p.DateOfBirth != nil && !p.DateOfBirth.IsZero() {
+				dob := *p.DateOfBirth
+				if dob.Day() != info.BirthDay || int(dob.Month()) != info.BirthMonth || dob.Year()%100 != info.BirthYear2 {
+					issues = append(issues, Issue{
+						Field:   "nik",
+						Message: fmt.Sprintf("encoded birth date %02d-%02d-%02d does not match date_of_birth %s", info.BirthDay, info.BirthMonth, info.BirthYear2, dob.Format("02-01-2006")),
+					})
+				}
+			}
+			if (p.Gender == "female" && !info.Female) || (p.Gender == "male" && info.Female) {
+				encoded := "male"
+				if info.Female {
+					encoded = "female"
+				}
+				issues = append(issues, Issue{Field: "nik", Message: fmt.Sprintf("encoded gender %s does not match gender %s", encoded, p.Gender)})
+			}
+		}
+	}
+
+	if noKK := strings.TrimSpace(p.NoKK); noKK != "" {
+		if err := ValidateNoKK(noKK); err != nil {
+			issues = append(issues, Issue{Field: "no_kk", Message: err.Error()})
+		}
+	}
+
+	if nisn := strings.TrimSpace(p.NISN); nisn != "" {
+		if err := ValidateNISN(nisn); err != nil {
+			issues = append(issues, Issue{Field: "nisn", Message: err.Error()})
+		}
+	}
+
+	return issues
+}
+
+// IdentityValidator menerapkan Check sesuai mode dari konfigurasi
+type IdentityValidator struct {
+	mode string
+}
+
+// NewIdentityValidator membuat validator; mode selain "warn" diperlakukan sebagai strict
+func NewIdentityValidator(mode string) *IdentityValidator {
+	if strings.ToLower(strings.TrimSpace(mode)) == ModeWarn {
+		return &IdentityValidator{mode: ModeWarn}
+	}
+	return &IdentityValidator{mode: ModeStrict}
+}
+
+// Strict mengembalikan true jika data yang tidak valid harus ditolak
+func (v *IdentityValidator) Strict() bool {
+	return v.mode == ModeStrict
+}
+
+// Validate memeriksa data identitas. Mode strict mengembalikan BadRequest berisi semua masalah;
+// mode warn mencatat masalah ke log dan mengembalikannya sebagai peringatan.
+func (v *IdentityValidator) Validate(subject string, p Person) ([]string, error) {
+	issues := Check(p)
+	if len(issues) == 0 {
+		return nil, nil
+	}
+
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.String()
+	}
+	if v.Strict() {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("Invalid %s identity data: %s", subject, strings.Join(messages, "; ")))
+	}
+
+	log.Printf("identity validation warning (%s): %s", subject, strings.Join(messages, "; "))
+	return messages, nil
+}
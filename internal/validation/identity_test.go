@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(s string) *time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return &t
+}
+
+// Test NIK laki-laki: tanggal lahir apa adanya
+func TestParseNIK_Male(t *testing.T) {
+	info, err := ParseNIK("3201021505080001")
+	assert.NoError(t, err)
+	assert.Equal(t, "32", info.ProvinceCode)
+	assert.Equal(t, 15, info.BirthDay)
+	assert.Equal(t, 5, info.BirthMonth)
+	assert.Equal(t, 8, info.BirthYear2)
+	assert.False(t, info.Female)
+}
+
+// Test NIK perempuan: tanggal lahir ditambah 40
+func TestParseNIK_Female(t *testing.T) {
+	info, err := ParseNIK("3201025505080001")
+	assert.NoError(t, err)
+	assert.Equal(t, 15, info.BirthDay)
+	assert.True(t, info.Female)
+}
+
+// Test struktur NIK yang salah ditolak
+func TestParseNIK_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"too short":       "320102150508001",
+		"non digit":       "32010215050800A1",
+		"unknown region":  "9901021505080001",
+		"zero regency":    "3200021505080001",
+		"invalid day":     "3201023205080001",
+		"invalid month":   "3201021513080001",
+		"30 february":     "3201023002080001",
+		"zero serial":     "3201021505080000",
+		"female day > 71": "3201027205080001",
+	}
+	for name, nik := range cases {
+		_, err := ParseNIK(nik)
+		assert.Error(t, err, name)
+	}
+}
+
+// Test 29 Februari valid hanya pada tahun kabisat
+func TestParseNIK_LeapDay(t *testing.T) {
+	_, err := ParseNIK("3201022902080001")
+	assert.NoError(t, err)
+	_, err = ParseNIK("3201022902090001")
+	assert.Error(t, err)
+}
+
+// Test kecocokan NIK dengan tanggal lahir dan jenis kelamin
+func TestCheck_CrossField(t *testing.T) {
+	assert.Empty(t, Check(Person{NIK: "3201025505080001", Gender: "female", DateOfBirth: date("2008-05-15")}))
+
+	issues := Check(Person{NIK: "3201025505080001", Gender: "male", DateOfBirth: date("2008-05-16")})
+	assert.Len(t, issues, 2)
+}
+
+// Test panjang No KK dan NISN
+func TestCheck_NoKKAndNISN(t *testing.T) {
+	assert.Empty(t, Check(Person{NoKK: "3201020101200003", NISN: "0081234567"}))
+
+	issues := Check(Person{NoKK: "12345", NISN: "12345"})
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "no_kk", issues[0].Field)
+	assert.Equal(t, "nisn", issues[1].Field)
+}
+
+// Test mode warn tidak menolak data, mode strict menolak
+func TestIdentityValidator_Modes(t *testing.T) {
+	p := Person{NIK: "123"}
+
+	warnings, err := NewIdentityValidator(ModeWarn).Validate("student", p)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	_, err = NewIdentityValidator("").Validate("student", p)
+	assert.Error(t, err)
+}
+
+// Test label provinsi Papua hasil pemekaran sesuai kode Kemendagri
+func TestProvinceCodes_Papua(t *testing.T) {
+	expected := map[string]string{
+		"91": "Papua",
+		"92": "Papua Barat",
+		"93": "Papua Selatan",
+		"94": "Papua Tengah",
+		"95": "Papua Pegunungan",
+		"96": "Papua Barat Daya",
+	}
+	for code, name := range expected {
+		assert.Equal(t, name, provinceCodes[code], "province %s", code)
+	}
+
+	seen := map[string]string{}
+	for code, name := range provinceCodes {
+		if other, ok := seen[name]; ok {
+			t.Errorf("province %q is used by both %s and %s", name, other, code)
+		}
+		seen[name] = code
+	}
+}
@@ -0,0 +1,131 @@
+// Package workload menghitung beban mengajar guru dalam jam pelajaran (JP) dari jadwal mingguan:
+// durasi jadwal dikonversi ke JP, jumlah pertemuan terjadwal dihitung per rentang tanggal,
+// lalu dibandingkan dengan beban minimum (mis. 24 JP per minggu).
+package workload
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Status pemenuhan beban minimum
+const (
+	StatusBelowMinimum = "BELOW_MINIMUM"
+	StatusMeetsMinimum = "MEETS_MINIMUM"
+)
+
+// Default yang dipakai jika konfigurasi tidak diisi
+const (
+	DefaultPeriodMinutes = 45
+	DefaultMinimumJP     = 24
+)
+
+// Month adalah satu bulan kalender yang sudah dipotong ke rentang laporan
+type Month struct {
+	Key  string // YYYY-MM
+	From time.Time
+	To   time.Time
+}
+
+// Periods mengonversi jam mulai-selesai ("HH:MM" atau "HH:MM:SS") menjadi JP, dibulatkan ke JP
+// terdekat dengan minimal 1 JP untuk jadwal yang durasinya positif
+func Periods(start, end string, periodMinutes int) (int, error) {
+	if periodMinutes <= 0 {
+		return 0, fmt.Errorf("period minutes must be positive")
+	}
+	startMin, err := parseClock(start)
+	if err != nil {
+		return 0, err
+	}
+	endMin, err := parseClock(end)
+	if err != nil {
+		return 0, err
+	}
+	if endMin <= startMin {
+		return 0, fmt.Errorf("end time %q must be after start time %q", end, start)
+	}
+	periods := int(math.Round(float64(endMin-startMin) / float64(periodMinutes)))
+	if periods < 1 {
+		periods = 1
+	}
+	return periods, nil
+}
+
+// CountWeekday menghitung berapa kali hari ISO (1=Senin ... 7=Minggu) muncul di rentang tanggal (inklusif)
+func CountWeekday(isoDay int, from, to time.Time) int {
+	from = dateOnly(from)
+	to = dateOnly(to)
+	if isoDay < 1 || isoDay > 7 || to.Before(from) {
+		return 0
+	}
+	offset := (isoDay - isoWeekday(from) + 7) % 7
+	first := from.AddDate(0, 0, offset)
+	if first.After(to) {
+		return 0
+	}
+	days := int(to.Sub(first).Hours()/24 + 0.5)
+	return days/7 + 1
+}
+
+// Months memecah rentang tanggal menjadi bulan kalender; bulan pertama dan terakhir dipotong ke rentang
+func Months(from, to time.Time) []Month {
+	from = dateOnly(from)
+	to = dateOnly(to)
+	var months []Month
+	for start := from; !start.After(to); {
+		end := time.Date(start.Year(), start.Month()+1, 1, 0, 0, 0, 0, start.Location()).AddDate(0, 0, -1)
+		if end.After(to) {
+			end = to
+		}
+		months = append(months, Month{Key: start.Format("2006-01"), From: start, To: end})
+		start = end.AddDate(0, 0, 1)
+	}
+	return months
+}
+
+// Status membandingkan JP mingguan dengan beban minimum
+func Status(weeklyJP, minimumJP int) string {
+	if weeklyJP < minimumJP {
+		return StatusBelowMinimum
+	}
+	return StatusMeetsMinimum
+}
+
+// Shortfall adalah kekurangan JP terhadap beban minimum (0 jika terpenuhi)
+func Shortfall(weeklyJP, minimumJP int) int {
+	if weeklyJP >= minimumJP {
+		return 0
+	}
+	return minimumJP - weeklyJP
+}
+
+// Percent menghitung persentase realisasi terhadap jadwal, dibulatkan 2 desimal
+func Percent(realized, scheduled int) float64 {
+	if scheduled == 0 {
+		return 0
+	}
+	return math.Round(float64(realized)/float64(scheduled)*10000) / 100
+}
+
+func parseClock(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Hour()*60 + t.Minute(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q", value)
+}
+
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,85 @@
+package workload
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriods(t *testing.T) {
+	tests := []struct {
+		start, end string
+		minutes    int
+		want       int
+	}{
+		{"07:00", "08:30", 45, 2},
+		{"07:00:00", "08:20:00", 40, 2},
+		{"07:00", "07:30", 45, 1}, // Dibulatkan ke atas minimal 1 JP
+		{"07:00", "09:10", 45, 3}, // 130 menit = 2.9 JP
+		{"10:00", "12:15", 45, 3},
+	}
+	for _, tt := range tests {
+		got, err := Periods(tt.start, tt.end, tt.minutes)
+		if err != nil || got != tt.want {
+			t.Errorf("Periods(%s, %s, %d) = %d, %v; want %d", tt.start, tt.end, tt.minutes, got, err, tt.want)
+		}
+	}
+
+	if _, err := Periods("09:00", "08:00", 45); err == nil {
+		t.Error("expected error when end is before start")
+	}
+	if _, err := Periods("7am", "08:00", 45); err == nil {
+		t.Error("expected error for invalid time")
+	}
+}
+
+func TestCountWeekday(t *testing.T) {
+	// Oktober 2026: tanggal 1 hari Kamis, ada 4 Senin (5, 12, 19, 26) dan 5 Kamis
+	from, to := date(2026, 10, 1), date(2026, 10, 31)
+	if got := CountWeekday(1, from, to); got != 4 {
+		t.Errorf("Mondays = %d, want 4", got)
+	}
+	if got := CountWeekday(4, from, to); got != 5 {
+		t.Errorf("Thursdays = %d, want 5", got)
+	}
+	if got := CountWeekday(7, from, date(2026, 10, 3)); got != 0 {
+		t.Errorf("Sundays in 1-3 Oct = %d, want 0", got)
+	}
+	if got := CountWeekday(1, date(2026, 10, 19), date(2026, 10, 19)); got != 1 {
+		t.Errorf("single Monday = %d, want 1", got)
+	}
+	if got := CountWeekday(1, to, from); got != 0 {
+		t.Errorf("reversed range = %d, want 0", got)
+	}
+}
+
+func TestMonths(t *testing.T) {
+	months := Months(date(2026, 7, 13), date(2026, 9, 10))
+	if len(months) != 3 {
+		t.Fatalf("expected 3 months, got %d", len(months))
+	}
+	if months[0].Key != "2026-07" || !months[0].From.Equal(date(2026, 7, 13)) || !months[0].To.Equal(date(2026, 7, 31)) {
+		t.Errorf("unexpected first month %+v", months[0])
+	}
+	if months[2].Key != "2026-09" || !months[2].To.Equal(date(2026, 9, 10)) {
+		t.Errorf("unexpected last month %+v", months[2])
+	}
+}
+
+func TestStatusShortfallPercent(t *testing.T) {
+	if Status(20, 24) != StatusBelowMinimum || Shortfall(20, 24) != 4 {
+		t.Error("20 JP should be 4 JP below a 24 JP minimum")
+	}
+	if Status(24, 24) != StatusMeetsMinimum || Shortfall(30, 24) != 0 {
+		t.Error("24 JP and above should meet the minimum")
+	}
+	if got := Percent(2, 3); got != 66.67 {
+		t.Errorf("Percent(2, 3) = %v, want 66.67", got)
+	}
+	if got := Percent(1, 0); got != 0 {
+		t.Errorf("Percent(1, 0) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,15 @@
+DROP INDEX idx_student_violations_deleted_at ON student_violations;
+DROP INDEX idx_subjects_deleted_at ON subjects;
+DROP INDEX idx_classrooms_deleted_at ON classrooms;
+DROP INDEX idx_employees_deleted_at ON employees;
+DROP INDEX idx_guardians_deleted_at ON guardians;
+DROP INDEX idx_parents_deleted_at ON parents;
+DROP INDEX idx_students_deleted_at ON students;
+
+ALTER TABLE student_violations DROP COLUMN deleted_at;
+ALTER TABLE subjects DROP COLUMN deleted_at;
+ALTER TABLE classrooms DROP COLUMN deleted_at;
+ALTER TABLE employees DROP COLUMN deleted_at;
+ALTER TABLE guardians DROP COLUMN deleted_at;
+ALTER TABLE parents DROP COLUMN deleted_at;
+ALTER TABLE students DROP COLUMN deleted_at;
@@ -0,0 +1,17 @@
+-- Soft delete untuk data inti: baris yang dihapus hanya ditandai deleted_at
+-- dan bisa dipulihkan dari trash sampai dibersihkan oleh perintah purge.
+ALTER TABLE students ADD COLUMN deleted_at DATETIME(3) NULL;
+ALTER TABLE parents ADD COLUMN deleted_at DATETIME(3) NULL;
+ALTER TABLE guardians ADD COLUMN deleted_at DATETIME(3) NULL;
+ALTER TABLE employees ADD COLUMN deleted_at DATETIME(3) NULL;
+ALTER TABLE classrooms ADD COLUMN deleted_at DATETIME(3) NULL;
+ALTER TABLE subjects ADD COLUMN deleted_at DATETIME(3) NULL;
+ALTER TABLE student_violations ADD COLUMN deleted_at DATETIME(3) NULL;
+
+CREATE INDEX idx_students_deleted_at ON students (deleted_at);
+CREATE INDEX idx_parents_deleted_at ON parents (deleted_at);
+CREATE INDEX idx_guardians_deleted_at ON guardians (deleted_at);
+CREATE INDEX idx_employees_deleted_at ON employees (deleted_at);
+CREATE INDEX idx_classrooms_deleted_at ON classrooms (deleted_at);
+CREATE INDEX idx_subjects_deleted_at ON subjects (deleted_at);
+CREATE INDEX idx_student_violations_deleted_at ON student_violations (deleted_at);
@@ -0,0 +1,53 @@
+ALTER TABLE students
+    ADD COLUMN birth_certificate_file VARCHAR(255),
+    ADD COLUMN family_card_file VARCHAR(255),
+    ADD COLUMN parent_statement_file VARCHAR(255),
+    ADD COLUMN student_statement_file VARCHAR(255),
+    ADD COLUMN health_insurance_file VARCHAR(255),
+    ADD COLUMN diploma_certificate_file VARCHAR(255),
+    ADD COLUMN graduation_certificate_file VARCHAR(255),
+    ADD COLUMN financial_hardship_letter_file VARCHAR(255);
+
+-- Kembalikan versi terbaru dokumen bawaan ke kolom lama; dokumen jenis lain ikut terhapus
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'birth_certificate'
+SET s.birth_certificate_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'family_card'
+SET s.family_card_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'parent_statement'
+SET s.parent_statement_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'student_statement'
+SET s.student_statement_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'health_insurance'
+SET s.health_insurance_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'diploma_certificate'
+SET s.diploma_certificate_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'graduation_certificate'
+SET s.graduation_certificate_file = d.file_path;
+
+UPDATE students s
+JOIN documents d ON d.owner_type = 'student' AND d.owner_id = s.id AND d.is_current = TRUE AND d.deleted_at IS NULL
+JOIN document_types dt ON dt.id = d.document_type_id AND dt.owner_type = 'student' AND dt.code = 'financial_hardship_letter'
+SET s.financial_hardship_letter_file = d.file_path;
+
+DROP TABLE IF EXISTS documents;
+DROP TABLE IF EXISTS document_types;
@@ -0,0 +1,88 @@
+-- Jenis dokumen didefinisikan admin (pengganti 8 kolom file tetap di tabel students)
+CREATE TABLE IF NOT EXISTS document_types (
+    id CHAR(36) PRIMARY KEY,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    owner_type VARCHAR(20) NOT NULL, -- student / employee
+    is_required BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_mime_types VARCHAR(255) NOT NULL DEFAULT 'application/pdf,image/jpeg,image/png',
+    max_size_kb INT NOT NULL DEFAULT 2048,
+    description TEXT,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+    deleted_at DATETIME(3) NULL,
+
+    UNIQUE KEY uq_document_types_owner_code (owner_type, code)
+);
+
+-- Setiap upload adalah satu baris; upload ulang menandai versi sebelumnya is_current = FALSE
+CREATE TABLE IF NOT EXISTS documents (
+    id CHAR(36) PRIMARY KEY,
+    document_type_id CHAR(36) NOT NULL,
+    owner_type VARCHAR(20) NOT NULL,
+    owner_id CHAR(36) NOT NULL,
+    file_path VARCHAR(255) NOT NULL,
+    original_name VARCHAR(255),
+    mime_type VARCHAR(100),
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    version INT NOT NULL DEFAULT 1,
+    is_current BOOLEAN NOT NULL DEFAULT TRUE,
+    status ENUM('pending', 'verified', 'rejected') NOT NULL DEFAULT 'pending',
+    notes TEXT,
+    uploaded_by CHAR(36) NULL,
+    verified_by CHAR(36) NULL,
+    verified_at DATETIME(3) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+    deleted_at DATETIME(3) NULL,
+
+    FOREIGN KEY (document_type_id) REFERENCES document_types(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_document_types_deleted_at ON document_types (deleted_at);
+CREATE INDEX idx_documents_owner ON documents (owner_type, owner_id, document_type_id);
+CREATE INDEX idx_documents_deleted_at ON documents (deleted_at);
+
+-- Jenis dokumen bawaan siswa, kode mengikuti nama kolom lama tanpa akhiran _file
+INSERT INTO document_types (id, code, name, owner_type, is_required) VALUES
+    (UUID(), 'birth_certificate', 'Akta Kelahiran', 'student', TRUE),
+    (UUID(), 'family_card', 'Kartu Keluarga', 'student', TRUE),
+    (UUID(), 'parent_statement', 'Surat Pernyataan Orang Tua', 'student', FALSE),
+    (UUID(), 'student_statement', 'Surat Pernyataan Siswa', 'student', FALSE),
+    (UUID(), 'health_insurance', 'Kartu Jaminan Kesehatan', 'student', FALSE),
+    (UUID(), 'diploma_certificate', 'Ijazah', 'student', FALSE),
+    (UUID(), 'graduation_certificate', 'Surat Keterangan Lulus', 'student', FALSE),
+    (UUID(), 'financial_hardship_letter', 'Surat Keterangan Tidak Mampu', 'student', FALSE);
+
+-- Pindahkan file lama ke tabel documents (status pending karena belum pernah diverifikasi)
+INSERT INTO documents (id, document_type_id, owner_type, owner_id, file_path, original_name, mime_type, status, created_at)
+SELECT UUID(), dt.id, 'student', s.id, f.file_path, SUBSTRING_INDEX(f.file_path, '/', -1),
+    CASE
+        WHEN LOWER(f.file_path) LIKE '%.pdf' THEN 'application/pdf'
+        WHEN LOWER(f.file_path) LIKE '%.png' THEN 'image/png'
+        ELSE 'image/jpeg'
+    END,
+    'pending', s.updated_at
+FROM (
+    SELECT id AS student_id, 'birth_certificate' AS code, birth_certificate_file AS file_path FROM students
+    UNION ALL SELECT id, 'family_card', family_card_file FROM students
+    UNION ALL SELECT id, 'parent_statement', parent_statement_file FROM students
+    UNION ALL SELECT id, 'student_statement', student_statement_file FROM students
+    UNION ALL SELECT id, 'health_insurance', health_insurance_file FROM students
+    UNION ALL SELECT id, 'diploma_certificate', diploma_certificate_file FROM students
+    UNION ALL SELECT id, 'graduation_certificate', graduation_certificate_file FROM students
+    UNION ALL SELECT id, 'financial_hardship_letter', financial_hardship_letter_file FROM students
+) f
+JOIN students s ON s.id = f.student_id
+JOIN document_types dt ON dt.owner_type = 'student' AND dt.code = f.code
+WHERE f.file_path IS NOT NULL AND f.file_path <> '';
+
+ALTER TABLE students
+    DROP COLUMN birth_certificate_file,
+    DROP COLUMN family_card_file,
+    DROP COLUMN parent_statement_file,
+    DROP COLUMN student_statement_file,
+    DROP COLUMN health_insurance_file,
+    DROP COLUMN diploma_certificate_file,
+    DROP COLUMN graduation_certificate_file,
+    DROP COLUMN financial_hardship_letter_file;
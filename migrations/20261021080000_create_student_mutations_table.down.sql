@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS student_mutation_documents;
+DROP TABLE IF EXISTS student_mutations;
+
+DELETE d FROM documents d
+JOIN document_types dt ON dt.id = d.document_type_id
+WHERE dt.owner_type = 'student' AND dt.code = 'transfer_letter';
+DELETE FROM document_types WHERE owner_type = 'student' AND code = 'transfer_letter';
+
+UPDATE students SET status = 'INACTIVE' WHERE status = 'TRANSFERRED';
+ALTER TABLE students MODIFY status ENUM('INACTIVE', 'ACTIVE', 'GRADUATED', 'DROPOUT') DEFAULT 'INACTIVE';
@@ -0,0 +1,37 @@
+-- Status TRANSFERRED untuk siswa yang pindah ke sekolah lain
+ALTER TABLE students MODIFY status ENUM('INACTIVE', 'ACTIVE', 'GRADUATED', 'DROPOUT', 'TRANSFERRED') DEFAULT 'INACTIVE';
+
+-- Riwayat mutasi siswa (masuk, keluar, lulus, putus sekolah, diterima kembali)
+CREATE TABLE IF NOT EXISTS student_mutations (
+    id CHAR(36) PRIMARY KEY,
+    student_id CHAR(36) NOT NULL,
+    type ENUM('TRANSFER_IN', 'TRANSFER_OUT', 'DROPOUT', 'GRADUATE', 'READMISSION') NOT NULL,
+    effective_date DATE NOT NULL,
+    reason TEXT NOT NULL,
+    school_name VARCHAR(150), -- Sekolah asal (pindah masuk) atau tujuan (pindah keluar)
+    previous_status VARCHAR(20) NOT NULL,
+    new_status VARCHAR(20) NOT NULL,
+    classroom_id CHAR(36) NULL, -- Kelas penempatan saat pindah masuk / diterima kembali
+    created_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    FOREIGN KEY (classroom_id) REFERENCES classrooms(id) ON DELETE SET NULL
+);
+
+-- Dokumen pendukung mutasi (diupload lewat /students/:id/documents)
+CREATE TABLE IF NOT EXISTS student_mutation_documents (
+    student_mutation_id CHAR(36) NOT NULL,
+    document_id CHAR(36) NOT NULL,
+    PRIMARY KEY (student_mutation_id, document_id),
+
+    FOREIGN KEY (student_mutation_id) REFERENCES student_mutations(id) ON DELETE CASCADE,
+    FOREIGN KEY (document_id) REFERENCES documents(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_student_mutations_student_id ON student_mutations(student_id);
+CREATE INDEX idx_student_mutations_effective_date ON student_mutations(effective_date);
+
+INSERT INTO document_types (id, code, name, owner_type, is_required) VALUES
+    (UUID(), 'transfer_letter', 'Surat Keterangan Pindah', 'student', FALSE);
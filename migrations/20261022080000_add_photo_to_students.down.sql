@@ -0,0 +1,2 @@
+ALTER TABLE students
+    DROP COLUMN photo;
@@ -0,0 +1,2 @@
+ALTER TABLE students
+    ADD COLUMN photo VARCHAR(255) NULL AFTER exit_year;
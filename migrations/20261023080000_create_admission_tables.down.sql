@@ -0,0 +1,6 @@
+DELETE FROM documents WHERE owner_type = 'applicant';
+
+DROP TABLE IF EXISTS applicant_status_logs;
+DROP TABLE IF EXISTS applicant_parents;
+DROP TABLE IF EXISTS applicants;
+DROP TABLE IF EXISTS admission_waves;
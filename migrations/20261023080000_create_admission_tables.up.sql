@@ -0,0 +1,96 @@
+-- Gelombang PPDB (penerimaan peserta didik baru) per tahun ajaran
+CREATE TABLE IF NOT EXISTS admission_waves (
+    id CHAR(36) PRIMARY KEY,
+    academic_year_id CHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    quota INT NOT NULL DEFAULT 0, -- 0 = tanpa batas
+    is_open BOOLEAN NOT NULL DEFAULT TRUE,
+    description TEXT,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    FOREIGN KEY (academic_year_id) REFERENCES academic_years(id) ON DELETE RESTRICT
+);
+
+-- Pendaftar, field mengikuti tabel students (NIK & No KK terenkripsi)
+CREATE TABLE IF NOT EXISTS applicants (
+    id CHAR(36) PRIMARY KEY,
+    admission_wave_id CHAR(36) NOT NULL,
+    registration_number VARCHAR(30) NOT NULL,
+    status ENUM('submitted', 'verified', 'tested', 'accepted', 'rejected') NOT NULL DEFAULT 'submitted',
+    full_name VARCHAR(100) NOT NULL,
+    no_kk TEXT,
+    nik TEXT,
+    nik_hash VARCHAR(64),
+    nisn VARCHAR(20),
+    gender VARCHAR(10) NOT NULL,
+    place_of_birth VARCHAR(100),
+    date_of_birth DATE NOT NULL,
+    address TEXT,
+    rt VARCHAR(3),
+    rw VARCHAR(3),
+    sub_district VARCHAR(100),
+    district VARCHAR(100),
+    city VARCHAR(100),
+    province VARCHAR(100),
+    postal_code VARCHAR(5),
+    previous_school VARCHAR(150),
+    phone_number VARCHAR(20),
+    email VARCHAR(100),
+    test_score DECIMAL(5,2) NULL,
+    notes TEXT,
+    student_id CHAR(36) NULL, -- Diisi saat pendaftar yang diterima dijadikan siswa
+    converted_at DATETIME(3) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY uq_applicants_registration_number (registration_number),
+    FOREIGN KEY (admission_wave_id) REFERENCES admission_waves(id) ON DELETE RESTRICT,
+    FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE SET NULL
+);
+
+-- Orang tua pendaftar, field mengikuti tabel parents
+CREATE TABLE IF NOT EXISTS applicant_parents (
+    id CHAR(36) PRIMARY KEY,
+    applicant_id CHAR(36) NOT NULL,
+    relationship_type VARCHAR(50) NOT NULL, -- FATHER / MOTHER
+    full_name VARCHAR(100) NOT NULL,
+    nik TEXT,
+    nik_hash VARCHAR(64),
+    gender VARCHAR(10),
+    place_of_birth VARCHAR(100),
+    date_of_birth DATE,
+    life_status VARCHAR(10) DEFAULT 'alive',
+    phone_number VARCHAR(20),
+    email VARCHAR(100),
+    education_level VARCHAR(50),
+    occupation VARCHAR(100),
+    income_range VARCHAR(50),
+    parent_id CHAR(36) NULL, -- Data orang tua yang dibuat/ditautkan saat konversi
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    FOREIGN KEY (applicant_id) REFERENCES applicants(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES parents(id) ON DELETE SET NULL
+);
+
+-- Riwayat perubahan status pendaftar
+CREATE TABLE IF NOT EXISTS applicant_status_logs (
+    id CHAR(36) PRIMARY KEY,
+    applicant_id CHAR(36) NOT NULL,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    notes TEXT,
+    created_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+
+    FOREIGN KEY (applicant_id) REFERENCES applicants(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_admission_waves_academic_year_id ON admission_waves(academic_year_id);
+CREATE INDEX idx_applicants_wave_status ON applicants(admission_wave_id, status);
+CREATE INDEX idx_applicants_nik_hash ON applicants(nik_hash);
+CREATE INDEX idx_applicant_parents_applicant_id ON applicant_parents(applicant_id);
+CREATE INDEX idx_applicant_status_logs_applicant_id ON applicant_status_logs(applicant_id);
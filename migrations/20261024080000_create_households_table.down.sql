@@ -0,0 +1,7 @@
+ALTER TABLE students DROP FOREIGN KEY fk_students_household;
+DROP INDEX idx_students_no_kk_hash ON students;
+ALTER TABLE students
+    DROP COLUMN household_id,
+    DROP COLUMN no_kk_hash;
+
+DROP TABLE IF EXISTS households;
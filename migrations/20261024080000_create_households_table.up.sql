@@ -0,0 +1,18 @@
+-- Keluarga (satu Kartu Keluarga). no_kk_hash adalah blind index No KK, sama seperti nik_hash.
+CREATE TABLE IF NOT EXISTS households (
+    id CHAR(36) PRIMARY KEY,
+    no_kk_hash VARCHAR(64) NOT NULL,
+    no_kk TEXT, -- Terenkripsi, disalin dari siswa pertama
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY uq_households_no_kk_hash (no_kk_hash)
+);
+
+-- Hash tidak bisa dihitung di SQL (butuh kunci aplikasi); isi data lama dengan `server -backfill-households`
+ALTER TABLE students
+    ADD COLUMN no_kk_hash VARCHAR(64) NULL AFTER no_kk,
+    ADD COLUMN household_id CHAR(36) NULL AFTER no_kk_hash,
+    ADD CONSTRAINT fk_students_household FOREIGN KEY (household_id) REFERENCES households(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_students_no_kk_hash ON students(no_kk_hash);
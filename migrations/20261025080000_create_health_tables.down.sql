@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS clinic_visits;
+DROP TABLE IF EXISTS student_health_profiles;
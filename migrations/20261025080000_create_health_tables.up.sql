@@ -0,0 +1,36 @@
+-- Profil kesehatan siswa. Kolom TEXT berisi data medis terenkripsi (field-level encryption).
+CREATE TABLE IF NOT EXISTS student_health_profiles (
+    id CHAR(36) PRIMARY KEY,
+    student_id CHAR(36) NOT NULL,
+    blood_type TEXT,
+    allergies TEXT,
+    chronic_conditions TEXT,
+    regular_medications TEXT,
+    notes TEXT,
+    updated_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY uq_student_health_profiles_student (student_id),
+    CONSTRAINT fk_student_health_profiles_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
+
+-- Log kunjungan UKS (klinik sekolah / pesantren)
+CREATE TABLE IF NOT EXISTS clinic_visits (
+    id CHAR(36) PRIMARY KEY,
+    student_id CHAR(36) NOT NULL,
+    visited_at DATETIME(3) NOT NULL,
+    complaint TEXT NOT NULL,
+    treatment TEXT,
+    medication_given TEXT,
+    outcome ENUM('returned_to_class', 'rested', 'sent_home', 'referred') NOT NULL DEFAULT 'returned_to_class',
+    referred_to VARCHAR(150) NULL,
+    attendance_marked INT NOT NULL DEFAULT 0, -- Jumlah sesi absensi yang ditandai SICK
+    handled_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    INDEX idx_clinic_visits_student_visited (student_id, visited_at),
+    INDEX idx_clinic_visits_visited (visited_at),
+    CONSTRAINT fk_clinic_visits_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dorm_assignments;
+DROP TABLE IF EXISTS dorm_rooms;
+DROP TABLE IF EXISTS dormitories;
@@ -0,0 +1,49 @@
+-- Asrama (gedung) untuk santri mukim
+CREATE TABLE IF NOT EXISTS dormitories (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    gender ENUM('male', 'female') NOT NULL,
+    description TEXT,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY uq_dormitories_name (name)
+);
+
+-- Kamar dalam asrama, masing-masing dengan kapasitas dan musyrif (pembina kamar)
+CREATE TABLE IF NOT EXISTS dorm_rooms (
+    id CHAR(36) PRIMARY KEY,
+    dormitory_id CHAR(36) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    floor INT NULL,
+    capacity INT NOT NULL,
+    musyrif_id CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY uq_dorm_rooms_dormitory_name (dormitory_id, name),
+    INDEX idx_dorm_rooms_musyrif (musyrif_id),
+    CONSTRAINT fk_dorm_rooms_dormitory FOREIGN KEY (dormitory_id) REFERENCES dormitories(id) ON DELETE CASCADE,
+    CONSTRAINT fk_dorm_rooms_musyrif FOREIGN KEY (musyrif_id) REFERENCES employees(id) ON DELETE SET NULL
+);
+
+-- Penempatan santri ke kamar per tahun ajaran. Baris lama tidak dihapus (status MOVED/ENDED) sebagai riwayat.
+CREATE TABLE IF NOT EXISTS dorm_assignments (
+    id CHAR(36) PRIMARY KEY,
+    dorm_room_id CHAR(36) NOT NULL,
+    student_id CHAR(36) NOT NULL,
+    academic_year_id CHAR(36) NOT NULL,
+    status ENUM('ACTIVE', 'MOVED', 'ENDED') NOT NULL DEFAULT 'ACTIVE',
+    start_date DATE NOT NULL,
+    end_date DATE NULL,
+    notes TEXT,
+    created_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    INDEX idx_dorm_assignments_room_status (dorm_room_id, status),
+    INDEX idx_dorm_assignments_student_year (student_id, academic_year_id, status),
+    CONSTRAINT fk_dorm_assignments_room FOREIGN KEY (dorm_room_id) REFERENCES dorm_rooms(id) ON DELETE CASCADE,
+    CONSTRAINT fk_dorm_assignments_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_dorm_assignments_academic_year FOREIGN KEY (academic_year_id) REFERENCES academic_years(id)
+);
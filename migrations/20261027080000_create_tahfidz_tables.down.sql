@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tahfidz_targets;
+DROP TABLE IF EXISTS tahfidz_sessions;
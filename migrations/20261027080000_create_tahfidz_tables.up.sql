@@ -0,0 +1,46 @@
+-- Setoran (hafalan baru) dan murajaah (mengulang) santri. Rentang disimpan sebagai surah:ayat,
+-- ayah_from/ayah_to adalah indeks ayat global (1-6236) untuk menghitung cakupan juz.
+CREATE TABLE IF NOT EXISTS tahfidz_sessions (
+    id CHAR(36) PRIMARY KEY,
+    student_id CHAR(36) NOT NULL,
+    academic_year_id CHAR(36) NOT NULL,
+    session_date DATE NOT NULL,
+    type ENUM('setoran', 'murajaah') NOT NULL,
+    start_surah TINYINT UNSIGNED NOT NULL,
+    start_ayah SMALLINT UNSIGNED NOT NULL,
+    end_surah TINYINT UNSIGNED NOT NULL,
+    end_ayah SMALLINT UNSIGNED NOT NULL,
+    ayah_from SMALLINT UNSIGNED NOT NULL,
+    ayah_to SMALLINT UNSIGNED NOT NULL,
+    ayah_count SMALLINT UNSIGNED NOT NULL,
+    juz_start TINYINT UNSIGNED NOT NULL,
+    juz_end TINYINT UNSIGNED NOT NULL,
+    grade ENUM('mumtaz', 'jayyid_jiddan', 'jayyid', 'maqbul', 'rasib') NOT NULL,
+    examiner_id CHAR(36) NULL,
+    notes TEXT,
+    created_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    INDEX idx_tahfidz_sessions_student_date (student_id, session_date),
+    INDEX idx_tahfidz_sessions_year (academic_year_id),
+    INDEX idx_tahfidz_sessions_examiner (examiner_id),
+    CONSTRAINT fk_tahfidz_sessions_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tahfidz_sessions_academic_year FOREIGN KEY (academic_year_id) REFERENCES academic_years(id),
+    CONSTRAINT fk_tahfidz_sessions_examiner FOREIGN KEY (examiner_id) REFERENCES employees(id) ON DELETE SET NULL
+);
+
+-- Target hafalan (dalam juz) per santri per tahun ajaran
+CREATE TABLE IF NOT EXISTS tahfidz_targets (
+    id CHAR(36) PRIMARY KEY,
+    student_id CHAR(36) NOT NULL,
+    academic_year_id CHAR(36) NOT NULL,
+    target_juz DECIMAL(4,2) NOT NULL,
+    notes TEXT,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE KEY uq_tahfidz_targets_student_year (student_id, academic_year_id),
+    CONSTRAINT fk_tahfidz_targets_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tahfidz_targets_academic_year FOREIGN KEY (academic_year_id) REFERENCES academic_years(id)
+);
@@ -0,0 +1,32 @@
+-- Perizinan pulang / keluar asrama santri.
+-- Alur: PENDING (menunggu konfirmasi wali & persetujuan musyrif) -> APPROVED -> CHECKED_OUT (keluar gerbang) -> RETURNED.
+CREATE TABLE IF NOT EXISTS leave_permits (
+    id CHAR(36) PRIMARY KEY,
+    student_id CHAR(36) NOT NULL,
+    academic_year_id CHAR(36) NOT NULL,
+    reason TEXT NOT NULL,
+    destination VARCHAR(255) NULL,
+    departure_at DATETIME NOT NULL,
+    expected_return_at DATETIME NOT NULL,
+    status ENUM('PENDING', 'APPROVED', 'REJECTED', 'CANCELLED', 'CHECKED_OUT', 'RETURNED') NOT NULL DEFAULT 'PENDING',
+    requester_type ENUM('staff', 'parent') NOT NULL,
+    requested_by CHAR(36) NULL,
+    guardian_confirmed_at DATETIME NULL,
+    guardian_confirmed_by CHAR(36) NULL,
+    guardian_confirmation_note VARCHAR(255) NULL,
+    reviewed_by CHAR(36) NULL,
+    reviewed_at DATETIME NULL,
+    review_notes TEXT,
+    checked_out_at DATETIME NULL,
+    checked_out_by CHAR(36) NULL,
+    picked_up_by VARCHAR(100) NULL,
+    checked_in_at DATETIME NULL,
+    checked_in_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    INDEX idx_leave_permits_student_departure (student_id, departure_at),
+    INDEX idx_leave_permits_status_return (status, expected_return_at),
+    CONSTRAINT fk_leave_permits_student FOREIGN KEY (student_id) REFERENCES students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_leave_permits_academic_year FOREIGN KEY (academic_year_id) REFERENCES academic_years(id)
+);
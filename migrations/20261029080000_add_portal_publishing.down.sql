@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS announcements;
+ALTER TABLE assessments DROP COLUMN published_at;
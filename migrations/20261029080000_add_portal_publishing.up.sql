@@ -0,0 +1,19 @@
+-- Nilai hanya tampil di portal orang tua setelah penilaian dipublikasikan guru
+ALTER TABLE assessments ADD COLUMN published_at DATETIME NULL AFTER description;
+
+-- Pengumuman sekolah; classroom_id NULL berarti untuk seluruh sekolah
+CREATE TABLE IF NOT EXISTS announcements (
+    id CHAR(36) PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    classroom_id CHAR(36) NULL,
+    published_at DATETIME NOT NULL,
+    expires_at DATETIME NULL,
+    created_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    INDEX idx_announcements_published (published_at),
+    INDEX idx_announcements_classroom (classroom_id),
+    CONSTRAINT fk_announcements_classroom FOREIGN KEY (classroom_id) REFERENCES classrooms(id) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+ALTER TABLE students
+    DROP INDEX idx_student_primary_contact,
+    DROP COLUMN primary_contact_type,
+    DROP COLUMN primary_contact_id;
+
+DROP TABLE IF EXISTS contact_preferences;
@@ -0,0 +1,21 @@
+-- Preferensi komunikasi per orang tua/wali (contact_type + contact_id, polimorfik seperti wali siswa)
+CREATE TABLE IF NOT EXISTS contact_preferences (
+    id CHAR(36) PRIMARY KEY,
+    contact_type VARCHAR(20) NOT NULL,
+    contact_id CHAR(36) NOT NULL,
+    preferred_channel VARCHAR(20) NOT NULL DEFAULT 'WHATSAPP',
+    language VARCHAR(5) NOT NULL DEFAULT 'id',
+    quiet_hours_start VARCHAR(5) NULL,
+    quiet_hours_end VARCHAR(5) NULL,
+    opt_out_categories VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX idx_contact_preference (contact_type, contact_id)
+);
+
+-- Kontak utama siswa untuk pesan sekolah (boleh berbeda dari wali)
+ALTER TABLE students
+    ADD COLUMN primary_contact_id CHAR(36) NULL AFTER guardian_type,
+    ADD COLUMN primary_contact_type VARCHAR(20) NULL AFTER primary_contact_id,
+    ADD INDEX idx_student_primary_contact (primary_contact_id, primary_contact_type);
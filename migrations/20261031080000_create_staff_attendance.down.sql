@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS staff_leaves;
+DROP TABLE IF EXISTS staff_attendances;
+
+ALTER TABLE employees
+    DROP FOREIGN KEY fk_employees_work_shift,
+    DROP INDEX idx_employees_work_shift_id,
+    DROP INDEX idx_employees_device_user_id,
+    DROP COLUMN work_shift_id,
+    DROP COLUMN device_user_id;
+
+DROP TABLE IF EXISTS work_shifts;
@@ -0,0 +1,62 @@
+-- Jam kerja pegawai; pegawai tanpa shift memakai shift dengan is_default = 1
+CREATE TABLE IF NOT EXISTS work_shifts (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    start_time VARCHAR(5) NOT NULL,
+    end_time VARCHAR(5) NOT NULL,
+    late_tolerance_minutes INT NOT NULL DEFAULT 0,
+    early_leave_tolerance_minutes INT NOT NULL DEFAULT 0,
+    work_days VARCHAR(20) NOT NULL DEFAULT '1,2,3,4,5,6',
+    is_default TINYINT(1) NOT NULL DEFAULT 0,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX idx_work_shifts_name (name)
+);
+
+-- ID user di mesin fingerprint dan shift kerja pegawai
+ALTER TABLE employees
+    ADD COLUMN device_user_id VARCHAR(20) NULL AFTER employment_status,
+    ADD COLUMN work_shift_id CHAR(36) NULL AFTER device_user_id,
+    ADD UNIQUE INDEX idx_employees_device_user_id (device_user_id),
+    ADD INDEX idx_employees_work_shift_id (work_shift_id),
+    ADD CONSTRAINT fk_employees_work_shift FOREIGN KEY (work_shift_id) REFERENCES work_shifts(id) ON DELETE SET NULL;
+
+-- Kehadiran harian pegawai (satu baris per pegawai per tanggal)
+CREATE TABLE IF NOT EXISTS staff_attendances (
+    id CHAR(36) PRIMARY KEY,
+    employee_id CHAR(36) NOT NULL,
+    date DATE NOT NULL,
+    clock_in_at DATETIME NULL,
+    clock_out_at DATETIME NULL,
+    work_shift_id CHAR(36) NULL,
+    shift_start VARCHAR(5) NULL,
+    shift_end VARCHAR(5) NULL,
+    late_minutes INT NOT NULL DEFAULT 0,
+    early_leave_minutes INT NOT NULL DEFAULT 0,
+    source VARCHAR(10) NOT NULL,
+    notes VARCHAR(255) NULL,
+    recorded_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX idx_staff_attendance_day (employee_id, date),
+    INDEX idx_staff_attendances_date (date),
+    CONSTRAINT fk_staff_attendances_employee FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE
+);
+
+-- Cuti/izin pegawai yang sudah disetujui
+CREATE TABLE IF NOT EXISTS staff_leaves (
+    id CHAR(36) PRIMARY KEY,
+    employee_id CHAR(36) NOT NULL,
+    leave_type ENUM('SICK', 'ANNUAL', 'PERMISSION', 'DUTY', 'MATERNITY') NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT NULL,
+    recorded_by CHAR(36) NULL,
+    created_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3),
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+
+    INDEX idx_staff_leaves_employee_dates (employee_id, start_date),
+    CONSTRAINT fk_staff_leaves_employee FOREIGN KEY (employee_id) REFERENCES employees(id) ON DELETE CASCADE
+);
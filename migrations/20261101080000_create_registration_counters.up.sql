@@ -0,0 +1,8 @@
+-- Nomor urut pendaftaran PPDB terakhir per prefix (PPDB-<tahun>-). Baris dikunci dengan
+-- SELECT ... FOR UPDATE saat pendaftar disimpan; counter yang belum ada dimulai dari
+-- nomor terbesar di applicants.
+CREATE TABLE IF NOT EXISTS registration_counters (
+    prefix VARCHAR(20) PRIMARY KEY,
+    last_number INT NOT NULL DEFAULT 0,
+    updated_at DATETIME(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE clinic_visits
+    DROP COLUMN mark_attendance_sick;
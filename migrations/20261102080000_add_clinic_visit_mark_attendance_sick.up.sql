@@ -0,0 +1,8 @@
+-- Pilihan "tandai SICK" saat siswa dipulangkan UKS. Hanya kunjungan dengan flag ini yang mengisi
+-- SICK pada sesi absensi, termasuk sesi yang baru dibuat guru setelah kunjungan.
+ALTER TABLE clinic_visits
+    ADD COLUMN mark_attendance_sick TINYINT(1) NOT NULL DEFAULT 0 AFTER referred_to;
+
+-- Kunjungan lama yang sudah menandai sesi absensi jelas memilih opsi ini
+UPDATE clinic_visits SET mark_attendance_sick = 1
+WHERE outcome = 'sent_home' AND attendance_marked > 0;